@@ -36,6 +36,22 @@ func (a *Adapter) DefaultDir() string {
 	return "plugins/agentkit"
 }
 
+// SupportedTools returns the canonical tools with an AgentKit local mapping.
+func (a *Adapter) SupportedTools() []string {
+	return append([]string{}, core.CanonicalTools...)
+}
+
+// SupportsMaxTurns reports that agentkit configs carry max_turns.
+func (a *Adapter) SupportsMaxTurns() bool {
+	return true
+}
+
+// SupportsStopConditions reports that agentkit configs carry
+// stop_conditions.
+func (a *Adapter) SupportsStopConditions() bool {
+	return true
+}
+
 // Parse converts agentkit config bytes to canonical Agent.
 func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
 	var cfg AgentConfig
@@ -48,6 +64,7 @@ func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
 // Marshal converts canonical Agent to agentkit config bytes.
 func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 	cfg := agentToConfig(agent)
+	cfg.Instructions = core.TransformInstructions(a.Name(), cfg.Instructions)
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return nil, &core.MarshalError{Format: "agentkit", Err: err}
@@ -85,12 +102,19 @@ func (a *Adapter) WriteFile(agent *core.Agent, path string) error {
 
 // AgentConfig matches agentkit/platforms/local AgentConfig structure.
 type AgentConfig struct {
-	Name         string   `json:"name"`
-	Description  string   `json:"description"`
-	Instructions string   `json:"instructions"`
-	Tools        []string `json:"tools"`
-	Model        string   `json:"model,omitempty"`
-	MaxTokens    int      `json:"max_tokens,omitempty"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Instructions  string   `json:"instructions"`
+	Tools         []string `json:"tools"`
+	Model         string   `json:"model,omitempty"`
+	FallbackModel string   `json:"fallback_model,omitempty"`
+	MaxTokens     int      `json:"max_tokens,omitempty"`
+	WorkingDir    string   `json:"working_dir,omitempty"`
+	MemoryDir     string   `json:"memory_dir,omitempty"`
+	Modalities    []string `json:"modalities,omitempty"`
+
+	MaxTurns       int      `json:"max_turns,omitempty"`
+	StopConditions []string `json:"stop_conditions,omitempty"`
 }
 
 // Config is the full agentkit local configuration.
@@ -154,7 +178,9 @@ func agentToConfig(agent *core.Agent) *AgentConfig {
 	cfg := &AgentConfig{
 		Name:         agent.Name,
 		Description:  agent.Description,
-		Instructions: agent.Instructions,
+		Instructions: agent.CombinedInstructions(),
+		WorkingDir:   agent.WorkingDir,
+		MemoryDir:    agent.Memory,
 	}
 
 	// Map tools using multi-agent-spec mappings
@@ -176,6 +202,16 @@ func agentToConfig(agent *core.Agent) *AgentConfig {
 	if agent.Model != "" {
 		cfg.Model = mapModelToAgentKit(agent.Model)
 	}
+	if agent.FallbackModel != "" {
+		cfg.FallbackModel = mapModelToAgentKit(agent.FallbackModel)
+	}
+	if !agent.IsTextOnly() {
+		cfg.Modalities = agent.InputModalities()
+	}
+	if agent.MaxTurns != nil {
+		cfg.MaxTurns = *agent.MaxTurns
+	}
+	cfg.StopConditions = agent.StopConditions
 
 	return cfg
 }
@@ -192,10 +228,20 @@ var reverseAgentKitToolMapping = map[string]string{
 
 func configToAgent(cfg *AgentConfig) *core.Agent {
 	agent := &core.Agent{
-		Name:         cfg.Name,
-		Description:  cfg.Description,
-		Instructions: cfg.Instructions,
-		Model:        core.Model(cfg.Model),
+		Spec: core.Spec{
+			Name:         cfg.Name,
+			Description:  cfg.Description,
+			Instructions: cfg.Instructions,
+			Model:        core.Model(cfg.Model),
+		},
+		FallbackModel:  core.Model(cfg.FallbackModel),
+		WorkingDir:     cfg.WorkingDir,
+		Memory:         cfg.MemoryDir,
+		Modalities:     cfg.Modalities,
+		StopConditions: cfg.StopConditions,
+	}
+	if cfg.MaxTurns > 0 {
+		agent.MaxTurns = &cfg.MaxTurns
 	}
 
 	// Reverse map tools
@@ -3,6 +3,8 @@
 // Supported tools:
 //   - Claude Code: agents/<name>.md (Markdown with YAML frontmatter)
 //   - AWS Kiro CLI: ~/.kiro/agents/<name>.json (JSON format)
+//   - OpenAI: agents/<name>.json (Responses API system and developer messages)
+//   - Python: agents/<name>.py (framework-agnostic stubs for LangGraph, CrewAI, etc.)
 //
 // Example usage:
 //
@@ -26,7 +28,7 @@
 //
 //	    // Write to Kiro format
 //	    kiroAdapter, _ := agents.GetAdapter("kiro")
-//	    kiroAdapter.WriteFile(agent, "~/.kiro/agents/release-coordinator.json")
+//	    kiroAdapter.WriteFile(agent, "~/.kiro/agents/release_coordinator.json")
 //	}
 package agents
 
@@ -40,13 +42,34 @@ import (
 	_ "github.com/agentplexus/assistantkit/agents/codex"
 	_ "github.com/agentplexus/assistantkit/agents/gemini"
 	_ "github.com/agentplexus/assistantkit/agents/kiro"
+	_ "github.com/agentplexus/assistantkit/agents/openai"
+	_ "github.com/agentplexus/assistantkit/agents/python"
 )
 
 // Re-export core types for convenience
 type (
-	Agent   = core.Agent
-	Adapter = core.Adapter
-	Model   = core.Model
+	Agent                = core.Agent
+	Spec                 = core.Spec
+	Runtime              = core.Runtime
+	MCPServer            = core.MCPServer
+	Approval             = core.Approval
+	Handoff              = core.Handoff
+	KnowledgeSource      = core.KnowledgeSource
+	Guardrails           = core.Guardrails
+	Adapter              = core.Adapter
+	Model                = core.Model
+	InstructionTransform = core.InstructionTransform
+	ToolAliases          = core.ToolAliases
+	ToolPermissions      = core.ToolPermissions
+
+	ResponseSchemaAdapter = core.ResponseSchemaAdapter
+	GuardrailsAdapter     = core.GuardrailsAdapter
+	LoopControlAdapter    = core.LoopControlAdapter
+	ExperimentalAdapter   = core.ExperimentalAdapter
+	FileNameAdapter       = core.FileNameAdapter
+	ToolsAdapter          = core.ToolsAdapter
+
+	PassthroughToolsAdapter = core.PassthroughToolsAdapter
 )
 
 // Re-export model constants
@@ -56,24 +79,104 @@ const (
 	ModelOpus   = core.ModelOpus
 )
 
+// Re-export approval policies
+const (
+	ApprovalAuto  = core.ApprovalAuto
+	ApprovalAsk   = core.ApprovalAsk
+	ApprovalNever = core.ApprovalNever
+)
+
+// Re-export input modalities
+const (
+	ModalityText  = core.ModalityText
+	ModalityImage = core.ModalityImage
+	ModalityAudio = core.ModalityAudio
+)
+
+// Re-export knowledge source types
+const (
+	KnowledgeSourceBedrock = core.KnowledgeSourceBedrock
+)
+
 // Re-export core functions
 var (
 	NewAgent             = core.NewAgent
+	FromSpec             = core.FromSpec
 	GetAdapter           = core.GetAdapter
 	AdapterNames         = core.AdapterNames
+	Convert              = core.Convert
+	ConvertWithWarnings  = core.ConvertWithWarnings
 	ReadCanonicalFile    = core.ReadCanonicalFile
 	WriteCanonicalFile   = core.WriteCanonicalFile
 	WriteCanonicalJSON   = core.WriteCanonicalJSON
 	ReadCanonicalDir     = core.ReadCanonicalDir
+	ResolveIncludes      = core.ResolveIncludes
 	WriteAgentsToDir     = core.WriteAgentsToDir
+	FileName             = core.FileName
+	CheckFileNames       = core.CheckFileNames
+	KebabCase            = core.KebabCase
+	SnakeCase            = core.SnakeCase
 	ParseMarkdownAgent   = core.ParseMarkdownAgent
 	MarshalMarkdownAgent = core.MarshalMarkdownAgent
+	ParseFrontmatter     = core.ParseFrontmatter
+
+	UpdateAgentFrontmatter = core.UpdateAgentFrontmatter
+
+	Preamble                   = core.Preamble
+	Epilogue                   = core.Epilogue
+	AddInstructionTransform    = core.AddInstructionTransform
+	ClearInstructionTransforms = core.ClearInstructionTransforms
+	TransformInstructions      = core.TransformInstructions
+
+	CanonicalTools = core.CanonicalTools
+	CheckTools     = core.CheckTools
+	RestrictTools  = core.RestrictTools
+
+	ResolveInheritance = core.ResolveInheritance
+	MergeTools         = core.MergeTools
+	MergeTags          = core.MergeTags
+
+	SupportsResponseSchema = core.SupportsResponseSchema
+	CheckResponseSchema    = core.CheckResponseSchema
+	SupportsGuardrails     = core.SupportsGuardrails
+	CheckGuardrails        = core.CheckGuardrails
+	CheckLoopControl       = core.CheckLoopControl
+	SupportsExperimental   = core.SupportsExperimental
+	CheckExperimental      = core.CheckExperimental
+	IsKnownModality        = core.IsKnownModality
+
+	ApplyHandoffs   = core.ApplyHandoffs
+	RoutingGuidance = core.RoutingGuidance
+	MermaidGraph    = core.MermaidGraph
+
+	Localize = core.Localize
+
+	DefaultCategories = core.DefaultCategories
+	RegisterCategory  = core.RegisterCategory
+	IsKnownCategory   = core.IsKnownCategory
+	KnownCategories   = core.KnownCategories
+	CollectCategories = core.CollectCategories
+
+	ParseToolAliases    = core.ParseToolAliases
+	ReadToolAliasesFile = core.ReadToolAliasesFile
+	SetToolAliases      = core.SetToolAliases
+	AddToolAlias        = core.AddToolAlias
+	ClearToolAliases    = core.ClearToolAliases
 )
 
+// ToolAliasesFileName is the conventional tool alias file name in a specs directory.
+const ToolAliasesFileName = core.ToolAliasesFileName
+
 // Re-export error types
 type (
 	ParseError   = core.ParseError
 	MarshalError = core.MarshalError
 	ReadError    = core.ReadError
 	WriteError   = core.WriteError
+
+	UnsupportedToolsError = core.UnsupportedToolsError
+	InheritanceError      = core.InheritanceError
+	HandoffError          = core.HandoffError
+	FileNameError         = core.FileNameError
+	ValidationError       = core.ValidationError
 )
@@ -1,6 +1,9 @@
 package agents
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -114,3 +117,869 @@ func TestClaudeAdapterMinimal(t *testing.T) {
 		t.Error("should not have skills when empty")
 	}
 }
+
+func TestInstructionTransformPreamble(t *testing.T) {
+	AddInstructionTransform("claude", Preamble("Always use tools to verify changes."))
+	defer ClearInstructionTransforms("claude")
+
+	adapter, ok := GetAdapter("claude")
+	if !ok {
+		t.Fatal("Claude adapter not found")
+	}
+
+	agent := NewAgent("reviewer", "Reviews code")
+	agent.Instructions = "You review code."
+
+	data, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	content := string(data)
+	if !strings.Contains(content, "Always use tools to verify changes.\n\nYou review code.") {
+		t.Errorf("expected preamble before instructions, got:\n%s", content)
+	}
+
+	// Canonical instructions must stay untouched
+	if agent.Instructions != "You review code." {
+		t.Errorf("canonical Instructions = %q, want %q", agent.Instructions, "You review code.")
+	}
+	canonical := string(MarshalMarkdownAgent(agent))
+	if strings.Contains(canonical, "Always use tools") {
+		t.Error("preamble should not appear in canonical output")
+	}
+
+	// Other adapters are unaffected
+	gemini, ok := GetAdapter("gemini")
+	if !ok {
+		t.Fatal("Gemini adapter not found")
+	}
+	data, err = gemini.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "Always use tools") {
+		t.Error("claude preamble should not appear in gemini output")
+	}
+}
+
+func TestConvertWithWarnings(t *testing.T) {
+	claudeMD := `---
+name: reviewer
+description: Reviews code
+model: sonnet
+tools: [Read, Grep]
+skills: [code-review]
+dependencies: [linter]
+---
+
+You review code.
+`
+
+	out, warnings, err := ConvertWithWarnings([]byte(claudeMD), "claude", "kiro")
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings failed: %v", err)
+	}
+	if !strings.Contains(string(out), `"name": "reviewer"`) {
+		t.Errorf("expected kiro JSON output, got:\n%s", out)
+	}
+
+	found := false
+	for _, w := range warnings {
+		if strings.Contains(w, `"dependencies"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected warning about dropped dependencies, got %v", warnings)
+	}
+}
+
+func TestRestrictToolsWarnsOnUnsupportedTool(t *testing.T) {
+	gemini, ok := GetAdapter("gemini")
+	if !ok {
+		t.Fatal("expected gemini adapter to be registered")
+	}
+
+	agent := NewAgent("researcher", "Research agent")
+	agent.Tools = []string{"Read", "WebSearch", "Task"}
+
+	restricted, warnings, err := RestrictTools(gemini, agent, false)
+	if err != nil {
+		t.Fatalf("RestrictTools failed: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `"Task"`) {
+		t.Errorf("expected one warning about Task, got %v", warnings)
+	}
+	if strings.Join(restricted.Tools, ",") != "Read,WebSearch" {
+		t.Errorf("expected supported tools Read,WebSearch, got %v", restricted.Tools)
+	}
+	if len(agent.Tools) != 3 {
+		t.Errorf("expected original agent to be unchanged, got %v", agent.Tools)
+	}
+
+	_, _, err = RestrictTools(gemini, agent, true)
+	var toolsErr *UnsupportedToolsError
+	if !errors.As(err, &toolsErr) {
+		t.Fatalf("expected UnsupportedToolsError in strict mode, got %v", err)
+	}
+	if len(toolsErr.Tools) != 1 || toolsErr.Tools[0] != "Task" {
+		t.Errorf("expected unsupported tools [Task], got %v", toolsErr.Tools)
+	}
+
+	claude, _ := GetAdapter("claude")
+	if _, warnings, _ := RestrictTools(claude, agent, false); len(warnings) != 0 {
+		t.Errorf("expected no warnings for claude, got %v", warnings)
+	}
+}
+
+func TestRestrictToolsKeepsClaudePassthroughTools(t *testing.T) {
+	claude, ok := GetAdapter("claude")
+	if !ok {
+		t.Fatal("expected claude adapter to be registered")
+	}
+
+	agent := NewAgent("triager", "Triages issues")
+	agent.Tools = []string{"Read", "mcp__github__list_issues", "MultiEdit", "Teleport"}
+
+	restricted, warnings, err := RestrictTools(claude, agent, false)
+	if err != nil {
+		t.Fatalf("RestrictTools failed: %v", err)
+	}
+	if got := strings.Join(restricted.Tools, ","); got != "Read,mcp__github__list_issues,MultiEdit" {
+		t.Errorf("expected MCP and native tools to be kept, got %v", got)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `"Teleport"`) {
+		t.Errorf("expected one warning about Teleport, got %v", warnings)
+	}
+}
+
+func TestAgentKitEmitsMemoryAndWorkingDir(t *testing.T) {
+	adapter, ok := GetAdapter("agentkit")
+	if !ok {
+		t.Fatal("expected agentkit adapter to be registered")
+	}
+
+	agent := NewAgent("tracker", "Tracks progress")
+	agent.Memory = ".agents/tracker"
+	agent.WorkingDir = "services/api"
+
+	data, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	content := string(data)
+	if !strings.Contains(content, `"memory_dir": ".agents/tracker"`) {
+		t.Errorf("expected memory_dir in output, got:\n%s", content)
+	}
+	if !strings.Contains(content, `"working_dir": "services/api"`) {
+		t.Errorf("expected working_dir in output, got:\n%s", content)
+	}
+
+	parsed, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if parsed.Memory != agent.Memory || parsed.WorkingDir != agent.WorkingDir {
+		t.Errorf("expected memory and working dir to round-trip, got %q, %q", parsed.Memory, parsed.WorkingDir)
+	}
+}
+
+func TestAgentKitEmitsFallbackModel(t *testing.T) {
+	md := `---
+name: planner
+description: Plans work
+model: opus
+fallbackModel: sonnet
+---
+
+Plan the work.
+`
+
+	agent, err := ParseMarkdownAgent([]byte(md), "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if agent.FallbackModel != "sonnet" {
+		t.Fatalf("expected fallbackModel sonnet, got %q", agent.FallbackModel)
+	}
+	if !strings.Contains(string(MarshalMarkdownAgent(agent)), "fallbackModel: sonnet\n") {
+		t.Error("expected canonical markdown to keep fallbackModel")
+	}
+
+	adapter, _ := GetAdapter("agentkit")
+	out, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(out), `"fallback_model": "claude-3-5-sonnet-20241022"`) {
+		t.Errorf("expected fallback_model in agentkit output, got:\n%s", out)
+	}
+
+	claude, _ := GetAdapter("claude")
+	out, err = claude.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(out), "allback") {
+		t.Errorf("expected no fallback model in Claude output, got:\n%s", out)
+	}
+}
+
+func TestAgentKitModalitiesRoundTrip(t *testing.T) {
+	md := `---
+name: inspector
+description: Reviews screenshots and recordings
+modalities: [text, image, audio]
+---
+
+Describe what you see and hear.
+`
+
+	agent, err := ParseMarkdownAgent([]byte(md), "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if err := agent.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !strings.Contains(string(MarshalMarkdownAgent(agent)), "modalities: [text, image, audio]\n") {
+		t.Error("expected canonical markdown to keep modalities")
+	}
+
+	adapter, _ := GetAdapter("agentkit")
+	out, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	parsed, err := adapter.Parse(out)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := strings.Join(parsed.InputModalities(), ","); got != "text,image,audio" {
+		t.Errorf("expected modalities to round-trip, got %q", got)
+	}
+
+	textOnly := NewAgent("writer", "Writes docs")
+	if got := textOnly.InputModalities(); len(got) != 1 || got[0] != ModalityText {
+		t.Errorf("expected text by default, got %v", got)
+	}
+	out, err = adapter.Marshal(textOnly)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(out), "modalities") {
+		t.Errorf("expected no modalities for a text-only agent, got:\n%s", out)
+	}
+
+	agent.Modalities = []string{"video"}
+	if err := agent.Validate(); err == nil {
+		t.Error("expected an unknown modality to fail validation")
+	}
+}
+
+func TestKnowledgeSourcesIgnoredOutsideBedrock(t *testing.T) {
+	md := `---
+name: support
+description: Answers product questions
+knowledgeSources:
+  - type: bedrock-knowledge-base
+    uri: "KB12345"
+---
+
+Answer from the knowledge base.
+`
+
+	agent, err := ParseMarkdownAgent([]byte(md), "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if len(agent.KnowledgeSources) != 1 || agent.KnowledgeSources[0].URI != "KB12345" {
+		t.Fatalf("unexpected knowledge sources %+v", agent.KnowledgeSources)
+	}
+	if !strings.Contains(string(MarshalMarkdownAgent(agent)), "knowledgeSources:\n  - type: bedrock-knowledge-base\n    uri: \"KB12345\"\n") {
+		t.Error("expected canonical markdown to keep knowledgeSources")
+	}
+
+	for _, name := range []string{"claude", "kiro", "openai", "agentkit"} {
+		adapter, _ := GetAdapter(name)
+		out, err := adapter.Marshal(agent)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", name, err)
+		}
+		if strings.Contains(string(out), "KB12345") {
+			t.Errorf("%s: expected knowledge sources to be ignored, got:\n%s", name, out)
+		}
+	}
+
+	bedrock, _ := GetAdapter("aws-agentcore")
+	out, err := bedrock.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(out), "knowledgeBaseId: 'KB12345'") {
+		t.Errorf("expected knowledge base in AgentCore output, got:\n%s", out)
+	}
+
+	agent.KnowledgeSources = append(agent.KnowledgeSources, KnowledgeSource{Type: KnowledgeSourceBedrock})
+	if err := agent.Validate(); err == nil {
+		t.Error("expected a source without uri to fail validation")
+	}
+}
+
+func TestDeveloperInstructionsSplitForOpenAI(t *testing.T) {
+	adapter, ok := GetAdapter("openai")
+	if !ok {
+		t.Fatal("expected openai adapter to be registered")
+	}
+
+	agent := NewAgent("reviewer", "Reviews code")
+	agent.Instructions = "You are a code reviewer."
+	agent.DeveloperInstructions = "Only comment on changed lines."
+
+	data, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	content := string(data)
+	if !strings.Contains(content, `"role": "system"`) || !strings.Contains(content, `"role": "developer"`) {
+		t.Errorf("expected separate system and developer messages, got:\n%s", content)
+	}
+
+	parsed, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if parsed.Instructions != agent.Instructions || parsed.DeveloperInstructions != agent.DeveloperInstructions {
+		t.Errorf("expected instructions to round-trip, got %q / %q", parsed.Instructions, parsed.DeveloperInstructions)
+	}
+}
+
+func TestParallelToolCallsEmittedForOpenAI(t *testing.T) {
+	md := `---
+name: triage
+description: Classifies issues
+parallelToolCalls: false
+---
+
+Classify the issue.
+`
+
+	agent, err := ParseMarkdownAgent([]byte(md), "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if agent.ParallelToolCalls == nil || *agent.ParallelToolCalls {
+		t.Fatalf("expected parallelToolCalls false, got %v", agent.ParallelToolCalls)
+	}
+	if !strings.Contains(string(MarshalMarkdownAgent(agent)), "parallelToolCalls: false\n") {
+		t.Error("expected canonical markdown to keep parallelToolCalls")
+	}
+
+	adapter, _ := GetAdapter("openai")
+	out, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(out), `"parallel_tool_calls": false`) {
+		t.Errorf("expected parallel_tool_calls in OpenAI output, got:\n%s", out)
+	}
+	parsed, err := adapter.Parse(out)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if parsed.ParallelToolCalls == nil || *parsed.ParallelToolCalls {
+		t.Errorf("expected parallel_tool_calls to round-trip, got %v", parsed.ParallelToolCalls)
+	}
+
+	agent.ParallelToolCalls = nil
+	if out, _ := adapter.Marshal(agent); strings.Contains(string(out), "parallel_tool_calls") {
+		t.Errorf("expected no parallel_tool_calls when unset, got:\n%s", out)
+	}
+
+	claude, _ := GetAdapter("claude")
+	enabled := true
+	agent.ParallelToolCalls = &enabled
+	if out, _ := claude.Marshal(agent); strings.Contains(strings.ToLower(string(out)), "parallel") {
+		t.Errorf("expected claude to omit the toggle, got:\n%s", out)
+	}
+}
+
+func TestMaxTurnsEmittedForLoopControl(t *testing.T) {
+	md := `---
+name: researcher
+description: Researches a topic
+maxTurns: 12
+stopConditions: [submit_report]
+---
+
+Research the topic.
+`
+
+	agent, err := ParseMarkdownAgent([]byte(md), "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if agent.MaxTurns == nil || *agent.MaxTurns != 12 {
+		t.Fatalf("expected maxTurns 12, got %v", agent.MaxTurns)
+	}
+	canonical := string(MarshalMarkdownAgent(agent))
+	if !strings.Contains(canonical, "maxTurns: 12\n") || !strings.Contains(canonical, "stopConditions: [submit_report]\n") {
+		t.Errorf("expected canonical markdown to keep loop limits, got:\n%s", canonical)
+	}
+
+	claude, _ := GetAdapter("claude")
+	out, err := claude.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(out), "maxTurns: 12\n") {
+		t.Errorf("expected maxTurns in Claude output, got:\n%s", out)
+	}
+	parsed, err := claude.Parse(out)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if parsed.MaxTurns == nil || *parsed.MaxTurns != 12 {
+		t.Errorf("expected maxTurns to round-trip through Claude, got %v", parsed.MaxTurns)
+	}
+
+	openai, _ := GetAdapter("openai")
+	out, err = openai.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, unwanted := range []string{"max_turns", "stop_at_tool_names"} {
+		if strings.Contains(string(out), unwanted) {
+			t.Errorf("expected no Agents SDK option %s in OpenAI request, got:\n%s", unwanted, out)
+		}
+	}
+	if warnings := CheckLoopControl(openai, agent); len(warnings) != 2 {
+		t.Errorf("expected warnings for both ignored loop limits, got %v", warnings)
+	}
+	if warnings := CheckLoopControl(claude, agent); len(warnings) != 1 || !strings.Contains(warnings[0], "stopConditions") {
+		t.Errorf("expected a stopConditions warning for Claude, got %v", warnings)
+	}
+
+	gemini, _ := GetAdapter("gemini")
+	if out, _ := gemini.Marshal(agent); strings.Contains(strings.ToLower(string(out)), "turns") {
+		t.Errorf("expected adapters without loop control to ignore maxTurns, got:\n%s", out)
+	}
+
+	zero := 0
+	agent.MaxTurns = &zero
+	if err := agent.Validate(); err == nil {
+		t.Error("expected maxTurns 0 to fail validation")
+	}
+}
+
+func TestHelpURLRoundTripsThroughClaude(t *testing.T) {
+	md := `---
+name: reviewer
+description: Reviews pull requests
+helpUrl: https://example.com/docs/reviewer
+---
+
+Review carefully.
+`
+
+	agent, err := ParseMarkdownAgent([]byte(md), "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if agent.HelpURL != "https://example.com/docs/reviewer" {
+		t.Fatalf("expected helpUrl from frontmatter, got %q", agent.HelpURL)
+	}
+	if !strings.Contains(string(MarshalMarkdownAgent(agent)), `helpUrl: "https://example.com/docs/reviewer"`+"\n") {
+		t.Error("expected canonical markdown to keep helpUrl")
+	}
+
+	claude, _ := GetAdapter("claude")
+	out, err := claude.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	parsed, err := claude.Parse(out)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if parsed.HelpURL != agent.HelpURL {
+		t.Errorf("expected helpUrl to round-trip through Claude, got %q in:\n%s", parsed.HelpURL, out)
+	}
+
+	agent.HelpURL = "ftp://example.com"
+	if err := agent.Validate(); err == nil {
+		t.Error("expected a non-http helpUrl to fail validation")
+	}
+}
+
+func TestClaudeParsesMultiLineFrontmatter(t *testing.T) {
+	md := `---
+name: release-coordinator
+description: >
+  Coordinates releases across teams.
+  Use when: a version is ready to ship.
+tools:
+  - Read
+  - Grep
+  - Bash
+skills: [versioning, changelog]
+model: "sonnet"
+---
+
+Coordinate the release.
+`
+
+	claude, _ := GetAdapter("claude")
+	agent, err := claude.Parse([]byte(md))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if want := "Coordinates releases across teams. Use when: a version is ready to ship."; agent.Description != want {
+		t.Errorf("Description = %q, want %q", agent.Description, want)
+	}
+	if strings.Join(agent.Tools, ",") != "Read,Grep,Bash" {
+		t.Errorf("Tools = %v, want [Read Grep Bash]", agent.Tools)
+	}
+	if strings.Join(agent.Skills, ",") != "versioning,changelog" {
+		t.Errorf("Skills = %v, want [versioning changelog]", agent.Skills)
+	}
+	if agent.Model != "sonnet" || agent.Instructions != "Coordinate the release." {
+		t.Errorf("unexpected model %q or instructions %q", agent.Model, agent.Instructions)
+	}
+
+	literal := "---\nname: reviewer\ndescription: |\n  Reviews code.\n  Flags risky changes.\n---\n\nReview.\n"
+	agent, err = claude.Parse([]byte(literal))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if agent.Description != "Reviews code.\nFlags risky changes." {
+		t.Errorf("expected a literal block description, got %q", agent.Description)
+	}
+
+	// Frontmatter that is not valid YAML is still read line by line
+	loose := "---\nname: reviewer\ndescription: Reviews code: carefully\ntools: Read, Grep\n---\n\nReview.\n"
+	agent, err = claude.Parse([]byte(loose))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if agent.Description != "Reviews code: carefully" || strings.Join(agent.Tools, ",") != "Read,Grep" {
+		t.Errorf("unexpected description %q or tools %v", agent.Description, agent.Tools)
+	}
+}
+
+func TestFileNameSluggedPerTool(t *testing.T) {
+	for _, tt := range []struct{ in, kebab, snake string }{
+		{"code-reviewer", "code-reviewer", "code_reviewer"},
+		{"code_reviewer", "code-reviewer", "code_reviewer"},
+		{"Code Reviewer", "code-reviewer", "code_reviewer"},
+		{"CodeReviewer2", "code-reviewer2", "code_reviewer2"},
+	} {
+		if got := KebabCase(tt.in); got != tt.kebab {
+			t.Errorf("KebabCase(%q) = %q, want %q", tt.in, got, tt.kebab)
+		}
+		if got := SnakeCase(tt.in); got != tt.snake {
+			t.Errorf("SnakeCase(%q) = %q, want %q", tt.in, got, tt.snake)
+		}
+	}
+
+	agent := NewAgent("code-reviewer", "Reviews code")
+	agent.Instructions = "Review the diff."
+	for _, tt := range []struct{ tool, file string }{
+		{"claude", "code-reviewer.md"},
+		{"kiro", "code_reviewer.json"},
+		{"gemini", "code-reviewer.toml"},
+	} {
+		dir := t.TempDir()
+		if err := WriteAgentsToDir([]*Agent{agent}, dir, tt.tool); err != nil {
+			t.Fatalf("WriteAgentsToDir(%s) failed: %v", tt.tool, err)
+		}
+		adapter, _ := GetAdapter(tt.tool)
+		got, err := adapter.ReadFile(filepath.Join(dir, tt.file))
+		if err != nil {
+			t.Fatalf("%s: expected %s: %v", tt.tool, tt.file, err)
+		}
+		if got.Name != "code-reviewer" {
+			t.Errorf("%s: expected in-file name code-reviewer, got %q", tt.tool, got.Name)
+		}
+	}
+
+	// Names that slug to the same file are rejected before anything is written
+	other := NewAgent("code_reviewer", "Reviews code too")
+	other.Instructions = "Review the diff."
+	dir := t.TempDir()
+	err := WriteAgentsToDir([]*Agent{agent, other}, dir, "claude")
+	var fe *FileNameError
+	if !errors.As(err, &fe) || fe.FileName != "code-reviewer.md" {
+		t.Fatalf("expected FileNameError for code-reviewer.md, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected no files written, got %d", len(entries))
+	}
+}
+
+func TestExperimentalPassedThrough(t *testing.T) {
+	md := `---
+name: reviewer
+description: Reviews pull requests
+model: sonnet
+experimental:
+  effort: high
+  model: opus
+---
+
+Review carefully.
+`
+
+	agent, err := ParseMarkdownAgent([]byte(md), "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if agent.Experimental["effort"] != "high" {
+		t.Fatalf("expected experimental options from frontmatter, got %v", agent.Experimental)
+	}
+	reparsed, err := ParseMarkdownAgent(MarshalMarkdownAgent(agent), "")
+	if err != nil || reparsed.Experimental["effort"] != "high" {
+		t.Errorf("expected experimental options to round-trip, got %v (%v)", reparsed.Experimental, err)
+	}
+
+	claude, _ := GetAdapter("claude")
+	out, err := claude.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(out), "effort: \"high\"\n") {
+		t.Errorf("expected effort in Claude frontmatter, got:\n%s", out)
+	}
+	if strings.Contains(string(out), "opus") {
+		t.Errorf("expected experimental options not to override model, got:\n%s", out)
+	}
+
+	kiro, _ := GetAdapter("kiro")
+	out, err = kiro.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(out), `"effort": "high"`) {
+		t.Errorf("expected effort in Kiro JSON, got:\n%s", out)
+	}
+
+	if warnings := CheckExperimental(kiro, agent); len(warnings) != 1 || !strings.Contains(warnings[0], "unvalidated") {
+		t.Errorf("expected an unvalidated warning, got %v", warnings)
+	}
+	gemini, _ := GetAdapter("gemini")
+	if warnings := CheckExperimental(gemini, agent); len(warnings) != 1 || !strings.Contains(warnings[0], "ignored") {
+		t.Errorf("expected an ignored warning for gemini, got %v", warnings)
+	}
+}
+
+func TestResponseSchemaEmittedForOpenAI(t *testing.T) {
+	md := `---
+name: triage
+description: Classifies issues
+responseSchema:
+  type: object
+  properties:
+    severity:
+      type: string
+  required: [severity]
+  additionalProperties: false
+---
+
+Classify the issue.
+`
+
+	spec, err := ParseMarkdownAgent([]byte(md), "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+
+	adapter, _ := GetAdapter("openai")
+	out, err := adapter.Marshal(spec)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	content := string(out)
+	for _, want := range []string{`"type": "json_schema"`, `"name": "triage_response"`, `"severity"`, `"strict": true`} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %s in OpenAI output, got:\n%s", want, content)
+		}
+	}
+
+	parsed, err := adapter.Parse(out)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !strings.Contains(string(parsed.ResponseSchema), `"severity"`) {
+		t.Errorf("expected schema to round-trip, got %s", parsed.ResponseSchema)
+	}
+
+	// An open object is rejected by strict mode, so it is sent non-strict
+	open := NewAgent("triage", "Classifies issues")
+	open.ResponseSchema = []byte(`{"type":"object","properties":{"severity":{"type":"string"}}}`)
+	out, err = adapter.Marshal(open)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(out), `"strict"`) {
+		t.Errorf("expected a non-strict format for an open schema, got:\n%s", out)
+	}
+
+	claude, _ := GetAdapter("claude")
+	agent := NewAgent("triage", "Classifies issues")
+	agent.ResponseSchema = []byte(`{"type":"object"}`)
+	if warnings := CheckResponseSchema(claude, agent); len(warnings) != 1 || !strings.Contains(warnings[0], "claude") {
+		t.Errorf("expected a warning for claude, got %v", warnings)
+	}
+	if warnings := CheckResponseSchema(adapter, agent); len(warnings) != 0 {
+		t.Errorf("expected no warning for openai, got %v", warnings)
+	}
+}
+
+func TestDeveloperInstructionsMergedForClaude(t *testing.T) {
+	adapter, ok := GetAdapter("claude")
+	if !ok {
+		t.Fatal("expected claude adapter to be registered")
+	}
+
+	agent := NewAgent("reviewer", "Reviews code")
+	agent.Instructions = "You are a code reviewer."
+	agent.DeveloperInstructions = "Only comment on changed lines."
+
+	data, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), "You are a code reviewer.\n\nOnly comment on changed lines.") {
+		t.Errorf("expected merged instructions, got:\n%s", data)
+	}
+}
+
+func TestKeywordsAppendedToClaudeDescription(t *testing.T) {
+	adapter, ok := GetAdapter("claude")
+	if !ok {
+		t.Fatal("expected claude adapter to be registered")
+	}
+
+	agent := NewAgent("security-reviewer", "Reviews code for vulnerabilities")
+	agent.Keywords = []string{"security", "CVE", "threat model"}
+
+	data, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := "description: Reviews code for vulnerabilities Keywords: security, CVE, threat model.\n"
+	if !strings.Contains(string(data), want) {
+		t.Errorf("expected keywords in description, got:\n%s", data)
+	}
+
+	parsed, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if parsed.Description != "Reviews code for vulnerabilities" {
+		t.Errorf("expected clean description, got %q", parsed.Description)
+	}
+	if strings.Join(parsed.Keywords, "|") != "security|CVE|threat model" {
+		t.Errorf("expected keywords to parse back, got %v", parsed.Keywords)
+	}
+}
+
+func TestClaudeEmitsDeniedTools(t *testing.T) {
+	agent := NewAgent("reviewer", "Reviews code").WithTools("Read", "Grep")
+	agent.DisallowedTools = []string{"Bash", "WebFetch"}
+
+	if perms := agent.ToolPermissions(); len(perms.Deny) != 2 || perms.Deny[0] != "Bash" {
+		t.Errorf("expected deny rules [Bash WebFetch], got %+v", perms)
+	}
+
+	adapter, _ := GetAdapter("claude")
+	out, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(out), "disallowedTools: [Bash, WebFetch]\n") {
+		t.Errorf("expected denied tools in Claude frontmatter, got:\n%s", out)
+	}
+
+	parsed, err := adapter.Parse(out)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(parsed.DisallowedTools) != 2 || parsed.DisallowedTools[1] != "WebFetch" {
+		t.Errorf("expected denied tools to round-trip, got %v", parsed.DisallowedTools)
+	}
+}
+
+func TestApprovalRoundTripsThroughClaudePermissionMode(t *testing.T) {
+	adapter, ok := GetAdapter("claude")
+	if !ok {
+		t.Fatal("expected claude adapter to be registered")
+	}
+
+	for approval, mode := range map[Approval]string{
+		ApprovalAuto:  "acceptEdits",
+		ApprovalAsk:   "default",
+		ApprovalNever: "plan",
+	} {
+		agent := NewAgent("deployer", "Deploys services")
+		agent.Approval = approval
+
+		data, err := adapter.Marshal(agent)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if !strings.Contains(string(data), "permissionMode: "+mode+"\n") {
+			t.Errorf("expected permissionMode %s for %s, got:\n%s", mode, approval, data)
+		}
+
+		parsed, err := adapter.Parse(data)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		if parsed.Approval != approval {
+			t.Errorf("expected approval %q to round-trip, got %q", approval, parsed.Approval)
+		}
+	}
+
+	agent := NewAgent("deployer", "Deploys services")
+	agent.Approval = "sometimes"
+	if err := agent.Validate(); err == nil {
+		t.Error("expected an invalid approval policy to fail validation")
+	}
+}
+
+func TestGuardrailsWarnOutsideBedrock(t *testing.T) {
+	md := `---
+name: support
+description: Answers product questions
+guardrails:
+  id: "gr123"
+  blockedTopics:
+    - "Investment advice"
+  piiFilters: [EMAIL, PHONE]
+---
+
+Answer product questions.
+`
+
+	agent, err := ParseMarkdownAgent([]byte(md), "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if g := agent.Guardrails; g == nil || g.ID != "gr123" || len(g.BlockedTopics) != 1 || len(g.PIIFilters) != 2 {
+		t.Fatalf("unexpected guardrails %+v", agent.Guardrails)
+	}
+	if !strings.Contains(string(MarshalMarkdownAgent(agent)), "guardrails:\n  id: \"gr123\"\n  blockedTopics:\n    - \"Investment advice\"\n  piiFilters: [EMAIL, PHONE]\n") {
+		t.Errorf("expected canonical markdown to keep guardrails, got:\n%s", MarshalMarkdownAgent(agent))
+	}
+
+	claude, _ := GetAdapter("claude")
+	if warnings := CheckGuardrails(claude, agent); len(warnings) != 1 || !strings.Contains(warnings[0], "guardrails are not supported by claude") {
+		t.Errorf("expected a warning for claude, got %v", warnings)
+	}
+	bedrock, _ := GetAdapter("aws-agentcore")
+	if warnings := CheckGuardrails(bedrock, agent); len(warnings) != 0 {
+		t.Errorf("expected no warning for aws-agentcore, got %v", warnings)
+	}
+}
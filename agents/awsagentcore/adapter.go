@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -38,6 +40,22 @@ func (a *Adapter) DefaultDir() string {
 	return "cdk"
 }
 
+// SupportedTools returns the canonical tools with an AgentCore Lambda action.
+func (a *Adapter) SupportedTools() []string {
+	tools := make([]string, 0, len(toolToAction))
+	for tool := range toolToAction {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// SupportsGuardrails reports that agents are deployed with Bedrock
+// guardrails.
+func (a *Adapter) SupportsGuardrails() bool {
+	return true
+}
+
 // Parse is not typically used for CDK output (it's a generator, not a reader).
 func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
 	return nil, &core.ParseError{Format: "aws-agentcore", Err: fmt.Errorf("parsing CDK output not supported")}
@@ -45,7 +63,7 @@ func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
 
 // Marshal converts canonical Agent to CDK construct bytes.
 func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
-	return generateAgentConstruct(agent)
+	return generateAgentConstruct(agent, DefaultAgentCoreConfig())
 }
 
 // ReadFile is not typically used for CDK output.
@@ -77,7 +95,21 @@ type AgentCoreConfig struct {
 	Region          string `json:"region"`
 	FoundationModel string `json:"foundation_model"`
 	LambdaRuntime   string `json:"lambda_runtime"`
+	Handler         string `json:"handler"` // Lambda handler for action group functions; empty means unset
 	StackName       string `json:"stack_name"`
+
+	// Stack-wide runtime defaults, overridden per agent by Agent.Runtime.
+	Timeout    int `json:"timeout"`     // seconds
+	Retries    int `json:"retries"`     // retries for failed invocations
+	MemorySize int `json:"memory_size"` // MB
+
+	// Stack-wide throughput limits; zero means unlimited.
+	ReservedConcurrency int `json:"reserved_concurrency"` // concurrent invocations
+	RateLimit           int `json:"rate_limit"`           // invocations per minute
+
+	// Stack-wide resource tags, merged with each agent's Runtime.Tags (the
+	// agent's value wins for a shared key).
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // DefaultAgentCoreConfig returns default configuration.
@@ -87,7 +119,80 @@ func DefaultAgentCoreConfig() *AgentCoreConfig {
 		FoundationModel: "anthropic.claude-3-sonnet-20240229-v1:0",
 		LambdaRuntime:   "python3.11",
 		StackName:       "MultiAgentStack",
+		Timeout:         600,
+		Retries:         2,
+		MemorySize:      512,
+	}
+}
+
+// resolveRuntime returns the agent's runtime limits, filling unset values
+// from the stack defaults in config. Retries is always set; an agent's
+// explicit 0 overrides a non-zero default.
+func resolveRuntime(agent *core.Agent, config *AgentCoreConfig) core.Runtime {
+	retries := config.Retries
+	rt := core.Runtime{
+		Entrypoint:  config.Handler,
+		Timeout:     config.Timeout,
+		Retries:     &retries,
+		Memory:      config.MemorySize,
+		Concurrency: config.ReservedConcurrency,
+		RateLimit:   config.RateLimit,
+		Tags:        core.MergeTags(config.Tags, nil),
+	}
+	if agent.Runtime == nil {
+		return rt
+	}
+	rt.Tags = core.MergeTags(config.Tags, agent.Runtime.Tags)
+	if agent.Runtime.Entrypoint != "" {
+		rt.Entrypoint = agent.Runtime.Entrypoint
+	}
+	if agent.Runtime.Timeout > 0 {
+		rt.Timeout = agent.Runtime.Timeout
+	}
+	if agent.Runtime.Retries != nil {
+		rt.Retries = agent.Runtime.Retries
+	}
+	if agent.Runtime.Memory > 0 {
+		rt.Memory = agent.Runtime.Memory
+	}
+	if agent.Runtime.Concurrency > 0 {
+		rt.Concurrency = agent.Runtime.Concurrency
+	}
+	if agent.Runtime.RateLimit > 0 {
+		rt.RateLimit = agent.Runtime.RateLimit
+	}
+	return rt
+}
+
+// templateFuncs are the functions available to the CDK templates.
+var templateFuncs = template.FuncMap{
+	"optional":       optionalNumber,
+	"optionalString": optionalString,
+	"quote":          escapeQuote,
+}
+
+// optionalNumber renders a limit as a TypeScript number, or undefined when
+// it is unset.
+func optionalNumber(n int) string {
+	if n <= 0 {
+		return "undefined"
+	}
+	return strconv.Itoa(n)
+}
+
+// optionalString renders a setting as a TypeScript string literal, or
+// undefined when it is unset.
+func optionalString(s string) string {
+	if s == "" {
+		return "undefined"
 	}
+	return "'" + escapeQuote(s) + "'"
+}
+
+// escapeQuote escapes a string for a single-quoted TypeScript literal.
+func escapeQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "'", `\'`)
 }
 
 // Model mapping is delegated to multi-agent-spec BedrockModels.
@@ -103,8 +208,8 @@ var toolToAction = map[string]string{
 	"Bash":      "execute_command",
 }
 
-func generateAgentConstruct(agent *core.Agent) ([]byte, error) {
-	tmpl, err := template.New("agent").Parse(agentConstructTemplate)
+func generateAgentConstruct(agent *core.Agent, config *AgentCoreConfig) ([]byte, error) {
+	tmpl, err := template.New("agent").Funcs(templateFuncs).Parse(agentConstructTemplate)
 	if err != nil {
 		return nil, &core.MarshalError{Format: "aws-agentcore", Err: err}
 	}
@@ -114,9 +219,13 @@ func generateAgentConstruct(agent *core.Agent) ([]byte, error) {
 		"Name":            agent.Name,
 		"NamePascal":      toPascalCase(agent.Name),
 		"Description":     escapeString(agent.Description),
-		"Instructions":    escapeString(agent.Instructions),
+		"Instructions":    escapeString(core.TransformInstructions("aws-agentcore", agent.CombinedInstructions())),
 		"FoundationModel": getFoundationModel(agent.Model),
 		"Actions":         getActions(agent.Tools),
+		"LambdaRuntime":   config.LambdaRuntime,
+		"Runtime":         resolveRuntime(agent, config),
+		"KnowledgeBases":  agent.KnowledgeSourcesOfType(core.KnowledgeSourceBedrock),
+		"Guardrails":      agent.Guardrails,
 	}
 
 	var buf bytes.Buffer
@@ -184,23 +293,58 @@ func getActions(tools []string) []string {
 
 const agentConstructTemplate = `import * as cdk from 'aws-cdk-lib';
 import * as bedrock from 'aws-cdk-lib/aws-bedrock';
+{{- if .Actions}}
 import * as lambda from 'aws-cdk-lib/aws-lambda';
+{{- end}}
 import * as iam from 'aws-cdk-lib/aws-iam';
 import { Construct } from 'constructs';
 
 export interface {{.NamePascal}}AgentProps {
   readonly foundationModel?: string;
+  readonly timeoutSeconds?: number;
+  readonly retries?: number;
+  readonly memorySize?: number;
+  readonly reservedConcurrency?: number;
+  readonly rateLimitPerMinute?: number;
+  readonly handler?: string;
+  readonly actionCodePath?: string;
 }
 
 export class {{.NamePascal}}Agent extends Construct {
   public readonly agent: bedrock.CfnAgent;
   public readonly agentAlias: bedrock.CfnAgentAlias;
+  public readonly timeoutSeconds: number;
+  public readonly retries: number;
+  public readonly memorySize: number;
+  public readonly reservedConcurrency?: number;
+  public readonly rateLimitPerMinute?: number;
+  public readonly handler?: string;
 
   constructor(scope: Construct, id: string, props?: {{.NamePascal}}AgentProps) {
     super(scope, id);
 
     const foundationModel = props?.foundationModel ?? '{{.FoundationModel}}';
 
+    // Runtime limits, also used by the action group Lambda function
+    this.timeoutSeconds = props?.timeoutSeconds ?? {{.Runtime.Timeout}};
+    this.retries = props?.retries ?? {{.Runtime.Retries}};
+    this.memorySize = props?.memorySize ?? {{.Runtime.Memory}};
+
+    // Throughput limits for the action group Lambda function; undefined
+    // means unlimited
+    this.reservedConcurrency = props?.reservedConcurrency ?? {{optional .Runtime.Concurrency}};
+    this.rateLimitPerMinute = props?.rateLimitPerMinute ?? {{optional .Runtime.RateLimit}};
+
+    // Entrypoint of the action group Lambda function (the Lambda handler)
+    this.handler = props?.handler ?? {{optionalString .Runtime.Entrypoint}};
+{{- if .Runtime.Tags}}
+
+    // Cost allocation tags, applied to every resource in this construct
+{{- range $key, $value := .Runtime.Tags}}
+    cdk.Tags.of(this).add('{{quote $key}}', '{{quote $value}}');
+{{- end}}
+{{- end}}
+
     // IAM role for the agent
     const agentRole = new iam.Role(this, 'AgentRole', {
       assumedBy: new iam.ServicePrincipal('bedrock.amazonaws.com'),
@@ -211,6 +355,51 @@ export class {{.NamePascal}}Agent extends Construct {
 
     // Agent instruction
     const instruction = ` + "`" + `{{.Instructions}}` + "`" + `;
+{{- if .Actions}}
+
+    // Lambda function serving the agent's action group
+    const actionFunction = new lambda.Function(this, 'ActionFunction', {
+      runtime: new lambda.Runtime('{{quote .LambdaRuntime}}'),
+      handler: this.handler ?? 'index.handler',
+      code: lambda.Code.fromAsset(props?.actionCodePath ?? 'lambda/{{.Name}}'),
+      timeout: cdk.Duration.seconds(Math.min(this.timeoutSeconds, 900)),
+      memorySize: this.memorySize,
+      reservedConcurrentExecutions: this.reservedConcurrency,
+      retryAttempts: Math.min(this.retries, 2),
+      // Lambda has no per-minute limit. The limit is only passed to the
+      // handler, which must enforce it itself.
+      environment: this.rateLimitPerMinute
+        ? { RATE_LIMIT_PER_MINUTE: String(this.rateLimitPerMinute) }
+        : undefined,
+    });
+{{- end}}
+{{- with .Guardrails}}{{if and (not .ID) (or .BlockedTopics .PIIFilters)}}
+
+    // Guardrail filtering the agent's input and output
+    const guardrail = new bedrock.CfnGuardrail(this, 'Guardrail', {
+      name: '{{$.Name}}-guardrail',
+      blockedInputMessaging: 'Sorry, I cannot help with that request.',
+      blockedOutputsMessaging: 'Sorry, I cannot provide that response.',
+{{- if .BlockedTopics}}
+      topicPolicyConfig: {
+        topicsConfig: [
+{{- range .BlockedTopics}}
+          { name: '{{quote .}}', definition: 'Discussion of {{quote .}}.', type: 'DENY' },
+{{- end}}
+        ],
+      },
+{{- end}}
+{{- if .PIIFilters}}
+      sensitiveInformationPolicyConfig: {
+        piiEntitiesConfig: [
+{{- range .PIIFilters}}
+          { type: '{{quote .}}', action: 'ANONYMIZE' },
+{{- end}}
+        ],
+      },
+{{- end}}
+    });
+{{- end}}{{end}}
 
     // Create the Bedrock Agent
     this.agent = new bedrock.CfnAgent(this, 'Agent', {
@@ -219,9 +408,57 @@ export class {{.NamePascal}}Agent extends Construct {
       foundationModel: foundationModel,
       instruction: instruction,
       agentResourceRoleArn: agentRole.roleArn,
-      idleSessionTtlInSeconds: 600,
+      idleSessionTtlInSeconds: this.timeoutSeconds,
       autoPrepare: true,
+{{- if .Actions}}
+      actionGroups: [
+        {
+          actionGroupName: '{{.Name}}-actions',
+          actionGroupExecutor: { lambda: actionFunction.functionArn },
+          functionSchema: {
+            functions: [
+{{- range .Actions}}
+              { name: '{{.}}' },
+{{- end}}
+            ],
+          },
+        },
+      ],
+{{- end}}
+{{- if .KnowledgeBases}}
+      knowledgeBases: [
+{{- range .KnowledgeBases}}
+        {
+          knowledgeBaseId: '{{quote .ID}}',
+          description: 'Knowledge base for {{$.Name}}',
+          knowledgeBaseState: 'ENABLED',
+        },
+{{- end}}
+      ],
+{{- end}}
+{{- with .Guardrails}}
+{{- if .ID}}
+      guardrailConfiguration: {
+        guardrailIdentifier: '{{quote .ID}}',
+        guardrailVersion: '{{if .Version}}{{quote .Version}}{{else}}DRAFT{{end}}',
+      },
+{{- else if or .BlockedTopics .PIIFilters}}
+      guardrailConfiguration: {
+        guardrailIdentifier: guardrail.attrGuardrailId,
+        guardrailVersion: guardrail.attrVersion,
+      },
+{{- end}}
+{{- end}}
+    });
+{{- if .Actions}}
+
+    // Only this agent may invoke the action group Lambda
+    actionFunction.addPermission('BedrockInvoke', {
+      principal: new iam.ServicePrincipal('bedrock.amazonaws.com'),
+      sourceArn: this.agent.attrAgentArn,
+      sourceAccount: cdk.Stack.of(this).account,
     });
+{{- end}}
 
     // Create agent alias for invocation
     this.agentAlias = new bedrock.CfnAgentAlias(this, 'AgentAlias', {
@@ -244,7 +481,7 @@ func GenerateStack(teamName string, agents []*core.Agent, config *AgentCoreConfi
 		config = DefaultAgentCoreConfig()
 	}
 
-	tmpl, err := template.New("stack").Parse(stackTemplate)
+	tmpl, err := template.New("stack").Funcs(templateFuncs).Parse(stackTemplate)
 	if err != nil {
 		return nil, &core.MarshalError{Format: "aws-agentcore", Err: err}
 	}
@@ -254,6 +491,7 @@ func GenerateStack(teamName string, agents []*core.Agent, config *AgentCoreConfi
 		Name       string
 		NamePascal string
 		NameCamel  string
+		Runtime    core.Runtime
 	}
 	agentsData := make([]agentData, len(agents))
 	for i, agent := range agents {
@@ -261,6 +499,7 @@ func GenerateStack(teamName string, agents []*core.Agent, config *AgentCoreConfi
 			Name:       agent.Name,
 			NamePascal: toPascalCase(agent.Name),
 			NameCamel:  toCamelCase(agent.Name),
+			Runtime:    resolveRuntime(agent, config),
 		}
 	}
 
@@ -304,6 +543,18 @@ export class {{.TeamPascal}}Stack extends cdk.Stack {
     // {{.NamePascal}} Agent
     this.{{.NameCamel}}Agent = new {{.NamePascal}}Agent(this, '{{.NamePascal}}', {
       foundationModel,
+      timeoutSeconds: {{.Runtime.Timeout}},
+      retries: {{.Runtime.Retries}},
+      memorySize: {{.Runtime.Memory}},
+{{- if .Runtime.Concurrency}}
+      reservedConcurrency: {{.Runtime.Concurrency}},
+{{- end}}
+{{- if .Runtime.RateLimit}}
+      rateLimitPerMinute: {{.Runtime.RateLimit}},
+{{- end}}
+{{- if .Runtime.Entrypoint}}
+      handler: {{optionalString .Runtime.Entrypoint}},
+{{- end}}
     });
 {{end}}
   }
@@ -445,7 +696,7 @@ func WriteCDKProject(teamName string, agents []*core.Agent, outputDir string, co
 
 	// Write individual agent constructs
 	for _, agent := range agents {
-		agentTS, err := generateAgentConstruct(agent)
+		agentTS, err := generateAgentConstruct(agent, config)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,282 @@
+package awsagentcore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+)
+
+func TestWriteCDKProjectRuntimeOverride(t *testing.T) {
+	config := DefaultAgentCoreConfig()
+	config.Timeout = 300
+
+	fast := &core.Agent{Spec: core.Spec{Name: "fast-agent", Description: "Uses stack defaults"}}
+	slow := &core.Agent{
+		Spec:    core.Spec{Name: "slow-agent", Description: "Needs more time"},
+		Runtime: &core.Runtime{Timeout: 900, Memory: 2048},
+	}
+	noRetries := 0
+	once := &core.Agent{
+		Spec:    core.Spec{Name: "once-agent", Description: "Must not retry"},
+		Runtime: &core.Runtime{Retries: &noRetries},
+	}
+
+	dir := t.TempDir()
+	if err := WriteCDKProject("team", []*core.Agent{fast, slow, once}, dir, config); err != nil {
+		t.Fatalf("WriteCDKProject failed: %v", err)
+	}
+
+	stack := readFile(t, filepath.Join(dir, "lib", "team-stack.ts"))
+	for _, want := range []string{
+		"new FastAgentAgent(this, 'FastAgent', {\n      foundationModel,\n      timeoutSeconds: 300,\n      retries: 2,\n      memorySize: 512,",
+		"new SlowAgentAgent(this, 'SlowAgent', {\n      foundationModel,\n      timeoutSeconds: 900,\n      retries: 2,\n      memorySize: 2048,",
+		"new OnceAgentAgent(this, 'OnceAgent', {\n      foundationModel,\n      timeoutSeconds: 300,\n      retries: 0,",
+	} {
+		if !strings.Contains(stack, want) {
+			t.Errorf("stack missing %q\n%s", want, stack)
+		}
+	}
+
+	construct := readFile(t, filepath.Join(dir, "lib", "agents", "slow-agent.ts"))
+	if !strings.Contains(construct, "props?.timeoutSeconds ?? 900;") {
+		t.Errorf("construct should default to the per-agent timeout\n%s", construct)
+	}
+	if !strings.Contains(construct, "idleSessionTtlInSeconds: this.timeoutSeconds,") {
+		t.Errorf("construct should use the resolved timeout for the session TTL\n%s", construct)
+	}
+
+	construct = readFile(t, filepath.Join(dir, "lib", "agents", "fast-agent.ts"))
+	if !strings.Contains(construct, "props?.timeoutSeconds ?? 300;") {
+		t.Errorf("construct should default to the stack timeout\n%s", construct)
+	}
+}
+
+func TestWriteCDKProjectConcurrency(t *testing.T) {
+	config := DefaultAgentCoreConfig()
+	config.RateLimit = 120
+
+	limited := &core.Agent{
+		Spec:    core.Spec{Name: "limited-agent", Description: "Calls a rate-limited API", Tools: []string{"WebFetch"}},
+		Runtime: &core.Runtime{Concurrency: 5, RateLimit: 30},
+	}
+	open := &core.Agent{Spec: core.Spec{Name: "open-agent", Description: "Uses stack defaults"}}
+
+	dir := t.TempDir()
+	if err := WriteCDKProject("team", []*core.Agent{limited, open}, dir, config); err != nil {
+		t.Fatalf("WriteCDKProject failed: %v", err)
+	}
+
+	stack := readFile(t, filepath.Join(dir, "lib", "team-stack.ts"))
+	for _, want := range []string{
+		"memorySize: 512,\n      reservedConcurrency: 5,\n      rateLimitPerMinute: 30,\n    });",
+		"new OpenAgentAgent(this, 'OpenAgent', {\n      foundationModel,\n      timeoutSeconds: 600,\n      retries: 2,\n      memorySize: 512,\n      rateLimitPerMinute: 120,\n    });",
+	} {
+		if !strings.Contains(stack, want) {
+			t.Errorf("stack missing %q\n%s", want, stack)
+		}
+	}
+
+	construct := readFile(t, filepath.Join(dir, "lib", "agents", "limited-agent.ts"))
+	for _, want := range []string{
+		"props?.reservedConcurrency ?? 5;",
+		"props?.rateLimitPerMinute ?? 30;",
+		"memorySize: this.memorySize,\n      reservedConcurrentExecutions: this.reservedConcurrency,\n      retryAttempts: Math.min(this.retries, 2),",
+		"{ RATE_LIMIT_PER_MINUTE: String(this.rateLimitPerMinute) }",
+	} {
+		if !strings.Contains(construct, want) {
+			t.Errorf("construct missing %q\n%s", want, construct)
+		}
+	}
+
+	construct = readFile(t, filepath.Join(dir, "lib", "agents", "open-agent.ts"))
+	if !strings.Contains(construct, "props?.reservedConcurrency ?? undefined;") {
+		t.Errorf("construct should leave concurrency unlimited\n%s", construct)
+	}
+}
+
+func TestWriteCDKProjectEntrypoint(t *testing.T) {
+	config := DefaultAgentCoreConfig()
+	config.Handler = "index.handler"
+
+	custom := &core.Agent{
+		Spec:    core.Spec{Name: "custom-agent", Description: "Ships its own handler", Tools: []string{"Read", "WebSearch"}},
+		Runtime: &core.Runtime{Entrypoint: "agent.main"},
+	}
+	plain := &core.Agent{Spec: core.Spec{Name: "plain-agent", Description: "Uses the stack handler"}}
+
+	dir := t.TempDir()
+	if err := WriteCDKProject("team", []*core.Agent{custom, plain}, dir, config); err != nil {
+		t.Fatalf("WriteCDKProject failed: %v", err)
+	}
+
+	stack := readFile(t, filepath.Join(dir, "lib", "team-stack.ts"))
+	for _, want := range []string{
+		"memorySize: 512,\n      handler: 'agent.main',\n    });",
+		"memorySize: 512,\n      handler: 'index.handler',\n    });",
+	} {
+		if !strings.Contains(stack, want) {
+			t.Errorf("stack missing %q\n%s", want, stack)
+		}
+	}
+
+	construct := readFile(t, filepath.Join(dir, "lib", "agents", "custom-agent.ts"))
+	for _, want := range []string{
+		"import * as lambda from 'aws-cdk-lib/aws-lambda';",
+		"readonly handler?: string;",
+		"this.handler = props?.handler ?? 'agent.main';",
+		"runtime: new lambda.Runtime('python3.11'),\n      handler: this.handler ?? 'index.handler',",
+		"code: lambda.Code.fromAsset(props?.actionCodePath ?? 'lambda/custom-agent'),",
+		"actionGroupExecutor: { lambda: actionFunction.functionArn },",
+		"{ name: 'read_file' },\n              { name: 'web_search' },",
+		"sourceArn: this.agent.attrAgentArn,\n      sourceAccount: cdk.Stack.of(this).account,",
+	} {
+		if !strings.Contains(construct, want) {
+			t.Errorf("construct missing %q\n%s", want, construct)
+		}
+	}
+
+	// Without a handler anywhere the entrypoint stays unset
+	dir = t.TempDir()
+	if err := WriteCDKProject("team", []*core.Agent{plain}, dir, DefaultAgentCoreConfig()); err != nil {
+		t.Fatalf("WriteCDKProject failed: %v", err)
+	}
+	construct = readFile(t, filepath.Join(dir, "lib", "agents", "plain-agent.ts"))
+	if !strings.Contains(construct, "this.handler = props?.handler ?? undefined;") {
+		t.Errorf("construct should leave the handler unset\n%s", construct)
+	}
+	if strings.Contains(construct, "aws-lambda") || strings.Contains(construct, "actionGroups") {
+		t.Errorf("construct without actions should have no Lambda function\n%s", construct)
+	}
+	if strings.Contains(readFile(t, filepath.Join(dir, "lib", "team-stack.ts")), "handler:") {
+		t.Error("stack should not pass an unset handler")
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestWriteCDKProjectTags(t *testing.T) {
+	config := DefaultAgentCoreConfig()
+	config.Tags = map[string]string{"project": "research", "cost-center": "100"}
+
+	tagged := &core.Agent{
+		Spec:    core.Spec{Name: "tagged-agent", Description: "Bills to its own team"},
+		Runtime: &core.Runtime{Tags: map[string]string{"cost-center": "200", "team": "data's"}},
+	}
+	plain := &core.Agent{Spec: core.Spec{Name: "plain-agent", Description: "Uses stack tags"}}
+
+	dir := t.TempDir()
+	if err := WriteCDKProject("team", []*core.Agent{tagged, plain}, dir, config); err != nil {
+		t.Fatalf("WriteCDKProject failed: %v", err)
+	}
+
+	construct := readFile(t, filepath.Join(dir, "lib", "agents", "tagged-agent.ts"))
+	want := "    cdk.Tags.of(this).add('cost-center', '200');\n" +
+		"    cdk.Tags.of(this).add('project', 'research');\n" +
+		"    cdk.Tags.of(this).add('team', 'data\\'s');\n"
+	if !strings.Contains(construct, want) {
+		t.Errorf("construct missing merged tags %q\n%s", want, construct)
+	}
+
+	construct = readFile(t, filepath.Join(dir, "lib", "agents", "plain-agent.ts"))
+	if !strings.Contains(construct, "cdk.Tags.of(this).add('cost-center', '100');") {
+		t.Errorf("construct should carry the stack tags\n%s", construct)
+	}
+
+	untagged, err := (&Adapter{}).Marshal(plain)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(untagged), "cdk.Tags") {
+		t.Errorf("construct without tags should not add any\n%s", untagged)
+	}
+}
+
+func TestMarshalKnowledgeBases(t *testing.T) {
+	agent := &core.Agent{
+		Spec: core.Spec{Name: "support-agent", Description: "Answers product questions"},
+		KnowledgeSources: []core.KnowledgeSource{
+			{Type: core.KnowledgeSourceBedrock, URI: "arn:aws:bedrock:us-east-1:123456789012:knowledge-base/KB12345"},
+			{Type: core.KnowledgeSourceBedrock, URI: "KB67890"},
+			{Type: "web", URI: "https://docs.example.com"},
+		},
+	}
+
+	data, err := (&Adapter{}).Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	construct := string(data)
+
+	for _, want := range []string{
+		"autoPrepare: true,\n      knowledgeBases: [\n        {\n          knowledgeBaseId: 'KB12345',",
+		"knowledgeBaseId: 'KB67890',\n          description: 'Knowledge base for support-agent',\n          knowledgeBaseState: 'ENABLED',",
+	} {
+		if !strings.Contains(construct, want) {
+			t.Errorf("construct missing %q\n%s", want, construct)
+		}
+	}
+	if strings.Contains(construct, "docs.example.com") {
+		t.Errorf("expected non-Bedrock sources to be skipped\n%s", construct)
+	}
+
+	agent.KnowledgeSources = nil
+	data, err = (&Adapter{}).Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "knowledgeBases") {
+		t.Errorf("expected no knowledgeBases without sources\n%s", data)
+	}
+}
+
+func TestMarshalGuardrails(t *testing.T) {
+	agent := &core.Agent{
+		Spec:       core.Spec{Name: "support-agent", Description: "Answers product questions"},
+		Guardrails: &core.Guardrails{ID: "arn:aws:bedrock:us-east-1:123456789012:guardrail/gr123", Version: "2"},
+	}
+
+	data, err := (&Adapter{}).Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := "guardrailConfiguration: {\n        guardrailIdentifier: 'arn:aws:bedrock:us-east-1:123456789012:guardrail/gr123',\n        guardrailVersion: '2',\n      },"
+	if construct := string(data); !strings.Contains(construct, want) || strings.Contains(construct, "CfnGuardrail") {
+		t.Errorf("expected a reference to the existing guardrail\n%s", construct)
+	}
+
+	agent.Guardrails = &core.Guardrails{BlockedTopics: []string{"Investment advice"}, PIIFilters: []string{"EMAIL", "PHONE"}}
+	data, err = (&Adapter{}).Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	construct := string(data)
+	for _, want := range []string{
+		"const guardrail = new bedrock.CfnGuardrail(this, 'Guardrail', {\n      name: 'support-agent-guardrail',",
+		"{ name: 'Investment advice', definition: 'Discussion of Investment advice.', type: 'DENY' },",
+		"{ type: 'EMAIL', action: 'ANONYMIZE' },\n          { type: 'PHONE', action: 'ANONYMIZE' },",
+		"guardrailIdentifier: guardrail.attrGuardrailId,\n        guardrailVersion: guardrail.attrVersion,",
+	} {
+		if !strings.Contains(construct, want) {
+			t.Errorf("construct missing %q\n%s", want, construct)
+		}
+	}
+
+	agent.Guardrails = nil
+	data, err = (&Adapter{}).Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "guardrail") {
+		t.Errorf("expected no guardrail without Guardrails\n%s", data)
+	}
+}
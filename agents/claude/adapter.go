@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/agentplexus/assistantkit/agents/core"
+	"github.com/agentplexus/assistantkit/internal/passthrough"
 )
 
 func init() {
@@ -28,25 +30,106 @@ func (a *Adapter) FileExtension() string {
 	return ".md"
 }
 
+// FileSlug returns name in kebab-case, Claude Code's agent file naming
+// convention.
+func (a *Adapter) FileSlug(name string) string {
+	return core.KebabCase(name)
+}
+
 // DefaultDir returns the default directory name for Claude agents.
 func (a *Adapter) DefaultDir() string {
 	return "agents"
 }
 
+// SupportedTools returns the canonical tools available to Claude Code agents.
+func (a *Adapter) SupportedTools() []string {
+	return append(append([]string{}, core.CanonicalTools...), "NotebookEdit", "TodoWrite")
+}
+
+// nativeTools are Claude Code tool names outside the canonical set that
+// agents may list directly.
+var nativeTools = map[string]bool{
+	"MultiEdit":    true,
+	"NotebookRead": true,
+	"LS":           true,
+	"BashOutput":   true,
+	"KillShell":    true,
+	"ExitPlanMode": true,
+	"SlashCommand": true,
+}
+
+// IsPassthroughTool reports whether tool is an MCP tool
+// ("mcp__<server>__<tool>") or a Claude Code native tool, which are written
+// as-is.
+func (a *Adapter) IsPassthroughTool(tool string) bool {
+	return strings.HasPrefix(tool, "mcp__") || nativeTools[tool]
+}
+
+// keywordsLabel introduces the keyword list appended to the description.
+// Claude Code picks sub-agents by description, so keywords are written there.
+const keywordsLabel = "Keywords: "
+
+// describe returns the description with the agent's keywords appended as
+// "Keywords: a, b." so they take part in sub-agent selection.
+func describe(agent *core.Agent) string {
+	if len(agent.Keywords) == 0 {
+		return agent.Description
+	}
+	keywords := keywordsLabel + strings.Join(agent.Keywords, ", ") + "."
+	if agent.Description == "" {
+		return keywords
+	}
+	return agent.Description + " " + keywords
+}
+
+// splitKeywords reverses describe, separating appended keywords from the
+// description.
+func splitKeywords(description string) (string, []string) {
+	idx := strings.LastIndex(description, keywordsLabel)
+	if idx < 0 || !strings.HasSuffix(description, ".") || (idx > 0 && description[idx-1] != ' ') {
+		return description, nil
+	}
+	keywords := parseList(strings.TrimSuffix(description[idx+len(keywordsLabel):], "."))
+	return strings.TrimSpace(description[:idx]), keywords
+}
+
+// SupportsExperimental reports that experimental options are written to the
+// subagent frontmatter.
+func (a *Adapter) SupportsExperimental() bool {
+	return true
+}
+
+// SupportsMaxTurns reports that maxTurns is written to the subagent
+// frontmatter.
+func (a *Adapter) SupportsMaxTurns() bool {
+	return true
+}
+
+// SupportsStopConditions reports that Claude subagents cannot stop on a
+// tool call.
+func (a *Adapter) SupportsStopConditions() bool {
+	return false
+}
+
 // Parse converts Claude agent Markdown bytes to canonical Agent.
 func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
-	frontmatter, body := parseFrontmatter(data)
+	frontmatter, body := core.ParseFrontmatter(data)
 
-	agent := &core.Agent{
+	agent := &core.Agent{Spec: core.Spec{
 		Name:         frontmatter["name"],
-		Description:  frontmatter["description"],
 		Model:        core.Model(frontmatter["model"]),
 		Instructions: strings.TrimSpace(body),
-	}
+	}}
+	agent.Description, agent.Keywords = splitKeywords(frontmatter["description"])
 
 	// Parse tools if present
 	if tools, ok := frontmatter["tools"]; ok {
-		agent.Tools = parseList(tools)
+		agent.Tools = core.UnaliasTools(a.Name(), parseList(tools))
+	}
+
+	// Parse denied tools if present
+	if denied, ok := frontmatter["disallowedTools"]; ok {
+		agent.DisallowedTools = core.UnaliasTools(a.Name(), parseList(denied))
 	}
 
 	// Parse skills if present
@@ -54,6 +137,21 @@ func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
 		agent.Skills = parseList(skills)
 	}
 
+	if mode, ok := frontmatter["permissionMode"]; ok {
+		agent.Approval = approvalFromPermissionMode(mode)
+	}
+
+	if turns, err := strconv.Atoi(frontmatter["maxTurns"]); err == nil {
+		agent.MaxTurns = &turns
+	}
+
+	agent.HelpURL = frontmatter["helpUrl"]
+
+	// Parse MCP server selection if present
+	if servers, ok := frontmatter["mcpServers"]; ok {
+		agent.MCPServers = parseList(servers)
+	}
+
 	// Parse dependencies if present
 	if deps, ok := frontmatter["dependencies"]; ok {
 		agent.Dependencies = parseList(deps)
@@ -69,29 +167,58 @@ func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 	// Write YAML frontmatter
 	buf.WriteString("---\n")
 	buf.WriteString(fmt.Sprintf("name: %s\n", agent.Name))
-	buf.WriteString(fmt.Sprintf("description: %s\n", agent.Description))
+	buf.WriteString(fmt.Sprintf("description: %s\n", describe(agent)))
 
 	if agent.Model != "" {
 		buf.WriteString(fmt.Sprintf("model: %s\n", agent.Model))
 	}
 
 	if len(agent.Tools) > 0 {
-		buf.WriteString(fmt.Sprintf("tools: [%s]\n", strings.Join(agent.Tools, ", ")))
+		buf.WriteString(fmt.Sprintf("tools: [%s]\n", strings.Join(core.AliasTools(a.Name(), agent.Tools), ", ")))
+	}
+
+	// Denied tools become the subagent's disallowedTools, Claude's per-agent
+	// deny rules.
+	if deny := agent.ToolPermissions().Deny; len(deny) > 0 {
+		buf.WriteString(fmt.Sprintf("disallowedTools: [%s]\n", strings.Join(core.AliasTools(a.Name(), deny), ", ")))
 	}
 
 	if len(agent.Skills) > 0 {
 		buf.WriteString(fmt.Sprintf("skills: [%s]\n", strings.Join(agent.Skills, ", ")))
 	}
 
+	if agent.Approval != "" {
+		buf.WriteString(fmt.Sprintf("permissionMode: %s\n", permissionMode(agent.Approval)))
+	}
+
+	if agent.MaxTurns != nil {
+		buf.WriteString(fmt.Sprintf("maxTurns: %d\n", *agent.MaxTurns))
+	}
+
+	if agent.HelpURL != "" {
+		buf.WriteString(fmt.Sprintf("helpUrl: %s\n", agent.HelpURL))
+	}
+
+	if len(agent.MCPServers) > 0 {
+		buf.WriteString(fmt.Sprintf("mcpServers: [%s]\n", strings.Join(agent.MCPServers, ", ")))
+	}
+
 	if len(agent.Dependencies) > 0 {
 		buf.WriteString(fmt.Sprintf("dependencies: [%s]\n", strings.Join(agent.Dependencies, ", ")))
 	}
 
+	// Experimental options follow the keys written above, which they never override
+	experimental, err := passthrough.Frontmatter(buf.String(), agent.Experimental)
+	if err != nil {
+		return nil, &core.MarshalError{Format: "claude", Err: err}
+	}
+	buf.WriteString(experimental)
+
 	buf.WriteString("---\n\n")
 
 	// Write instructions directly (they already contain markdown formatting)
-	if agent.Instructions != "" {
-		buf.WriteString(agent.Instructions)
+	if instructions := core.TransformInstructions(a.Name(), agent.CombinedInstructions()); instructions != "" {
+		buf.WriteString(instructions)
 		buf.WriteString("\n")
 	}
 
@@ -141,40 +268,6 @@ func (a *Adapter) WriteFile(agent *core.Agent, path string) error {
 	return nil
 }
 
-// parseFrontmatter extracts YAML frontmatter and body from Markdown.
-func parseFrontmatter(data []byte) (map[string]string, string) {
-	content := string(data)
-	frontmatter := make(map[string]string)
-
-	if !strings.HasPrefix(content, "---") {
-		return frontmatter, content
-	}
-
-	parts := strings.SplitN(content, "---", 3)
-	if len(parts) < 3 {
-		return frontmatter, content
-	}
-
-	// Parse simple YAML key: value pairs
-	lines := strings.Split(strings.TrimSpace(parts[1]), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-		idx := strings.Index(line, ":")
-		if idx > 0 {
-			key := strings.TrimSpace(line[:idx])
-			value := strings.TrimSpace(line[idx+1:])
-			// Remove quotes if present
-			value = strings.Trim(value, "\"'")
-			frontmatter[key] = value
-		}
-	}
-
-	return frontmatter, strings.TrimSpace(parts[2])
-}
-
 // parseList parses a list in either YAML array format [a, b, c] or comma-separated format.
 func parseList(s string) []string {
 	s = strings.TrimSpace(s)
@@ -194,3 +287,30 @@ func parseList(s string) []string {
 	}
 	return result
 }
+
+// permissionMode maps an approval policy to a Claude subagent permission
+// mode: auto accepts edits, ask keeps the default prompts, and never runs the
+// agent in plan mode, where it can only propose changes.
+func permissionMode(approval core.Approval) string {
+	switch approval {
+	case core.ApprovalAuto:
+		return "acceptEdits"
+	case core.ApprovalNever:
+		return "plan"
+	default:
+		return "default"
+	}
+}
+
+// approvalFromPermissionMode maps a Claude permission mode to an approval
+// policy.
+func approvalFromPermissionMode(mode string) core.Approval {
+	switch mode {
+	case "acceptEdits", "bypassPermissions":
+		return core.ApprovalAuto
+	case "plan":
+		return core.ApprovalNever
+	default:
+		return core.ApprovalAsk
+	}
+}
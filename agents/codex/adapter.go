@@ -33,20 +33,26 @@ func (a *Adapter) DefaultDir() string {
 	return "agents"
 }
 
+// SupportedTools returns the canonical tools available to Codex agents.
+// File and search tools are served by the shell; Codex has no web fetch or subagent tool.
+func (a *Adapter) SupportedTools() []string {
+	return []string{"Read", "Write", "Edit", "Glob", "Grep", "Bash", "WebSearch"}
+}
+
 // Parse converts Codex agent Markdown bytes to canonical Agent.
 func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
-	frontmatter, body := parseFrontmatter(data)
+	frontmatter, body := core.ParseFrontmatter(data)
 
-	agent := &core.Agent{
+	agent := &core.Agent{Spec: core.Spec{
 		Name:         frontmatter["name"],
 		Description:  frontmatter["description"],
 		Model:        mapCodexModelToCanonical(frontmatter["model"]),
 		Instructions: strings.TrimSpace(body),
-	}
+	}}
 
 	// Parse tools if present
 	if tools, ok := frontmatter["tools"]; ok {
-		agent.Tools = parseList(tools)
+		agent.Tools = core.UnaliasTools(a.Name(), parseList(tools))
 	}
 
 	// Parse skills if present
@@ -76,7 +82,7 @@ func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 	}
 
 	if len(agent.Tools) > 0 {
-		buf.WriteString(fmt.Sprintf("tools: [%s]\n", strings.Join(agent.Tools, ", ")))
+		buf.WriteString(fmt.Sprintf("tools: [%s]\n", strings.Join(core.AliasTools(a.Name(), agent.Tools), ", ")))
 	}
 
 	if len(agent.Skills) > 0 {
@@ -90,8 +96,8 @@ func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 	buf.WriteString("---\n\n")
 
 	// Write instructions directly
-	if agent.Instructions != "" {
-		buf.WriteString(agent.Instructions)
+	if instructions := core.TransformInstructions(a.Name(), agent.CombinedInstructions()); instructions != "" {
+		buf.WriteString(instructions)
 		buf.WriteString("\n")
 	}
 
@@ -141,40 +147,6 @@ func (a *Adapter) WriteFile(agent *core.Agent, path string) error {
 	return nil
 }
 
-// parseFrontmatter extracts YAML frontmatter and body from Markdown.
-func parseFrontmatter(data []byte) (map[string]string, string) {
-	content := string(data)
-	frontmatter := make(map[string]string)
-
-	if !strings.HasPrefix(content, "---") {
-		return frontmatter, content
-	}
-
-	parts := strings.SplitN(content, "---", 3)
-	if len(parts) < 3 {
-		return frontmatter, content
-	}
-
-	// Parse simple YAML key: value pairs
-	lines := strings.Split(strings.TrimSpace(parts[1]), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-		idx := strings.Index(line, ":")
-		if idx > 0 {
-			key := strings.TrimSpace(line[:idx])
-			value := strings.TrimSpace(line[idx+1:])
-			// Remove quotes if present
-			value = strings.Trim(value, "\"'")
-			frontmatter[key] = value
-		}
-	}
-
-	return frontmatter, strings.TrimSpace(parts[2])
-}
-
 // parseList parses a list in either YAML array format [a, b, c] or comma-separated format.
 func parseList(s string) []string {
 	s = strings.TrimSpace(s)
@@ -0,0 +1,30 @@
+package codex
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+)
+
+// IndexFileName is the top-level file Codex reads project guidance from.
+const IndexFileName = "AGENTS.md"
+
+// MarshalIndex renders an AGENTS.md section listing each agent with its
+// description and a link to its file in agentsDir, a slash-separated path
+// relative to the index.
+func MarshalIndex(agents []*core.Agent, agentsDir string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("## Agents\n\n")
+	buf.WriteString("This project defines the following agents. Each links to its full instructions.\n\n")
+	for _, agent := range agents {
+		link := path.Join(agentsDir, agent.Name+".md")
+		if agent.Description == "" {
+			buf.WriteString(fmt.Sprintf("- [%s](%s)\n", agent.Name, link))
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("- [%s](%s): %s\n", agent.Name, link, agent.Description))
+	}
+	return buf.Bytes()
+}
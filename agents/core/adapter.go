@@ -2,7 +2,6 @@ package core
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io/fs"
 	"os"
@@ -11,7 +10,12 @@ import (
 	"strings"
 	"sync"
 
-	multiagentspec "github.com/agentplexus/multi-agent-spec/sdk/go"
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+	"github.com/agentplexus/assistantkit/internal/fields"
+	"github.com/agentplexus/assistantkit/internal/ignore"
+	"github.com/agentplexus/assistantkit/internal/include"
+	"github.com/agentplexus/assistantkit/internal/jsonc"
+	"github.com/agentplexus/assistantkit/internal/passthrough"
 )
 
 // DefaultFileMode is the default permission for generated files.
@@ -84,6 +88,45 @@ func (r *Registry) AdapterNames() []string {
 	return names
 }
 
+// Convert converts agent data from one format to another.
+func (r *Registry) Convert(data []byte, from, to string) ([]byte, error) {
+	out, _, err := r.ConvertWithWarnings(data, from, to)
+	return out, err
+}
+
+// ConvertWithWarnings converts agent data from one format to another and
+// returns warnings describing fields the target format could not represent.
+// Dropped fields are detected by parsing the converted output back and
+// comparing it with the source agent.
+func (r *Registry) ConvertWithWarnings(data []byte, from, to string) ([]byte, []string, error) {
+	fromAdapter, ok := r.GetAdapter(from)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown source adapter: %s", from)
+	}
+
+	toAdapter, ok := r.GetAdapter(to)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown target adapter: %s", to)
+	}
+
+	agent, err := fromAdapter.Parse(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", from, err)
+	}
+
+	out, err := toAdapter.Marshal(agent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	converted, err := toAdapter.Parse(out)
+	if err != nil {
+		return out, []string{fmt.Sprintf("unable to verify conversion to %s: %v", to, err)}, nil
+	}
+
+	return out, fields.Dropped(agent, converted, to), nil
+}
+
 // DefaultRegistry is the global adapter registry.
 var DefaultRegistry = NewRegistry()
 
@@ -102,6 +145,16 @@ func AdapterNames() []string {
 	return DefaultRegistry.AdapterNames()
 }
 
+// Convert converts using the default registry.
+func Convert(data []byte, from, to string) ([]byte, error) {
+	return DefaultRegistry.Convert(data, from, to)
+}
+
+// ConvertWithWarnings converts using the default registry and reports dropped fields.
+func ConvertWithWarnings(data []byte, from, to string) ([]byte, []string, error) {
+	return DefaultRegistry.ConvertWithWarnings(data, from, to)
+}
+
 // ReadCanonicalFile reads a canonical agent file (Markdown + YAML frontmatter or JSON).
 // The format is auto-detected based on file extension or content.
 func ReadCanonicalFile(path string) (*Agent, error) {
@@ -110,10 +163,11 @@ func ReadCanonicalFile(path string) (*Agent, error) {
 		return nil, &ReadError{Path: path, Err: err}
 	}
 
-	// Detect format: if it starts with "---" or has .md extension, use multi-agent-spec loader
+	// Detect format: +++ is TOML frontmatter; otherwise if it starts with "---"
+	// or has .md extension, use multi-agent-spec loader (YAML frontmatter)
 	ext := filepath.Ext(path)
-	if ext == ".md" || (len(data) >= 3 && string(data[:3]) == "---") {
-		agent, err := multiagentspec.ParseAgentMarkdown(data)
+	if HasTOMLFrontmatter(data) || ext == ".md" || (len(data) >= 3 && string(data[:3]) == "---") {
+		agent, err := parseMarkdown(data)
 		if err != nil {
 			return nil, &ParseError{Format: "markdown", Path: path, Err: err}
 		}
@@ -125,9 +179,9 @@ func ReadCanonicalFile(path string) (*Agent, error) {
 		return agent, nil
 	}
 
-	// Fall back to JSON for .json files or other formats
+	// Fall back to JSON for .json files or other formats. Comments and trailing commas are allowed.
 	var agent Agent
-	if err := json.Unmarshal(data, &agent); err != nil {
+	if err := jsonc.Unmarshal(data, &agent); err != nil {
 		return nil, &ParseError{Format: "canonical", Path: path, Err: err}
 	}
 
@@ -152,7 +206,7 @@ func WriteCanonicalFile(agent *Agent, path string) error {
 
 // WriteCanonicalJSON writes a canonical agent.json file (for validation/schema compatibility).
 func WriteCanonicalJSON(agent *Agent, path string) error {
-	data, err := json.MarshalIndent(agent, "", "  ")
+	data, err := canonicaljson.Marshal(agent)
 	if err != nil {
 		return &MarshalError{Format: "canonical", Err: err}
 	}
@@ -162,7 +216,7 @@ func WriteCanonicalJSON(agent *Agent, path string) error {
 		return &WriteError{Path: path, Err: err}
 	}
 
-	if err := os.WriteFile(path, append(data, '\n'), DefaultFileMode); err != nil {
+	if err := os.WriteFile(path, data, DefaultFileMode); err != nil {
 		return &WriteError{Path: path, Err: err}
 	}
 
@@ -170,15 +224,65 @@ func WriteCanonicalJSON(agent *Agent, path string) error {
 }
 
 // ReadCanonicalDir reads all agent files (.md or .json) from a directory.
-// This delegates to multiagentspec.LoadAgentsFromDir for markdown files.
+// Markdown files are loaded recursively with namespaces derived from
+// subdirectories; .json files are read from the top level only.
+// Include directives are resolved relative to the parent of dir (see
+// ResolveIncludes) and Inherits chains with ResolveInheritance. Files
+// matched by a .assistantkitignore file in the parent of dir are skipped.
 func ReadCanonicalDir(dir string) ([]*Agent, error) {
-	// Try multiagentspec loader first (handles .md files properly)
-	agents, err := multiagentspec.LoadAgentsFromDir(dir)
+	root := filepath.Dir(filepath.Clean(dir))
+
+	ignored, err := ignore.Load(root)
+	if err != nil {
+		return nil, &ReadError{Path: filepath.Join(root, ignore.FileName), Err: err}
+	}
+	skip := func(path string, isDir bool) bool {
+		rel, err := filepath.Rel(root, path)
+		return err == nil && ignored.Match(rel, isDir)
+	}
+
+	// Load .md files recursively, deriving namespace from subdirectories
+	var agents []*Agent
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && skip(path, true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(d.Name()) != ".md" || skip(path, false) {
+			return nil
+		}
+
+		agent, err := ReadCanonicalFile(path)
+		if err != nil {
+			return err
+		}
+		if err := ResolveIncludes(agent, root); err != nil {
+			return &ReadError{Path: path, Err: err}
+		}
+
+		if agent.Namespace == "" {
+			if rel, err := filepath.Rel(dir, filepath.Dir(path)); err == nil && rel != "." {
+				agent.Namespace = filepath.ToSlash(rel)
+			}
+		}
+
+		agents = append(agents, agent)
+		return nil
+	})
 	if err != nil {
+		switch err.(type) {
+		case *ParseError, *ReadError:
+			return nil, err
+		}
 		return nil, &ReadError{Path: dir, Err: err}
 	}
 
-	// Also load any .json files that multiagentspec loader skips
+	// Also load any top-level .json files
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, &ReadError{Path: dir, Err: err}
@@ -191,24 +295,53 @@ func ReadCanonicalDir(dir string) ([]*Agent, error) {
 
 		ext := filepath.Ext(entry.Name())
 		if ext != ".json" {
-			continue // .md files already loaded by multiagentspec
+			continue // .md files already loaded above
 		}
 
 		path := filepath.Join(dir, entry.Name())
+		if skip(path, false) {
+			continue
+		}
 		agent, err := ReadCanonicalFile(path)
 		if err != nil {
 			return nil, err
 		}
+		if err := ResolveIncludes(agent, root); err != nil {
+			return nil, &ReadError{Path: path, Err: err}
+		}
 		agents = append(agents, agent)
 	}
 
-	return agents, nil
+	return ResolveInheritance(agents)
+}
+
+// ResolveIncludes inlines {{include: path}} fragments in the agent's
+// instructions, localized instructions, and developer instructions. Paths
+// are relative to root, normally the specs directory containing agents/.
+func ResolveIncludes(agent *Agent, root string) error {
+	instructions, err := include.Resolve(agent.Instructions, root)
+	if err != nil {
+		return err
+	}
+	developer, err := include.Resolve(agent.DeveloperInstructions, root)
+	if err != nil {
+		return err
+	}
+	for locale, text := range agent.LocalizedInstructions {
+		resolved, err := include.Resolve(text, root)
+		if err != nil {
+			return err
+		}
+		agent.LocalizedInstructions[locale] = resolved
+	}
+	agent.Instructions = instructions
+	agent.DeveloperInstructions = developer
+	return nil
 }
 
 // ParseMarkdownAgent parses a Markdown file with YAML frontmatter into an Agent.
-// Deprecated: Use multiagentspec.ParseAgentMarkdown directly.
 func ParseMarkdownAgent(data []byte, path string) (*Agent, error) {
-	agent, err := multiagentspec.ParseAgentMarkdown(data)
+	agent, err := parseMarkdown(data)
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +362,7 @@ func MarshalMarkdownAgent(agent *Agent) []byte {
 	// Write YAML frontmatter
 	buf.WriteString("---\n")
 	buf.WriteString(fmt.Sprintf("name: %s\n", agent.Name))
-	buf.WriteString(fmt.Sprintf("description: %s\n", agent.Description))
+	buf.WriteString(fmt.Sprintf("description: %q\n", agent.Description))
 
 	if agent.Model != "" {
 		buf.WriteString(fmt.Sprintf("model: %s\n", string(agent.Model)))
@@ -251,6 +384,208 @@ func MarshalMarkdownAgent(agent *Agent) []byte {
 		buf.WriteString(fmt.Sprintf("requires: [%s]\n", strings.Join(agent.Requires, ", ")))
 	}
 
+	if len(agent.Keywords) > 0 {
+		buf.WriteString(fmt.Sprintf("keywords: [%s]\n", strings.Join(agent.Keywords, ", ")))
+	}
+
+	if len(agent.Categories) > 0 {
+		buf.WriteString(fmt.Sprintf("categories: [%s]\n", strings.Join(agent.Categories, ", ")))
+	}
+
+	if len(agent.MCPServers) > 0 {
+		buf.WriteString(fmt.Sprintf("mcpServers: [%s]\n", strings.Join(agent.MCPServers, ", ")))
+	}
+
+	if len(agent.Handoffs) > 0 {
+		buf.WriteString("handoffs:\n")
+		for _, h := range agent.Handoffs {
+			buf.WriteString(fmt.Sprintf("  - to: %s\n", h.To))
+			if h.When != "" {
+				buf.WriteString(fmt.Sprintf("    when: %q\n", h.When))
+			}
+		}
+	}
+
+	if len(agent.KnowledgeSources) > 0 {
+		buf.WriteString("knowledgeSources:\n")
+		for _, k := range agent.KnowledgeSources {
+			buf.WriteString(fmt.Sprintf("  - type: %s\n", k.Type))
+			buf.WriteString(fmt.Sprintf("    uri: %q\n", k.URI))
+		}
+	}
+
+	if len(agent.Resources) > 0 {
+		buf.WriteString("resources:\n")
+		for _, r := range agent.Resources {
+			buf.WriteString(fmt.Sprintf("  - %q\n", r))
+		}
+	}
+
+	if g := agent.Guardrails; !g.IsZero() {
+		buf.WriteString("guardrails:\n")
+		if g.ID != "" {
+			buf.WriteString(fmt.Sprintf("  id: %q\n", g.ID))
+		}
+		if g.Version != "" {
+			buf.WriteString(fmt.Sprintf("  version: %q\n", g.Version))
+		}
+		if len(g.BlockedTopics) > 0 {
+			buf.WriteString("  blockedTopics:\n")
+			for _, topic := range g.BlockedTopics {
+				buf.WriteString(fmt.Sprintf("    - %q\n", topic))
+			}
+		}
+		if len(g.PIIFilters) > 0 {
+			buf.WriteString(fmt.Sprintf("  piiFilters: [%s]\n", strings.Join(g.PIIFilters, ", ")))
+		}
+	}
+
+	if len(agent.Modalities) > 0 {
+		buf.WriteString(fmt.Sprintf("modalities: [%s]\n", strings.Join(agent.Modalities, ", ")))
+	}
+
+	if agent.Inherits != "" {
+		buf.WriteString(fmt.Sprintf("inherits: %s\n", agent.Inherits))
+	}
+
+	if len(agent.DisallowedTools) > 0 {
+		buf.WriteString(fmt.Sprintf("disallowedTools: [%s]\n", strings.Join(agent.DisallowedTools, ", ")))
+	}
+
+	if agent.Memory != "" {
+		buf.WriteString(fmt.Sprintf("memory: %q\n", agent.Memory))
+	}
+
+	if agent.WorkingDir != "" {
+		buf.WriteString(fmt.Sprintf("workingDir: %q\n", agent.WorkingDir))
+	}
+
+	if agent.Approval != "" {
+		buf.WriteString(fmt.Sprintf("approval: %s\n", agent.Approval))
+	}
+
+	if agent.FallbackModel != "" {
+		buf.WriteString(fmt.Sprintf("fallbackModel: %s\n", agent.FallbackModel))
+	}
+
+	if agent.ParallelToolCalls != nil {
+		buf.WriteString(fmt.Sprintf("parallelToolCalls: %t\n", *agent.ParallelToolCalls))
+	}
+
+	if agent.MaxTurns != nil {
+		buf.WriteString(fmt.Sprintf("maxTurns: %d\n", *agent.MaxTurns))
+	}
+
+	if len(agent.StopConditions) > 0 {
+		buf.WriteString(fmt.Sprintf("stopConditions: [%s]\n", strings.Join(agent.StopConditions, ", ")))
+	}
+
+	if agent.HelpURL != "" {
+		buf.WriteString(fmt.Sprintf("helpUrl: %q\n", agent.HelpURL))
+	}
+
+	if agent.Deprecated {
+		buf.WriteString("deprecated: true\n")
+		if agent.DeprecationMessage != "" {
+			buf.WriteString(fmt.Sprintf("deprecationMessage: %q\n", agent.DeprecationMessage))
+		}
+	}
+
+	if len(agent.Experimental) > 0 {
+		if lines, err := passthrough.Frontmatter("", agent.Experimental); err == nil {
+			buf.WriteString("experimental:\n")
+			for _, line := range strings.Split(strings.TrimSuffix(lines, "\n"), "\n") {
+				buf.WriteString("  " + line + "\n")
+			}
+		}
+	}
+
+	if len(agent.ResponseSchema) > 0 {
+		buf.WriteString(fmt.Sprintf("responseSchema: %s\n", compactSchema(agent.ResponseSchema)))
+	}
+
+	if rt := agent.Runtime; !rt.IsZero() {
+		buf.WriteString("runtime:\n")
+		if rt.Entrypoint != "" {
+			buf.WriteString(fmt.Sprintf("  entrypoint: %q\n", rt.Entrypoint))
+		}
+		if rt.Timeout != 0 {
+			buf.WriteString(fmt.Sprintf("  timeout: %d\n", rt.Timeout))
+		}
+		if rt.Retries != nil {
+			buf.WriteString(fmt.Sprintf("  retries: %d\n", *rt.Retries))
+		}
+		if rt.Memory != 0 {
+			buf.WriteString(fmt.Sprintf("  memory: %d\n", rt.Memory))
+		}
+		if rt.Concurrency != 0 {
+			buf.WriteString(fmt.Sprintf("  concurrency: %d\n", rt.Concurrency))
+		}
+		if rt.RateLimit != 0 {
+			buf.WriteString(fmt.Sprintf("  rateLimit: %d\n", rt.RateLimit))
+		}
+		if len(rt.Tags) > 0 {
+			keys := make([]string, 0, len(rt.Tags))
+			for key := range rt.Tags {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			buf.WriteString("  tags:\n")
+			for _, key := range keys {
+				buf.WriteString(fmt.Sprintf("    %q: %q\n", key, rt.Tags[key]))
+			}
+		}
+		if res := rt.Resources; res != nil {
+			buf.WriteString("  resources:\n")
+			if res.CPU != "" {
+				buf.WriteString(fmt.Sprintf("    cpu: %q\n", res.CPU))
+			}
+			if res.Memory != "" {
+				buf.WriteString(fmt.Sprintf("    memory: %q\n", res.Memory))
+			}
+		}
+		if np := rt.NetworkPolicy; np != nil {
+			buf.WriteString("  networkPolicy:\n")
+			if len(np.Ingress) > 0 {
+				buf.WriteString("    ingress:\n")
+				for _, cidr := range np.Ingress {
+					buf.WriteString(fmt.Sprintf("      - %q\n", cidr))
+				}
+			}
+			if len(np.Egress) > 0 {
+				buf.WriteString("    egress:\n")
+				for _, cidr := range np.Egress {
+					buf.WriteString(fmt.Sprintf("      - %q\n", cidr))
+				}
+			}
+		}
+	}
+
+	if agent.DeveloperInstructions != "" {
+		buf.WriteString("developerInstructions: |-\n")
+		for _, line := range strings.Split(strings.TrimRight(agent.DeveloperInstructions, "\n"), "\n") {
+			if line == "" {
+				buf.WriteString("\n")
+				continue
+			}
+			buf.WriteString("  " + line + "\n")
+		}
+	}
+
+	if len(agent.LocalizedInstructions) > 0 {
+		buf.WriteString("localizedInstructions:\n")
+		for _, locale := range sortedLocales(agent.LocalizedInstructions) {
+			buf.WriteString(fmt.Sprintf("  %s: |-\n", locale))
+			for _, line := range strings.Split(strings.TrimRight(agent.LocalizedInstructions[locale], "\n"), "\n") {
+				if line == "" {
+					buf.WriteString("\n")
+					continue
+				}
+				buf.WriteString("    " + line + "\n")
+			}
+		}
+	}
+
 	buf.WriteString("---\n\n")
 
 	// Write instructions directly (they already contain markdown formatting)
@@ -269,13 +604,15 @@ func WriteAgentsToDir(agents []*Agent, dir string, adapterName string) error {
 		return &AdapterError{Name: adapterName}
 	}
 
+	if err := CheckFileNames(adapter, agents); err != nil {
+		return err
+	}
 	if err := os.MkdirAll(dir, DefaultDirMode); err != nil {
 		return &WriteError{Path: dir, Err: err}
 	}
 
 	for _, agent := range agents {
-		filename := agent.Name + adapter.FileExtension()
-		path := filepath.Join(dir, filename)
+		path := filepath.Join(dir, FileName(adapter, agent))
 		if err := adapter.WriteFile(agent, path); err != nil {
 			return err
 		}
@@ -283,3 +620,12 @@ func WriteAgentsToDir(agents []*Agent, dir string, adapterName string) error {
 
 	return nil
 }
+
+// parseMarkdown parses a Markdown agent, choosing TOML (+++) or YAML (---)
+// frontmatter based on the opening delimiter. YAML is the default.
+func parseMarkdown(data []byte) (*Agent, error) {
+	if HasTOMLFrontmatter(data) {
+		return ParseTOMLAgentMarkdown(data)
+	}
+	return ParseYAMLAgentMarkdown(data)
+}
@@ -1,15 +1,250 @@
 // Package core provides the canonical agent definition types.
-// Agent definitions use the multi-agent-spec types as the canonical form,
+// Agent definitions build on the multi-agent-spec types as the canonical form,
 // which maps losslessly to Claude Code, Kiro CLI, and OpenAI Codex.
 package core
 
 import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
 	multiagentspec "github.com/agentplexus/multi-agent-spec/sdk/go"
 )
 
-// Agent is an alias for multiagentspec.Agent.
-// This is the canonical agent definition type used across all platforms.
-type Agent = multiagentspec.Agent
+// Spec is an alias for multiagentspec.Agent, the portable part of an agent definition.
+type Spec = multiagentspec.Agent
+
+// Agent is the canonical agent definition type used across all platforms.
+// It embeds the multi-agent-spec fields and adds fields that only some
+// platforms can represent. JSON and YAML encodings are flat. Composite
+// literals set the spec fields through Spec:
+//
+//	&Agent{Spec: Spec{Name: "reviewer"}, Keywords: []string{"review"}}
+type Agent struct {
+	Spec `yaml:",inline"`
+
+	// Memory is the directory where the agent persists state between runs,
+	// relative to the project root.
+	Memory string `json:"memory,omitempty" yaml:"memory,omitempty"`
+
+	// WorkingDir is the directory the agent runs in, relative to the project root.
+	WorkingDir string `json:"workingDir,omitempty" yaml:"workingDir,omitempty"`
+
+	// DeveloperInstructions are sent as a separate developer message on
+	// platforms that distinguish system and developer roles (e.g., OpenAI).
+	// Platforms with a single instruction slot receive them appended to
+	// Instructions; see CombinedInstructions.
+	DeveloperInstructions string `json:"developerInstructions,omitempty" yaml:"developerInstructions,omitempty"`
+
+	// LocalizedInstructions holds per-locale variants of Instructions keyed
+	// by locale (e.g., "fr", "pt-BR"). Generation emits one variant; see
+	// Localize.
+	LocalizedInstructions map[string]string `json:"localizedInstructions,omitempty" yaml:"localizedInstructions,omitempty"`
+
+	// Inherits names a parent agent whose definition this agent extends.
+	// See ResolveInheritance for the merge rules.
+	Inherits string `json:"inherits,omitempty" yaml:"inherits,omitempty"`
+
+	// DisallowedTools are tools denied to this agent, removing them from
+	// Tools and from any tools inherited from a parent.
+	DisallowedTools []string `json:"disallowedTools,omitempty" yaml:"disallowedTools,omitempty"`
+
+	// Keywords are terms that should route tasks to this agent. They are
+	// kept out of Description so it stays readable; platforms that select
+	// agents by description (e.g., Claude Code) append them in a fixed form.
+	Keywords []string `json:"keywords,omitempty" yaml:"keywords,omitempty"`
+
+	// Categories classify the agent for distribution (e.g., "code-review",
+	// "security"). Each must be in the category vocabulary; see
+	// DefaultCategories and RegisterCategory. Plugin manifests list them for
+	// marketplace discovery.
+	Categories []string `json:"categories,omitempty" yaml:"categories,omitempty"`
+
+	// Handoffs are the agents this agent may delegate to, each with routing
+	// guidance. See ApplyHandoffs for how the guidance reaches instructions.
+	Handoffs []Handoff `json:"handoffs,omitempty" yaml:"handoffs,omitempty"`
+
+	// ResponseSchema is a JSON Schema the agent's responses must follow.
+	// Adapters that support structured output emit it (see
+	// ResponseSchemaAdapter); others ignore it with a warning. In
+	// frontmatter it may be written as a YAML mapping.
+	ResponseSchema json.RawMessage `json:"responseSchema,omitempty" yaml:"-" toml:"-"`
+
+	// KnowledgeSources are retrieval sources the agent queries. Runtimes
+	// with managed retrieval (Bedrock knowledge bases via AWS AgentCore)
+	// attach the sources of types they support; other platforms ignore them.
+	KnowledgeSources []KnowledgeSource `json:"knowledgeSources,omitempty" yaml:"knowledgeSources,omitempty"`
+
+	// Resources are context files loaded with the agent, as file:// URIs or
+	// globs (e.g., "file://README.md"). Platforms with agent resources
+	// (Kiro) emit them alongside the steering files derived from Skills;
+	// others ignore them.
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+
+	// Guardrails filter the agent's input and output (blocked topics, PII).
+	// Runtimes with managed guardrails (Bedrock via AWS AgentCore) apply
+	// them (see GuardrailsAdapter); others ignore them with a warning.
+	Guardrails *Guardrails `json:"guardrails,omitempty" yaml:"guardrails,omitempty"`
+
+	// Modalities are the input types the agent accepts ("text", "image",
+	// "audio"). Unset means text only; see InputModalities. Runtimes that
+	// route multimodal input (the agentkit runtime) emit them.
+	Modalities []string `json:"modalities,omitempty" yaml:"modalities,omitempty"`
+
+	// Approval is "auto", "ask", or "never": whether the agent acts without
+	// confirmation, asks first, or only proposes actions for the user to
+	// run. Tools without an equivalent setting ignore it.
+	Approval Approval `json:"approval,omitempty" yaml:"approval,omitempty"`
+
+	// FallbackModel is used when Model is unavailable. Only platforms with
+	// model fallback (the agentkit runtime) emit it; elsewhere the agent
+	// runs on Model alone.
+	FallbackModel Model `json:"fallbackModel,omitempty" yaml:"fallbackModel,omitempty"`
+
+	// ParallelToolCalls enables or disables calling several tools in one
+	// model turn. Nil leaves the platform default. Only platforms with the
+	// setting (OpenAI) emit it.
+	ParallelToolCalls *bool `json:"parallelToolCalls,omitempty" yaml:"parallelToolCalls,omitempty"`
+
+	// MaxTurns bounds the agent's loop to this many model turns. Nil leaves
+	// the platform default. Platforms with loop control (Claude, the
+	// agentkit runtime) emit it; elsewhere it is ignored with a warning
+	// (see LoopControlAdapter).
+	MaxTurns *int `json:"maxTurns,omitempty" yaml:"maxTurns,omitempty"`
+
+	// StopConditions are tool names whose call ends the agent's loop, e.g.,
+	// a "submit_report" tool that returns the final answer. Platforms that
+	// can stop on a tool call (the agentkit runtime) emit them; elsewhere
+	// they are ignored with a warning.
+	StopConditions []string `json:"stopConditions,omitempty" yaml:"stopConditions,omitempty"`
+
+	// HelpURL links to the agent's documentation (an http or https URL).
+	// It is assistantkit metadata, not a platform setting: the Claude
+	// adapter keeps it in frontmatter as helpUrl so it round-trips, and
+	// other adapters drop it.
+	HelpURL string `json:"helpUrl,omitempty" yaml:"helpUrl,omitempty"`
+
+	// Deprecated marks an agent being phased out. It is still generated, but
+	// generation warns, and the generation manifest records it.
+	Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+
+	// DeprecationMessage tells users what to use instead. It is included in
+	// the deprecation warning.
+	DeprecationMessage string `json:"deprecationMessage,omitempty" yaml:"deprecationMessage,omitempty"`
+
+	// Experimental holds tool options the library does not model, written
+	// verbatim into the agent's native config by adapters with passthrough
+	// (see ExperimentalAdapter). They are not validated, and never override
+	// a setting the adapter already writes.
+	Experimental map[string]any `json:"experimental,omitempty" yaml:"experimental,omitempty"`
+
+	// MCPServers names the MCP servers the agent may use, drawn from the
+	// bundle's MCP config and agent MCP declarations. Tools with per-agent
+	// MCP selection (Claude) expose only these servers to the agent; empty
+	// means all servers.
+	MCPServers []string `json:"mcpServers,omitempty" yaml:"mcpServers,omitempty"`
+
+	// MCP declares MCP servers the agent needs, keyed by server name.
+	// Tools that consolidate every agent into one plugin manifest (Claude)
+	// merge them with the bundle's servers.
+	MCP map[string]MCPServer `json:"mcp,omitempty" yaml:"mcp,omitempty"`
+
+	// Runtime holds execution limits for platforms that deploy agents as
+	// managed services (e.g., AWS AgentCore). Unset values use the
+	// deployment's defaults.
+	Runtime *Runtime `json:"runtime,omitempty" yaml:"runtime,omitempty"`
+}
+
+// Approval is a policy for confirming an agent's actions.
+type Approval string
+
+const (
+	// ApprovalAuto acts without asking for confirmation.
+	ApprovalAuto Approval = "auto"
+
+	// ApprovalAsk asks for confirmation before acting (the usual default).
+	ApprovalAsk Approval = "ask"
+
+	// ApprovalNever never acts on its own; actions are proposed to the user.
+	ApprovalNever Approval = "never"
+)
+
+// MCPServer is an MCP server declared by an agent: a stdio server launched
+// with Command, or a remote HTTP server at URL.
+type MCPServer struct {
+	Command string            `json:"command,omitempty" yaml:"command,omitempty"`
+	Args    []string          `json:"args,omitempty" yaml:"args,omitempty"`
+	Cwd     string            `json:"cwd,omitempty" yaml:"cwd,omitempty"`
+	Env     map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	URL     string            `json:"url,omitempty" yaml:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+}
+
+// Runtime holds per-agent execution settings for runtime platforms.
+// Zero values mean "use the deployment default".
+type Runtime struct {
+	// Entrypoint is the handler or command that starts the agent's service,
+	// e.g., a Lambda handler ("handler.main") or a container command.
+	Entrypoint string `json:"entrypoint,omitempty" yaml:"entrypoint,omitempty"`
+
+	// Timeout is the maximum run time in seconds.
+	Timeout int `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// Retries is the number of times a failed invocation is retried. Nil
+	// uses the deployment's default; 0 disables retries.
+	Retries *int `json:"retries,omitempty" yaml:"retries,omitempty"`
+
+	// Memory is the memory allocation in MB.
+	Memory int `json:"memory,omitempty" yaml:"memory,omitempty"`
+
+	// Concurrency is the maximum number of invocations that run at once
+	// (e.g., Lambda reserved concurrency).
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+
+	// RateLimit is the maximum number of invocations per minute. Runtimes
+	// without a managed rate limit (AWS Lambda) only pass it to the handler,
+	// which must enforce it.
+	RateLimit int `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty"`
+
+	// Tags are billing and cost allocation tags applied to the resources
+	// deployed for the agent (e.g., {"team": "research", "cost-center": "42"}).
+	Tags map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Resources are the CPU and memory limits for container deployments.
+	Resources *Resources `json:"resources,omitempty" yaml:"resources,omitempty"`
+
+	// NetworkPolicy restricts the agent's traffic on container deployments.
+	// Nil leaves the network unrestricted.
+	NetworkPolicy *NetworkPolicy `json:"networkPolicy,omitempty" yaml:"networkPolicy,omitempty"`
+}
+
+// IsZero reports whether the runtime sets nothing.
+func (r *Runtime) IsZero() bool {
+	return r == nil || (r.Entrypoint == "" && r.Timeout == 0 && r.Retries == nil &&
+		r.Memory == 0 && r.Concurrency == 0 && r.RateLimit == 0 && len(r.Tags) == 0 &&
+		r.Resources == nil && r.NetworkPolicy == nil)
+}
+
+// Resources are container resource limits as Kubernetes quantities
+// (e.g., CPU "500m", Memory "512Mi").
+type Resources struct {
+	CPU    string `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+// NetworkPolicy lists the traffic an agent is allowed; anything not listed
+// is denied.
+type NetworkPolicy struct {
+	// Ingress are the CIDR blocks allowed to connect to the agent.
+	Ingress []string `json:"ingress,omitempty" yaml:"ingress,omitempty"`
+
+	// Egress are the CIDR blocks the agent may connect to.
+	Egress []string `json:"egress,omitempty" yaml:"egress,omitempty"`
+}
 
 // Task is an alias for multiagentspec.Task.
 type Task = multiagentspec.Task
@@ -26,5 +261,176 @@ const (
 
 // NewAgent creates a new Agent with the given name and description.
 func NewAgent(name, description string) *Agent {
-	return multiagentspec.NewAgent(name, description)
+	return &Agent{Spec: *multiagentspec.NewAgent(name, description)}
+}
+
+// FromSpec wraps a multi-agent-spec agent as a canonical Agent.
+func FromSpec(spec *Spec) *Agent {
+	return &Agent{Spec: *spec}
+}
+
+// WithModel sets the agent's model and returns the agent for chaining.
+func (a *Agent) WithModel(model Model) *Agent {
+	a.Model = model
+	return a
+}
+
+// WithTools sets the agent's tools and returns the agent for chaining.
+func (a *Agent) WithTools(tools ...string) *Agent {
+	a.Tools = tools
+	return a
+}
+
+// WithInstructions sets the agent's instructions and returns the agent for chaining.
+func (a *Agent) WithInstructions(instructions string) *Agent {
+	a.Instructions = instructions
+	return a
+}
+
+// WithNamespace sets the agent's namespace and returns the agent for chaining.
+func (a *Agent) WithNamespace(namespace string) *Agent {
+	a.Namespace = namespace
+	return a
+}
+
+// CombinedInstructions returns Instructions followed by DeveloperInstructions,
+// separated by a blank line, for platforms with a single instruction slot.
+func (a *Agent) CombinedInstructions() string {
+	switch {
+	case a.DeveloperInstructions == "":
+		return a.Instructions
+	case a.Instructions == "":
+		return a.DeveloperInstructions
+	default:
+		return a.Instructions + "\n\n" + a.DeveloperInstructions
+	}
+}
+
+// DeprecationWarning returns the warning printed when a deprecated agent is
+// generated, or "" when the agent is not deprecated.
+func (a *Agent) DeprecationWarning() string {
+	if !a.Deprecated {
+		return ""
+	}
+	if a.DeprecationMessage == "" {
+		return fmt.Sprintf("agent %q is deprecated", a.Name)
+	}
+	return fmt.Sprintf("agent %q is deprecated: %s", a.Name, a.DeprecationMessage)
+}
+
+// Validate checks fields that adapters cannot check on their own.
+func (a *Agent) Validate() error {
+	if err := validateRelativePath("memory", a.Memory); err != nil {
+		return err
+	}
+	if err := validateRelativePath("workingDir", a.WorkingDir); err != nil {
+		return err
+	}
+	switch a.Approval {
+	case "", ApprovalAuto, ApprovalAsk, ApprovalNever:
+	default:
+		return &ValidationError{Field: "approval", Message: "must be auto, ask, or never"}
+	}
+	for _, category := range a.Categories {
+		if !IsKnownCategory(category) {
+			return &ValidationError{Field: "categories", Message: fmt.Sprintf("unknown category %q", category)}
+		}
+	}
+	for _, modality := range a.Modalities {
+		if !IsKnownModality(modality) {
+			return &ValidationError{Field: "modalities", Message: fmt.Sprintf("unknown modality %q", modality)}
+		}
+	}
+	if a.HelpURL != "" {
+		if u, err := url.Parse(a.HelpURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return &ValidationError{Field: "helpUrl", Message: "must be an http or https URL"}
+		}
+	}
+	if a.MaxTurns != nil && *a.MaxTurns < 1 {
+		return &ValidationError{Field: "maxTurns", Message: "must be at least 1"}
+	}
+	for _, source := range a.KnowledgeSources {
+		if source.Type == "" || source.URI == "" {
+			return &ValidationError{Field: "knowledgeSources", Message: "each source needs a type and uri"}
+		}
+	}
+	if len(a.ResponseSchema) > 0 {
+		var schema map[string]interface{}
+		if err := json.Unmarshal(a.ResponseSchema, &schema); err != nil {
+			return &ValidationError{Field: "responseSchema", Message: "must be a JSON object"}
+		}
+	}
+	return a.Runtime.validate()
+}
+
+// quantityPattern matches a non-negative Kubernetes resource quantity, e.g.,
+// "500m", "2", "1.5", "512Mi", or "1e3".
+var quantityPattern = regexp.MustCompile(`^([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+|[numkMGTPE]|[KMGTPE]i)?$`)
+
+// validate checks that runtime limits are not negative, that resource
+// limits are Kubernetes quantities, and that network policy entries are
+// CIDR blocks.
+func (r *Runtime) validate() error {
+	if r == nil {
+		return nil
+	}
+	switch {
+	case r.Timeout < 0:
+		return &ValidationError{Field: "runtime.timeout", Message: "must not be negative"}
+	case r.Retries != nil && *r.Retries < 0:
+		return &ValidationError{Field: "runtime.retries", Message: "must not be negative"}
+	case r.Memory < 0:
+		return &ValidationError{Field: "runtime.memory", Message: "must not be negative"}
+	case r.Concurrency < 0:
+		return &ValidationError{Field: "runtime.concurrency", Message: "must not be negative"}
+	case r.RateLimit < 0:
+		return &ValidationError{Field: "runtime.rateLimit", Message: "must not be negative"}
+	}
+	for key := range r.Tags {
+		if strings.TrimSpace(key) == "" {
+			return &ValidationError{Field: "runtime.tags", Message: "tag keys must not be empty"}
+		}
+	}
+	if res := r.Resources; res != nil {
+		if res.CPU != "" && !quantityPattern.MatchString(res.CPU) {
+			return &ValidationError{Field: "runtime.resources.cpu", Message: fmt.Sprintf("%q is not a resource quantity", res.CPU)}
+		}
+		if res.Memory != "" && !quantityPattern.MatchString(res.Memory) {
+			return &ValidationError{Field: "runtime.resources.memory", Message: fmt.Sprintf("%q is not a resource quantity", res.Memory)}
+		}
+	}
+	if np := r.NetworkPolicy; np != nil {
+		if err := validateCIDRs("runtime.networkPolicy.ingress", np.Ingress); err != nil {
+			return err
+		}
+		if err := validateCIDRs("runtime.networkPolicy.egress", np.Egress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCIDRs checks that each block is in CIDR notation.
+func validateCIDRs(field string, blocks []string) error {
+	for _, block := range blocks {
+		if _, _, err := net.ParseCIDR(block); err != nil {
+			return &ValidationError{Field: field, Message: fmt.Sprintf("%q is not a CIDR block", block)}
+		}
+	}
+	return nil
+}
+
+// validateRelativePath checks that path stays within the project root.
+func validateRelativePath(field, path string) error {
+	if path == "" {
+		return nil
+	}
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "/") {
+		return &ValidationError{Field: field, Message: "must be a relative path"}
+	}
+	clean := filepath.ToSlash(filepath.Clean(path))
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return &ValidationError{Field: field, Message: "must not escape the project root"}
+	}
+	return nil
 }
@@ -1,6 +1,12 @@
 package core
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestNewAgent(t *testing.T) {
 	agent := NewAgent("release-coordinator", "Orchestrates releases")
@@ -36,3 +42,208 @@ func TestAgentWithInstructions(t *testing.T) {
 		t.Errorf("expected Instructions 'Do the thing', got '%s'", agent.Instructions)
 	}
 }
+
+func TestAgentValidateMemoryPath(t *testing.T) {
+	tests := []struct {
+		memory  string
+		wantErr bool
+	}{
+		{"", false},
+		{".agents/state", false},
+		{"state/../cache", false},
+		{"/var/lib/agent", true},
+		{"../outside", true},
+	}
+	for _, tt := range tests {
+		agent := NewAgent("test", "test")
+		agent.Memory = tt.memory
+		err := agent.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate() with memory %q: err = %v, wantErr %v", tt.memory, err, tt.wantErr)
+		}
+	}
+
+	agent := NewAgent("test", "test")
+	agent.WorkingDir = "/tmp"
+	var valErr *ValidationError
+	if err := agent.Validate(); !errors.As(err, &valErr) || valErr.Field != "workingDir" {
+		t.Errorf("expected workingDir ValidationError, got %v", err)
+	}
+}
+
+func TestAgentValidateCategories(t *testing.T) {
+	agent := NewAgent("scanner", "Scans for vulnerabilities")
+	agent.Categories = []string{"security", "code-review"}
+	if err := agent.Validate(); err != nil {
+		t.Errorf("expected known categories to validate, got %v", err)
+	}
+
+	agent.Categories = []string{"security", "compliance"}
+	var valErr *ValidationError
+	if err := agent.Validate(); !errors.As(err, &valErr) || valErr.Field != "categories" {
+		t.Fatalf("expected categories ValidationError, got %v", err)
+	}
+
+	RegisterCategory("compliance")
+	t.Cleanup(func() {
+		categoriesMu.Lock()
+		delete(categories, "compliance")
+		categoriesMu.Unlock()
+	})
+	if err := agent.Validate(); err != nil {
+		t.Errorf("expected a registered category to validate, got %v", err)
+	}
+
+	md := MarshalMarkdownAgent(agent)
+	parsed, err := ParseMarkdownAgent(md, "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if len(parsed.Categories) != 2 || parsed.Categories[1] != "compliance" {
+		t.Errorf("expected categories to round-trip, got %v", parsed.Categories)
+	}
+}
+
+func TestAgentJSONIsFlat(t *testing.T) {
+	agent := NewAgent("test", "A test agent")
+	agent.Memory = ".agents/test"
+
+	data, err := json.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["name"] != "test" || fields["memory"] != ".agents/test" {
+		t.Errorf("expected flat name and memory fields, got %s", data)
+	}
+}
+
+func TestParseYAMLAgentMarkdownMemory(t *testing.T) {
+	data := []byte("---\nname: tracker\nmemory: .agents/tracker\nworkingDir: services/api\n---\n\nTrack progress.\n")
+
+	agent, err := ParseYAMLAgentMarkdown(data)
+	if err != nil {
+		t.Fatalf("ParseYAMLAgentMarkdown failed: %v", err)
+	}
+	if agent.Name != "tracker" || agent.Memory != ".agents/tracker" || agent.WorkingDir != "services/api" {
+		t.Errorf("unexpected agent: %+v", agent)
+	}
+	if agent.Instructions != "Track progress." {
+		t.Errorf("unexpected instructions: %q", agent.Instructions)
+	}
+}
+
+func TestMarshalMarkdownAgentDeveloperInstructions(t *testing.T) {
+	agent := NewAgent("reviewer", "Reviews code")
+	agent.Instructions = "You are a code reviewer."
+	agent.DeveloperInstructions = "Only comment on changed lines.\n\nBe brief."
+
+	parsed, err := ParseYAMLAgentMarkdown(MarshalMarkdownAgent(agent))
+	if err != nil {
+		t.Fatalf("ParseYAMLAgentMarkdown failed: %v", err)
+	}
+	if parsed.DeveloperInstructions != agent.DeveloperInstructions {
+		t.Errorf("DeveloperInstructions = %q, want %q", parsed.DeveloperInstructions, agent.DeveloperInstructions)
+	}
+	if parsed.Instructions != agent.Instructions {
+		t.Errorf("Instructions = %q, want %q", parsed.Instructions, agent.Instructions)
+	}
+}
+
+func TestMarshalMarkdownAgentRuntimeRoundTrip(t *testing.T) {
+	agent := NewAgent("worker", "Long-running worker")
+	retries := 3
+	agent.Runtime = &Runtime{Entrypoint: "python -m worker: serve #1", Timeout: 900, Retries: &retries, Concurrency: 4, RateLimit: 60,
+		Tags:          map[string]string{"cost-center": "42", "team": "research: core"},
+		Resources:     &Resources{CPU: "500m", Memory: "512Mi"},
+		NetworkPolicy: &NetworkPolicy{Egress: []string{"10.0.0.0/8"}}}
+
+	parsed, err := ParseMarkdownAgent(MarshalMarkdownAgent(agent), "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if parsed.Runtime == nil || !reflect.DeepEqual(*parsed.Runtime, *agent.Runtime) {
+		t.Errorf("Runtime = %+v, want %+v", parsed.Runtime, agent.Runtime)
+	}
+
+	parsed.Runtime.Memory = -1
+	var valErr *ValidationError
+	if err := parsed.Validate(); !errors.As(err, &valErr) || valErr.Field != "runtime.memory" {
+		t.Errorf("expected runtime.memory ValidationError, got %v", err)
+	}
+
+	parsed.Runtime.Memory = 0
+	parsed.Runtime.Concurrency = -1
+	if err := parsed.Validate(); !errors.As(err, &valErr) || valErr.Field != "runtime.concurrency" {
+		t.Errorf("expected runtime.concurrency ValidationError, got %v", err)
+	}
+}
+
+func TestValidateRuntimeResourcesAndNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime Runtime
+		field   string
+	}{
+		{"valid", Runtime{
+			Resources:     &Resources{CPU: "1.5", Memory: "4Gi"},
+			NetworkPolicy: &NetworkPolicy{Ingress: []string{"10.0.0.0/8"}, Egress: []string{"::/0"}},
+		}, ""},
+		{"millicores", Runtime{Resources: &Resources{CPU: "250m", Memory: "1e9"}}, ""},
+		{"cpu unit", Runtime{Resources: &Resources{CPU: "2 cores"}}, "runtime.resources.cpu"},
+		{"negative memory", Runtime{Resources: &Resources{Memory: "-512Mi"}}, "runtime.resources.memory"},
+		{"memory unit", Runtime{Resources: &Resources{Memory: "512MB"}}, "runtime.resources.memory"},
+		{"bare address", Runtime{NetworkPolicy: &NetworkPolicy{Ingress: []string{"10.0.0.1"}}}, "runtime.networkPolicy.ingress"},
+		{"bad egress", Runtime{NetworkPolicy: &NetworkPolicy{Egress: []string{"10.0.0.0/33"}}}, "runtime.networkPolicy.egress"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := NewAgent("worker", "Worker")
+			agent.Runtime = &tt.runtime
+			err := agent.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) || valErr.Field != tt.field {
+				t.Errorf("expected %s ValidationError, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestMarshalMarkdownAgentResponseSchemaRoundTrip(t *testing.T) {
+	agent := NewAgent("triage", "Classifies issues")
+	agent.ResponseSchema = []byte(`{
+  "type": "object",
+  "required": ["severity"]
+}`)
+
+	data := MarshalMarkdownAgent(agent)
+	if !strings.Contains(string(data), `responseSchema: {"type":"object","required":["severity"]}`) {
+		t.Errorf("expected compact responseSchema, got:\n%s", data)
+	}
+
+	parsed, err := ParseMarkdownAgent(data, "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownAgent failed: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+	if !strings.Contains(string(parsed.ResponseSchema), `"severity"`) {
+		t.Errorf("expected schema to round-trip, got %s", parsed.ResponseSchema)
+	}
+
+	parsed.ResponseSchema = []byte(`[1, 2]`)
+	var valErr *ValidationError
+	if err := parsed.Validate(); !errors.As(err, &valErr) || valErr.Field != "responseSchema" {
+		t.Errorf("expected responseSchema ValidationError, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ToolAliasesFileName is the conventional name of a tool alias file in a
+// specs directory.
+const ToolAliasesFileName = "tool-aliases.yaml"
+
+// ToolAliases maps adapter names to user-defined canonical-to-native tool
+// name mappings. It is typically loaded from YAML:
+//
+//	kiro:
+//	  DeployTool: deploy_tool
+//	  Bash: shell
+//
+// Aliases take precedence over an adapter's built-in mapping, so they can
+// both add custom tools and override built-in names.
+type ToolAliases map[string]map[string]string
+
+// ParseToolAliases parses a YAML tool alias table.
+func ParseToolAliases(data []byte) (ToolAliases, error) {
+	var aliases ToolAliases
+	if err := yaml.Unmarshal(data, &aliases); err != nil {
+		return nil, &ParseError{Format: "tool-aliases", Err: err}
+	}
+	for adapterName, mapping := range aliases {
+		for canonical, native := range mapping {
+			if canonical == "" || native == "" {
+				return nil, &ParseError{Format: "tool-aliases", Err: fmt.Errorf("%s: empty tool name in alias %q: %q", adapterName, canonical, native)}
+			}
+		}
+	}
+	return aliases, nil
+}
+
+// ReadToolAliasesFile reads a YAML tool alias table from a file.
+func ReadToolAliasesFile(path string) (ToolAliases, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ReadError{Path: path, Err: err}
+	}
+	aliases, err := ParseToolAliases(data)
+	if err != nil {
+		if pe, ok := err.(*ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return aliases, nil
+}
+
+var (
+	aliasesMu sync.RWMutex
+	aliases   = ToolAliases{}
+)
+
+// SetToolAliases replaces the registered tool aliases and returns the
+// previous table, so callers can restore it when generation finishes.
+func SetToolAliases(table ToolAliases) ToolAliases {
+	aliasesMu.Lock()
+	defer aliasesMu.Unlock()
+	previous := aliases
+	aliases = ToolAliases{}
+	for adapterName, mapping := range table {
+		aliases[adapterName] = make(map[string]string, len(mapping))
+		for canonical, native := range mapping {
+			aliases[adapterName][canonical] = native
+		}
+	}
+	return previous
+}
+
+// AddToolAlias registers a single alias for the named adapter.
+func AddToolAlias(adapterName, canonical, native string) {
+	aliasesMu.Lock()
+	defer aliasesMu.Unlock()
+	if aliases[adapterName] == nil {
+		aliases[adapterName] = make(map[string]string)
+	}
+	aliases[adapterName][canonical] = native
+}
+
+// ClearToolAliases removes all registered tool aliases.
+func ClearToolAliases() {
+	SetToolAliases(nil)
+}
+
+// ToolAlias returns the user-defined native name of a canonical tool for
+// the named adapter.
+func ToolAlias(adapterName, canonical string) (string, bool) {
+	aliasesMu.RLock()
+	defer aliasesMu.RUnlock()
+	native, ok := aliases[adapterName][canonical]
+	return native, ok
+}
+
+// CanonicalToolAlias returns the canonical tool a user alias maps to the
+// given native name for the named adapter.
+func CanonicalToolAlias(adapterName, native string) (string, bool) {
+	aliasesMu.RLock()
+	defer aliasesMu.RUnlock()
+	mapping := aliases[adapterName]
+	canonicals := make([]string, 0, len(mapping))
+	for canonical := range mapping {
+		canonicals = append(canonicals, canonical)
+	}
+	sort.Strings(canonicals)
+	for _, canonical := range canonicals {
+		if mapping[canonical] == native {
+			return canonical, true
+		}
+	}
+	return "", false
+}
+
+// AliasTools maps canonical tools to native names using the aliases for the
+// named adapter. Tools without an alias are returned unchanged.
+// It is used by adapters that otherwise write canonical names as-is.
+func AliasTools(adapterName string, tools []string) []string {
+	if len(tools) == 0 {
+		return tools
+	}
+	out := make([]string, len(tools))
+	for i, tool := range tools {
+		if native, ok := ToolAlias(adapterName, tool); ok {
+			tool = native
+		}
+		out[i] = tool
+	}
+	return out
+}
+
+// UnaliasTools reverses AliasTools.
+func UnaliasTools(adapterName string, tools []string) []string {
+	if len(tools) == 0 {
+		return tools
+	}
+	out := make([]string, len(tools))
+	for i, tool := range tools {
+		if canonical, ok := CanonicalToolAlias(adapterName, tool); ok {
+			tool = canonical
+		}
+		out[i] = tool
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseToolAliases(t *testing.T) {
+	aliases, err := ParseToolAliases([]byte("kiro:\n  DeployTool: deploy_tool\nclaude:\n  DeployTool: mcp__deploy__run\n"))
+	if err != nil {
+		t.Fatalf("ParseToolAliases failed: %v", err)
+	}
+	want := ToolAliases{
+		"kiro":   {"DeployTool": "deploy_tool"},
+		"claude": {"DeployTool": "mcp__deploy__run"},
+	}
+	if !reflect.DeepEqual(aliases, want) {
+		t.Errorf("aliases = %v, want %v", aliases, want)
+	}
+
+	if _, err := ParseToolAliases([]byte("kiro:\n  DeployTool: \"\"\n")); err == nil {
+		t.Error("expected error for an empty alias")
+	}
+}
+
+func TestAliasTools(t *testing.T) {
+	previous := SetToolAliases(ToolAliases{"claude": {"DeployTool": "mcp__deploy__run"}})
+	defer SetToolAliases(previous)
+
+	got := AliasTools("claude", []string{"Read", "DeployTool"})
+	if want := []string{"Read", "mcp__deploy__run"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AliasTools = %v, want %v", got, want)
+	}
+	if back := UnaliasTools("claude", got); !reflect.DeepEqual(back, []string{"Read", "DeployTool"}) {
+		t.Errorf("UnaliasTools = %v", back)
+	}
+	if got := AliasTools("codex", []string{"DeployTool"}); !reflect.DeepEqual(got, []string{"DeployTool"}) {
+		t.Errorf("expected aliases to be scoped to their adapter, got %v", got)
+	}
+}
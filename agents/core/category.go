@@ -0,0 +1,63 @@
+package core
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultCategories is the built-in category vocabulary for agents.
+var DefaultCategories = []string{
+	"code-review",
+	"data",
+	"design",
+	"devops",
+	"documentation",
+	"productivity",
+	"research",
+	"security",
+	"testing",
+	"writing",
+}
+
+var (
+	categoriesMu sync.RWMutex
+	categories   = toSet(DefaultCategories)
+)
+
+// RegisterCategory adds categories to the vocabulary accepted by Validate.
+func RegisterCategory(names ...string) {
+	categoriesMu.Lock()
+	defer categoriesMu.Unlock()
+	for _, name := range names {
+		categories[name] = true
+	}
+}
+
+// IsKnownCategory reports whether name is in the category vocabulary.
+func IsKnownCategory(name string) bool {
+	categoriesMu.RLock()
+	defer categoriesMu.RUnlock()
+	return categories[name]
+}
+
+// KnownCategories returns the category vocabulary sorted alphabetically.
+func KnownCategories() []string {
+	categoriesMu.RLock()
+	defer categoriesMu.RUnlock()
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CollectCategories returns the categories of the agents, deduplicated in
+// first-seen order.
+func CollectCategories(agents []*Agent) []string {
+	lists := make([][]string, len(agents))
+	for i, agent := range agents {
+		lists[i] = agent.Categories
+	}
+	return unionStrings(lists...)
+}
@@ -1,6 +1,9 @@
 package core
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ReadError indicates a failure to read a file.
 type ReadError struct {
@@ -70,3 +73,60 @@ type AdapterError struct {
 func (e *AdapterError) Error() string {
 	return fmt.Sprintf("unknown adapter: %s", e.Name)
 }
+
+// UnsupportedToolsError indicates an agent requests tools the target adapter lacks.
+type UnsupportedToolsError struct {
+	Adapter string
+	Agent   string
+	Tools   []string
+}
+
+func (e *UnsupportedToolsError) Error() string {
+	return fmt.Sprintf("agent %s requests tools not supported by %s: %s", e.Agent, e.Adapter, strings.Join(e.Tools, ", "))
+}
+
+// ValidationError indicates an invalid agent field.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// FileNameError indicates that two agents map to the same file name.
+type FileNameError struct {
+	Agent    string
+	Other    string
+	FileName string
+}
+
+func (e *FileNameError) Error() string {
+	return fmt.Sprintf("agents %s and %s both map to file name %s", e.Other, e.Agent, e.FileName)
+}
+
+// InheritanceError indicates an agent's Inherits chain cannot be resolved.
+type InheritanceError struct {
+	Agent   string
+	Parent  string
+	Message string
+}
+
+func (e *InheritanceError) Error() string {
+	return fmt.Sprintf("agent %s inherits %s: %s", e.Agent, e.Parent, e.Message)
+}
+
+// HandoffError indicates an agent's handoff cannot be resolved.
+type HandoffError struct {
+	Agent   string
+	To      string
+	Message string
+}
+
+func (e *HandoffError) Error() string {
+	if e.To == "" {
+		return fmt.Sprintf("agent %s: %s", e.Agent, e.Message)
+	}
+	return fmt.Sprintf("agent %s hands off to %s: %s", e.Agent, e.To, e.Message)
+}
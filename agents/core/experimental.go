@@ -0,0 +1,22 @@
+package core
+
+import "github.com/agentplexus/assistantkit/internal/capability"
+
+// ExperimentalAdapter is implemented by adapters that pass
+// Agent.Experimental through to their native format.
+type ExperimentalAdapter interface {
+	SupportsExperimental() bool
+}
+
+// SupportsExperimental reports whether the adapter emits Experimental.
+func SupportsExperimental(adapter Adapter) bool {
+	ea, ok := adapter.(ExperimentalAdapter)
+	return ok && ea.SupportsExperimental()
+}
+
+// CheckExperimental returns a warning when the agent sets experimental
+// options: they are passed to the adapter's format unvalidated, or ignored
+// by adapters without passthrough.
+func CheckExperimental(adapter Adapter, agent *Agent) []string {
+	return capability.Experimental("agent", agent.Name, adapter.Name(), agent.Experimental, SupportsExperimental(adapter))
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FileNameAdapter is implemented by adapters whose tool expects agent file
+// names in its own case convention. Only the file name is slugged; the
+// agent's name field stays canonical.
+type FileNameAdapter interface {
+	FileSlug(name string) string
+}
+
+// FileName returns the file name the adapter writes agent to: the agent
+// name, slugged when the adapter implements FileNameAdapter, followed by
+// the adapter's file extension.
+func FileName(adapter Adapter, agent *Agent) string {
+	name := agent.Name
+	if fa, ok := adapter.(FileNameAdapter); ok {
+		name = fa.FileSlug(name)
+	}
+	return name + adapter.FileExtension()
+}
+
+// CheckFileNames returns a *FileNameError when two agents would be written
+// to the same file by the adapter, such as "code_reviewer" and
+// "code-reviewer" under a kebab-case FileNameAdapter.
+func CheckFileNames(adapter Adapter, agents []*Agent) error {
+	seen := make(map[string]string, len(agents))
+	for _, agent := range agents {
+		name := FileName(adapter, agent)
+		if other, ok := seen[name]; ok && other != agent.Name {
+			return &FileNameError{Agent: agent.Name, Other: other, FileName: name}
+		}
+		seen[name] = agent.Name
+	}
+	return nil
+}
+
+// KebabCase converts a name such as "Code Reviewer", "code_reviewer" or
+// "CodeReviewer" to "code-reviewer".
+func KebabCase(name string) string {
+	return strings.Join(slugWords(name), "-")
+}
+
+// SnakeCase converts a name such as "Code Reviewer", "code-reviewer" or
+// "CodeReviewer" to "code_reviewer".
+func SnakeCase(name string) string {
+	return strings.Join(slugWords(name), "_")
+}
+
+// slugWords splits name into lowercase words at separators (any rune that
+// is not a letter or digit) and at lower-to-upper case transitions.
+func slugWords(name string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+	var prev rune
+	for _, r := range name {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			flush()
+			word = append(word, unicode.ToLower(r))
+		default:
+			word = append(word, unicode.ToLower(r))
+		}
+		prev = r
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,274 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentplexus/assistantkit/internal/frontmatter"
+)
+
+const (
+	// YAMLFrontmatterDelimiter delimits YAML frontmatter (the default).
+	YAMLFrontmatterDelimiter = "---"
+
+	// TOMLFrontmatterDelimiter delimits Hugo-style TOML frontmatter.
+	TOMLFrontmatterDelimiter = "+++"
+)
+
+// HasTOMLFrontmatter reports whether data starts with a +++ TOML frontmatter block.
+func HasTOMLFrontmatter(data []byte) bool {
+	first, _, _ := bytes.Cut(data, []byte("\n"))
+	return strings.TrimSpace(string(first)) == TOMLFrontmatterDelimiter
+}
+
+// ParseTOMLAgentMarkdown parses a Markdown agent with +++ delimited TOML frontmatter.
+// The body after the closing delimiter becomes the agent instructions.
+func ParseTOMLAgentMarkdown(data []byte) (*Agent, error) {
+	fm, body, err := frontmatter.Split(data, TOMLFrontmatterDelimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	var agent Agent
+	if err := toml.Unmarshal(fm, &agent); err != nil {
+		return nil, fmt.Errorf("parse toml: %w", err)
+	}
+	if agent.ResponseSchema, err = decodeResponseSchema(fm, true); err != nil {
+		return nil, fmt.Errorf("parse toml: %w", err)
+	}
+
+	agent.Instructions = strings.TrimSpace(string(body))
+
+	return &agent, nil
+}
+
+// ParseYAMLAgentMarkdown parses a Markdown agent with --- delimited YAML frontmatter.
+// The body after the closing delimiter becomes the agent instructions.
+func ParseYAMLAgentMarkdown(data []byte) (*Agent, error) {
+	fm, body, err := frontmatter.Split(data, YAMLFrontmatterDelimiter)
+	if err != nil {
+		return nil, fmt.Errorf("parse frontmatter: %w", err)
+	}
+
+	var agent Agent
+	if err := yaml.Unmarshal(fm, &agent); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	if agent.ResponseSchema, err = decodeResponseSchema(fm, false); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+
+	agent.Instructions = strings.TrimSpace(string(body))
+
+	return &agent, nil
+}
+
+// ParseFrontmatter splits --- delimited YAML frontmatter from a Markdown body
+// and returns its top-level keys as strings, for adapters that read a few
+// known keys. Quoted values and block scalars (| and >) are decoded, and
+// sequences, whether inline ([a, b]) or indented "- a" items, are joined as
+// "a, b". Nested mappings are skipped. Frontmatter that is not valid YAML,
+// such as an unquoted value containing ": ", is read line by line as
+// key: value pairs. Data without frontmatter is returned as the body.
+func ParseFrontmatter(data []byte) (map[string]string, string) {
+	fm, body, err := frontmatter.Split(data, YAMLFrontmatterDelimiter)
+	if err != nil {
+		return map[string]string{}, string(data)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(fm, &doc); err != nil {
+		return parseFrontmatterLines(fm), strings.TrimSpace(string(body))
+	}
+
+	values := make(map[string]string)
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return values, strings.TrimSpace(string(body))
+	}
+	mapping := doc.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i].Value, mapping.Content[i+1]
+		switch value.Kind {
+		case yaml.ScalarNode:
+			values[key] = strings.TrimSpace(value.Value)
+		case yaml.SequenceNode:
+			items := make([]string, 0, len(value.Content))
+			for _, item := range value.Content {
+				if item.Kind == yaml.ScalarNode {
+					items = append(items, item.Value)
+				}
+			}
+			values[key] = strings.Join(items, ", ")
+		}
+	}
+	return values, strings.TrimSpace(string(body))
+}
+
+// parseFrontmatterLines reads frontmatter as single-line key: value pairs,
+// removing surrounding quotes from values.
+func parseFrontmatterLines(frontmatter []byte) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(frontmatter), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok && key != "" {
+			values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), "\"'")
+		}
+	}
+	return values
+}
+
+// UpdateAgentFrontmatter rewrites only the frontmatter block of the agent
+// Markdown file at path, leaving the body bytes untouched. Each update sets
+// a top-level key: an existing key is replaced in place (along with any
+// indented continuation lines), a new key is appended at the end of the
+// block in sorted order, and an empty value removes the key.
+// Both --- YAML and +++ TOML frontmatter are supported.
+func UpdateAgentFrontmatter(path string, updates map[string]string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return &ReadError{Path: path, Err: err}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &ReadError{Path: path, Err: err}
+	}
+
+	delimiter := YAMLFrontmatterDelimiter
+	format := "yaml"
+	if HasTOMLFrontmatter(data) {
+		delimiter = TOMLFrontmatterDelimiter
+		format = "toml"
+	}
+
+	start, end, err := frontmatterBounds(data, delimiter)
+	if err != nil {
+		return &ParseError{Format: format, Path: path, Err: err}
+	}
+
+	lines := strings.SplitAfter(string(data[start:end]), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	newline := "\n"
+	if strings.HasSuffix(string(data[:start]), "\r\n") {
+		newline = "\r\n"
+	}
+
+	keys := make([]string, 0, len(updates))
+	for key := range updates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := updates[key]
+		line := ""
+		if value != "" {
+			line = formatFrontmatterEntry(format, key, value) + newline
+		}
+
+		idx := findFrontmatterKey(lines, format, key)
+		if idx < 0 {
+			if line != "" {
+				lines = append(lines, line)
+			}
+			continue
+		}
+
+		// Drop indented continuation lines of the old value.
+		next := idx + 1
+		for next < len(lines) && isContinuationLine(lines[next]) {
+			next++
+		}
+		replacement := []string{}
+		if line != "" {
+			replacement = append(replacement, line)
+		}
+		lines = append(lines[:idx], append(replacement, lines[next:]...)...)
+	}
+
+	var out bytes.Buffer
+	out.Write(data[:start])
+	out.WriteString(strings.Join(lines, ""))
+	out.Write(data[end:])
+
+	if err := os.WriteFile(path, out.Bytes(), info.Mode().Perm()); err != nil {
+		return &WriteError{Path: path, Err: err}
+	}
+	return nil
+}
+
+// frontmatterBounds returns the byte offsets of the frontmatter lines
+// between the opening and closing delimiter lines.
+func frontmatterBounds(data []byte, delimiter string) (start, end int, err error) {
+	first, _, found := bytes.Cut(data, []byte("\n"))
+	if !found || strings.TrimSpace(string(first)) != delimiter {
+		return 0, 0, fmt.Errorf("missing frontmatter delimiter %q", delimiter)
+	}
+	start = len(first) + 1
+
+	for offset := start; offset < len(data); {
+		line, _, _ := bytes.Cut(data[offset:], []byte("\n"))
+		if strings.TrimSpace(string(line)) == delimiter {
+			return start, offset, nil
+		}
+		offset += len(line) + 1
+	}
+	return 0, 0, fmt.Errorf("missing closing frontmatter delimiter %q", delimiter)
+}
+
+// findFrontmatterKey returns the index of the top-level line setting key.
+func findFrontmatterKey(lines []string, format, key string) int {
+	sep := ":"
+	if format == "toml" {
+		sep = "="
+	}
+	for i, line := range lines {
+		if isContinuationLine(line) {
+			continue
+		}
+		name, _, ok := strings.Cut(line, sep)
+		if ok && strings.TrimSpace(name) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// isContinuationLine reports whether a frontmatter line continues the
+// previous key's value (an indented line or a YAML list item).
+func isContinuationLine(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "- ")
+}
+
+// formatFrontmatterEntry formats a single key and string value.
+func formatFrontmatterEntry(format, key, value string) string {
+	if format == "toml" {
+		return fmt.Sprintf("%s = %s", key, strconv.Quote(value))
+	}
+	if yamlNeedsQuotes(value) {
+		value = strconv.Quote(value)
+	}
+	return key + ": " + value
+}
+
+// yamlNeedsQuotes reports whether a plain YAML scalar would be misread.
+func yamlNeedsQuotes(value string) bool {
+	if strings.TrimSpace(value) != value || strings.ContainsAny(value, "\n\"") {
+		return true
+	}
+	if strings.Contains(value, ": ") || strings.Contains(value, " #") {
+		return true
+	}
+	return strings.ContainsAny(value[:1], "!&*{}[]|>'%@`#,?-:")
+}
@@ -0,0 +1,255 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const tomlAgent = `+++
+name = "release-coordinator"
+description = "Orchestrates software releases"
+model = "sonnet"
+tools = ["Read", "Bash"]
+allowedTools = ["Read"]
+skills = ["version-analysis"]
++++
+
+You are a release coordinator.
+`
+
+func TestParseTOMLAgentMarkdown(t *testing.T) {
+	agent, err := ParseTOMLAgentMarkdown([]byte(tomlAgent))
+	if err != nil {
+		t.Fatalf("ParseTOMLAgentMarkdown failed: %v", err)
+	}
+
+	if agent.Name != "release-coordinator" {
+		t.Errorf("expected Name 'release-coordinator', got '%s'", agent.Name)
+	}
+	if agent.Description != "Orchestrates software releases" {
+		t.Errorf("expected Description 'Orchestrates software releases', got '%s'", agent.Description)
+	}
+	if agent.Model != ModelSonnet {
+		t.Errorf("expected Model 'sonnet', got '%s'", agent.Model)
+	}
+	if len(agent.Tools) != 2 || agent.Tools[1] != "Bash" {
+		t.Errorf("expected Tools [Read Bash], got %v", agent.Tools)
+	}
+	if len(agent.AllowedTools) != 1 || agent.AllowedTools[0] != "Read" {
+		t.Errorf("expected AllowedTools [Read], got %v", agent.AllowedTools)
+	}
+	if len(agent.Skills) != 1 || agent.Skills[0] != "version-analysis" {
+		t.Errorf("expected Skills [version-analysis], got %v", agent.Skills)
+	}
+	if agent.Instructions != "You are a release coordinator." {
+		t.Errorf("expected Instructions 'You are a release coordinator.', got '%s'", agent.Instructions)
+	}
+}
+
+func TestParseTOMLAgentMarkdownMissingDelimiter(t *testing.T) {
+	if _, err := ParseTOMLAgentMarkdown([]byte("+++\nname = \"x\"\n")); err == nil {
+		t.Error("expected error for missing closing delimiter")
+	}
+}
+
+func TestReadCanonicalFileTOML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "release-coordinator.md")
+	if err := os.WriteFile(path, []byte(tomlAgent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	agent, err := ReadCanonicalFile(path)
+	if err != nil {
+		t.Fatalf("ReadCanonicalFile failed: %v", err)
+	}
+	if agent.Name != "release-coordinator" {
+		t.Errorf("expected Name 'release-coordinator', got '%s'", agent.Name)
+	}
+
+	// YAML remains the default and both can live in the same directory
+	yamlAgent := "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview code.\n"
+	if err := os.WriteFile(filepath.Join(dir, "reviewer.md"), []byte(yamlAgent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	agents, err := ReadCanonicalDir(dir)
+	if err != nil {
+		t.Fatalf("ReadCanonicalDir failed: %v", err)
+	}
+	if len(agents) != 2 {
+		t.Errorf("expected 2 agents, got %d", len(agents))
+	}
+}
+
+func TestReadCanonicalFileRelaxedJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviewer.json")
+	data := `{
+  // Hand-written agents may carry comments and trailing commas.
+  "name": "reviewer",
+  "description": "Reviews code", /* shown in pickers */
+  "tools": ["Read", "Grep",],
+}
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	agent, err := ReadCanonicalFile(path)
+	if err != nil {
+		t.Fatalf("ReadCanonicalFile failed: %v", err)
+	}
+	if agent.Name != "reviewer" || agent.Description != "Reviews code" {
+		t.Errorf("unexpected agent: %+v", agent.Spec)
+	}
+	if len(agent.Tools) != 2 || agent.Tools[1] != "Grep" {
+		t.Errorf("expected tools [Read Grep], got %v", agent.Tools)
+	}
+}
+
+func TestReadCanonicalDirResolvesIncludes(t *testing.T) {
+	specs := t.TempDir()
+	agentsDir := filepath.Join(specs, "agents")
+	fragmentsDir := filepath.Join(specs, "fragments")
+	for _, dir := range []string{agentsDir, fragmentsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(fragmentsDir, "style.md"), []byte("Prefer small diffs.\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	agent := "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview code.\n\n{{include: fragments/style.md}}\n"
+	if err := os.WriteFile(filepath.Join(agentsDir, "reviewer.md"), []byte(agent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	agents, err := ReadCanonicalDir(agentsDir)
+	if err != nil {
+		t.Fatalf("ReadCanonicalDir failed: %v", err)
+	}
+	if len(agents) != 1 || !strings.Contains(agents[0].Instructions, "Review code.\n\nPrefer small diffs.") {
+		t.Errorf("expected fragment inlined into instructions, got %q", agents[0].Instructions)
+	}
+
+	cyclic := "{{include: fragments/loop.md}}"
+	if err := os.WriteFile(filepath.Join(fragmentsDir, "loop.md"), []byte(cyclic), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agentsDir, "looper.md"), []byte("---\nname: looper\n---\n\n"+cyclic+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadCanonicalDir(agentsDir); err == nil {
+		t.Error("expected an error for a cyclic include")
+	}
+}
+
+func TestReadCanonicalDirHonorsIgnoreFile(t *testing.T) {
+	specs := t.TempDir()
+	agentsDir := filepath.Join(specs, "agents")
+	if err := os.MkdirAll(filepath.Join(agentsDir, "drafts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		".assistantkitignore":     "_*.md\n*.bak\nagents/drafts/\n",
+		"agents/reviewer.md":      "---\nname: reviewer\n---\n\nReview code.\n",
+		"agents/_partial.md":      "Shared fragment without frontmatter.\n",
+		"agents/reviewer.md.bak":  "stale backup\n",
+		"agents/drafts/writer.md": "---\nname: writer\n---\n\nWrite docs.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(specs, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	agents, err := ReadCanonicalDir(agentsDir)
+	if err != nil {
+		t.Fatalf("ReadCanonicalDir failed: %v", err)
+	}
+	if len(agents) != 1 || agents[0].Name != "reviewer" {
+		names := make([]string, len(agents))
+		for i, a := range agents {
+			names[i] = a.Name
+		}
+		t.Errorf("expected only reviewer to be read, got %v", names)
+	}
+}
+
+func TestUpdateAgentFrontmatter(t *testing.T) {
+	body := "\n# Reviewer\n\n  Indented text,   odd spacing\t\n\n---\n\nA horizontal rule above.\n"
+	original := "---\nname: reviewer\ndescription: Reviews code\nmodel: haiku\ntools:\n  - Read\n  - Grep\n---\n" + body
+
+	path := filepath.Join(t.TempDir(), "reviewer.md")
+	if err := os.WriteFile(path, []byte(original), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	err := UpdateAgentFrontmatter(path, map[string]string{
+		"model":  "opus",
+		"tools":  "",
+		"memory": "notes/reviewer",
+	})
+	if err != nil {
+		t.Fatalf("UpdateAgentFrontmatter failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "---\nname: reviewer\ndescription: Reviews code\nmodel: opus\nmemory: notes/reviewer\n---\n"
+	if got := string(data); !strings.HasPrefix(got, want) {
+		t.Errorf("unexpected frontmatter:\n%s", got)
+	}
+	if got := string(data[len(want):]); got != body {
+		t.Errorf("body changed:\ngot  %q\nwant %q", got, body)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("expected file mode to be preserved, got %v", info.Mode().Perm())
+	}
+}
+
+func TestUpdateAgentFrontmatterTOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coordinator.md")
+	if err := os.WriteFile(path, []byte(tomlAgent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UpdateAgentFrontmatter(path, map[string]string{"model": "opus"}); err != nil {
+		t.Fatalf("UpdateAgentFrontmatter failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.Replace(tomlAgent, `model = "sonnet"`, `model = "opus"`, 1); string(data) != want {
+		t.Errorf("unexpected content:\n%s", data)
+	}
+
+	agent, err := ParseTOMLAgentMarkdown(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agent.Model != ModelOpus {
+		t.Errorf("Model = %q, want opus", agent.Model)
+	}
+}
+
+func TestUpdateAgentFrontmatterMissingFrontmatter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plain.md")
+	if err := os.WriteFile(path, []byte("# No frontmatter\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateAgentFrontmatter(path, map[string]string{"model": "opus"}); err == nil {
+		t.Error("expected an error for a file without frontmatter")
+	}
+}
@@ -0,0 +1,50 @@
+package core
+
+import "fmt"
+
+// Guardrails configure content filtering on an agent's input and output.
+// Runtimes with managed guardrails (Bedrock via AWS AgentCore) apply them;
+// see GuardrailsAdapter.
+type Guardrails struct {
+	// ID references an existing guardrail, e.g., a Bedrock guardrail ID or
+	// ARN. When empty, runtimes create a guardrail from BlockedTopics and
+	// PIIFilters.
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+
+	// Version pins the version of the referenced guardrail. Empty means the
+	// working draft.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
+	// BlockedTopics are topics the agent refuses to engage with.
+	BlockedTopics []string `json:"blockedTopics,omitempty" yaml:"blockedTopics,omitempty"`
+
+	// PIIFilters are PII entity types masked in inputs and outputs (e.g.,
+	// "EMAIL", "PHONE").
+	PIIFilters []string `json:"piiFilters,omitempty" yaml:"piiFilters,omitempty"`
+}
+
+// IsZero reports whether the guardrails configure nothing.
+func (g *Guardrails) IsZero() bool {
+	return g == nil || (g.ID == "" && g.Version == "" && len(g.BlockedTopics) == 0 && len(g.PIIFilters) == 0)
+}
+
+// GuardrailsAdapter is implemented by adapters whose runtime can apply
+// guardrails (see Agent.Guardrails).
+type GuardrailsAdapter interface {
+	SupportsGuardrails() bool
+}
+
+// SupportsGuardrails reports whether the adapter emits Guardrails.
+func SupportsGuardrails(adapter Adapter) bool {
+	ga, ok := adapter.(GuardrailsAdapter)
+	return ok && ga.SupportsGuardrails()
+}
+
+// CheckGuardrails returns a warning when the agent declares guardrails the
+// adapter cannot emit. The guardrails are ignored by such adapters.
+func CheckGuardrails(adapter Adapter, agent *Agent) []string {
+	if agent.Guardrails.IsZero() || SupportsGuardrails(adapter) {
+		return nil
+	}
+	return []string{fmt.Sprintf("agent %q: guardrails are not supported by %s and were ignored", agent.Name, adapter.Name())}
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Handoff names an agent that this agent may delegate to, with guidance on
+// when to do so.
+type Handoff struct {
+	// To is the name of the target agent.
+	To string `json:"to" yaml:"to"`
+
+	// When describes the tasks or conditions that should be routed to To.
+	When string `json:"when,omitempty" yaml:"when,omitempty"`
+}
+
+// UnmarshalJSON accepts either a handoff object or a bare agent name.
+func (h *Handoff) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*h = Handoff{To: name}
+		return nil
+	}
+	type plain Handoff
+	return json.Unmarshal(data, (*plain)(h))
+}
+
+// UnmarshalYAML accepts either a handoff mapping or a bare agent name.
+func (h *Handoff) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*h = Handoff{To: value.Value}
+		return nil
+	}
+	type plain Handoff
+	return value.Decode((*plain)(h))
+}
+
+// RoutingHeading is the heading of the section ApplyHandoffs appends to a
+// coordinator's instructions.
+const RoutingHeading = "## Routing"
+
+// RoutingGuidance renders handoffs as a Markdown section listing each target
+// agent and the conditions under which work should be routed to it.
+// It returns an empty string when there are no handoffs.
+func RoutingGuidance(handoffs []Handoff) string {
+	if len(handoffs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(RoutingHeading + "\n\n")
+	b.WriteString("Delegate work to the following agents when their conditions apply:\n\n")
+	for _, h := range handoffs {
+		if h.When == "" {
+			b.WriteString("- **" + h.To + "**\n")
+			continue
+		}
+		b.WriteString("- **" + h.To + "**: " + strings.TrimSpace(h.When) + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// ApplyHandoffs returns copies of agents with routing guidance appended to
+// the instructions of every agent that declares Handoffs. Each handoff target
+// must name another agent in the list. Instructions that already end with
+// the guidance are not changed, so applying handoffs twice is harmless.
+func ApplyHandoffs(agents []*Agent) ([]*Agent, error) {
+	names := make(map[string]bool, len(agents))
+	for _, agent := range agents {
+		names[agent.Name] = true
+	}
+
+	out := make([]*Agent, 0, len(agents))
+	for _, agent := range agents {
+		if len(agent.Handoffs) == 0 {
+			out = append(out, agent)
+			continue
+		}
+
+		for _, h := range agent.Handoffs {
+			switch {
+			case h.To == "":
+				return nil, &HandoffError{Agent: agent.Name, Message: "handoff target is empty"}
+			case h.To == agent.Name:
+				return nil, &HandoffError{Agent: agent.Name, To: h.To, Message: "agent cannot hand off to itself"}
+			case !names[h.To]:
+				return nil, &HandoffError{Agent: agent.Name, To: h.To, Message: "target agent not found"}
+			}
+		}
+
+		// Instructions that already end with the guidance, such as an agent
+		// read back from generated output, are left as they are
+		guidance := RoutingGuidance(agent.Handoffs)
+		if strings.HasSuffix(strings.TrimSpace(agent.Instructions), guidance) {
+			out = append(out, agent)
+			continue
+		}
+
+		applied := *agent
+		applied.Instructions = joinNonEmpty(agent.Instructions, guidance)
+		out = append(out, &applied)
+	}
+	return out, nil
+}
+
+// MermaidGraph renders agents as a Mermaid flowchart: one node per agent and
+// one edge per handoff, labeled with the handoff's When condition. The result
+// has no code fence.
+func MermaidGraph(agents []*Agent) string {
+	var b strings.Builder
+	b.WriteString("graph TD\n")
+	for _, agent := range agents {
+		b.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", mermaidID(agent.Name), mermaidText(agent.Name)))
+	}
+	for _, agent := range agents {
+		for _, h := range agent.Handoffs {
+			if when := strings.TrimSpace(h.When); when != "" {
+				b.WriteString(fmt.Sprintf("    %s -->|\"%s\"| %s\n", mermaidID(agent.Name), mermaidText(when), mermaidID(h.To)))
+				continue
+			}
+			b.WriteString(fmt.Sprintf("    %s --> %s\n", mermaidID(agent.Name), mermaidID(h.To)))
+		}
+	}
+	return b.String()
+}
+
+// mermaidID returns a node identifier for an agent name. Mermaid reads
+// hyphens in bare identifiers as edge syntax, so anything other than
+// letters, digits, and underscores becomes an underscore.
+func mermaidID(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// mermaidText escapes text for a quoted Mermaid label.
+func mermaidText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.ReplaceAll(text, `"`, "#quot;")
+}
+
+// mergeHandoffs returns the parent's handoffs with the child's appended;
+// a child handoff to the same target replaces the parent's.
+func mergeHandoffs(parent, child []Handoff) []Handoff {
+	if len(parent) == 0 {
+		return child
+	}
+	override := make(map[string]Handoff, len(child))
+	for _, h := range child {
+		override[h.To] = h
+	}
+
+	var merged []Handoff
+	seen := make(map[string]bool, len(parent)+len(child))
+	for _, h := range append(append([]Handoff{}, parent...), child...) {
+		if seen[h.To] {
+			continue
+		}
+		seen[h.To] = true
+		if o, ok := override[h.To]; ok {
+			h = o
+		}
+		merged = append(merged, h)
+	}
+	return merged
+}
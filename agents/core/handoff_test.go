@@ -0,0 +1,93 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseHandoffs(t *testing.T) {
+	data := []byte("---\nname: lead\ndescription: Leads\nhandoffs:\n  - researcher\n  - to: writer\n    when: the draft is ready\n---\n\nLead.\n")
+
+	agent, err := ParseYAMLAgentMarkdown(data)
+	if err != nil {
+		t.Fatalf("ParseYAMLAgentMarkdown failed: %v", err)
+	}
+	want := []Handoff{{To: "researcher"}, {To: "writer", When: "the draft is ready"}}
+	if !reflect.DeepEqual(agent.Handoffs, want) {
+		t.Fatalf("Handoffs = %+v, want %+v", agent.Handoffs, want)
+	}
+
+	reparsed, err := ParseYAMLAgentMarkdown(MarshalMarkdownAgent(agent))
+	if err != nil {
+		t.Fatalf("reparse failed: %v", err)
+	}
+	if !reflect.DeepEqual(reparsed.Handoffs, want) {
+		t.Errorf("round-tripped Handoffs = %+v, want %+v", reparsed.Handoffs, want)
+	}
+}
+
+func TestApplyHandoffs(t *testing.T) {
+	lead := NewAgent("lead", "Leads")
+	lead.Instructions = "Coordinate the team."
+	lead.Handoffs = []Handoff{
+		{To: "researcher", When: "a question needs sources"},
+		{To: "writer"},
+	}
+	researcher := NewAgent("researcher", "Researches")
+	writer := NewAgent("writer", "Writes")
+
+	applied, err := ApplyHandoffs([]*Agent{lead, researcher, writer})
+	if err != nil {
+		t.Fatalf("ApplyHandoffs failed: %v", err)
+	}
+
+	got := applied[0].Instructions
+	if !strings.HasPrefix(got, "Coordinate the team.\n\n"+RoutingHeading) {
+		t.Errorf("expected routing section after instructions, got:\n%s", got)
+	}
+	for _, want := range []string{"- **researcher**: a question needs sources", "- **writer**"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in instructions, got:\n%s", want, got)
+		}
+	}
+	if lead.Instructions != "Coordinate the team." {
+		t.Error("expected the input agent to be left unchanged")
+	}
+	if applied[1] != researcher {
+		t.Error("expected agents without handoffs to be returned as-is")
+	}
+
+	again, err := ApplyHandoffs(applied)
+	if err != nil {
+		t.Fatalf("ApplyHandoffs failed: %v", err)
+	}
+	if again[0].Instructions != got {
+		t.Errorf("expected applying handoffs twice to leave instructions unchanged, got:\n%s", again[0].Instructions)
+	}
+
+	lead.Handoffs = []Handoff{{To: "missing"}}
+	_, err = ApplyHandoffs([]*Agent{lead})
+	var he *HandoffError
+	if !errors.As(err, &he) || he.To != "missing" {
+		t.Errorf("expected HandoffError for missing target, got %v", err)
+	}
+}
+
+func TestResolveInheritanceMergesHandoffs(t *testing.T) {
+	parent := NewAgent("base", "Base")
+	parent.Handoffs = []Handoff{{To: "researcher", When: "research"}, {To: "writer", When: "writing"}}
+	child := NewAgent("lead", "")
+	child.Inherits = "base"
+	child.Handoffs = []Handoff{{To: "writer", When: "the draft is ready"}, {To: "reviewer"}}
+
+	resolved, err := ResolveInheritance([]*Agent{parent, child})
+	if err != nil {
+		t.Fatalf("ResolveInheritance failed: %v", err)
+	}
+	want := []Handoff{{To: "researcher", When: "research"}, {To: "writer", When: "the draft is ready"}, {To: "reviewer"}}
+	if !reflect.DeepEqual(resolved[1].Handoffs, want) {
+		t.Errorf("Handoffs = %+v, want %+v", resolved[1].Handoffs, want)
+	}
+}
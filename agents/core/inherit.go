@@ -0,0 +1,279 @@
+package core
+
+// ResolveInheritance returns copies of agents with each Inherits chain
+// flattened into the child. Parents are referenced by name, or by
+// namespace/name when names collide across namespaces.
+//
+// Merge rules, applied from the root ancestor down:
+//   - Scalar fields (description, model, fallbackModel, icon, memory,
+//     workingDir, approval, parallelToolCalls, maxTurns) use the child's value when set, otherwise the parent's.
+//   - Instructions and DeveloperInstructions are concatenated, parent first.
+//     LocalizedInstructions are concatenated per locale, falling back to the
+//     default instructions on the side that lacks the locale.
+//   - Tools, AllowedTools, Skills, Dependencies, Requires, Keywords,
+//     Categories, and MCPServers are unioned and deduplicated, preserving
+//     first-seen order.
+//   - Handoffs are merged by target; a child handoff replaces the parent's
+//     handoff to the same agent.
+//   - KnowledgeSources and Resources are unioned, preserving first-seen
+//     order.
+//   - Guardrails are the child's when set, otherwise the parent's.
+//   - Modalities, StopConditions, and Experimental are the child's when set,
+//     otherwise the parent's.
+//   - Deprecated and DeprecationMessage are never inherited.
+//   - DisallowedTools are unioned, except tools the child explicitly lists in
+//     Tools, which re-enables a tool a parent denied.
+//   - Denied tools are then removed from Tools and AllowedTools, so a child
+//     can deny a tool its parent allowed.
+//
+// Agents without a parent still have their own DisallowedTools applied.
+// The resolved agents have Inherits cleared.
+func ResolveInheritance(agents []*Agent) ([]*Agent, error) {
+	byName := make(map[string]*Agent, len(agents))
+	for _, agent := range agents {
+		byName[qualifiedName(agent)] = agent
+		if _, ok := byName[agent.Name]; !ok {
+			byName[agent.Name] = agent
+		}
+	}
+
+	resolved := make(map[*Agent]*Agent, len(agents))
+	var resolve func(agent *Agent, visiting map[*Agent]bool) (*Agent, error)
+	resolve = func(agent *Agent, visiting map[*Agent]bool) (*Agent, error) {
+		if r, ok := resolved[agent]; ok {
+			return r, nil
+		}
+		if agent.Inherits == "" {
+			r := mergeAgents(nil, agent)
+			resolved[agent] = r
+			return r, nil
+		}
+
+		parent, ok := byName[agent.Inherits]
+		if !ok {
+			return nil, &InheritanceError{Agent: agent.Name, Parent: agent.Inherits, Message: "parent agent not found"}
+		}
+		if visiting[agent] {
+			return nil, &InheritanceError{Agent: agent.Name, Parent: agent.Inherits, Message: "inheritance cycle"}
+		}
+		visiting[agent] = true
+
+		base, err := resolve(parent, visiting)
+		if err != nil {
+			return nil, err
+		}
+		r := mergeAgents(base, agent)
+		resolved[agent] = r
+		return r, nil
+	}
+
+	out := make([]*Agent, 0, len(agents))
+	for _, agent := range agents {
+		r, err := resolve(agent, map[*Agent]bool{})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// mergeAgents returns child merged over an already-resolved parent.
+// A nil parent applies the child's own deny list.
+func mergeAgents(parent, child *Agent) *Agent {
+	merged := *child
+	merged.Inherits = ""
+
+	if parent != nil {
+		merged.Description = firstNonEmpty(child.Description, parent.Description)
+		merged.Icon = firstNonEmpty(child.Icon, parent.Icon)
+		merged.Model = Model(firstNonEmpty(string(child.Model), string(parent.Model)))
+		merged.FallbackModel = Model(firstNonEmpty(string(child.FallbackModel), string(parent.FallbackModel)))
+		merged.Memory = firstNonEmpty(child.Memory, parent.Memory)
+		merged.WorkingDir = firstNonEmpty(child.WorkingDir, parent.WorkingDir)
+		merged.Approval = Approval(firstNonEmpty(string(child.Approval), string(parent.Approval)))
+		if merged.ParallelToolCalls == nil {
+			merged.ParallelToolCalls = parent.ParallelToolCalls
+		}
+		if merged.MaxTurns == nil {
+			merged.MaxTurns = parent.MaxTurns
+		}
+		merged.Runtime = mergeRuntime(parent.Runtime, child.Runtime)
+		merged.Instructions = joinNonEmpty(parent.Instructions, child.Instructions)
+		merged.DeveloperInstructions = joinNonEmpty(parent.DeveloperInstructions, child.DeveloperInstructions)
+		merged.LocalizedInstructions = mergeLocalizedInstructions(parent, child)
+		merged.Skills = unionStrings(parent.Skills, child.Skills)
+		merged.Dependencies = unionStrings(parent.Dependencies, child.Dependencies)
+		merged.Requires = unionStrings(parent.Requires, child.Requires)
+		merged.Keywords = unionStrings(parent.Keywords, child.Keywords)
+		merged.Categories = unionStrings(parent.Categories, child.Categories)
+		merged.MCPServers = unionStrings(parent.MCPServers, child.MCPServers)
+		merged.Handoffs = mergeHandoffs(parent.Handoffs, child.Handoffs)
+		merged.KnowledgeSources = mergeKnowledgeSources(parent.KnowledgeSources, child.KnowledgeSources)
+		merged.Resources = unionStrings(parent.Resources, child.Resources)
+		if child.Guardrails.IsZero() {
+			merged.Guardrails = parent.Guardrails
+		}
+		if len(child.Modalities) == 0 {
+			merged.Modalities = parent.Modalities
+		}
+		if len(child.StopConditions) == 0 {
+			merged.StopConditions = parent.StopConditions
+		}
+		if len(child.Experimental) == 0 {
+			merged.Experimental = parent.Experimental
+		}
+		if len(child.Tasks) == 0 {
+			merged.Tasks = parent.Tasks
+		}
+	}
+
+	merged.Tools, merged.AllowedTools, merged.DisallowedTools = MergeTools(parent, child)
+	return &merged
+}
+
+// MergeTools applies the allow/deny rules of ResolveInheritance and returns
+// the child's effective Tools, AllowedTools, and DisallowedTools.
+// A nil parent merges the child with itself.
+func MergeTools(parent, child *Agent) (tools, allowed, disallowed []string) {
+	var parentTools, parentAllowed, parentDenied []string
+	if parent != nil {
+		parentTools, parentAllowed, parentDenied = parent.Tools, parent.AllowedTools, parent.DisallowedTools
+	}
+
+	explicit := toSet(child.Tools)
+	for _, tool := range unionStrings(parentDenied, child.DisallowedTools) {
+		if !explicit[tool] || containsString(child.DisallowedTools, tool) {
+			disallowed = append(disallowed, tool)
+		}
+	}
+
+	denied := toSet(disallowed)
+	tools = removeStrings(unionStrings(parentTools, child.Tools), denied)
+	allowed = removeStrings(unionStrings(parentAllowed, child.AllowedTools), denied)
+	return tools, allowed, disallowed
+}
+
+func qualifiedName(agent *Agent) string {
+	if agent.Namespace == "" {
+		return agent.Name
+	}
+	return agent.Namespace + "/" + agent.Name
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func joinNonEmpty(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	default:
+		return parent + "\n\n" + child
+	}
+}
+
+// unionStrings concatenates lists, dropping duplicates and keeping first-seen order.
+func unionStrings(lists ...[]string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, s := range list {
+			if !seen[s] {
+				seen[s] = true
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+func removeStrings(list []string, remove map[string]bool) []string {
+	var out []string
+	for _, s := range list {
+		if !remove[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func toSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, s := range list {
+		set[s] = true
+	}
+	return set
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeRuntime returns child's runtime limits with unset fields taken from
+// parent. Tags are merged, with the child's value winning for a shared key.
+// A child's network policy replaces the parent's rather than widening it.
+func mergeRuntime(parent, child *Runtime) *Runtime {
+	switch {
+	case parent == nil:
+		return child
+	case child == nil:
+		return parent
+	}
+	merged := *child
+	if merged.Entrypoint == "" {
+		merged.Entrypoint = parent.Entrypoint
+	}
+	if merged.Timeout == 0 {
+		merged.Timeout = parent.Timeout
+	}
+	if merged.Retries == nil {
+		merged.Retries = parent.Retries
+	}
+	if merged.Memory == 0 {
+		merged.Memory = parent.Memory
+	}
+	if merged.Concurrency == 0 {
+		merged.Concurrency = parent.Concurrency
+	}
+	if merged.RateLimit == 0 {
+		merged.RateLimit = parent.RateLimit
+	}
+	merged.Tags = MergeTags(parent.Tags, child.Tags)
+	if merged.Resources == nil {
+		merged.Resources = parent.Resources
+	}
+	if merged.NetworkPolicy == nil {
+		merged.NetworkPolicy = parent.NetworkPolicy
+	}
+	return &merged
+}
+
+// MergeTags returns base overlaid with override, or nil when both are
+// empty. Neither map is modified.
+func MergeTags(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestResolveInheritanceChildDeniesParentTool(t *testing.T) {
+	parent := NewAgent("base", "Base agent").WithTools("Read", "Grep", "Bash")
+	parent.AllowedTools = []string{"Read", "Bash"}
+	parent.Instructions = "Follow the style guide."
+
+	child := NewAgent("reviewer", "")
+	child.Inherits = "base"
+	child.Tools = []string{"Grep", "WebFetch"}
+	child.DisallowedTools = []string{"Bash"}
+	child.Instructions = "Review the diff."
+
+	resolved, err := ResolveInheritance([]*Agent{parent, child})
+	if err != nil {
+		t.Fatalf("ResolveInheritance failed: %v", err)
+	}
+	got := resolved[1]
+
+	if want := []string{"Read", "Grep", "WebFetch"}; !reflect.DeepEqual(got.Tools, want) {
+		t.Errorf("Tools = %v, want %v", got.Tools, want)
+	}
+	if want := []string{"Read"}; !reflect.DeepEqual(got.AllowedTools, want) {
+		t.Errorf("AllowedTools = %v, want %v", got.AllowedTools, want)
+	}
+	if got.Description != "Base agent" {
+		t.Errorf("Description = %q, want inherited %q", got.Description, "Base agent")
+	}
+	if got.Instructions != "Follow the style guide.\n\nReview the diff." {
+		t.Errorf("unexpected Instructions %q", got.Instructions)
+	}
+	if got.Inherits != "" {
+		t.Errorf("expected Inherits to be cleared, got %q", got.Inherits)
+	}
+	if !reflect.DeepEqual(resolved[0].Tools, parent.Tools) {
+		t.Errorf("parent tools changed: %v", resolved[0].Tools)
+	}
+}
+
+func TestResolveInheritanceChildReenablesDeniedTool(t *testing.T) {
+	parent := NewAgent("base", "Base agent").WithTools("Read")
+	parent.DisallowedTools = []string{"Bash", "Write"}
+
+	child := NewAgent("builder", "Builds")
+	child.Inherits = "base"
+	child.Tools = []string{"Bash"}
+
+	resolved, err := ResolveInheritance([]*Agent{child, parent})
+	if err != nil {
+		t.Fatalf("ResolveInheritance failed: %v", err)
+	}
+	got := resolved[0]
+	if want := []string{"Read", "Bash"}; !reflect.DeepEqual(got.Tools, want) {
+		t.Errorf("Tools = %v, want %v", got.Tools, want)
+	}
+	if want := []string{"Write"}; !reflect.DeepEqual(got.DisallowedTools, want) {
+		t.Errorf("DisallowedTools = %v, want %v", got.DisallowedTools, want)
+	}
+}
+
+func TestResolveInheritanceErrors(t *testing.T) {
+	orphan := NewAgent("orphan", "")
+	orphan.Inherits = "missing"
+	var inheritErr *InheritanceError
+	if _, err := ResolveInheritance([]*Agent{orphan}); !errors.As(err, &inheritErr) {
+		t.Errorf("expected InheritanceError for missing parent, got %v", err)
+	}
+
+	a := NewAgent("a", "")
+	a.Inherits = "b"
+	b := NewAgent("b", "")
+	b.Inherits = "a"
+	if _, err := ResolveInheritance([]*Agent{a, b}); !errors.As(err, &inheritErr) {
+		t.Errorf("expected InheritanceError for cycle, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package core
+
+import "strings"
+
+// Knowledge source types.
+const (
+	// KnowledgeSourceBedrock is an Amazon Bedrock knowledge base, referenced
+	// by its ID or ARN.
+	KnowledgeSourceBedrock = "bedrock-knowledge-base"
+)
+
+// KnowledgeSource is a retrieval (RAG) source that backs an agent.
+type KnowledgeSource struct {
+	// Type identifies the kind of source (e.g., KnowledgeSourceBedrock).
+	Type string `json:"type" yaml:"type"`
+
+	// URI locates the source, e.g., a Bedrock knowledge base ID or ARN.
+	URI string `json:"uri" yaml:"uri"`
+}
+
+// ID returns the last path segment of URI, which is the knowledge base ID
+// of a Bedrock ARN (arn:aws:bedrock:...:knowledge-base/<id>).
+func (k KnowledgeSource) ID() string {
+	return k.URI[strings.LastIndex(k.URI, "/")+1:]
+}
+
+// KnowledgeSourcesOfType returns the agent's knowledge sources of one type.
+func (a *Agent) KnowledgeSourcesOfType(sourceType string) []KnowledgeSource {
+	var sources []KnowledgeSource
+	for _, source := range a.KnowledgeSources {
+		if source.Type == sourceType {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// mergeKnowledgeSources unions parent and child sources, preserving
+// first-seen order.
+func mergeKnowledgeSources(parent, child []KnowledgeSource) []KnowledgeSource {
+	seen := make(map[KnowledgeSource]bool, len(parent)+len(child))
+	var merged []KnowledgeSource
+	for _, source := range append(append([]KnowledgeSource{}, parent...), child...) {
+		if !seen[source] {
+			seen[source] = true
+			merged = append(merged, source)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+// InstructionsFor returns the agent's instructions for locale. The exact
+// locale is tried first, then its base language (e.g., "fr" for "fr-CA"),
+// and finally the default Instructions.
+func (a *Agent) InstructionsFor(locale string) string {
+	if locale == "" || len(a.LocalizedInstructions) == 0 {
+		return a.Instructions
+	}
+	if instructions, ok := a.LocalizedInstructions[locale]; ok {
+		return instructions
+	}
+	if base, _, ok := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-"); ok {
+		if instructions, ok := a.LocalizedInstructions[base]; ok {
+			return instructions
+		}
+	}
+	return a.Instructions
+}
+
+// Localize returns copies of agents with Instructions replaced by their
+// variant for locale (see InstructionsFor) and LocalizedInstructions
+// cleared. An empty locale returns agents unchanged.
+func Localize(agents []*Agent, locale string) []*Agent {
+	if locale == "" {
+		return agents
+	}
+	out := make([]*Agent, 0, len(agents))
+	for _, agent := range agents {
+		localized := *agent
+		localized.Instructions = agent.InstructionsFor(locale)
+		localized.LocalizedInstructions = nil
+		out = append(out, &localized)
+	}
+	return out
+}
+
+// mergeLocalizedInstructions concatenates each locale's instructions,
+// parent first. A locale present on only one side is joined with the other
+// side's default instructions, so inherited text is kept in every locale.
+func mergeLocalizedInstructions(parent, child *Agent) map[string]string {
+	if len(parent.LocalizedInstructions) == 0 && len(child.LocalizedInstructions) == 0 {
+		return nil
+	}
+	locales := make(map[string]bool)
+	for locale := range parent.LocalizedInstructions {
+		locales[locale] = true
+	}
+	for locale := range child.LocalizedInstructions {
+		locales[locale] = true
+	}
+	merged := make(map[string]string, len(locales))
+	for locale := range locales {
+		merged[locale] = joinNonEmpty(parent.InstructionsFor(locale), child.InstructionsFor(locale))
+	}
+	return merged
+}
+
+// sortedLocales returns the locales of m in sorted order.
+func sortedLocales(m map[string]string) []string {
+	locales := make([]string, 0, len(m))
+	for locale := range m {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInstructionsFor(t *testing.T) {
+	agent := &Agent{
+		Spec:                  Spec{Name: "reviewer", Instructions: "Review the diff."},
+		LocalizedInstructions: map[string]string{"fr": "Relisez le diff.", "pt-BR": "Revise o diff."},
+	}
+
+	tests := map[string]string{
+		"":      "Review the diff.",
+		"fr":    "Relisez le diff.",
+		"fr-CA": "Relisez le diff.",
+		"fr_CA": "Relisez le diff.",
+		"pt-BR": "Revise o diff.",
+		"pt":    "Review the diff.",
+		"de":    "Review the diff.",
+	}
+	for locale, want := range tests {
+		if got := agent.InstructionsFor(locale); got != want {
+			t.Errorf("InstructionsFor(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	agent := &Agent{
+		Spec:                  Spec{Name: "reviewer", Instructions: "Review the diff."},
+		LocalizedInstructions: map[string]string{"fr": "Relisez le diff."},
+	}
+
+	localized := Localize([]*Agent{agent}, "fr")
+	if localized[0].Instructions != "Relisez le diff." {
+		t.Errorf("expected French instructions, got %q", localized[0].Instructions)
+	}
+	if localized[0].LocalizedInstructions != nil {
+		t.Error("expected LocalizedInstructions to be cleared")
+	}
+	if agent.Instructions != "Review the diff." {
+		t.Error("expected the original agent to be unchanged")
+	}
+}
+
+func TestResolveInheritanceLocalizedInstructions(t *testing.T) {
+	base := &Agent{
+		Spec:                  Spec{Name: "base", Instructions: "Follow the style guide."},
+		LocalizedInstructions: map[string]string{"fr": "Suivez le guide de style."},
+	}
+	child := &Agent{
+		Spec:     Spec{Name: "reviewer", Instructions: "Review the diff."},
+		Inherits: "base",
+	}
+
+	resolved, err := ResolveInheritance([]*Agent{base, child})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Suivez le guide de style.\n\nReview the diff."
+	if got := resolved[1].InstructionsFor("fr"); got != want {
+		t.Errorf("InstructionsFor(fr) = %q, want %q", got, want)
+	}
+}
+
+func TestMarshalMarkdownAgentLocalizedInstructions(t *testing.T) {
+	agent := &Agent{
+		Spec:                  Spec{Name: "reviewer", Description: "Reviews code", Instructions: "Review the diff."},
+		LocalizedInstructions: map[string]string{"fr": "Relisez le diff.\n\nSoyez bref."},
+	}
+
+	data := MarshalMarkdownAgent(agent)
+	if !strings.Contains(string(data), "localizedInstructions:\n  fr: |-\n") {
+		t.Errorf("expected localizedInstructions block, got:\n%s", data)
+	}
+
+	parsed, err := ParseMarkdownAgent(data, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parsed.LocalizedInstructions["fr"]; got != "Relisez le diff.\n\nSoyez bref." {
+		t.Errorf("round trip lost localized instructions: %q", got)
+	}
+}
@@ -0,0 +1,28 @@
+package core
+
+import "fmt"
+
+// LoopControlAdapter is implemented by adapters whose output format can
+// bound the agent's loop (see Agent.MaxTurns and Agent.StopConditions).
+type LoopControlAdapter interface {
+	// SupportsMaxTurns reports whether the adapter emits MaxTurns.
+	SupportsMaxTurns() bool
+
+	// SupportsStopConditions reports whether the adapter emits
+	// StopConditions.
+	SupportsStopConditions() bool
+}
+
+// CheckLoopControl returns a warning for each loop limit the agent declares
+// that the adapter cannot emit. Such limits are ignored by the adapter.
+func CheckLoopControl(adapter Adapter, agent *Agent) []string {
+	lc, _ := adapter.(LoopControlAdapter)
+	var warnings []string
+	if agent.MaxTurns != nil && (lc == nil || !lc.SupportsMaxTurns()) {
+		warnings = append(warnings, fmt.Sprintf("agent %q: maxTurns is not supported by %s and was ignored", agent.Name, adapter.Name()))
+	}
+	if len(agent.StopConditions) > 0 && (lc == nil || !lc.SupportsStopConditions()) {
+		warnings = append(warnings, fmt.Sprintf("agent %q: stopConditions are not supported by %s and were ignored", agent.Name, adapter.Name()))
+	}
+	return warnings
+}
@@ -0,0 +1,33 @@
+package core
+
+// Input modalities an agent can accept.
+const (
+	ModalityText  = "text"
+	ModalityImage = "image"
+	ModalityAudio = "audio"
+)
+
+// IsKnownModality reports whether modality is one of the Modality constants.
+func IsKnownModality(modality string) bool {
+	switch modality {
+	case ModalityText, ModalityImage, ModalityAudio:
+		return true
+	}
+	return false
+}
+
+// InputModalities returns the agent's declared modalities, or text alone
+// when none are declared.
+func (a *Agent) InputModalities() []string {
+	if len(a.Modalities) == 0 {
+		return []string{ModalityText}
+	}
+	return a.Modalities
+}
+
+// IsTextOnly reports whether the agent accepts only text input, in which
+// case adapters need not emit modalities at all.
+func (a *Agent) IsTextOnly() bool {
+	modalities := a.InputModalities()
+	return len(modalities) == 1 && modalities[0] == ModalityText
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ResponseSchemaAdapter is implemented by adapters whose output format can
+// carry a structured response schema (see Agent.ResponseSchema).
+type ResponseSchemaAdapter interface {
+	SupportsResponseSchema() bool
+}
+
+// SupportsResponseSchema reports whether the adapter emits ResponseSchema.
+func SupportsResponseSchema(adapter Adapter) bool {
+	rs, ok := adapter.(ResponseSchemaAdapter)
+	return ok && rs.SupportsResponseSchema()
+}
+
+// CheckResponseSchema returns a warning when the agent declares a response
+// schema the adapter cannot emit. The schema is ignored by such adapters.
+func CheckResponseSchema(adapter Adapter, agent *Agent) []string {
+	if len(agent.ResponseSchema) == 0 || SupportsResponseSchema(adapter) {
+		return nil
+	}
+	return []string{fmt.Sprintf("agent %q: responseSchema is not supported by %s and was ignored", agent.Name, adapter.Name())}
+}
+
+// responseSchemaField is the frontmatter key holding the response schema.
+const responseSchemaField = "responseSchema"
+
+// decodeResponseSchema reads the responseSchema key from frontmatter as JSON.
+// Frontmatter encodings decode the schema as a native mapping, so it cannot
+// be unmarshaled into Agent.ResponseSchema directly.
+func decodeResponseSchema(frontmatter []byte, isTOML bool) (json.RawMessage, error) {
+	var fields map[string]interface{}
+	var err error
+	if isTOML {
+		err = toml.Unmarshal(frontmatter, &fields)
+	} else {
+		err = yaml.Unmarshal(frontmatter, &fields)
+	}
+	if err != nil {
+		return nil, err
+	}
+	schema, ok := fields[responseSchemaField]
+	if !ok || schema == nil {
+		return nil, nil
+	}
+	if s, ok := schema.(string); ok {
+		// A schema written as a JSON string.
+		if !json.Valid([]byte(s)) {
+			return nil, &ValidationError{Field: responseSchemaField, Message: "must be a JSON object"}
+		}
+		return json.RawMessage(s), nil
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, &ValidationError{Field: responseSchemaField, Message: err.Error()}
+	}
+	return data, nil
+}
+
+// compactSchema returns the schema as single-line JSON, which is also valid
+// YAML flow syntax.
+func compactSchema(schema json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, schema); err != nil {
+		return string(schema)
+	}
+	return buf.String()
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"fmt"
+
+	multiagentspec "github.com/agentplexus/multi-agent-spec/sdk/go"
+)
+
+// CanonicalTools lists the canonical tool names defined by multi-agent-spec.
+var CanonicalTools = []string{
+	string(multiagentspec.ToolRead),
+	string(multiagentspec.ToolWrite),
+	string(multiagentspec.ToolEdit),
+	string(multiagentspec.ToolGlob),
+	string(multiagentspec.ToolGrep),
+	string(multiagentspec.ToolBash),
+	string(multiagentspec.ToolWebSearch),
+	string(multiagentspec.ToolWebFetch),
+	string(multiagentspec.ToolTask),
+}
+
+// ToolPermissions are an agent's tool rules in the allow/deny form used by
+// permission settings (e.g., Claude Code's "permissions" block).
+type ToolPermissions struct {
+	// Allow lists tools the agent may use without asking.
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny lists tools the agent may not use.
+	Deny []string `json:"deny,omitempty"`
+}
+
+// ToolPermissions returns the agent's AllowedTools and DisallowedTools as
+// permission rules.
+func (a *Agent) ToolPermissions() ToolPermissions {
+	return ToolPermissions{Allow: a.AllowedTools, Deny: a.DisallowedTools}
+}
+
+// ToolsAdapter is implemented by adapters that can only represent a fixed
+// set of tools. Adapters without it accept every tool.
+type ToolsAdapter interface {
+	// SupportedTools returns the canonical tool names this tool can represent.
+	SupportedTools() []string
+}
+
+// PassthroughToolsAdapter is implemented by adapters that also accept tool
+// names outside SupportedTools and write them through unchanged, such as MCP
+// tools ("mcp__<server>__<tool>") or tool-native names.
+type PassthroughToolsAdapter interface {
+	IsPassthroughTool(tool string) bool
+}
+
+// CheckTools splits the agent's tools into those the adapter supports and
+// those it does not. Tools with a registered alias for the adapter (see
+// ToolAliases) and passthrough tools (see PassthroughToolsAdapter) are
+// supported. Agents without tools, and adapters that do not implement
+// ToolsAdapter, are always fully supported.
+func CheckTools(adapter Adapter, agent *Agent) (supported, unsupported []string) {
+	ta, ok := adapter.(ToolsAdapter)
+	if !ok {
+		return agent.Tools, nil
+	}
+	known := make(map[string]bool)
+	for _, tool := range ta.SupportedTools() {
+		known[tool] = true
+	}
+	passthrough, _ := adapter.(PassthroughToolsAdapter)
+
+	for _, tool := range agent.Tools {
+		_, aliased := ToolAlias(adapter.Name(), tool)
+		if known[tool] || aliased || (passthrough != nil && passthrough.IsPassthroughTool(tool)) {
+			supported = append(supported, tool)
+		} else {
+			unsupported = append(unsupported, tool)
+		}
+	}
+	return supported, unsupported
+}
+
+// RestrictTools returns a copy of the agent limited to the tools the adapter
+// supports, along with a warning for each tool that was removed. In strict
+// mode an UnsupportedToolsError is returned instead.
+func RestrictTools(adapter Adapter, agent *Agent, strict bool) (*Agent, []string, error) {
+	supported, unsupported := CheckTools(adapter, agent)
+	if len(unsupported) == 0 {
+		return agent, nil, nil
+	}
+
+	if strict {
+		return nil, nil, &UnsupportedToolsError{Adapter: adapter.Name(), Agent: agent.Name, Tools: unsupported}
+	}
+
+	warnings := make([]string, 0, len(unsupported))
+	for _, tool := range unsupported {
+		warnings = append(warnings, fmt.Sprintf("agent %q: tool %q is not supported by %s and was dropped", agent.Name, tool, adapter.Name()))
+	}
+
+	restricted := *agent
+	restricted.Tools = supported
+	return &restricted, warnings, nil
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"strings"
+	"sync"
+)
+
+// InstructionTransform rewrites agent instructions for a specific tool.
+// Transforms are applied by adapters during Marshal so the canonical
+// instructions stay free of tool-specific boilerplate.
+type InstructionTransform func(instructions string) string
+
+// Preamble returns an InstructionTransform that prepends text to the instructions.
+func Preamble(text string) InstructionTransform {
+	return func(instructions string) string {
+		if instructions == "" {
+			return text
+		}
+		return strings.TrimRight(text, "\n") + "\n\n" + instructions
+	}
+}
+
+// Epilogue returns an InstructionTransform that appends text to the instructions.
+func Epilogue(text string) InstructionTransform {
+	return func(instructions string) string {
+		if instructions == "" {
+			return text
+		}
+		return strings.TrimRight(instructions, "\n") + "\n\n" + text
+	}
+}
+
+var (
+	transformsMu sync.RWMutex
+	transforms   = make(map[string][]InstructionTransform)
+)
+
+// AddInstructionTransform registers a transform for the named adapter.
+// Multiple transforms are applied in the order they were added.
+func AddInstructionTransform(adapterName string, transform InstructionTransform) {
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+	transforms[adapterName] = append(transforms[adapterName], transform)
+}
+
+// ClearInstructionTransforms removes all transforms for the named adapter.
+func ClearInstructionTransforms(adapterName string) {
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+	delete(transforms, adapterName)
+}
+
+// TransformInstructions applies the transforms registered for the named adapter.
+func TransformInstructions(adapterName, instructions string) string {
+	transformsMu.RLock()
+	defer transformsMu.RUnlock()
+	for _, transform := range transforms[adapterName] {
+		instructions = transform(instructions)
+	}
+	return instructions
+}
@@ -32,6 +32,12 @@ func (a *Adapter) DefaultDir() string {
 	return "agents"
 }
 
+// SupportedTools returns the canonical tools available to Gemini CLI agents.
+// Gemini CLI has no subagent tool.
+func (a *Adapter) SupportedTools() []string {
+	return []string{"Read", "Write", "Edit", "Glob", "Grep", "Bash", "WebSearch", "WebFetch"}
+}
+
 // GeminiAgent represents a Gemini CLI agent in TOML format.
 type GeminiAgent struct {
 	Agent        AgentSection `toml:"agent"`
@@ -55,15 +61,15 @@ func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
 		return nil, &core.ParseError{Format: "gemini", Err: err}
 	}
 
-	agent := &core.Agent{
+	agent := &core.Agent{Spec: core.Spec{
 		Name:         ga.Agent.Name,
 		Description:  ga.Agent.Description,
 		Model:        mapGeminiModelToCanonical(ga.Agent.Model),
-		Tools:        ga.Agent.Tools,
+		Tools:        core.UnaliasTools(a.Name(), ga.Agent.Tools),
 		Skills:       ga.Agent.Skills,
 		Dependencies: ga.Agent.Dependencies,
 		Instructions: ga.Instructions,
-	}
+	}}
 
 	return agent, nil
 }
@@ -75,11 +81,11 @@ func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 			Name:         agent.Name,
 			Description:  agent.Description,
 			Model:        mapCanonicalModelToGemini(agent.Model),
-			Tools:        agent.Tools,
+			Tools:        core.AliasTools(a.Name(), agent.Tools),
 			Skills:       agent.Skills,
 			Dependencies: agent.Dependencies,
 		},
-		Instructions: agent.Instructions,
+		Instructions: core.TransformInstructions(a.Name(), agent.CombinedInstructions()),
 	}
 
 	data, err := toml.Marshal(ga)
@@ -0,0 +1,576 @@
+// Package k8s generates Helm charts for deploying multi-agent teams to
+// Kubernetes (EKS, AKS, GKE, or any conformant cluster).
+//
+// Each agent becomes a Deployment running the configured agent image, with
+// the agent's instructions and tools mounted from a ConfigMap at
+// /etc/agent. Runtime.Entrypoint becomes the container command,
+// Runtime.Tags become labels (or annotations, for values labels cannot
+// hold), and Runtime.Concurrency sets the agent's default replica count.
+// Agents with a Runtime.NetworkPolicy also get a NetworkPolicy.
+package k8s
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+)
+
+// HelmConfig holds configuration for Helm chart generation.
+type HelmConfig struct {
+	// Image is the container image repository every agent runs.
+	Image string `json:"image"`
+
+	// ImageTag is the image tag, also used as the chart's appVersion.
+	ImageTag string `json:"image_tag"`
+
+	// ImagePullPolicy is the container image pull policy.
+	ImagePullPolicy string `json:"image_pull_policy"`
+
+	// Replicas is the number of pods per agent that sets no
+	// Runtime.Concurrency.
+	Replicas int `json:"replicas"`
+
+	// ChartVersion is the version written to Chart.yaml.
+	ChartVersion string `json:"chart_version"`
+
+	// Chart-wide resource limits as Kubernetes quantities, overridden per
+	// agent by Runtime.Resources. Empty means no limit.
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+// DefaultHelmConfig returns default configuration.
+func DefaultHelmConfig() *HelmConfig {
+	return &HelmConfig{
+		Image:           "ghcr.io/agentplexus/agentkit",
+		ImageTag:        "latest",
+		ImagePullPolicy: "IfNotPresent",
+		Replicas:        1,
+		ChartVersion:    "0.1.0",
+		CPU:             "500m",
+		Memory:          "512Mi",
+	}
+}
+
+// configMountPath is where each agent's ConfigMap is mounted.
+const configMountPath = "/etc/agent"
+
+// resolveResources returns the agent's resource limits, filling unset values
+// from the chart defaults in config. Runtime.Memory (MB) is used when the
+// agent sets no memory quantity.
+func resolveResources(agent *core.Agent, config *HelmConfig) core.Resources {
+	res := core.Resources{CPU: config.CPU, Memory: config.Memory}
+	if agent.Runtime == nil {
+		return res
+	}
+	if agent.Runtime.Memory > 0 {
+		res.Memory = strconv.Itoa(agent.Runtime.Memory) + "Mi"
+	}
+	if r := agent.Runtime.Resources; r != nil {
+		if r.CPU != "" {
+			res.CPU = r.CPU
+		}
+		if r.Memory != "" {
+			res.Memory = r.Memory
+		}
+	}
+	return res
+}
+
+// labelNamePattern matches a Kubernetes label name, and a label value when
+// non-empty.
+var labelNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+
+// labelPrefixPattern matches the DNS subdomain prefix of a label key.
+var labelPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+
+// validLabelKey reports whether key is a valid label or annotation key: a
+// name of at most 63 characters, optionally preceded by a DNS subdomain
+// prefix and a slash.
+func validLabelKey(key string) bool {
+	prefix, name, found := strings.Cut(key, "/")
+	if !found {
+		prefix, name = "", key
+	} else if len(prefix) > 253 || !labelPrefixPattern.MatchString(prefix) {
+		return false
+	}
+	return len(name) <= 63 && labelNamePattern.MatchString(name)
+}
+
+// validLabelValue reports whether value can be used as a label value.
+func validLabelValue(value string) bool {
+	return value == "" || (len(value) <= 63 && labelNamePattern.MatchString(value))
+}
+
+// agentData is the template data for one agent's manifests.
+type agentData struct {
+	Name          string // DNS-1123 resource name suffix
+	Model         string
+	Command       []string
+	Labels        map[string]string
+	Annotations   map[string]string
+	Replicas      int // default replica count, 0 to use the chart default
+	Resources     core.Resources
+	NetworkPolicy *core.NetworkPolicy
+	MountPath     string
+}
+
+func newAgentData(agent *core.Agent, config *HelmConfig) (agentData, error) {
+	data := agentData{
+		Name:      core.KebabCase(agent.Name),
+		Model:     string(agent.Model),
+		Resources: resolveResources(agent, config),
+		MountPath: configMountPath,
+	}
+	r := agent.Runtime
+	if r == nil {
+		return data, nil
+	}
+	data.Command = strings.Fields(r.Entrypoint)
+	data.Replicas = r.Concurrency
+	data.NetworkPolicy = r.NetworkPolicy
+	for key, value := range r.Tags {
+		if !validLabelKey(key) {
+			return data, &core.MarshalError{Format: "k8s", Err: fmt.Errorf("agent %q: tag %q is not a valid Kubernetes label key", agent.Name, key)}
+		}
+		if validLabelValue(value) {
+			if data.Labels == nil {
+				data.Labels = make(map[string]string)
+			}
+			data.Labels[key] = value
+		} else {
+			if data.Annotations == nil {
+				data.Annotations = make(map[string]string)
+			}
+			data.Annotations[key] = value
+		}
+	}
+	return data, nil
+}
+
+// newAgentsData returns the template data for each agent, in order.
+func newAgentsData(agents []*core.Agent, config *HelmConfig) ([]agentData, error) {
+	data := make([]agentData, 0, len(agents))
+	for _, agent := range agents {
+		d, err := newAgentData(agent, config)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, d)
+	}
+	return data, nil
+}
+
+// templateFuncs are the functions available to the chart templates.
+var templateFuncs = template.FuncMap{
+	"quote": strconv.Quote,
+}
+
+// render executes a chart template. The templates use [[ ]] delimiters so
+// that Helm's own {{ }} actions pass through unchanged.
+func render(name, text string, data any) ([]byte, error) {
+	tmpl, err := template.New(name).Delims("[[", "]]").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, &core.MarshalError{Format: "k8s", Err: err}
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, &core.MarshalError{Format: "k8s", Err: err}
+	}
+	return buf.Bytes(), nil
+}
+
+// GenerateChartYAML creates the Chart.yaml file.
+func GenerateChartYAML(teamName string, config *HelmConfig) ([]byte, error) {
+	return render("chart", chartTemplate, map[string]any{
+		"Name":         core.KebabCase(teamName),
+		"TeamName":     teamName,
+		"ChartVersion": config.ChartVersion,
+		"AppVersion":   config.ImageTag,
+	})
+}
+
+const chartTemplate = `apiVersion: v2
+name: [[.Name]]
+description: [[quote (printf "Multi-agent team %s" .TeamName)]]
+type: application
+version: [[quote .ChartVersion]]
+appVersion: [[quote .AppVersion]]
+`
+
+// GenerateValuesYAML creates the values.yaml file, with an entry per agent
+// under "agents" for per-agent overrides.
+func GenerateValuesYAML(agents []*core.Agent, config *HelmConfig) ([]byte, error) {
+	data, err := newAgentsData(agents, config)
+	if err != nil {
+		return nil, err
+	}
+	return render("values", valuesTemplate, map[string]any{
+		"Config": config,
+		"Agents": data,
+	})
+}
+
+const valuesTemplate = `# Default number of pods per agent.
+replicaCount: [[.Config.Replicas]]
+
+image:
+  repository: [[quote .Config.Image]]
+  tag: [[quote .Config.ImageTag]]
+  pullPolicy: [[.Config.ImagePullPolicy]]
+
+# Per-agent overrides. An agent's replicaCount defaults to its runtime
+# concurrency, or to the replicaCount above.
+[[- if .Agents]]
+agents:
+[[- range .Agents]]
+[[- if .Replicas]]
+  [[.Name]]:
+    replicaCount: [[.Replicas]]
+[[- else]]
+  [[.Name]]: {}
+[[- end]]
+[[- end]]
+[[- else]]
+agents: {}
+[[- end]]
+`
+
+// GenerateHelpers creates the templates/_helpers.tpl file.
+func GenerateHelpers(teamName string) ([]byte, error) {
+	return render("helpers", helpersTemplate, map[string]any{
+		"Name": core.KebabCase(teamName),
+	})
+}
+
+const helpersTemplate = `{{/*
+Common labels.
+*/}}
+{{- define "[[.Name]].labels" -}}
+helm.sh/chart: {{ printf "%s-%s" .Chart.Name .Chart.Version | replace "+" "_" | trunc 63 | trimSuffix "-" }}
+{{ include "[[.Name]].selectorLabels" . }}
+app.kubernetes.io/version: {{ .Chart.AppVersion | quote }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end }}
+
+{{/*
+Selector labels.
+*/}}
+{{- define "[[.Name]].selectorLabels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end }}
+
+{{/*
+Resource name for an agent: "<release>-<agent>", truncated to 63 characters.
+*/}}
+{{- define "[[.Name]].agentName" -}}
+{{- printf "%s-%s" .root.Release.Name .agent | trunc 63 | trimSuffix "-" }}
+{{- end }}
+`
+
+// GenerateConfigMap creates the ConfigMap template for an agent. Its data
+// comes from the chart files written under files/<agent>/.
+func GenerateConfigMap(teamName string, agent *core.Agent, config *HelmConfig) ([]byte, error) {
+	data, err := newAgentData(agent, config)
+	if err != nil {
+		return nil, err
+	}
+	return render("configmap", configMapTemplate, map[string]any{
+		"Chart": core.KebabCase(teamName),
+		"Agent": data,
+	})
+}
+
+const configMapTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "[[.Chart]].agentName" (dict "root" . "agent" "[[.Agent.Name]]") }}
+  labels:
+    {{- include "[[.Chart]].labels" . | nindent 4 }}
+    app.kubernetes.io/component: [[.Agent.Name]]
+data:
+  {{- (.Files.Glob "files/[[.Agent.Name]]/*").AsConfig | nindent 2 }}
+`
+
+// GenerateDeployment creates the Deployment template for an agent.
+func GenerateDeployment(teamName string, agent *core.Agent, config *HelmConfig) ([]byte, error) {
+	data, err := newAgentData(agent, config)
+	if err != nil {
+		return nil, err
+	}
+	return render("deployment", deploymentTemplate, map[string]any{
+		"Chart": core.KebabCase(teamName),
+		"Agent": data,
+	})
+}
+
+const deploymentTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "[[.Chart]].agentName" (dict "root" . "agent" "[[.Agent.Name]]") }}
+  labels:
+    {{- include "[[.Chart]].labels" . | nindent 4 }}
+    app.kubernetes.io/component: [[.Agent.Name]]
+[[- range $key, $value := .Agent.Labels]]
+    [[quote $key]]: [[quote $value]]
+[[- end]]
+[[- if .Agent.Annotations]]
+  annotations:
+[[- range $key, $value := .Agent.Annotations]]
+    [[quote $key]]: [[quote $value]]
+[[- end]]
+[[- end]]
+spec:
+  replicas: {{ (index .Values.agents "[[.Agent.Name]]").replicaCount | default .Values.replicaCount }}
+  selector:
+    matchLabels:
+      {{- include "[[.Chart]].selectorLabels" . | nindent 6 }}
+      app.kubernetes.io/component: [[.Agent.Name]]
+  template:
+    metadata:
+      labels:
+        {{- include "[[.Chart]].selectorLabels" . | nindent 8 }}
+        app.kubernetes.io/component: [[.Agent.Name]]
+[[- range $key, $value := .Agent.Labels]]
+        [[quote $key]]: [[quote $value]]
+[[- end]]
+      annotations:
+        checksum/config: {{ include (print $.Template.BasePath "/[[.Agent.Name]]-configmap.yaml") . | sha256sum }}
+[[- range $key, $value := .Agent.Annotations]]
+        [[quote $key]]: [[quote $value]]
+[[- end]]
+    spec:
+      containers:
+        - name: agent
+          image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+          imagePullPolicy: {{ .Values.image.pullPolicy }}
+[[- if .Agent.Command]]
+          command:
+[[- range .Agent.Command]]
+            - [[quote .]]
+[[- end]]
+[[- end]]
+          env:
+            - name: AGENT_NAME
+              value: [[quote .Agent.Name]]
+            - name: AGENT_CONFIG_DIR
+              value: [[quote .Agent.MountPath]]
+[[- if .Agent.Model]]
+            - name: AGENT_MODEL
+              value: [[quote .Agent.Model]]
+[[- end]]
+[[- if or .Agent.Resources.CPU .Agent.Resources.Memory]]
+          resources:
+            limits:
+[[- if .Agent.Resources.CPU]]
+              cpu: [[quote .Agent.Resources.CPU]]
+[[- end]]
+[[- if .Agent.Resources.Memory]]
+              memory: [[quote .Agent.Resources.Memory]]
+[[- end]]
+[[- end]]
+          volumeMounts:
+            - name: agent-config
+              mountPath: [[.Agent.MountPath]]
+              readOnly: true
+      volumes:
+        - name: agent-config
+          configMap:
+            name: {{ include "[[.Chart]].agentName" (dict "root" . "agent" "[[.Agent.Name]]") }}
+`
+
+// GenerateNetworkPolicy creates the NetworkPolicy template for an agent,
+// or nil when the agent sets no policy.
+func GenerateNetworkPolicy(teamName string, agent *core.Agent, config *HelmConfig) ([]byte, error) {
+	data, err := newAgentData(agent, config)
+	if err != nil || data.NetworkPolicy == nil {
+		return nil, err
+	}
+	return render("networkpolicy", networkPolicyTemplate, map[string]any{
+		"Chart": core.KebabCase(teamName),
+		"Agent": data,
+	})
+}
+
+const networkPolicyTemplate = `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: {{ include "[[.Chart]].agentName" (dict "root" . "agent" "[[.Agent.Name]]") }}
+  labels:
+    {{- include "[[.Chart]].labels" . | nindent 4 }}
+    app.kubernetes.io/component: [[.Agent.Name]]
+spec:
+  podSelector:
+    matchLabels:
+      {{- include "[[.Chart]].selectorLabels" . | nindent 6 }}
+      app.kubernetes.io/component: [[.Agent.Name]]
+  policyTypes:
+    - Ingress
+    - Egress
+[[- with .Agent.NetworkPolicy]]
+[[- if .Ingress]]
+  ingress:
+    - from:
+[[- range .Ingress]]
+        - ipBlock:
+            cidr: [[quote .]]
+[[- end]]
+[[- else]]
+  ingress: []
+[[- end]]
+[[- if .Egress]]
+  egress:
+    - to:
+[[- range .Egress]]
+        - ipBlock:
+            cidr: [[quote .]]
+[[- end]]
+[[- else]]
+  egress: []
+[[- end]]
+[[- end]]
+`
+
+// WriteHelmChart writes a complete Helm chart for the team to outputDir:
+// Chart.yaml, values.yaml, and per agent a ConfigMap holding the agent's
+// instructions and tools plus a Deployment that mounts it. Agents whose
+// names map to the same resource name are rejected before anything is
+// written.
+func WriteHelmChart(teamName string, agents []*core.Agent, outputDir string, cfg *HelmConfig) error {
+	if cfg == nil {
+		cfg = DefaultHelmConfig()
+	}
+	if core.KebabCase(teamName) == "" {
+		return &core.WriteError{Path: outputDir, Err: fmt.Errorf("team name %q is not a valid chart name", teamName)}
+	}
+	if err := checkResourceNames(agents, outputDir); err != nil {
+		return err
+	}
+
+	templatesDir := filepath.Join(outputDir, "templates")
+	if err := os.MkdirAll(templatesDir, core.DefaultDirMode); err != nil {
+		return &core.WriteError{Path: templatesDir, Err: err}
+	}
+
+	chartYAML, err := GenerateChartYAML(teamName, cfg)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(outputDir, "Chart.yaml"), chartYAML); err != nil {
+		return err
+	}
+
+	valuesYAML, err := GenerateValuesYAML(agents, cfg)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(outputDir, "values.yaml"), valuesYAML); err != nil {
+		return err
+	}
+
+	helpers, err := GenerateHelpers(teamName)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(templatesDir, "_helpers.tpl"), helpers); err != nil {
+		return err
+	}
+
+	for _, agent := range agents {
+		if err := writeAgent(teamName, agent, outputDir, cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkResourceNames returns a WriteError when an agent has no usable
+// resource name or two agents share one.
+func checkResourceNames(agents []*core.Agent, outputDir string) error {
+	seen := make(map[string]string, len(agents))
+	for _, agent := range agents {
+		name := core.KebabCase(agent.Name)
+		if name == "" {
+			return &core.WriteError{Path: outputDir, Err: fmt.Errorf("agent %q has no usable resource name", agent.Name)}
+		}
+		if other, ok := seen[name]; ok {
+			return &core.WriteError{Path: outputDir, Err: fmt.Errorf("agents %q and %q both map to resource name %q", other, agent.Name, name)}
+		}
+		seen[name] = agent.Name
+	}
+	return nil
+}
+
+// writeAgent writes an agent's ConfigMap files and templates.
+func writeAgent(teamName string, agent *core.Agent, outputDir string, cfg *HelmConfig) error {
+	name := core.KebabCase(agent.Name)
+
+	// The agent's files are read by the ConfigMap template with .Files, so
+	// their contents never pass through Helm's template engine.
+	filesDir := filepath.Join(outputDir, "files", name)
+	if err := os.MkdirAll(filesDir, core.DefaultDirMode); err != nil {
+		return &core.WriteError{Path: filesDir, Err: err}
+	}
+	instructions := core.TransformInstructions("k8s", agent.CombinedInstructions())
+	if err := writeFile(filepath.Join(filesDir, "instructions.md"), []byte(instructions)); err != nil {
+		return err
+	}
+	tools := agent.Tools
+	if tools == nil {
+		tools = []string{}
+	}
+	toolsJSON, err := json.Marshal(tools)
+	if err != nil {
+		return &core.MarshalError{Format: "k8s", Err: err}
+	}
+	if err := writeFile(filepath.Join(filesDir, "tools.json"), toolsJSON); err != nil {
+		return err
+	}
+
+	templatesDir := filepath.Join(outputDir, "templates")
+	configMap, err := GenerateConfigMap(teamName, agent, cfg)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(templatesDir, name+"-configmap.yaml"), configMap); err != nil {
+		return err
+	}
+
+	deployment, err := GenerateDeployment(teamName, agent, cfg)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(templatesDir, name+"-deployment.yaml"), deployment); err != nil {
+		return err
+	}
+
+	policy, err := GenerateNetworkPolicy(teamName, agent, cfg)
+	if err != nil {
+		return err
+	}
+	if policy != nil {
+		if err := writeFile(filepath.Join(templatesDir, name+"-networkpolicy.yaml"), policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeFile writes data to path with the default file mode.
+func writeFile(path string, data []byte) error {
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Path: path, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,429 @@
+package k8s
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+)
+
+func TestWriteHelmChart(t *testing.T) {
+	config := DefaultHelmConfig()
+	config.Image = "registry.example.com/agents/runtime"
+	config.ImageTag = "1.4.2"
+	config.Replicas = 3
+
+	reviewer := &core.Agent{
+		Spec: core.Spec{Name: "code-reviewer", Description: "Reviews changes", Model: core.ModelSonnet,
+			Tools: []string{"Read", "Grep"}},
+	}
+	reviewer.Instructions = "Review the diff. Leave {{ .Values }} alone."
+
+	dir := t.TempDir()
+	if err := WriteHelmChart("release-team", []*core.Agent{reviewer}, dir, config); err != nil {
+		t.Fatalf("WriteHelmChart failed: %v", err)
+	}
+
+	var chart map[string]string
+	if err := yaml.Unmarshal([]byte(readFile(t, filepath.Join(dir, "Chart.yaml"))), &chart); err != nil {
+		t.Fatalf("Chart.yaml is not valid YAML: %v", err)
+	}
+	if chart["apiVersion"] != "v2" || chart["name"] != "release-team" || chart["version"] != "0.1.0" || chart["appVersion"] != "1.4.2" {
+		t.Errorf("unexpected Chart.yaml: %v", chart)
+	}
+
+	var values struct {
+		ReplicaCount int `yaml:"replicaCount"`
+		Image        struct {
+			Repository string `yaml:"repository"`
+			Tag        string `yaml:"tag"`
+		} `yaml:"image"`
+	}
+	if err := yaml.Unmarshal([]byte(readFile(t, filepath.Join(dir, "values.yaml"))), &values); err != nil {
+		t.Fatalf("values.yaml is not valid YAML: %v", err)
+	}
+	if values.ReplicaCount != 3 || values.Image.Repository != config.Image || values.Image.Tag != "1.4.2" {
+		t.Errorf("unexpected values.yaml: %+v", values)
+	}
+
+	// Instructions and tools are chart files, read verbatim by the ConfigMap.
+	if got := readFile(t, filepath.Join(dir, "files", "code-reviewer", "instructions.md")); got != reviewer.Instructions {
+		t.Errorf("instructions.md = %q, want %q", got, reviewer.Instructions)
+	}
+	var tools []string
+	if err := json.Unmarshal([]byte(readFile(t, filepath.Join(dir, "files", "code-reviewer", "tools.json"))), &tools); err != nil {
+		t.Fatal(err)
+	}
+	if len(tools) != 2 || tools[0] != "Read" || tools[1] != "Grep" {
+		t.Errorf("tools.json = %v", tools)
+	}
+
+	configMap := readFile(t, filepath.Join(dir, "templates", "code-reviewer-configmap.yaml"))
+	if !strings.Contains(configMap, `(.Files.Glob "files/code-reviewer/*").AsConfig`) {
+		t.Errorf("ConfigMap should load the agent's files\n%s", configMap)
+	}
+
+	deployment := readFile(t, filepath.Join(dir, "templates", "code-reviewer-deployment.yaml"))
+	for _, want := range []string{
+		"kind: Deployment",
+		`replicas: {{ (index .Values.agents "code-reviewer").replicaCount | default .Values.replicaCount }}`,
+		`image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"`,
+		`include "release-team.agentName" (dict "root" . "agent" "code-reviewer")`,
+		"value: \"sonnet\"",
+		"mountPath: /etc/agent",
+		"limits:\n              cpu: \"500m\"\n              memory: \"512Mi\"",
+	} {
+		if !strings.Contains(deployment, want) {
+			t.Errorf("Deployment missing %q\n%s", want, deployment)
+		}
+	}
+
+	if !strings.Contains(readFile(t, filepath.Join(dir, "templates", "_helpers.tpl")), `define "release-team.labels"`) {
+		t.Error("expected chart helpers to define the labels template")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "templates", "code-reviewer-networkpolicy.yaml")); !os.IsNotExist(err) {
+		t.Errorf("expected no NetworkPolicy for an agent without one, got %v", err)
+	}
+}
+
+func TestWriteHelmChartRuntimeResources(t *testing.T) {
+	sized := &core.Agent{
+		Spec: core.Spec{Name: "Data Cruncher", Description: "Heavy lifting"},
+		Runtime: &core.Runtime{
+			Resources:     &core.Resources{CPU: "2", Memory: "4Gi"},
+			NetworkPolicy: &core.NetworkPolicy{Egress: []string{"10.0.0.0/8"}},
+		},
+	}
+	sizedByMB := &core.Agent{
+		Spec:    core.Spec{Name: "light", Description: "Uses Runtime.Memory"},
+		Runtime: &core.Runtime{Memory: 256},
+	}
+
+	dir := t.TempDir()
+	if err := WriteHelmChart("team", []*core.Agent{sized, sizedByMB}, dir, nil); err != nil {
+		t.Fatalf("WriteHelmChart failed: %v", err)
+	}
+
+	deployment := readFile(t, filepath.Join(dir, "templates", "data-cruncher-deployment.yaml"))
+	if !strings.Contains(deployment, "limits:\n              cpu: \"2\"\n              memory: \"4Gi\"") {
+		t.Errorf("Deployment should use the agent's resource limits\n%s", deployment)
+	}
+
+	deployment = readFile(t, filepath.Join(dir, "templates", "light-deployment.yaml"))
+	if !strings.Contains(deployment, "cpu: \"500m\"\n              memory: \"256Mi\"") {
+		t.Errorf("Deployment should fall back to Runtime.Memory and the chart CPU\n%s", deployment)
+	}
+
+	policy := readFile(t, filepath.Join(dir, "templates", "data-cruncher-networkpolicy.yaml"))
+	for _, want := range []string{
+		"kind: NetworkPolicy",
+		"app.kubernetes.io/component: data-cruncher",
+		"ingress: []",
+		"egress:\n    - to:\n        - ipBlock:\n            cidr: \"10.0.0.0/8\"",
+	} {
+		if !strings.Contains(policy, want) {
+			t.Errorf("NetworkPolicy missing %q\n%s", want, policy)
+		}
+	}
+}
+
+func TestWriteHelmChartRuntimeSettings(t *testing.T) {
+	worker := &core.Agent{
+		Spec: core.Spec{Name: "worker", Description: "Works"},
+		Runtime: &core.Runtime{
+			Entrypoint:  "python -m agent.serve --port 8080",
+			Concurrency: 4,
+			Tags:        map[string]string{"team": "research", "owner": "Data Platform"},
+		},
+	}
+	idle := &core.Agent{Spec: core.Spec{Name: "idle", Description: "Waits"}}
+
+	dir := t.TempDir()
+	if err := WriteHelmChart("team", []*core.Agent{worker, idle}, dir, nil); err != nil {
+		t.Fatalf("WriteHelmChart failed: %v", err)
+	}
+
+	var values struct {
+		Agents map[string]struct {
+			ReplicaCount int `yaml:"replicaCount"`
+		} `yaml:"agents"`
+	}
+	if err := yaml.Unmarshal([]byte(readFile(t, filepath.Join(dir, "values.yaml"))), &values); err != nil {
+		t.Fatalf("values.yaml is not valid YAML: %v", err)
+	}
+	if values.Agents["worker"].ReplicaCount != 4 {
+		t.Errorf("expected worker replicaCount 4 from Runtime.Concurrency, got %+v", values.Agents)
+	}
+	if _, ok := values.Agents["idle"]; !ok || values.Agents["idle"].ReplicaCount != 0 {
+		t.Errorf("expected an empty override entry for idle, got %+v", values.Agents)
+	}
+
+	manifests := renderChart(t, dir)
+	deployment := manifests["templates/worker-deployment.yaml"]
+	containers := deployment["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any)
+	command := containers[0].(map[string]any)["command"]
+	if fmt.Sprint(command) != "[python -m agent.serve --port 8080]" {
+		t.Errorf("expected the entrypoint as container command, got %v", command)
+	}
+	if replicas := deployment["spec"].(map[string]any)["replicas"]; replicas != 4 {
+		t.Errorf("expected 4 replicas, got %v", replicas)
+	}
+	metadata := deployment["metadata"].(map[string]any)
+	if labels := metadata["labels"].(map[string]any); labels["team"] != "research" {
+		t.Errorf("expected the team tag as a label, got %v", labels)
+	}
+	if annotations := metadata["annotations"].(map[string]any); annotations["owner"] != "Data Platform" {
+		t.Errorf("expected a tag that is not a valid label value as an annotation, got %v", annotations)
+	}
+
+	if replicas := manifests["templates/idle-deployment.yaml"]["spec"].(map[string]any)["replicas"]; replicas != 1 {
+		t.Errorf("expected idle to use the chart replicaCount, got %v", replicas)
+	}
+}
+
+func TestWriteHelmChartDuplicateResourceNames(t *testing.T) {
+	a := &core.Agent{Spec: core.Spec{Name: "Code Reviewer", Description: "A"}}
+	b := &core.Agent{Spec: core.Spec{Name: "code_reviewer", Description: "B"}}
+
+	dir := t.TempDir()
+	var writeErr *core.WriteError
+	err := WriteHelmChart("team", []*core.Agent{a, b}, dir, nil)
+	if err == nil || !errors.As(err, &writeErr) || !strings.Contains(err.Error(), "code-reviewer") {
+		t.Fatalf("expected WriteError naming the shared resource name, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected nothing to be written, got %v", entries)
+	}
+}
+
+func TestWriteHelmChartInvalidTagKey(t *testing.T) {
+	agent := &core.Agent{
+		Spec:    core.Spec{Name: "tagged", Description: "Tagged"},
+		Runtime: &core.Runtime{Tags: map[string]string{"aws:cost center": "42"}},
+	}
+	var marshalErr *core.MarshalError
+	if err := WriteHelmChart("team", []*core.Agent{agent}, t.TempDir(), nil); !errors.As(err, &marshalErr) {
+		t.Errorf("expected MarshalError for a tag key labels cannot hold, got %v", err)
+	}
+}
+
+// TestWriteHelmChartRendersValidYAML renders every template of a chart
+// covering all optional settings and checks each manifest parses as YAML.
+func TestWriteHelmChartRendersValidYAML(t *testing.T) {
+	full := &core.Agent{
+		Spec: core.Spec{Name: "Full Agent", Description: "Everything set", Model: core.ModelOpus,
+			Tools: []string{"Read"}},
+		Runtime: &core.Runtime{
+			Entrypoint:    "agent serve",
+			Concurrency:   2,
+			Memory:        1024,
+			Tags:          map[string]string{"team": "core", "note": "free text: with colons"},
+			Resources:     &core.Resources{CPU: "1"},
+			NetworkPolicy: &core.NetworkPolicy{Ingress: []string{"10.0.0.0/8"}, Egress: []string{"0.0.0.0/0", "::/0"}},
+		},
+	}
+	full.Instructions = "Say {{ hello }}: \"quoted\"\n- not a list"
+	bare := &core.Agent{Spec: core.Spec{Name: "bare", Description: "Nothing set"}}
+
+	dir := t.TempDir()
+	if err := WriteHelmChart("Full Team", []*core.Agent{full, bare}, dir, nil); err != nil {
+		t.Fatalf("WriteHelmChart failed: %v", err)
+	}
+
+	manifests := renderChart(t, dir)
+	var names []string
+	for name := range manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	want := []string{
+		"templates/bare-configmap.yaml",
+		"templates/bare-deployment.yaml",
+		"templates/full-agent-configmap.yaml",
+		"templates/full-agent-deployment.yaml",
+		"templates/full-agent-networkpolicy.yaml",
+	}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("rendered %v, want %v", names, want)
+	}
+
+	data := manifests["templates/full-agent-configmap.yaml"]["data"].(map[string]any)
+	if data["instructions.md"] != full.Instructions {
+		t.Errorf("ConfigMap instructions = %q, want %q", data["instructions.md"], full.Instructions)
+	}
+}
+
+// renderChart renders the chart in dir with a minimal stand-in for Helm's
+// template engine and returns each manifest parsed as YAML, keyed by
+// template path.
+func renderChart(t *testing.T, dir string) map[string]map[string]any {
+	t.Helper()
+
+	var chart map[string]any
+	if err := yaml.Unmarshal([]byte(readFile(t, filepath.Join(dir, "Chart.yaml"))), &chart); err != nil {
+		t.Fatalf("Chart.yaml is not valid YAML: %v", err)
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal([]byte(readFile(t, filepath.Join(dir, "values.yaml"))), &values); err != nil {
+		t.Fatalf("values.yaml is not valid YAML: %v", err)
+	}
+	root := map[string]any{
+		"Chart":    map[string]any{"Name": chart["name"], "Version": chart["version"], "AppVersion": chart["appVersion"]},
+		"Release":  map[string]any{"Name": "rel", "Service": "Helm"},
+		"Values":   values,
+		"Template": map[string]any{"BasePath": "templates"},
+		"Files":    chartFiles(dir),
+	}
+
+	tmpl := template.New("chart")
+	tmpl.Funcs(template.FuncMap{
+		"include": func(name string, data any) (string, error) {
+			var buf bytes.Buffer
+			err := tmpl.ExecuteTemplate(&buf, name, data)
+			return buf.String(), err
+		},
+		"dict": func(kv ...any) map[string]any {
+			m := make(map[string]any)
+			for i := 0; i+1 < len(kv); i += 2 {
+				m[kv[i].(string)] = kv[i+1]
+			}
+			return m
+		},
+		"nindent": func(n int, s string) string {
+			pad := strings.Repeat(" ", n)
+			return "\n" + pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+		},
+		"trunc": func(n int, s string) string {
+			if len(s) > n {
+				return s[:n]
+			}
+			return s
+		},
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"quote":      func(v any) string { return fmt.Sprintf("%q", fmt.Sprint(v)) },
+		"default": func(def, v any) any {
+			if v == nil || v == 0 || v == "" {
+				return def
+			}
+			return v
+		},
+		"sha256sum": func(s string) string {
+			sum := sha256.Sum256([]byte(s))
+			return hex.EncodeToString(sum[:])
+		},
+	})
+
+	paths, err := filepath.Glob(filepath.Join(dir, "templates", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifestNames []string
+	for _, p := range paths {
+		name := path.Join("templates", filepath.Base(p))
+		if _, err := tmpl.New(name).Parse(readFile(t, p)); err != nil {
+			t.Fatalf("%s does not parse: %v", name, err)
+		}
+		if !strings.HasPrefix(filepath.Base(p), "_") {
+			manifestNames = append(manifestNames, name)
+		}
+	}
+
+	manifests := make(map[string]map[string]any)
+	for _, name := range manifestNames {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, name, root); err != nil {
+			t.Fatalf("%s does not render: %v", name, err)
+		}
+		var manifest map[string]any
+		if err := yaml.Unmarshal(buf.Bytes(), &manifest); err != nil {
+			t.Fatalf("%s is not valid YAML: %v\n%s", name, err, buf.String())
+		}
+		manifests[name] = manifest
+	}
+	return manifests
+}
+
+// chartFiles stands in for Helm's .Files object.
+type chartFiles string
+
+// Glob returns the chart files matching pattern.
+func (dir chartFiles) Glob(pattern string) chartGlob {
+	matches, _ := filepath.Glob(filepath.Join(string(dir), filepath.FromSlash(pattern)))
+	files := make(chartGlob)
+	for _, match := range matches {
+		data, _ := os.ReadFile(match)
+		files[filepath.Base(match)] = string(data)
+	}
+	return files
+}
+
+// chartGlob stands in for Helm's files.Glob result.
+type chartGlob map[string]string
+
+// AsConfig renders the files as ConfigMap data, as Helm does.
+func (g chartGlob) AsConfig() string {
+	data, err := yaml.Marshal(map[string]string(g))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+func TestWriteHelmChartPassesHelmLint(t *testing.T) {
+	helm, err := exec.LookPath("helm")
+	if err != nil {
+		t.Skip("helm not installed, skipping test")
+	}
+
+	agent := &core.Agent{
+		Spec: core.Spec{Name: "worker", Description: "Does work"},
+		Runtime: &core.Runtime{
+			Concurrency:   2,
+			Tags:          map[string]string{"team": "core"},
+			NetworkPolicy: &core.NetworkPolicy{Egress: []string{"0.0.0.0/0"}},
+		},
+	}
+	dir := filepath.Join(t.TempDir(), "lint-team")
+	if err := WriteHelmChart("lint-team", []*core.Agent{agent}, dir, nil); err != nil {
+		t.Fatalf("WriteHelmChart failed: %v", err)
+	}
+
+	for _, args := range [][]string{{"lint", dir}, {"template", "lint-team", dir}} {
+		out, err := exec.Command(helm, args...).CombinedOutput()
+		if err != nil {
+			t.Errorf("helm %s failed: %v\n%s", args[0], err, out)
+		}
+	}
+}
+
+func TestWriteHelmChartInvalidTeamName(t *testing.T) {
+	var writeErr *core.WriteError
+	err := WriteHelmChart("!!!", nil, t.TempDir(), nil)
+	if err == nil || !errors.As(err, &writeErr) {
+		t.Errorf("expected WriteError for an unusable team name, got %v", err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/agentplexus/assistantkit/agents/core"
+	"github.com/agentplexus/assistantkit/internal/passthrough"
 )
 
 const (
@@ -37,11 +39,26 @@ func (a *Adapter) FileExtension() string {
 	return ".json"
 }
 
+// FileSlug returns name in snake_case, Kiro's agent file naming convention.
+func (a *Adapter) FileSlug(name string) string {
+	return core.SnakeCase(name)
+}
+
 // DefaultDir returns the default directory name for Kiro agents.
 func (a *Adapter) DefaultDir() string {
 	return AgentsDir
 }
 
+// SupportedTools returns the canonical tools with a Kiro CLI equivalent.
+func (a *Adapter) SupportedTools() []string {
+	tools := make([]string, 0, len(kiroToolMap))
+	for tool := range kiroToolMap {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
 // Parse converts Kiro agent JSON bytes to canonical Agent.
 func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
 	var kiroCfg AgentConfig
@@ -55,7 +72,21 @@ func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
 // Marshal converts canonical Agent to Kiro agent JSON bytes.
 func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 	kiroCfg := a.FromCore(agent)
-	return json.MarshalIndent(kiroCfg, "", "  ")
+	data, err := json.MarshalIndent(kiroCfg, "", "  ")
+	if err != nil {
+		return nil, &core.MarshalError{Format: "kiro", Err: err}
+	}
+	data, err = passthrough.MergeJSON(data, agent.Experimental)
+	if err != nil {
+		return nil, &core.MarshalError{Format: "kiro", Err: err}
+	}
+	return data, nil
+}
+
+// SupportsExperimental reports that experimental options are written to the
+// agent JSON.
+func (a *Adapter) SupportsExperimental() bool {
+	return true
 }
 
 // ReadFile reads a Kiro agent JSON file and returns canonical Agent.
@@ -103,11 +134,11 @@ func (a *Adapter) WriteFile(agent *core.Agent, path string) error {
 
 // ToCore converts Kiro agent config to canonical Agent.
 func (a *Adapter) ToCore(kiroCfg *AgentConfig) *core.Agent {
-	agent := &core.Agent{
+	agent := &core.Agent{Spec: core.Spec{
 		Name:         kiroCfg.Name,
 		Description:  kiroCfg.Description,
 		Instructions: kiroCfg.Prompt,
-	}
+	}}
 
 	// Map Kiro model names to canonical model names
 	if kiroCfg.Model != "" {
@@ -124,8 +155,22 @@ func (a *Adapter) ToCore(kiroCfg *AgentConfig) *core.Agent {
 		agent.AllowedTools = mapKiroToolsToCanonical(kiroCfg.AllowedTools)
 	}
 
-	// Store resources as skills (closest mapping)
-	// Note: Resources in Kiro load context files, similar to skill dependencies
+	// Steering files written for skills map back to skills; other
+	// resources are kept as they are
+	agent.Skills, agent.Resources = mapResourcesToSkills(kiroCfg.Resources)
+
+	if len(kiroCfg.MCPServers) > 0 {
+		agent.MCP = make(map[string]core.MCPServer, len(kiroCfg.MCPServers))
+		for name, server := range kiroCfg.MCPServers {
+			agent.MCP[name] = core.MCPServer{
+				Command: server.Command,
+				Args:    server.Args,
+				Env:     server.Env,
+				URL:     server.URL,
+				Headers: server.Headers,
+			}
+		}
+	}
 
 	return agent
 }
@@ -135,7 +180,7 @@ func (a *Adapter) FromCore(agent *core.Agent) *AgentConfig {
 	kiroCfg := &AgentConfig{
 		Name:        agent.Name,
 		Description: agent.Description,
-		Prompt:      agent.Instructions,
+		Prompt:      core.TransformInstructions(a.Name(), agent.CombinedInstructions()),
 	}
 
 	// Map canonical model to Kiro model name
@@ -153,9 +198,22 @@ func (a *Adapter) FromCore(agent *core.Agent) *AgentConfig {
 		kiroCfg.AllowedTools = mapCanonicalToolsToKiro(agent.AllowedTools)
 	}
 
-	// Map skills to resources (steering files)
-	if len(agent.Skills) > 0 {
-		kiroCfg.Resources = mapSkillsToResources(agent.Skills)
+	// Map skills to resources (steering files), followed by the agent's
+	// own resources
+	kiroCfg.Resources = append(mapSkillsToResources(agent.Skills), agent.Resources...)
+
+	// Declared MCP servers are configured on the agent itself
+	if len(agent.MCP) > 0 {
+		kiroCfg.MCPServers = make(map[string]MCPServerConfig, len(agent.MCP))
+		for name, server := range agent.MCP {
+			kiroCfg.MCPServers[name] = MCPServerConfig{
+				Command: server.Command,
+				Args:    server.Args,
+				Env:     server.Env,
+				URL:     server.URL,
+				Headers: server.Headers,
+			}
+		}
 	}
 
 	return kiroCfg
@@ -191,6 +249,7 @@ func mapCanonicalModelToKiro(model core.Model) string {
 }
 
 // mapKiroToolsToCanonical maps Kiro tool names to canonical names.
+// User tool aliases take precedence over the built-in mapping.
 func mapKiroToolsToCanonical(kiroTools []string) []string {
 	toolMap := map[string]string{
 		// Core tools
@@ -216,7 +275,9 @@ func mapKiroToolsToCanonical(kiroTools []string) []string {
 
 	var canonical []string
 	for _, tool := range kiroTools {
-		if mapped, ok := toolMap[tool]; ok {
+		if mapped, ok := core.CanonicalToolAlias(AdapterName, tool); ok {
+			canonical = append(canonical, mapped)
+		} else if mapped, ok := toolMap[tool]; ok {
 			canonical = append(canonical, mapped)
 		} else {
 			// Capitalize first letter for unknown tools
@@ -228,36 +289,40 @@ func mapKiroToolsToCanonical(kiroTools []string) []string {
 	return canonical
 }
 
+// kiroToolMap maps canonical tool names to Kiro CLI tool names.
+var kiroToolMap = map[string]string{
+	// Core tools
+	"Bash":      "execute_bash",
+	"Read":      "fs_read",
+	"Write":     "fs_write",
+	"Edit":      "fs_write", // Edit maps to fs_write in Kiro
+	"Grep":      "grep",
+	"Glob":      "glob",
+	"WebSearch": "web_search",
+	"WebFetch":  "web_fetch",
+	// Advanced tools
+	"Code":        "code",
+	"AWS":         "use_aws",
+	"Task":        "use_subagent",
+	"Introspect":  "introspect",
+	"ReportIssue": "report_issue",
+	// Experimental tools
+	"Knowledge": "knowledge",
+	"Thinking":  "thinking",
+	"TodoList":  "todo_list",
+	"Delegate":  "delegate",
+}
+
 // mapCanonicalToolsToKiro maps canonical tool names to Kiro names.
+// User tool aliases take precedence over the built-in mapping.
 func mapCanonicalToolsToKiro(tools []string) []string {
-	toolMap := map[string]string{
-		// Core tools
-		"Bash":      "execute_bash",
-		"Read":      "fs_read",
-		"Write":     "fs_write",
-		"Edit":      "fs_write", // Edit maps to fs_write in Kiro
-		"Grep":      "grep",
-		"Glob":      "glob",
-		"WebSearch": "web_search",
-		"WebFetch":  "web_fetch",
-		// Advanced tools
-		"Code":        "code",
-		"AWS":         "use_aws",
-		"Task":        "use_subagent",
-		"Introspect":  "introspect",
-		"ReportIssue": "report_issue",
-		// Experimental tools
-		"Knowledge": "knowledge",
-		"Thinking":  "thinking",
-		"TodoList":  "todo_list",
-		"Delegate":  "delegate",
-	}
-
 	seen := make(map[string]bool)
 	var kiroTools []string
 	for _, tool := range tools {
 		var kiroTool string
-		if mapped, ok := toolMap[tool]; ok {
+		if mapped, ok := core.ToolAlias(AdapterName, tool); ok {
+			kiroTool = mapped
+		} else if mapped, ok := kiroToolMap[tool]; ok {
 			kiroTool = mapped
 		} else {
 			// Lowercase with underscore for unknown tools
@@ -282,6 +347,20 @@ func mapSkillsToResources(skills []string) []string {
 	return resources
 }
 
+// mapResourcesToSkills splits Kiro resources into the skills whose steering
+// files they load (see mapSkillsToResources) and the remaining resources.
+func mapResourcesToSkills(resources []string) (skills, other []string) {
+	for _, resource := range resources {
+		name, ok := strings.CutPrefix(resource, "file://.kiro/steering/")
+		if ok && strings.HasSuffix(name, ".md") && !strings.ContainsAny(name, "/*") {
+			skills = append(skills, strings.TrimSuffix(name, ".md"))
+			continue
+		}
+		other = append(other, resource)
+	}
+	return skills, other
+}
+
 // UserAgentsPath returns the path to the user's agents directory.
 func UserAgentsPath() (string, error) {
 	home, err := os.UserHomeDir()
@@ -1,6 +1,8 @@
 package kiro
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -79,14 +81,14 @@ func TestAdapter_Parse(t *testing.T) {
 func TestAdapter_Marshal(t *testing.T) {
 	adapter := &Adapter{}
 
-	agent := &core.Agent{
+	agent := &core.Agent{Spec: core.Spec{
 		Name:         "test-agent",
 		Description:  "A test agent",
 		Model:        "sonnet",
 		Tools:        []string{"Read", "Write", "Bash", "Grep"},
 		Skills:       []string{"version-analysis"},
 		Instructions: "You are a helpful assistant.",
-	}
+	}}
 
 	data, err := adapter.Marshal(agent)
 	if err != nil {
@@ -132,13 +134,13 @@ func TestAdapter_Marshal(t *testing.T) {
 func TestAdapter_RoundTrip(t *testing.T) {
 	adapter := &Adapter{}
 
-	original := &core.Agent{
+	original := &core.Agent{Spec: core.Spec{
 		Name:         "round-trip-agent",
 		Description:  "Tests round-trip conversion",
 		Model:        "opus",
 		Tools:        []string{"Read", "Write"},
 		Instructions: "System instructions here.",
-	}
+	}}
 
 	// Marshal to Kiro format
 	data, err := adapter.Marshal(original)
@@ -167,6 +169,73 @@ func TestAdapter_RoundTrip(t *testing.T) {
 	}
 }
 
+func TestAdapter_RoundTripPreservesAllowedToolsAndResources(t *testing.T) {
+	adapter := &Adapter{}
+
+	input := `{
+  "name": "release-agent",
+  "description": "Automates software releases",
+  "tools": ["fs_read", "fs_write", "execute_bash"],
+  "allowedTools": ["fs_read"],
+  "resources": ["file://.kiro/steering/versioning.md", "file://README.md"],
+  "prompt": "You are a release automation specialist.",
+  "model": "claude-sonnet-4"
+}`
+
+	agent, err := adapter.Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(agent.Skills) != 1 || agent.Skills[0] != "versioning" {
+		t.Errorf("Skills = %v, want [versioning]", agent.Skills)
+	}
+	if len(agent.Resources) != 1 || agent.Resources[0] != "file://README.md" {
+		t.Errorf("Resources = %v, want [file://README.md]", agent.Resources)
+	}
+
+	data, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var want, got bytes.Buffer
+	if err := json.Compact(&want, []byte(input)); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Compact(&got, data); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("round trip changed the agent:\ngot:  %s\nwant: %s", got.String(), want.String())
+	}
+}
+
+func TestAdapter_RoundTripPreservesMCPServers(t *testing.T) {
+	adapter := &Adapter{}
+	agent := core.NewAgent("docs-agent", "Answers from the docs")
+	agent.MCP = map[string]core.MCPServer{
+		"local":  {Command: "docs-mcp", Args: []string{"--stdio"}},
+		"remote": {URL: "https://docs.example.com/mcp", Headers: map[string]string{"Authorization": "Bearer ${DOCS_TOKEN}"}},
+	}
+
+	data, err := adapter.Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"mcpServers"`) {
+		t.Errorf("expected mcpServers in agent JSON, got:\n%s", data)
+	}
+
+	parsed, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.MCP["local"].Command != "docs-mcp" || parsed.MCP["remote"].URL != "https://docs.example.com/mcp" ||
+		parsed.MCP["remote"].Headers["Authorization"] != "Bearer ${DOCS_TOKEN}" {
+		t.Errorf("MCP servers did not round-trip: %+v", parsed.MCP)
+	}
+}
+
 func TestAdapter_WriteFile_ReadFile(t *testing.T) {
 	adapter := &Adapter{}
 
@@ -177,13 +246,13 @@ func TestAdapter_WriteFile_ReadFile(t *testing.T) {
 	}
 	defer os.RemoveAll(tmpDir)
 
-	agent := &core.Agent{
+	agent := &core.Agent{Spec: core.Spec{
 		Name:         "file-test-agent",
 		Description:  "Tests file operations",
 		Model:        "haiku",
 		Tools:        []string{"Read", "Grep", "Glob"},
 		Instructions: "You help with file operations.",
-	}
+	}}
 
 	// Write to file
 	path := filepath.Join(tmpDir, "file-test-agent.json")
@@ -267,3 +336,25 @@ func TestReverseToolMapping(t *testing.T) {
 		}
 	}
 }
+
+func TestToolAliases(t *testing.T) {
+	previous := core.SetToolAliases(core.ToolAliases{
+		AdapterName: {"DeployTool": "deploy_tool", "Bash": "shell"},
+	})
+	defer core.SetToolAliases(previous)
+
+	got := mapCanonicalToolsToKiro([]string{"Read", "Bash", "DeployTool"})
+	if want := []string{"fs_read", "shell", "deploy_tool"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("mapCanonicalToolsToKiro = %v, want %v", got, want)
+	}
+
+	back := mapKiroToolsToCanonical([]string{"fs_read", "shell", "deploy_tool"})
+	if want := []string{"Read", "Bash", "DeployTool"}; strings.Join(back, ",") != strings.Join(want, ",") {
+		t.Errorf("mapKiroToolsToCanonical = %v, want %v", back, want)
+	}
+
+	agent := core.NewAgent("deployer", "Deploys").WithTools("DeployTool")
+	if _, unsupported := core.CheckTools(&Adapter{}, agent); len(unsupported) != 0 {
+		t.Errorf("expected aliased tool to be supported, got unsupported %v", unsupported)
+	}
+}
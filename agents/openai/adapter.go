@@ -0,0 +1,360 @@
+// Package openai provides an adapter for OpenAI agent definitions.
+//
+// Agents are written as JSON request templates for the OpenAI Responses API:
+// the agent instructions become a "system" message and developer
+// instructions a separate "developer" message. A response schema becomes a
+// "json_schema" text format, marked strict when the schema meets OpenAI's
+// strict mode rules (see strictSchema). Agents SDK run options (max turns, stop
+// conditions) are not Responses API request fields and are not written.
+package openai
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+	"github.com/agentplexus/assistantkit/internal/passthrough"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "openai"
+
+	// RoleSystem is the message role for agent instructions.
+	RoleSystem = "system"
+
+	// RoleDeveloper is the message role for developer instructions.
+	RoleDeveloper = "developer"
+
+	// FormatJSONSchema is the text format type for structured output.
+	FormatJSONSchema = "json_schema"
+)
+
+func init() {
+	core.Register(&Adapter{})
+}
+
+// Adapter converts between canonical Agent and OpenAI agent JSON.
+type Adapter struct{}
+
+// Name returns the adapter identifier.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// FileExtension returns the file extension for OpenAI agent files.
+func (a *Adapter) FileExtension() string {
+	return ".json"
+}
+
+// DefaultDir returns the default directory name for OpenAI agents.
+func (a *Adapter) DefaultDir() string {
+	return "agents"
+}
+
+// SupportedTools returns the canonical tools with an OpenAI hosted tool.
+func (a *Adapter) SupportedTools() []string {
+	tools := make([]string, 0, len(toolMap))
+	for tool := range toolMap {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// SupportsExperimental reports that experimental options are written to the
+// agent JSON.
+func (a *Adapter) SupportsExperimental() bool {
+	return true
+}
+
+// SupportsResponseSchema reports that OpenAI agents carry a response schema.
+func (a *Adapter) SupportsResponseSchema() bool {
+	return true
+}
+
+// AgentConfig is an OpenAI agent definition.
+type AgentConfig struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Model       string      `json:"model,omitempty"`
+	Input       []Message   `json:"input,omitempty"`
+	Tools       []Tool      `json:"tools,omitempty"`
+	Text        *TextConfig `json:"text,omitempty"`
+
+	ParallelToolCalls *bool `json:"parallel_tool_calls,omitempty"`
+}
+
+// TextConfig configures the response text format.
+type TextConfig struct {
+	Format TextFormat `json:"format"`
+}
+
+// TextFormat is a structured output format.
+type TextFormat struct {
+	Type   string          `json:"type"`
+	Name   string          `json:"name,omitempty"`
+	Schema json.RawMessage `json:"schema,omitempty"`
+	Strict bool            `json:"strict,omitempty"`
+}
+
+// Message is an input message with a role.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Tool is an OpenAI hosted tool reference.
+type Tool struct {
+	Type string `json:"type"`
+}
+
+// toolMap maps canonical tools to OpenAI hosted tool types.
+var toolMap = map[string]string{
+	"Bash":      "local_shell",
+	"WebSearch": "web_search",
+}
+
+// Parse converts OpenAI agent JSON bytes to canonical Agent.
+func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
+	var cfg AgentConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+
+	agent := &core.Agent{Spec: core.Spec{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		Model:       mapOpenAIModelToCanonical(cfg.Model),
+	}}
+	agent.ParallelToolCalls = cfg.ParallelToolCalls
+
+	for _, msg := range cfg.Input {
+		switch msg.Role {
+		case RoleSystem:
+			agent.Instructions = msg.Content
+		case RoleDeveloper:
+			agent.DeveloperInstructions = msg.Content
+		}
+	}
+
+	for _, tool := range cfg.Tools {
+		agent.Tools = append(agent.Tools, mapOpenAIToolToCanonical(tool.Type))
+	}
+
+	if cfg.Text != nil && cfg.Text.Format.Type == FormatJSONSchema {
+		agent.ResponseSchema = cfg.Text.Format.Schema
+	}
+
+	return agent, nil
+}
+
+// Marshal converts canonical Agent to OpenAI agent JSON bytes.
+func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
+	cfg := AgentConfig{
+		Name:              agent.Name,
+		Description:       agent.Description,
+		ParallelToolCalls: agent.ParallelToolCalls,
+	}
+
+	if agent.Model != "" {
+		cfg.Model = mapCanonicalModelToOpenAI(agent.Model)
+	}
+
+	if instructions := core.TransformInstructions(a.Name(), agent.Instructions); instructions != "" {
+		cfg.Input = append(cfg.Input, Message{Role: RoleSystem, Content: instructions})
+	}
+	if agent.DeveloperInstructions != "" {
+		cfg.Input = append(cfg.Input, Message{Role: RoleDeveloper, Content: agent.DeveloperInstructions})
+	}
+
+	for _, tool := range agent.Tools {
+		if mapped, ok := core.ToolAlias(AdapterName, tool); ok {
+			cfg.Tools = append(cfg.Tools, Tool{Type: mapped})
+		} else if mapped, ok := toolMap[tool]; ok {
+			cfg.Tools = append(cfg.Tools, Tool{Type: mapped})
+		}
+	}
+
+	if len(agent.ResponseSchema) > 0 {
+		cfg.Text = &TextConfig{Format: TextFormat{
+			Type:   FormatJSONSchema,
+			Name:   schemaName(agent.Name),
+			Schema: agent.ResponseSchema,
+			Strict: strictSchema(agent.ResponseSchema),
+		}}
+	}
+
+	data, err := canonicaljson.Marshal(cfg)
+	if err != nil {
+		return nil, &core.MarshalError{Format: AdapterName, Err: err}
+	}
+	data, err = passthrough.MergeJSON(data, agent.Experimental)
+	if err != nil {
+		return nil, &core.MarshalError{Format: AdapterName, Err: err}
+	}
+	return data, nil
+}
+
+// strictSchema reports whether schema can be used with strict structured
+// outputs: every object must list all of its properties as required and
+// set additionalProperties to false. Other schemas are sent non-strict,
+// since the API rejects them in strict mode.
+func strictSchema(schema json.RawMessage) bool {
+	var root any
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return false
+	}
+	return strictNode(root)
+}
+
+// strictNode checks one schema node and its subschemas.
+func strictNode(node any) bool {
+	switch n := node.(type) {
+	case []any:
+		for _, item := range n {
+			if !strictNode(item) {
+				return false
+			}
+		}
+	case map[string]any:
+		if props, ok := n["properties"].(map[string]any); ok || n["type"] == "object" {
+			if n["additionalProperties"] != false {
+				return false
+			}
+			required := make(map[string]bool)
+			if list, ok := n["required"].([]any); ok {
+				for _, name := range list {
+					if s, ok := name.(string); ok {
+						required[s] = true
+					}
+				}
+			}
+			for name := range props {
+				if !required[name] {
+					return false
+				}
+			}
+		}
+		for _, key := range []string{"properties", "$defs", "definitions"} {
+			if children, ok := n[key].(map[string]any); ok {
+				for _, child := range children {
+					if !strictNode(child) {
+						return false
+					}
+				}
+			}
+		}
+		for _, key := range []string{"items", "anyOf"} {
+			if child, ok := n[key]; ok && !strictNode(child) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// schemaName returns a response format name for the agent. OpenAI format
+// names allow only letters, digits, underscores, and dashes.
+func schemaName(agent string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, agent)
+	if name == "" {
+		return "response"
+	}
+	return name + "_response"
+}
+
+// ReadFile reads an OpenAI agent JSON file and returns canonical Agent.
+func (a *Adapter) ReadFile(path string) (*core.Agent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ReadError{Path: path, Err: err}
+	}
+
+	agent, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+
+	// Infer name from filename if not set
+	if agent.Name == "" {
+		base := filepath.Base(path)
+		agent.Name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+
+	return agent, nil
+}
+
+// WriteFile writes canonical Agent to an OpenAI agent JSON file.
+func (a *Adapter) WriteFile(agent *core.Agent, path string) error {
+	data, err := a.Marshal(agent)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, core.DefaultDirMode); err != nil {
+		return &core.WriteError{Path: path, Err: err}
+	}
+
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Path: path, Err: err}
+	}
+
+	return nil
+}
+
+// mapOpenAIToolToCanonical maps an OpenAI hosted tool type to a canonical tool.
+func mapOpenAIToolToCanonical(toolType string) string {
+	if canonical, ok := core.CanonicalToolAlias(AdapterName, toolType); ok {
+		return canonical
+	}
+	for canonical, mapped := range toolMap {
+		if mapped == toolType {
+			return canonical
+		}
+	}
+	return toolType
+}
+
+// mapOpenAIModelToCanonical maps OpenAI model names to canonical names.
+func mapOpenAIModelToCanonical(model string) core.Model {
+	switch strings.ToLower(model) {
+	case "gpt-4o-mini":
+		return core.ModelHaiku
+	case "gpt-4o":
+		return core.ModelSonnet
+	case "o1":
+		return core.ModelOpus
+	default:
+		return core.Model(model)
+	}
+}
+
+// mapCanonicalModelToOpenAI maps canonical model names to OpenAI names.
+func mapCanonicalModelToOpenAI(model core.Model) string {
+	switch model {
+	case core.ModelHaiku:
+		return "gpt-4o-mini"
+	case core.ModelSonnet:
+		return "gpt-4o"
+	case core.ModelOpus:
+		return "o1"
+	default:
+		return string(model)
+	}
+}
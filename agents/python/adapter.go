@@ -0,0 +1,160 @@
+// Package python provides an adapter for generating Python agent stubs.
+//
+// Each agent becomes a framework-agnostic Python module that defines the
+// agent's name, description, model, tools, and instructions as constants,
+// plus an AGENT dict combining them, ready to pass to LangGraph, CrewAI, or
+// custom orchestration code.
+package python
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+)
+
+// AdapterName is the identifier for this adapter.
+const AdapterName = "python"
+
+func init() {
+	core.Register(&Adapter{})
+}
+
+// Adapter converts canonical Agent definitions to Python agent stubs.
+type Adapter struct{}
+
+// Name returns the adapter identifier.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// FileExtension returns the file extension for Python modules.
+func (a *Adapter) FileExtension() string {
+	return ".py"
+}
+
+// FileSlug returns name in snake_case, so each stub is an importable Python
+// module.
+func (a *Adapter) FileSlug(name string) string {
+	return core.SnakeCase(name)
+}
+
+// DefaultDir returns the default directory name for Python agent stubs.
+func (a *Adapter) DefaultDir() string {
+	return "agents"
+}
+
+// SupportedTools returns the canonical tools. Stubs list tools by their
+// canonical names for the framework to bind.
+func (a *Adapter) SupportedTools() []string {
+	return append([]string{}, core.CanonicalTools...)
+}
+
+// Parse is not supported for generated Python stubs.
+func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
+	return nil, &core.ParseError{Format: AdapterName, Err: fmt.Errorf("parsing Python stubs not supported")}
+}
+
+// Marshal converts canonical Agent to a Python module.
+func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
+	tmpl, err := template.New("agent").Funcs(template.FuncMap{
+		"str":       pyString,
+		"multiline": pyMultiline,
+		"list":      pyList,
+	}).Parse(moduleTemplate)
+	if err != nil {
+		return nil, &core.MarshalError{Format: AdapterName, Err: err}
+	}
+
+	data := map[string]interface{}{
+		"Name":         agent.Name,
+		"Description":  agent.Description,
+		"Model":        string(agent.Model),
+		"Tools":        agent.Tools,
+		"Instructions": core.TransformInstructions(AdapterName, agent.CombinedInstructions()),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, &core.MarshalError{Format: AdapterName, Err: err}
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadFile is not supported for generated Python stubs.
+func (a *Adapter) ReadFile(path string) (*core.Agent, error) {
+	return nil, &core.ReadError{Path: path, Err: fmt.Errorf("reading Python stubs not supported")}
+}
+
+// WriteFile writes canonical Agent as a Python module to path.
+func (a *Adapter) WriteFile(agent *core.Agent, path string) error {
+	data, err := a.Marshal(agent)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, core.DefaultDirMode); err != nil {
+		return &core.WriteError{Path: path, Err: err}
+	}
+
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Path: path, Err: err}
+	}
+
+	return nil
+}
+
+// pyString quotes s as a Python string literal. JSON string escapes are a
+// subset of Python's.
+func pyString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// pyMultiline quotes s as a triple-quoted Python string.
+func pyMultiline(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"""`, `\"\"\"`)
+	if strings.HasSuffix(s, `"`) {
+		s = s[:len(s)-1] + `\"`
+	}
+	return `"""` + s + `"""`
+}
+
+// pyList formats items as a Python list of strings.
+func pyList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = pyString(item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+const moduleTemplate = `"""{{.Name}} agent.
+
+Generated by assistantkit from the canonical agent spec. The definition is
+framework-agnostic: pass AGENT, or the constants, to LangGraph, CrewAI, or
+your own orchestration code.
+"""
+
+NAME = {{str .Name}}
+DESCRIPTION = {{str .Description}}
+MODEL = {{str .Model}}
+TOOLS = {{list .Tools}}
+
+INSTRUCTIONS = {{multiline .Instructions}}
+
+AGENT = {
+    "name": NAME,
+    "description": DESCRIPTION,
+    "model": MODEL,
+    "tools": TOOLS,
+    "instructions": INSTRUCTIONS,
+}
+`
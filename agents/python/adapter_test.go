@@ -0,0 +1,51 @@
+package python
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+)
+
+func TestMarshalStub(t *testing.T) {
+	agent := &core.Agent{Spec: core.Spec{
+		Name:         "reviewer",
+		Description:  `Reviews "risky" changes`,
+		Model:        core.ModelSonnet,
+		Tools:        []string{"Read", "Grep"},
+		Instructions: "Review the diff.\nFlag anything matching \\d+ or \"\"\"quoted\"\"\".",
+	}}
+
+	data, err := (&Adapter{}).Marshal(agent)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	stub := string(data)
+
+	for _, want := range []string{
+		`NAME = "reviewer"`,
+		`DESCRIPTION = "Reviews \"risky\" changes"`,
+		`MODEL = "sonnet"`,
+		`TOOLS = ["Read", "Grep"]`,
+		"INSTRUCTIONS = \"\"\"Review the diff.\nFlag anything matching \\\\d+ or \\\"\\\"\\\"quoted\\\"\\\"\\\".\"\"\"",
+		`"instructions": INSTRUCTIONS,`,
+	} {
+		if !strings.Contains(stub, want) {
+			t.Errorf("stub missing %q\n%s", want, stub)
+		}
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	adapter, ok := core.GetAdapter(AdapterName)
+	if !ok {
+		t.Fatal("expected python adapter to be registered")
+	}
+	if adapter.FileExtension() != ".py" {
+		t.Errorf("FileExtension = %q, want .py", adapter.FileExtension())
+	}
+	agent := &core.Agent{Spec: core.Spec{Name: "code-reviewer"}}
+	if got := core.FileName(adapter, agent); got != "code_reviewer.py" {
+		t.Errorf("FileName = %q, want an importable module name code_reviewer.py", got)
+	}
+}
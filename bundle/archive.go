@@ -0,0 +1,212 @@
+package bundle
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// ArchiveFormat names the stream format written by GenerateArchive.
+type ArchiveFormat string
+
+const (
+	// ArchiveZip writes a zip archive. It is the default.
+	ArchiveZip ArchiveFormat = "zip"
+
+	// ArchiveTarGz writes a gzip-compressed tar archive.
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+)
+
+// archiveModTime is the modification time stamped on every archive entry,
+// so that generating the same bundle twice yields identical archives. Zip
+// cannot represent times before 1980.
+var archiveModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// GenerateArchive writes the bundle's output for a specific tool to w as a
+// single archive in b.ArchiveFormat. Entry paths are relative to the tool's
+// root, as Generate would lay them out in outputDir.
+//
+// The adapters write through the OS filesystem, so generation runs into a
+// temporary directory that is removed before GenerateArchive returns.
+// Entry modes are normalized to 0755 for directories and executable files
+// and 0644 for other files, whatever the local umask or file modes.
+func (b *Bundle) GenerateArchive(tool string, w io.Writer) error {
+	return b.generateArchive(tool, w, func(dir string) error {
+		return b.Generate(tool, dir)
+	})
+}
+
+// GenerateAllArchive writes the bundle's output for all supported tools to
+// w as a single archive in b.ArchiveFormat, laid out as GenerateAll would.
+// Generation is staged and modes normalized as for GenerateArchive.
+func (b *Bundle) GenerateAllArchive(w io.Writer) error {
+	return b.generateArchive("all", w, b.GenerateAll)
+}
+
+// generateArchive runs gen into a staging directory and streams the result
+// to w. The staging directory is removed afterwards.
+func (b *Bundle) generateArchive(tool string, w io.Writer, gen func(dir string) error) error {
+	format := b.ArchiveFormat
+	if format == "" {
+		format = ArchiveZip
+	}
+	if format != ArchiveZip && format != ArchiveTarGz {
+		return &GenerateError{Tool: tool, Component: "archive", Err: fmt.Errorf("%w %q", ErrUnknownArchiveFormat, format)}
+	}
+
+	stagingDir, err := os.MkdirTemp("", "assistantkit-bundle-*")
+	if err != nil {
+		return &GenerateError{Tool: tool, Component: "archive", Err: err}
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := gen(stagingDir); err != nil {
+		return err
+	}
+
+	if format == ArchiveTarGz {
+		err = writeTarGz(os.DirFS(stagingDir), w)
+	} else {
+		err = writeZip(os.DirFS(stagingDir), w)
+	}
+	if err != nil {
+		return &GenerateError{Tool: tool, Component: "archive", Err: err}
+	}
+	return nil
+}
+
+// archiveEntry is a file or directory found in a generated tree.
+type archiveEntry struct {
+	name string // slash-separated path relative to the root
+	mode fs.FileMode
+	size int64
+	dir  bool
+}
+
+// archiveMode returns the normalized mode of an archive entry: 0755 for
+// directories and files with any execute bit set, 0644 otherwise.
+func archiveMode(mode fs.FileMode) fs.FileMode {
+	if mode.IsDir() || mode.Perm()&0111 != 0 {
+		return 0755
+	}
+	return 0644
+}
+
+// archiveEntries lists the entries in fsys in lexical order, skipping the
+// root itself.
+func archiveEntries(fsys fs.FS) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == "." {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entries = append(entries, archiveEntry{
+			name: path,
+			mode: archiveMode(info.Mode()),
+			size: info.Size(),
+			dir:  d.IsDir(),
+		})
+		return nil
+	})
+	return entries, err
+}
+
+// writeZip writes the files in fsys to w as a zip archive.
+func writeZip(fsys fs.FS, w io.Writer) error {
+	entries, err := archiveEntries(fsys)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	for _, entry := range entries {
+		header := &zip.FileHeader{
+			Name:     entry.name,
+			Method:   zip.Deflate,
+			Modified: archiveModTime,
+		}
+		if entry.dir {
+			header.Name += "/"
+			header.Method = zip.Store
+			header.SetMode(fs.ModeDir | entry.mode)
+		} else {
+			header.SetMode(entry.mode)
+		}
+
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if entry.dir {
+			continue
+		}
+		if err := copyFile(fw, fsys, entry.name); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeTarGz writes the files in fsys to w as a gzip-compressed tar
+// archive.
+func writeTarGz(fsys fs.FS, w io.Writer) error {
+	entries, err := archiveEntries(fsys)
+	if err != nil {
+		return err
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	for _, entry := range entries {
+		header := &tar.Header{
+			Name:    entry.name,
+			Mode:    int64(entry.mode),
+			ModTime: archiveModTime,
+			Format:  tar.FormatPAX,
+		}
+		if entry.dir {
+			header.Name += "/"
+			header.Typeflag = tar.TypeDir
+		} else {
+			header.Typeflag = tar.TypeReg
+			header.Size = entry.size
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if entry.dir {
+			continue
+		}
+		if err := copyFile(tw, fsys, entry.name); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// copyFile copies the contents of the named file in fsys to w.
+func copyFile(w io.Writer, fsys fs.FS, name string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -37,6 +37,16 @@
 //	    if err := b.GenerateAll("./plugins"); err != nil {
 //	        log.Fatal(err)
 //	    }
+//
+//	    // Or write a single downloadable archive
+//	    f, err := os.Create("agentcall-claude.zip")
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    defer f.Close()
+//	    if err := b.GenerateArchive("claude", f); err != nil {
+//	        log.Fatal(err)
+//	    }
 //	}
 package bundle
 
@@ -45,6 +55,7 @@ import (
 	commandscore "github.com/agentplexus/assistantkit/commands/core"
 	contextcore "github.com/agentplexus/assistantkit/context/core"
 	hookscore "github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/internal/envfile"
 	mcpcore "github.com/agentplexus/assistantkit/mcp/core"
 	pluginscore "github.com/agentplexus/assistantkit/plugins/core"
 	skillscore "github.com/agentplexus/assistantkit/skills/core"
@@ -57,6 +68,8 @@ var SupportedTools = []string{
 	"gemini",
 	"cursor",
 	"codex",
+	"windsurf",
+	"jetbrains",
 }
 
 // Bundle represents a complete plugin bundle with all components.
@@ -81,6 +94,51 @@ type Bundle struct {
 
 	// MCP is the MCP server configuration.
 	MCP *mcpcore.Config
+
+	// TargetOS selects OS-specific hooks (see hookscore.Hook.OS) for tools
+	// that cannot guard hook commands by OS. Defaults to runtime.GOOS.
+	// Claude keeps every hook, wrapped in a shell guard.
+	TargetOS string
+
+	// HookGroups limits generated hooks to those in the named groups (see
+	// hookscore.Hook.Group). Empty generates every hook.
+	HookGroups []string
+
+	// CodexAgentsIndex adds an "Agents" section to Codex's top-level
+	// AGENTS.md that links each agent file, appended to the project context
+	// when there is one, or else to the AGENTS.md already in the output
+	// directory, whose other content is kept.
+	CodexAgentsIndex bool
+
+	// SharedEnv holds environment variables common to every hook and MCP
+	// server. It is merged into the env of each stdio MCP server, where the
+	// server's own values win, and exported before each hook command, where
+	// assignments in the command itself win.
+	SharedEnv map[string]string
+
+	// Layout names the output layout profile (see Layouts). Empty uses
+	// LayoutDefault.
+	Layout string
+
+	// ToolVersions maps tool names to the tool release being targeted.
+	// MCP servers that need a feature a release does not support are
+	// reported as warnings (errors when Strict is set).
+	ToolVersions map[string]string
+
+	// Strict makes generation fail when an agent requests a tool the
+	// target does not support, an MCP server needs a feature the
+	// targeted tool version lacks, agents declare conflicting MCP servers,
+	// the plugin manifest references a missing or empty component
+	// directory, or a tool cannot receive a component of the bundle (see
+	// ComponentGaps), instead of reporting a warning.
+	Strict bool
+
+	// ArchiveFormat selects the format written by GenerateArchive and
+	// GenerateAllArchive. Empty uses ArchiveZip.
+	ArchiveFormat ArchiveFormat
+
+	// Warnings collects non-fatal issues reported during generation.
+	Warnings []string
 }
 
 // New creates a new Bundle with the given name, version, and description.
@@ -140,6 +198,40 @@ func (b *Bundle) AddMCPServer(name string, server MCPServer) {
 	})
 }
 
+// ExpandEnv resolves ${VAR} placeholders in MCP server and hook configuration
+// and in SharedEnv.
+// Values come from the process environment, falling back to the .env file at
+// envFile when it is non-empty. Unresolved placeholders are left unchanged.
+func (b *Bundle) ExpandEnv(envFile string) error {
+	vars := map[string]string{}
+	if envFile != "" {
+		loaded, err := envfile.Load(envFile)
+		if err != nil {
+			return &LoadError{Path: envFile, Err: err}
+		}
+		vars = loaded
+	}
+	lookup := envfile.Lookup(vars)
+
+	b.SharedEnv = envfile.ExpandMap(b.SharedEnv, lookup)
+	if b.MCP != nil {
+		b.MCP = b.MCP.ExpandEnv(lookup)
+	}
+	if b.Hooks != nil {
+		b.Hooks = b.Hooks.ExpandEnv(lookup)
+	}
+	if b.Plugin != nil {
+		for name, server := range b.Plugin.MCPServers {
+			server.Command = envfile.Expand(server.Command, lookup)
+			server.Args = envfile.ExpandAll(server.Args, lookup)
+			server.Cwd = envfile.Expand(server.Cwd, lookup)
+			server.Env = envfile.ExpandMap(server.Env, lookup)
+			b.Plugin.MCPServers[name] = server
+		}
+	}
+	return nil
+}
+
 // MCPServer represents an MCP server configuration.
 type MCPServer struct {
 	Command string
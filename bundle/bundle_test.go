@@ -1,9 +1,27 @@
 package bundle
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+
+	agentscore "github.com/agentplexus/assistantkit/agents/core"
+	contextcore "github.com/agentplexus/assistantkit/context/core"
+	hookscore "github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/internal/jsonc"
+	mcpcore "github.com/agentplexus/assistantkit/mcp/core"
+	pluginscore "github.com/agentplexus/assistantkit/plugins/core"
 )
 
 func TestNewBundle(t *testing.T) {
@@ -205,9 +223,9 @@ func TestGenerateKiro(t *testing.T) {
 	}
 
 	// Check agent file exists
-	agentFile := filepath.Join(agentsDir, "voice-caller.json")
+	agentFile := filepath.Join(agentsDir, "voice_caller.json")
 	if _, err := os.Stat(agentFile); os.IsNotExist(err) {
-		t.Error("expected voice-caller.json to be created")
+		t.Error("expected voice_caller.json to be created")
 	}
 }
 
@@ -219,3 +237,1195 @@ func TestToolConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestSummary(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.AddSkill(NewSkill("skill-a", "Skill A"))
+	b.AddSkill(NewSkill("skill-b", "Skill B"))
+	b.AddCommand(NewCommand("call", "Initiate a call"))
+	b.AddAgent(NewAgent("agent-a", "Agent A"))
+	b.AddAgent(NewAgent("agent-b", "Agent B"))
+	b.AddAgent(NewAgent("agent-c", "Agent C"))
+	b.Hooks.AddHook(EventOnStop, Hook{Type: "command", Command: "echo done"})
+	b.Hooks.AddHook(EventBeforeCommand, Hook{Type: "command", Command: "echo before"})
+	b.AddMCPServer("agentcall", MCPServer{Command: "./agentcall"})
+
+	s := b.Summary()
+
+	if s.Name != "test" {
+		t.Errorf("expected name 'test', got '%s'", s.Name)
+	}
+	if s.Skills != 2 {
+		t.Errorf("expected 2 skills, got %d", s.Skills)
+	}
+	if s.Commands != 1 {
+		t.Errorf("expected 1 command, got %d", s.Commands)
+	}
+	if s.Agents != 3 {
+		t.Errorf("expected 3 agents, got %d", s.Agents)
+	}
+	if s.Hooks != 2 {
+		t.Errorf("expected 2 hooks, got %d", s.Hooks)
+	}
+	if s.MCPServers != 1 {
+		t.Errorf("expected 1 MCP server, got %d", s.MCPServers)
+	}
+	if s.HasContext {
+		t.Error("expected HasContext to be false")
+	}
+	if _, ok := s.Targets["context"]; ok {
+		t.Error("expected no context targets without context")
+	}
+
+	if !containsTool(s.Targets["agents"], "claude") || !containsTool(s.Targets["agents"], "kiro") {
+		t.Errorf("expected agents to target claude and kiro, got %v", s.Targets["agents"])
+	}
+	if !containsTool(s.Targets["mcp"], "claude") || !containsTool(s.Targets["mcp"], "kiro") {
+		t.Errorf("expected mcp to target claude and kiro, got %v", s.Targets["mcp"])
+	}
+	if !containsTool(s.Targets["skills"], "claude") {
+		t.Errorf("expected skills to target claude, got %v", s.Targets["skills"])
+	}
+
+	b.SetContext(NewContext("test"))
+	s = b.Summary()
+	if !s.HasContext {
+		t.Error("expected HasContext to be true")
+	}
+	if !containsTool(s.Targets["context"], "claude") {
+		t.Errorf("expected context to target claude, got %v", s.Targets["context"])
+	}
+}
+
+func containsTool(tools []string, tool string) bool {
+	for _, t := range tools {
+		if t == tool {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGenerateJetBrains(t *testing.T) {
+	b := New("agentcall", "0.1.0", "Voice calling for AI assistants")
+
+	ctx := NewContext("agentcall")
+	ctx.Description = "Voice calling for AI assistants"
+	ctx.SetCommand("test", "go test ./...")
+	b.SetContext(ctx)
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("jetbrains", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	guidelinesPath := filepath.Join(tmpDir, ".junie", "guidelines.md")
+	data, err := os.ReadFile(guidelinesPath)
+	if err != nil {
+		t.Fatalf("expected guidelines.md to be created: %v", err)
+	}
+	if !strings.Contains(string(data), "Voice calling for AI assistants") {
+		t.Errorf("expected guidelines to contain project description, got:\n%s", data)
+	}
+}
+
+func TestGenerateWindsurf(t *testing.T) {
+	b := New("agentcall", "0.1.0", "Voice calling for AI assistants")
+	b.AddSkill(NewSkill("phone-input", "Ask the user by phone"))
+	b.AddCommand(NewCommand("call", "Initiate a call"))
+	b.Hooks.AddHook(EventOnStop, Hook{Type: "command", Command: "echo done"})
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("windsurf", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, rel := range []string{
+		filepath.Join(".windsurf", "plugin.json"),
+		filepath.Join(".windsurf", "skills", "phone-input", "SKILL.md"),
+		filepath.Join(".windsurf", "workflows", "call.md"),
+		filepath.Join(".windsurf", "hooks.json"),
+	} {
+		if _, err := os.Stat(filepath.Join(tmpDir, rel)); err != nil {
+			t.Errorf("expected %s to be created: %v", rel, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".windsurf", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("invalid plugin.json: %v", err)
+	}
+	if manifest["workflows"] != ".windsurf/workflows" {
+		t.Errorf("expected workflows '.windsurf/workflows', got %v", manifest["workflows"])
+	}
+	if manifest["skills"] != ".windsurf/skills" {
+		t.Errorf("expected skills '.windsurf/skills', got %v", manifest["skills"])
+	}
+	if manifest["hooks"] != ".windsurf/hooks.json" {
+		t.Errorf("expected hooks '.windsurf/hooks.json', got %v", manifest["hooks"])
+	}
+}
+
+func TestGenerateConsolidatedHooks(t *testing.T) {
+	windsurf := DefaultToolConfigs["windsurf"]
+	windsurf.ConsolidatedHooks = true
+	claude := DefaultToolConfigs["claude"]
+	claude.ConsolidatedHooks = false
+	claude.HooksDir = "hooks"
+	claude.HooksFile = "hooks.json"
+	Layouts["consolidated-test"] = Layout{Tools: map[string]ToolConfig{"windsurf": windsurf, "claude": claude}}
+	defer delete(Layouts, "consolidated-test")
+
+	b := New("test", "1.0.0", "test")
+	b.Layout = "consolidated-test"
+	b.Hooks.AddHook(EventBeforeCommand, Hook{Type: "command", Command: "echo done"})
+
+	// Windsurf embeds its hooks in plugin.json instead of hooks.json
+	tmpDir := t.TempDir()
+	if err := b.Generate("windsurf", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".windsurf", "hooks.json")); !os.IsNotExist(err) {
+		t.Errorf("expected no separate hooks.json, got err %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".windsurf", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest struct {
+		Name  string                     `json:"name"`
+		Hooks map[string]json.RawMessage `json:"hooks"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("expected hooks object in plugin.json: %v\n%s", err, data)
+	}
+	if manifest.Name != "test" || len(manifest.Hooks) == 0 || !strings.Contains(string(data), "echo done") {
+		t.Errorf("expected plugin.json with embedded hooks, got:\n%s", data)
+	}
+	if info, err := os.Stat(filepath.Join(tmpDir, ".windsurf", "plugin.json")); err != nil || info.Mode().Perm() != pluginscore.DefaultFileMode {
+		t.Errorf("expected plugin.json at mode %v, got %v", pluginscore.DefaultFileMode, info.Mode().Perm())
+	}
+
+	// Claude writes a separate hooks file when consolidation is off
+	tmpDir = t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "hooks", "hooks.json")); err != nil {
+		t.Errorf("expected hooks/hooks.json: %v", err)
+	}
+	data, err = os.ReadFile(filepath.Join(tmpDir, ".claude-plugin", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"hooks"`) {
+		t.Errorf("expected no embedded hooks in plugin.json, got:\n%s", data)
+	}
+}
+
+func TestGenerateConsolidatedMCP(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.AddCommand(NewCommand("call", "Initiate a call"))
+	b.AddMCPServer("agentcall", MCPServer{Command: "./agentcall"})
+
+	// Gemini embeds MCP servers in gemini-extension.json by default
+	s := b.Summary()
+	if !containsTool(s.Targets["mcp"], "gemini") {
+		t.Errorf("expected mcp to target gemini, got %v", s.Targets["mcp"])
+	}
+	tmpDir := t.TempDir()
+	if err := b.Generate("gemini", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "gemini-extension.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "agentcall") {
+		t.Errorf("expected embedded agentcall server, got:\n%s", data)
+	}
+
+	gemini := DefaultToolConfigs["gemini"]
+	gemini.ConsolidatedMCP = false
+	claude := DefaultToolConfigs["claude"]
+	claude.ConsolidatedMCP = false
+	claude.MCPDir = "."
+	claude.MCPFile = ".mcp.json"
+	Layouts["consolidated-test"] = Layout{Tools: map[string]ToolConfig{"gemini": gemini, "claude": claude}}
+	defer delete(Layouts, "consolidated-test")
+	b.Layout = "consolidated-test"
+
+	s = b.Summary()
+	if containsTool(s.Targets["mcp"], "gemini") || !containsTool(s.Targets["mcp"], "claude") {
+		t.Errorf("expected mcp to target claude but not gemini, got %v", s.Targets["mcp"])
+	}
+
+	// Gemini leaves MCP servers out of its manifest when consolidation is off
+	tmpDir = t.TempDir()
+	if err := b.Generate("gemini", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err = os.ReadFile(filepath.Join(tmpDir, "gemini-extension.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "mcpServers") {
+		t.Errorf("expected no embedded MCP servers in gemini-extension.json, got:\n%s", data)
+	}
+
+	// Claude writes a separate MCP file when consolidation is off
+	tmpDir = t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".mcp.json")); err != nil {
+		t.Errorf("expected .mcp.json: %v", err)
+	}
+	data, err = os.ReadFile(filepath.Join(tmpDir, ".claude-plugin", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "mcpServers") {
+		t.Errorf("expected no embedded MCP servers in plugin.json, got:\n%s", data)
+	}
+}
+
+func TestGenerateWarnsOnUnsupportedAgentTool(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	agent := NewAgent("researcher", "Research agent")
+	agent.Tools = []string{"Read", "Bash", "NotebookEdit"}
+	b.AddAgent(agent)
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("kiro", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(b.Warnings) != 1 || !strings.Contains(b.Warnings[0], "NotebookEdit") {
+		t.Errorf("expected one warning about NotebookEdit, got %v", b.Warnings)
+	}
+
+	b.Strict = true
+	if err := b.Generate("kiro", t.TempDir()); err == nil {
+		t.Error("expected strict generation to fail on unsupported tool")
+	}
+}
+
+func TestGenerateStrictComponentGaps(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.Hooks.AddHook(EventOnStop, Hook{Type: "command", Command: "echo done"})
+	b.AddSkill(NewSkill("review", "Review code"))
+
+	if err := b.Generate("kiro", t.TempDir()); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	want := []string{"kiro does not support skills; skipped", "kiro does not support hooks; skipped"}
+	if strings.Join(b.Warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected warnings %q, got %q", want, b.Warnings)
+	}
+
+	b.Warnings = nil
+	b.Strict = true
+	err := b.Generate("kiro", t.TempDir())
+	var gapsErr *UnsupportedComponentsError
+	if !errors.As(err, &gapsErr) || !errors.Is(err, ErrUnsupportedComponent) {
+		t.Fatalf("expected UnsupportedComponentsError, got %v", err)
+	}
+	if len(gapsErr.Gaps) != 2 || gapsErr.Gaps[1] != (ComponentGap{Tool: "kiro", Component: "hooks"}) {
+		t.Errorf("expected skills and hooks gaps, got %+v", gapsErr.Gaps)
+	}
+
+	err = b.GenerateAll(t.TempDir())
+	if !errors.As(err, &gapsErr) || !strings.Contains(err.Error(), "gemini/skills") || !strings.Contains(err.Error(), "kiro/hooks") {
+		t.Errorf("expected GenerateAll to list every tool's gaps, got %v", err)
+	}
+}
+
+func TestGenerateWarnsOnUnsupportedMCPFeature(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.MCP.AddServer("remote", mcpcore.Server{
+		Transport: mcpcore.TransportHTTP,
+		URL:       "https://mcp.example.com/mcp",
+	})
+	b.ToolVersions = map[string]string{"claude": "1.0.20"}
+
+	if err := b.Generate("claude", t.TempDir()); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(b.Warnings) != 1 || !strings.Contains(b.Warnings[0], "http-transport") {
+		t.Errorf("expected one warning about http-transport, got %v", b.Warnings)
+	}
+
+	b.Warnings = nil
+	b.ToolVersions["claude"] = "1.0.27"
+	if err := b.Generate("claude", t.TempDir()); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(b.Warnings) != 0 {
+		t.Errorf("expected no warnings for a supporting version, got %v", b.Warnings)
+	}
+
+	b.Strict = true
+	b.ToolVersions["claude"] = "1.0.20"
+	if err := b.Generate("claude", t.TempDir()); !errors.Is(err, mcpcore.ErrUnsupportedFeature) {
+		t.Errorf("expected ErrUnsupportedFeature in strict mode, got %v", err)
+	}
+
+	// Servers declared only by agents are checked too
+	agentOnly := New("test", "1.0.0", "test")
+	researcher := NewAgent("researcher", "Research agent")
+	researcher.MCP = map[string]agentscore.MCPServer{"docs": {URL: "https://docs.example.com/mcp"}}
+	agentOnly.AddAgent(researcher)
+	agentOnly.ToolVersions = map[string]string{"claude": "1.0.20"}
+	if err := agentOnly.Generate("claude", t.TempDir()); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(agentOnly.Warnings) != 1 || !strings.Contains(agentOnly.Warnings[0], `"docs"`) {
+		t.Errorf("expected a warning about the agent's docs server, got %v", agentOnly.Warnings)
+	}
+}
+
+func TestGenerateClaudeMergesAgentMCPServers(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.AddMCPServer("github", MCPServer{Command: "github-mcp-server"})
+
+	researcher := NewAgent("researcher", "Research agent")
+	researcher.MCP = map[string]agentscore.MCPServer{
+		"github": {Command: "github-mcp-server"},
+		"search": {Command: "search-mcp", Args: []string{"--safe"}},
+	}
+	writer := NewAgent("writer", "Writing agent")
+	writer.MCP = map[string]agentscore.MCPServer{
+		"search": {Command: "search-mcp", Args: []string{"--fast"}},
+		"docs":   {Command: "docs-mcp"},
+	}
+	b.AddAgent(researcher)
+	b.AddAgent(writer)
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".claude-plugin", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest struct {
+		MCPServers map[string]struct {
+			Command string   `json:"command"`
+			Args    []string `json:"args"`
+		} `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.MCPServers) != 3 {
+		t.Errorf("expected 3 merged MCP servers, got %v", manifest.MCPServers)
+	}
+	if search := manifest.MCPServers["search"]; len(search.Args) != 1 || search.Args[0] != "--safe" {
+		t.Errorf("expected the first search definition to win, got %+v", search)
+	}
+	if _, ok := manifest.MCPServers["docs"]; !ok {
+		t.Error("expected the writer's docs server to be merged")
+	}
+
+	if len(b.Warnings) != 1 || !strings.Contains(b.Warnings[0], `"search"`) || !strings.Contains(b.Warnings[0], `agent "researcher"`) {
+		t.Errorf("expected one conflict warning about search, got %v", b.Warnings)
+	}
+
+	b.Strict = true
+	var conflict *MCPConflictError
+	if err := b.Generate("claude", t.TempDir()); !errors.As(err, &conflict) || conflict.Agent != "writer" {
+		t.Errorf("expected MCPConflictError for writer in strict mode, got %v", err)
+	}
+}
+
+func TestGenerateClaudeOrdersMCPServers(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.MCP.AddServer("zeta", mcpcore.Server{Command: "zeta-mcp", Order: 1})
+	b.MCP.AddServer("alpha", mcpcore.Server{Command: "alpha-mcp", Order: 2})
+
+	tmpDir := t.TempDir()
+	pluginPath := filepath.Join(tmpDir, ".claude-plugin", "plugin.json")
+	for i := 0; i < 2; i++ {
+		// The second run merges into the existing file, which has a key of its own
+		if i == 1 {
+			if err := os.WriteFile(pluginPath, []byte(`{"homepage": "https://example.com"}`), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := b.Generate("claude", tmpDir); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		data, err := os.ReadFile(pluginPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := string(data)
+		if zeta, alpha := strings.Index(content, `"zeta"`), strings.Index(content, `"alpha"`); zeta < 0 || alpha < zeta {
+			t.Errorf("expected servers in Order, got:\n%s", content)
+		}
+	}
+}
+
+func TestGenerateClaudeKeepsHTTPMCPServers(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.MCP.AddServer("remote", mcpcore.Server{
+		Transport: mcpcore.TransportHTTP,
+		URL:       "https://mcp.example.com/mcp",
+		Headers:   map[string]string{"Authorization": "Bearer ${TOKEN}"},
+	})
+	researcher := NewAgent("researcher", "Research agent")
+	researcher.MCP = map[string]agentscore.MCPServer{
+		"docs": {URL: "https://docs.example.com/mcp", Headers: map[string]string{"X-Team": "platform"}},
+	}
+	b.AddAgent(researcher)
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".claude-plugin", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest struct {
+		MCPServers map[string]struct {
+			Type    string            `json:"type"`
+			Command string            `json:"command"`
+			URL     string            `json:"url"`
+			Headers map[string]string `json:"headers"`
+		} `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	remote := manifest.MCPServers["remote"]
+	if remote.Type != "http" || remote.URL != "https://mcp.example.com/mcp" || remote.Headers["Authorization"] != "Bearer ${TOKEN}" || remote.Command != "" {
+		t.Errorf("expected the bundle's HTTP server to keep type, url, and headers, got %+v", remote)
+	}
+	docs := manifest.MCPServers["docs"]
+	if docs.Type != "http" || docs.URL != "https://docs.example.com/mcp" || docs.Headers["X-Team"] != "platform" {
+		t.Errorf("expected the agent's HTTP server to keep url and headers, got %+v", docs)
+	}
+}
+
+func TestGenerateClaudeKeepsExistingPluginKeys(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.AddMCPServer("github", MCPServer{Command: "github-mcp-server"})
+
+	tmpDir := t.TempDir()
+	pluginPath := filepath.Join(tmpDir, ".claude-plugin", "plugin.json")
+	if err := os.MkdirAll(filepath.Dir(pluginPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	existing := `{"name": "old", "version": "0.1.0", "skills": "./stale/", "x-owner": {"team": "platform"}, "x-setup": "make && make install"}`
+	if err := os.WriteFile(pluginPath, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(pluginPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if string(manifest["name"]) != `"test"` || string(manifest["version"]) != `"1.0.0"` {
+		t.Errorf("expected generated name and version, got %s", data)
+	}
+	if _, ok := manifest["mcpServers"]; !ok {
+		t.Errorf("expected generated mcpServers, got %s", data)
+	}
+	if _, ok := manifest["skills"]; ok {
+		t.Errorf("expected stale skills path to be dropped, got %s", data)
+	}
+	if !strings.Contains(string(manifest["x-owner"]), `"platform"`) {
+		t.Errorf("expected manually added x-owner to survive, got %s", data)
+	}
+	if !strings.Contains(string(data), `"make && make install"`) || !strings.HasSuffix(string(data), "}\n") {
+		t.Errorf("expected canonical JSON with unescaped kept keys, got %s", data)
+	}
+
+	// Regenerating is stable once the keys are merged.
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	again, err := os.ReadFile(pluginPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("expected identical output on regeneration, got:\n%s\nvs\n%s", data, again)
+	}
+}
+
+func TestGenerateClaudeAgentMCPServerSelection(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.AddMCPServer("github", MCPServer{Command: "github-mcp-server"})
+	b.AddMCPServer("search", MCPServer{Command: "search-mcp"})
+
+	reviewer := NewAgent("reviewer", "Review agent")
+	reviewer.MCPServers = []string{"github"}
+	b.AddAgent(reviewer)
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "agents", "reviewer.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "mcpServers: [github]") {
+		t.Errorf("expected reviewer to reference only github, got:\n%s", content)
+	}
+	if strings.Contains(content, "search") {
+		t.Errorf("expected search to be left out of reviewer, got:\n%s", content)
+	}
+
+	reviewer.MCPServers = []string{"github", "jira"}
+	err = b.Generate("claude", t.TempDir())
+	if !errors.Is(err, ErrUnknownMCPServer) || !strings.Contains(err.Error(), `"jira"`) {
+		t.Errorf("expected ErrUnknownMCPServer for jira, got %v", err)
+	}
+}
+
+func TestGenerateFlagsEmptySkillsDir(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.Plugin.Skills = "skills" // referenced, but the bundle has no skills
+	b.AddCommand(NewCommand("release", "Release"))
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(b.Warnings) != 1 || !strings.Contains(b.Warnings[0], `plugin skills path "skills"`) {
+		t.Errorf("expected one warning about the skills path, got %v", b.Warnings)
+	}
+
+	b.Strict = true
+	err := b.Generate("claude", t.TempDir())
+	if !errors.Is(err, ErrComponentMissing) {
+		t.Errorf("expected ErrComponentMissing in strict mode, got %v", err)
+	}
+}
+
+func TestVerifyComponents(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "skills"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "commands"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "commands", "release.md"), []byte("# Release\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	plugin := &pluginscore.Plugin{Skills: "skills", Commands: "commands", Agents: "agents", Hooks: "hooks/hooks.json"}
+	mismatches := VerifyComponents(plugin, dir)
+
+	want := map[string]error{
+		"skills": ErrComponentEmpty,
+		"agents": ErrComponentMissing,
+		"hooks":  ErrComponentMissing,
+	}
+	if len(mismatches) != len(want) {
+		t.Fatalf("expected %d mismatches, got %v", len(want), mismatches)
+	}
+	for _, m := range mismatches {
+		if !errors.Is(m, want[m.Component]) {
+			t.Errorf("%s: expected %v, got %v", m.Component, want[m.Component], m.Err)
+		}
+	}
+}
+
+func TestExpandEnvFromEnvFile(t *testing.T) {
+	envPath := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(envPath, []byte("AGENTCALL_TEST_TOKEN=secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	b := New("test", "1.0.0", "test")
+	b.AddMCPServer("agentcall", MCPServer{
+		Command: "./agentcall",
+		Env:     map[string]string{"TOKEN": "${AGENTCALL_TEST_TOKEN}", "ROOT": "${CLAUDE_PLUGIN_ROOT}"},
+	})
+	b.Hooks.AddHook(EventBeforeCommand, Hook{Type: "command", Command: "check --token=${AGENTCALL_TEST_TOKEN} $1"})
+
+	if err := b.ExpandEnv(envPath); err != nil {
+		t.Fatalf("ExpandEnv failed: %v", err)
+	}
+
+	server := b.MCP.Servers["agentcall"]
+	if server.Env["TOKEN"] != "secret" {
+		t.Errorf("expected TOKEN to resolve from env file, got %q", server.Env["TOKEN"])
+	}
+	if server.Env["ROOT"] != "${CLAUDE_PLUGIN_ROOT}" {
+		t.Errorf("expected unresolved placeholder to be kept, got %q", server.Env["ROOT"])
+	}
+	if b.Plugin.MCPServers["agentcall"].Env["TOKEN"] != "secret" {
+		t.Errorf("expected plugin MCP server env to be expanded, got %v", b.Plugin.MCPServers["agentcall"].Env)
+	}
+	if hooks := b.Hooks.GetAllHooksForEvent(EventBeforeCommand); hooks[0].Command != "check --token=secret $1" {
+		t.Errorf("expected hook command to be expanded, got %q", hooks[0].Command)
+	}
+
+	if err := b.ExpandEnv(filepath.Join(t.TempDir(), "missing.env")); err == nil {
+		t.Error("expected error for missing env file")
+	}
+}
+
+func TestGenerateHookGroups(t *testing.T) {
+	b := New("agentcall", "0.1.0", "Voice calling for AI assistants")
+	b.Hooks.AddHook(EventBeforeCommand, Hook{Type: "command", Command: "golangci-lint run", Group: "linting"})
+	b.Hooks.AddHook(EventBeforeCommand, Hook{Type: "command", Command: "gitleaks detect", Group: "security"})
+	b.HookGroups = []string{"security"}
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("windsurf", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".windsurf", "hooks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hooks := string(data); !strings.Contains(hooks, "gitleaks detect") || strings.Contains(hooks, "golangci-lint") {
+		t.Errorf("expected only the security hook, got:\n%s", hooks)
+	}
+}
+
+func TestGenerateHooksTargetOS(t *testing.T) {
+	b := New("agentcall", "0.1.0", "Voice calling for AI assistants")
+	b.Hooks.AddHook(EventBeforeCommand, Hook{Type: "command", Command: "./check.sh", OS: "linux"})
+	b.Hooks.AddHook(EventBeforeCommand, Hook{Type: "command", Command: "pwsh check.ps1", OS: "windows"})
+	b.Hooks.AddHook(EventBeforeCommand, Hook{Type: "command", Command: "echo always"})
+	b.TargetOS = "windows"
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("windsurf", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".windsurf", "hooks.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hooks := string(data)
+	if !strings.Contains(hooks, "pwsh check.ps1") || !strings.Contains(hooks, "echo always") {
+		t.Errorf("expected windows and unrestricted hooks, got:\n%s", hooks)
+	}
+	if strings.Contains(hooks, "./check.sh") {
+		t.Errorf("expected linux hook to be filtered out, got:\n%s", hooks)
+	}
+
+	claudeDir := t.TempDir()
+	if err := b.Generate("claude", claudeDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err = os.ReadFile(filepath.Join(claudeDir, ".claude-plugin", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `Linux) ./check.sh ;; esac`) {
+		t.Errorf("expected claude to keep the linux hook behind a guard, got:\n%s", data)
+	}
+}
+
+func TestGenerateCodexAgentsIndex(t *testing.T) {
+	b := New("support", "1.0.0", "Support agents")
+	b.AddAgent(NewAgent("triage", "Classifies incoming issues"))
+	b.AddAgent(NewAgent("responder", "Drafts replies"))
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("codex", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "AGENTS.md")); !os.IsNotExist(err) {
+		t.Errorf("expected no AGENTS.md without CodexAgentsIndex, got err=%v", err)
+	}
+
+	b.CodexAgentsIndex = true
+	for i := 0; i < 2; i++ {
+		if err := b.Generate("codex", tmpDir); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "AGENTS.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := string(data)
+	for _, want := range []string{
+		"- [triage](agents/triage.md): Classifies incoming issues\n",
+		"- [responder](agents/responder.md): Drafts replies\n",
+	} {
+		if strings.Count(index, want) != 1 {
+			t.Errorf("expected AGENTS.md to reference %q once, got:\n%s", want, index)
+		}
+	}
+	for _, agent := range []string{"triage", "responder"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "agents", agent+".md")); err != nil {
+			t.Errorf("expected linked agent file for %s: %v", agent, err)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(tmpDir, "AGENTS.md")); err != nil || info.Mode().Perm() != contextcore.DefaultFileMode {
+		t.Errorf("expected AGENTS.md at mode %v, got %v", contextcore.DefaultFileMode, info.Mode().Perm())
+	}
+}
+
+func TestGenerateCodexAgentsIndexKeepsExistingAgentsMD(t *testing.T) {
+	b := New("support", "1.0.0", "Support agents")
+	b.AddAgent(NewAgent("triage", "Classifies incoming issues"))
+	b.CodexAgentsIndex = true
+
+	tmpDir := t.TempDir()
+	agentsMD := filepath.Join(tmpDir, "AGENTS.md")
+	written := "# Project\n\nRun make test before committing.\n\n## Style\n\nUse tabs.\n"
+	if err := os.WriteFile(agentsMD, []byte(written), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := b.Generate("codex", tmpDir); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(agentsMD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), written+"\n## Agents\n") {
+		t.Errorf("expected the index after the existing content, got:\n%s", data)
+	}
+	if n := strings.Count(string(data), "- [triage](agents/triage.md)"); n != 1 {
+		t.Errorf("expected one index entry after regenerating, got %d:\n%s", n, data)
+	}
+}
+
+func TestGenerateClaudeAgentDeniedTools(t *testing.T) {
+	b := New("review", "1.0.0", "Review agents")
+	agent := NewAgent("reviewer", "Reviews code")
+	agent.Tools = []string{"Read", "Grep"}
+	agent.DisallowedTools = []string{"Bash"}
+	b.AddAgent(agent)
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "agents", "reviewer.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "disallowedTools: [Bash]\n") {
+		t.Errorf("expected the denied tool in the agent's permissions, got:\n%s", data)
+	}
+}
+
+func TestGenerateClaudePluginListsAgentCategories(t *testing.T) {
+	b := New("review", "1.0.0", "Review agents")
+	reviewer := NewAgent("reviewer", "Reviews code")
+	reviewer.Categories = []string{"code-review", "security"}
+	scanner := NewAgent("scanner", "Scans dependencies")
+	scanner.Categories = []string{"security"}
+	b.AddAgent(reviewer)
+	b.AddAgent(scanner)
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".claude-plugin", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest struct {
+		Keywords []string `json:"keywords"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(manifest.Keywords, ",") != "code-review,security" {
+		t.Errorf("expected categories in the manifest, got %v", manifest.Keywords)
+	}
+
+	scanner.Categories = []string{"astrology"}
+	if err := b.Generate("claude", t.TempDir()); err == nil || !strings.Contains(err.Error(), "astrology") {
+		t.Errorf("expected an unknown category to fail generation, got %v", err)
+	}
+}
+
+func TestGenerateXDGLayout(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	b := New("agentcall", "0.1.0", "Voice calling for AI assistants")
+	b.Layout = LayoutXDG
+	b.AddMCPServer("agentcall", MCPServer{Command: "./agentcall"})
+	skill := NewSkill("phone-input", "Voice calling via phone")
+	skill.Instructions = "Use initiate_call to start a call..."
+	b.AddSkill(skill)
+	agent := NewAgent("voice-caller", "Handles voice calling")
+	agent.Instructions = "You are a voice calling agent..."
+	b.AddAgent(agent)
+
+	for _, tool := range []string{"claude", "kiro"} {
+		if err := b.Generate(tool, ""); err != nil {
+			t.Fatalf("Generate(%s) failed: %v", tool, err)
+		}
+	}
+
+	for _, path := range []string{
+		"claude/.claude-plugin/plugin.json",
+		"claude/skills/phone-input/SKILL.md",
+		"claude/agents/voice-caller.md",
+		"kiro/agents/voice_caller.json",
+		"kiro/settings/mcp.json",
+	} {
+		if _, err := os.Stat(filepath.Join(configHome, path)); err != nil {
+			t.Errorf("expected %s under XDG_CONFIG_HOME: %v", path, err)
+		}
+	}
+
+	// Plugin manifest paths stay relative to the tool's root.
+	data, err := os.ReadFile(filepath.Join(configHome, "claude", ".claude-plugin", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"skills": "./skills/"`) {
+		t.Errorf("expected root-relative skills path, got:\n%s", data)
+	}
+}
+
+func TestGenerateLayouts(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{LayoutDefault, ".kiro/agents/voice_caller.json"},
+		{LayoutFlat, "agents/voice_caller.json"},
+		{LayoutNested, ".kiro/agents/voice_caller.json"},
+	}
+	for _, tt := range tests {
+		b := New("agentcall", "0.1.0", "Voice calling")
+		b.Layout = tt.layout
+		b.AddAgent(NewAgent("voice-caller", "Handles voice calling"))
+
+		tmpDir := t.TempDir()
+		if err := b.Generate("kiro", tmpDir); err != nil {
+			t.Fatalf("%s: Generate failed: %v", tt.layout, err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, tt.want)); err != nil {
+			t.Errorf("%s: expected %s: %v", tt.layout, tt.want, err)
+		}
+	}
+
+	b := New("agentcall", "0.1.0", "Voice calling")
+	b.Layout = "sideways"
+	if err := b.Generate("claude", t.TempDir()); !errors.Is(err, ErrUnknownLayout) {
+		t.Errorf("expected ErrUnknownLayout, got %v", err)
+	}
+}
+
+func TestGenerateSharedEnv(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.AddMCPServer("github", MCPServer{Command: "github-mcp-server", Env: map[string]string{"LOG_LEVEL": "debug"}})
+	b.AddMCPServer("docs", MCPServer{Command: "docs-mcp"})
+	b.Hooks.AddHook(EventBeforeCommand, Hook{Type: "command", Command: "golangci-lint run"})
+	b.SharedEnv = map[string]string{"LOG_LEVEL": "info", "TEAM": "platform"}
+
+	type servers struct {
+		MCPServers map[string]struct {
+			Env map[string]string `json:"env"`
+		} `json:"mcpServers"`
+	}
+	check := func(name string, data []byte) {
+		t.Helper()
+		var got servers
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if env := got.MCPServers["docs"].Env; env["LOG_LEVEL"] != "info" || env["TEAM"] != "platform" {
+			t.Errorf("%s: expected shared env on docs, got %v", name, env)
+		}
+		if env := got.MCPServers["github"].Env; env["LOG_LEVEL"] != "debug" || env["TEAM"] != "platform" {
+			t.Errorf("%s: expected github to keep its LOG_LEVEL, got %v", name, env)
+		}
+	}
+
+	claudeDir := t.TempDir()
+	if err := b.Generate("claude", claudeDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(claudeDir, ".claude-plugin", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("claude", data)
+	if !strings.Contains(string(data), `export LOG_LEVEL=\"info\" TEAM=\"platform\"; golangci-lint run`) {
+		t.Errorf("expected the hook to export the shared env, got:\n%s", data)
+	}
+
+	kiroDir := t.TempDir()
+	if err := b.Generate("kiro", kiroDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err = os.ReadFile(filepath.Join(kiroDir, ".kiro", "settings", "mcp.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("kiro", data)
+
+	if env := b.MCP.Servers["docs"].Env; len(env) != 0 {
+		t.Errorf("expected the bundle's own servers to be unchanged, got %v", env)
+	}
+
+	b.SharedEnv["BAD-NAME"] = "x"
+	if err := b.Generate("claude", t.TempDir()); !errors.Is(err, hookscore.ErrInvalidEnvName) {
+		t.Errorf("expected ErrInvalidEnvName for a non-identifier key, got %v", err)
+	}
+}
+
+func TestGenerateClaudePluginChangelog(t *testing.T) {
+	b := New("test", "1.1.0", "test")
+	b.Plugin.AddChangeEntry("1.1.0", "2025-06-01", "Add release agent")
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".claude-plugin", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest struct {
+		Changelog []pluginscore.ChangeEntry `json:"changelog"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Changelog) != 1 || manifest.Changelog[0].Version != "1.1.0" || manifest.Changelog[0].Changes[0] != "Add release agent" {
+		t.Errorf("expected the changelog in plugin.json, got %+v", manifest.Changelog)
+	}
+}
+
+func TestGenerateVSCodeSettings(t *testing.T) {
+	b := New("test", "1.0.0", "test")
+	b.AddMCPServer("github", MCPServer{Command: "github-mcp-server"})
+
+	tmpDir := t.TempDir()
+	settingsPath := filepath.Join(tmpDir, ".vscode", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	existing := `{
+  // Team formatting
+  "editor.tabSize": 2,
+  "files.exclude": {"**/dist": true}, // build output
+}
+`
+	if err := os.WriteFile(settingsPath, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Generate("vscode", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".vscode", "mcp.json")); err != nil {
+		t.Errorf("expected mcp.json alongside settings.json: %v", err)
+	}
+
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  // Team formatting
+  "editor.tabSize": 2,
+  "files.exclude": {"**/dist": true},
+  "chat.mcp.enabled": true, // build output
+}
+`
+	if string(data) != want {
+		t.Errorf("expected the missing key to be added and the rest kept, got:\n%s", data)
+	}
+	var settings map[string]any
+	if err := jsonc.Unmarshal(data, &settings); err != nil || settings["chat.mcp.enabled"] != true {
+		t.Errorf("expected valid settings with chat.mcp.enabled, got %v, %v", settings, err)
+	}
+	if info, err := os.Stat(settingsPath); err != nil || info.Mode().Perm() != mcpcore.DefaultFileMode {
+		t.Errorf("expected settings.json at mode %v, got %v", mcpcore.DefaultFileMode, info.Mode().Perm())
+	}
+
+	// A key already set, even to another value, leaves the file untouched.
+	current := "{\n  // Disabled by hand\n  \"chat.mcp.enabled\": false\n}\n"
+	if err := os.WriteFile(settingsPath, []byte(current), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Generate("vscode", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if data, _ := os.ReadFile(settingsPath); string(data) != current {
+		t.Errorf("expected existing settings to be untouched, got:\n%s", data)
+	}
+
+	// An empty object receives the key.
+	if err := os.WriteFile(settingsPath, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Generate("vscode", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if data, _ := os.ReadFile(settingsPath); string(data) != "{\n  \"chat.mcp.enabled\": true\n}" {
+		t.Errorf("expected the key in the empty object, got:\n%s", data)
+	}
+
+	// Without MCP servers there is nothing to wire up.
+	noMCP := New("test", "1.0.0", "test")
+	emptyDir := t.TempDir()
+	if err := noMCP.Generate("vscode", emptyDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(emptyDir, ".vscode", "settings.json")); !os.IsNotExist(err) {
+		t.Errorf("expected no settings.json without MCP servers, got %v", err)
+	}
+}
+
+func TestGenerateArchive(t *testing.T) {
+	b := New("agentcall", "0.1.0", "Voice calling for AI assistants")
+	skill := NewSkill("phone-input", "Voice calling via phone")
+	skill.Instructions = "Use initiate_call to start a call..."
+	b.AddSkill(skill)
+	cmd := NewCommand("call", "Initiate a phone call to the user")
+	cmd.Instructions = "Initiate a phone call..."
+	b.AddCommand(cmd)
+	agent := NewAgent("caller", "Places calls")
+	agent.Instructions = "Call the user."
+	b.AddAgent(agent)
+
+	var buf bytes.Buffer
+	if err := b.GenerateArchive("claude", &buf); err != nil {
+		t.Fatalf("GenerateArchive failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("expected a zip archive: %v", err)
+	}
+	entries := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		entries[f.Name] = f
+	}
+	for _, name := range []string{
+		".claude-plugin/plugin.json",
+		"skills/phone-input/SKILL.md",
+		"commands/call.md",
+		"agents/caller.md",
+	} {
+		if entries[name] == nil {
+			t.Errorf("expected archive entry %q, got %v", name, slices.Sorted(maps.Keys(entries)))
+		}
+	}
+
+	// Entries match what Generate writes to disk.
+	tmpDir := t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join(tmpDir, "skills", "phone-input", "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := entries["skills/phone-input/SKILL.md"].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("archived SKILL.md = %q, want %q", got, want)
+	}
+
+	// Modes are normalized, not copied from the 0600 files on disk.
+	if mode := entries["skills/phone-input/SKILL.md"].Mode(); mode != 0644 {
+		t.Errorf("expected SKILL.md at mode 0644, got %v", mode)
+	}
+	if mode := entries["skills/"].Mode(); mode != fs.ModeDir|0755 {
+		t.Errorf("expected skills/ at mode 0755, got %v", mode)
+	}
+
+	// The same bundle archives to the same bytes.
+	var again bytes.Buffer
+	if err := b.GenerateArchive("claude", &again); err != nil {
+		t.Fatalf("GenerateArchive failed: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Error("expected archives of the same bundle to be identical")
+	}
+}
+
+func TestGenerateAllArchiveTarGz(t *testing.T) {
+	b := New("agentcall", "0.1.0", "Voice calling for AI assistants")
+	skill := NewSkill("phone-input", "Voice calling via phone")
+	skill.Instructions = "Use initiate_call to start a call..."
+	b.AddSkill(skill)
+	b.ArchiveFormat = ArchiveTarGz
+
+	var buf bytes.Buffer
+	if err := b.GenerateAllArchive(&buf); err != nil {
+		t.Fatalf("GenerateAllArchive failed: %v", err)
+	}
+
+	gr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("expected a gzip stream: %v", err)
+	}
+	tr := tar.NewReader(gr)
+	entries := make(map[string]bool)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[header.Name] = true
+		want := int64(0644)
+		if header.Typeflag == tar.TypeDir {
+			want = 0755
+		}
+		if header.Mode != want {
+			t.Errorf("expected %s at mode %o, got %o", header.Name, want, header.Mode)
+		}
+	}
+	for _, name := range []string{
+		"claude/.claude-plugin/plugin.json",
+		"claude/skills/phone-input/SKILL.md",
+		"kiro/",
+	} {
+		if !entries[name] {
+			t.Errorf("expected archive entry %q, got %v", name, slices.Sorted(maps.Keys(entries)))
+		}
+	}
+
+	b.ArchiveFormat = "rar"
+	if err := b.GenerateAllArchive(io.Discard); !errors.Is(err, ErrUnknownArchiveFormat) {
+		t.Errorf("expected ErrUnknownArchiveFormat, got %v", err)
+	}
+}
@@ -1,6 +1,36 @@
 package bundle
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrComponentMissing is returned when a component path referenced by
+	// the plugin manifest does not exist.
+	ErrComponentMissing = errors.New("referenced path does not exist")
+
+	// ErrComponentEmpty is returned when a component directory referenced by
+	// the plugin manifest is empty.
+	ErrComponentEmpty = errors.New("referenced directory is empty")
+
+	// ErrUnknownLayout is returned when Bundle.Layout names no layout in
+	// Layouts.
+	ErrUnknownLayout = errors.New("unknown layout")
+
+	// ErrUnknownMCPServer is returned when an agent selects an MCP server
+	// that neither the bundle nor any agent declares.
+	ErrUnknownMCPServer = errors.New("unknown MCP server")
+
+	// ErrUnsupportedComponent is returned in strict mode when a tool cannot
+	// receive a component of the bundle.
+	ErrUnsupportedComponent = errors.New("unsupported component")
+
+	// ErrUnknownArchiveFormat is returned when Bundle.ArchiveFormat names
+	// no supported ArchiveFormat.
+	ErrUnknownArchiveFormat = errors.New("unknown archive format")
+)
 
 // GenerateError represents an error during bundle generation.
 type GenerateError struct {
@@ -19,3 +49,64 @@ func (e *GenerateError) Error() string {
 func (e *GenerateError) Unwrap() error {
 	return e.Err
 }
+
+// LoadError represents an error loading a file used to build the bundle.
+type LoadError struct {
+	Path string
+	Err  error
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("bundle load %s: %v", e.Path, e.Err)
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// MCPConflictError reports an agent MCP server whose definition differs
+// from a server of the same name declared earlier by the bundle or another
+// agent. The earlier definition is kept.
+type MCPConflictError struct {
+	Server string
+	Agent  string
+	Source string
+}
+
+func (e *MCPConflictError) Error() string {
+	return fmt.Sprintf("agent %q declares MCP server %q differently from %s; keeping the earlier definition", e.Agent, e.Server, e.Source)
+}
+
+// UnsupportedComponentsError lists the bundle components that tools cannot
+// receive. Generate and GenerateAll return it when Strict is set.
+type UnsupportedComponentsError struct {
+	Gaps []ComponentGap
+}
+
+func (e *UnsupportedComponentsError) Error() string {
+	gaps := make([]string, len(e.Gaps))
+	for i, gap := range e.Gaps {
+		gaps[i] = gap.String()
+	}
+	return fmt.Sprintf("%v: %s", ErrUnsupportedComponent, strings.Join(gaps, ", "))
+}
+
+func (e *UnsupportedComponentsError) Unwrap() error {
+	return ErrUnsupportedComponent
+}
+
+// ComponentError reports a plugin manifest component path that does not
+// match the generated output.
+type ComponentError struct {
+	Component string
+	Path      string
+	Err       error
+}
+
+func (e *ComponentError) Error() string {
+	return fmt.Sprintf("plugin %s path %q: %v", e.Component, e.Path, e.Err)
+}
+
+func (e *ComponentError) Unwrap() error {
+	return e.Err
+}
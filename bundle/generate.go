@@ -1,16 +1,23 @@
 package bundle
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
 
+	agentscodex "github.com/agentplexus/assistantkit/agents/codex"
 	agentscore "github.com/agentplexus/assistantkit/agents/core"
 	commandscore "github.com/agentplexus/assistantkit/commands/core"
 	contextcore "github.com/agentplexus/assistantkit/context/core"
 	hooksclaude "github.com/agentplexus/assistantkit/hooks/claude"
 	hookscore "github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
 	mcpcore "github.com/agentplexus/assistantkit/mcp/core"
 	pluginsclaude "github.com/agentplexus/assistantkit/plugins/claude"
 	pluginscore "github.com/agentplexus/assistantkit/plugins/core"
@@ -18,13 +25,14 @@ import (
 
 	// Import adapters for side-effect registration
 	_ "github.com/agentplexus/assistantkit/agents/claude"
-	_ "github.com/agentplexus/assistantkit/agents/codex"
 	_ "github.com/agentplexus/assistantkit/agents/gemini"
 	_ "github.com/agentplexus/assistantkit/agents/kiro"
 	_ "github.com/agentplexus/assistantkit/commands/claude"
 	_ "github.com/agentplexus/assistantkit/commands/codex"
 	_ "github.com/agentplexus/assistantkit/commands/gemini"
+	_ "github.com/agentplexus/assistantkit/commands/windsurf"
 	_ "github.com/agentplexus/assistantkit/context/claude"
+	_ "github.com/agentplexus/assistantkit/context/jetbrains"
 	_ "github.com/agentplexus/assistantkit/hooks/claude"
 	_ "github.com/agentplexus/assistantkit/hooks/cursor"
 	_ "github.com/agentplexus/assistantkit/hooks/windsurf"
@@ -35,8 +43,10 @@ import (
 	_ "github.com/agentplexus/assistantkit/mcp/vscode"
 	_ "github.com/agentplexus/assistantkit/plugins/claude"
 	_ "github.com/agentplexus/assistantkit/plugins/gemini"
+	_ "github.com/agentplexus/assistantkit/plugins/windsurf"
 	_ "github.com/agentplexus/assistantkit/skills/claude"
 	_ "github.com/agentplexus/assistantkit/skills/codex"
+	_ "github.com/agentplexus/assistantkit/skills/windsurf"
 )
 
 // ToolConfig defines the output paths and supported components for a tool.
@@ -53,6 +63,14 @@ type ToolConfig struct {
 	HooksDir string
 	// HooksFile is the hooks config filename.
 	HooksFile string
+	// ConsolidatedHooks embeds hooks in the plugin manifest instead of
+	// writing them to HooksDir/HooksFile, for tools that prefer a single
+	// file.
+	ConsolidatedHooks bool
+	// ConsolidatedMCP embeds MCP servers in the plugin manifest, for tools
+	// whose plugin adapter writes them there. Otherwise they are left out
+	// of the manifest and written to MCPDir/MCPFile.
+	ConsolidatedMCP bool
 	// AgentsDir is the directory for agents.
 	AgentsDir string
 	// MCPDir is the directory for MCP config.
@@ -63,6 +81,11 @@ type ToolConfig struct {
 	ContextDir string
 	// ContextFile is the context filename.
 	ContextFile string
+	// SettingsDir is the directory for editor settings.
+	SettingsDir string
+	// SettingsFile is the editor settings filename. RecommendedSettings
+	// are merged into it.
+	SettingsFile string
 }
 
 // DefaultToolConfigs maps tool names to their configurations.
@@ -75,8 +98,10 @@ var DefaultToolConfigs = map[string]ToolConfig{
 		AgentsDir:   "agents",
 		// Note: Hooks and MCP are embedded in plugin.json for Claude (consolidated format)
 		// HooksDir and MCPDir are intentionally empty
-		ContextDir:  ".",
-		ContextFile: "CLAUDE.md",
+		ConsolidatedHooks: true,
+		ConsolidatedMCP:   true,
+		ContextDir:        ".",
+		ContextFile:       "CLAUDE.md",
 	},
 	"kiro": {
 		AgentsDir: ".kiro/agents",
@@ -88,6 +113,8 @@ var DefaultToolConfigs = map[string]ToolConfig{
 		PluginFile:  "gemini-extension.json",
 		CommandsDir: "commands",
 		AgentsDir:   "agents",
+		// MCP servers are embedded in gemini-extension.json
+		ConsolidatedMCP: true,
 	},
 	"cursor": {
 		HooksDir:    ".",
@@ -107,17 +134,58 @@ var DefaultToolConfigs = map[string]ToolConfig{
 		ContextFile: "AGENTS.md",
 	},
 	"vscode": {
-		MCPDir:  ".vscode",
-		MCPFile: "mcp.json",
+		MCPDir:       ".vscode",
+		MCPFile:      "mcp.json",
+		SettingsDir:  ".vscode",
+		SettingsFile: "settings.json",
+	},
+	"windsurf": {
+		PluginDir:   ".windsurf",
+		PluginFile:  "plugin.json",
+		SkillsDir:   ".windsurf/skills",
+		CommandsDir: ".windsurf/workflows",
+		HooksDir:    ".windsurf",
+		HooksFile:   "hooks.json",
+		// Note: Windsurf only reads MCP servers from the user-level
+		// ~/.codeium/windsurf/mcp_config.json, so MCPDir is intentionally empty
+	},
+	"jetbrains": {
+		// Junie reads project guidelines from .junie/guidelines.md
+		ContextDir:  ".junie",
+		ContextFile: "guidelines.md",
 	},
 }
 
-// Generate outputs the bundle for a specific tool to the given directory.
+// Generate outputs the bundle for a specific tool to the given directory,
+// using the paths of the bundle's layout. An empty outputDir uses the
+// layout's base directory (e.g., the XDG config directory for LayoutXDG).
 func (b *Bundle) Generate(tool, outputDir string) error {
-	config, ok := DefaultToolConfigs[tool]
+	layout, err := b.layout()
+	if err != nil {
+		return &GenerateError{Tool: tool, Err: err}
+	}
+	config, ok := layout.ToolConfig(tool)
 	if !ok {
 		return &GenerateError{Tool: tool, Err: fmt.Errorf("unsupported tool")}
 	}
+	outputDir, err = layout.Root(tool, outputDir)
+	if err != nil {
+		return &GenerateError{Tool: tool, Err: err}
+	}
+
+	// Check MCP features against the targeted tool version before writing
+	// anything, since Claude embeds MCP servers in its plugin manifest.
+	if err := b.checkMCPFeatures(tool); err != nil {
+		return err
+	}
+	if err := hookscore.ValidateEnv(b.SharedEnv); err != nil {
+		return &GenerateError{Tool: tool, Err: err}
+	}
+
+	// Report components the tool cannot receive instead of dropping them
+	if err := b.checkComponentGaps(b.ComponentGaps(tool)); err != nil {
+		return err
+	}
 
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
@@ -154,18 +222,46 @@ func (b *Bundle) Generate(tool, outputDir string) error {
 		return err
 	}
 
+	// Merge recommended editor settings for the MCP config
+	if err := b.generateSettings(tool, outputDir, config); err != nil {
+		return err
+	}
+
 	// Generate context
 	if err := b.generateContext(tool, outputDir, config); err != nil {
 		return err
 	}
 
-	return nil
+	// Generate the Codex agents index, after the context it extends
+	if err := b.generateCodexAgentsIndex(tool, outputDir, config); err != nil {
+		return err
+	}
+
+	// Check that the manifest's component paths match what was written
+	return b.verifyComponents(tool, outputDir, config)
 }
 
-// GenerateAll outputs the bundle for all supported tools.
+// GenerateAll outputs the bundle for all supported tools, each in a
+// subdirectory named after the tool unless the layout gives tools their
+// own roots.
 func (b *Bundle) GenerateAll(outputDir string) error {
+	// In strict mode, list every tool's gaps before writing anything
+	if b.Strict {
+		var gaps []ComponentGap
+		for _, tool := range SupportedTools {
+			gaps = append(gaps, b.ComponentGaps(tool)...)
+		}
+		if err := b.checkComponentGaps(gaps); err != nil {
+			return err
+		}
+	}
+
+	layout, _ := b.layout() // Generate reports an unknown layout
 	for _, tool := range SupportedTools {
 		toolDir := filepath.Join(outputDir, tool)
+		if len(layout.Roots) > 0 {
+			toolDir = outputDir
+		}
 		if err := b.Generate(tool, toolDir); err != nil {
 			return err
 		}
@@ -173,6 +269,19 @@ func (b *Bundle) GenerateAll(outputDir string) error {
 	return nil
 }
 
+// layout returns the bundle's layout profile.
+func (b *Bundle) layout() (Layout, error) {
+	name := b.Layout
+	if name == "" {
+		name = LayoutDefault
+	}
+	layout, ok := Layouts[name]
+	if !ok {
+		return Layout{}, fmt.Errorf("%w %q", ErrUnknownLayout, name)
+	}
+	return layout, nil
+}
+
 // generatePlugin generates the plugin manifest for a tool.
 func (b *Bundle) generatePlugin(tool, outputDir string, config ToolConfig) error {
 	if config.PluginDir == "" || config.PluginFile == "" {
@@ -203,14 +312,67 @@ func (b *Bundle) generatePlugin(tool, outputDir string, config ToolConfig) error
 		return nil // No adapter for this tool
 	}
 
-	if b.Hooks != nil && b.Hooks.HasHooks() && config.HooksDir != "" {
+	hooks := b.selectedHooks()
+	hasHooks := hooks != nil && hooks.HasHooks()
+	if hasHooks && config.HooksDir != "" && !config.ConsolidatedHooks {
 		b.Plugin.Hooks = filepath.Join(config.HooksDir, config.HooksFile)
 	}
 
-	if err := adapter.WriteFile(b.Plugin, pluginPath); err != nil {
+	plugin := b.pluginWithSharedEnv()
+	if !config.ConsolidatedMCP && len(plugin.MCPServers) > 0 {
+		withoutMCP := *plugin
+		withoutMCP.MCPServers = nil
+		plugin = &withoutMCP
+	}
+	if err := adapter.WriteFile(plugin, pluginPath); err != nil {
+		return &GenerateError{Tool: tool, Component: "plugin", Err: err}
+	}
+
+	if hasHooks && config.ConsolidatedHooks {
+		return b.embedHooks(tool, pluginPath)
+	}
+	return nil
+}
+
+// embedHooks writes the tool's hooks into the "hooks" key of the plugin
+// manifest at pluginPath, replacing any hooks file reference. The hooks
+// are converted by the tool's hooks adapter; when its output wraps them
+// in a "hooks" key, only the wrapped value is embedded.
+func (b *Bundle) embedHooks(tool, pluginPath string) error {
+	adapter, ok := hookscore.GetAdapter(tool)
+	if !ok {
+		return nil // No adapter for this tool
+	}
+
+	hooks, err := adapter.Marshal(b.toolHooks(tool))
+	if err != nil {
+		return &GenerateError{Tool: tool, Component: "hooks", Err: err}
+	}
+	var wrapped map[string]json.RawMessage
+	if err := json.Unmarshal(hooks, &wrapped); err != nil {
+		return &GenerateError{Tool: tool, Component: "hooks", Err: fmt.Errorf("consolidated hooks must be a JSON object: %w", err)}
+	}
+	if inner, ok := wrapped["hooks"]; ok && len(wrapped) == 1 {
+		hooks = inner
+	}
+
+	data, err := os.ReadFile(pluginPath)
+	if err != nil {
+		return &GenerateError{Tool: tool, Component: "plugin", Err: err}
+	}
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal(data, &manifest); err != nil {
 		return &GenerateError{Tool: tool, Component: "plugin", Err: err}
 	}
+	manifest["hooks"] = hooks
 
+	data, err = canonicaljson.Marshal(manifest)
+	if err != nil {
+		return &GenerateError{Tool: tool, Component: "plugin", Err: err}
+	}
+	if err := os.WriteFile(pluginPath, data, pluginscore.DefaultFileMode); err != nil {
+		return &GenerateError{Tool: tool, Component: "plugin", Err: err}
+	}
 	return nil
 }
 
@@ -230,7 +392,10 @@ func (b *Bundle) generateSkills(tool, outputDir string, config ToolConfig) error
 		return &GenerateError{Tool: tool, Component: "skills", Err: err}
 	}
 
-	for _, skill := range b.Skills {
+	for _, skill := range skillscore.SortByOrder(b.Skills) {
+		b.warnDeprecated(skill.DeprecationWarning())
+		b.Warnings = append(b.Warnings, skillscore.CheckExperimental(adapter, skill)...)
+		b.Warnings = append(b.Warnings, skillscore.CheckEnabledByDefault(adapter, skill)...)
 		if err := adapter.WriteSkillDir(skill, skillsDir); err != nil {
 			return &GenerateError{Tool: tool, Component: "skill:" + skill.Name, Err: err}
 		}
@@ -256,6 +421,13 @@ func (b *Bundle) generateCommands(tool, outputDir string, config ToolConfig) err
 	}
 
 	for _, cmd := range b.Commands {
+		if err := cmd.Validate(); err != nil {
+			return &GenerateError{Tool: tool, Component: "command:" + cmd.Name, Err: err}
+		}
+		b.Warnings = append(b.Warnings, commandscore.CheckExperimental(adapter, cmd)...)
+		b.warnDeprecated(cmd.DeprecationWarning())
+		b.Warnings = append(b.Warnings, commandscore.CheckEnabledByDefault(adapter, cmd)...)
+
 		filename := cmd.Name + adapter.FileExtension()
 		cmdPath := filepath.Join(commandsDir, filename)
 		if err := adapter.WriteFile(cmd, cmdPath); err != nil {
@@ -268,7 +440,8 @@ func (b *Bundle) generateCommands(tool, outputDir string, config ToolConfig) err
 
 // generateHooks generates hooks configuration for a tool.
 func (b *Bundle) generateHooks(tool, outputDir string, config ToolConfig) error {
-	if b.Hooks == nil || !b.Hooks.HasHooks() || config.HooksDir == "" {
+	hooks := b.selectedHooks()
+	if hooks == nil || !hooks.HasHooks() || config.HooksDir == "" || config.ConsolidatedHooks {
 		return nil
 	}
 
@@ -276,6 +449,7 @@ func (b *Bundle) generateHooks(tool, outputDir string, config ToolConfig) error
 	if !ok {
 		return nil // No adapter for this tool
 	}
+	hooks = b.toolHooks(tool)
 
 	hooksPath := filepath.Join(outputDir, config.HooksDir, config.HooksFile)
 
@@ -284,13 +458,69 @@ func (b *Bundle) generateHooks(tool, outputDir string, config ToolConfig) error
 		return &GenerateError{Tool: tool, Component: "hooks", Err: err}
 	}
 
-	if err := adapter.WriteFile(b.Hooks, hooksPath); err != nil {
+	if err := adapter.WriteFile(hooks, hooksPath); err != nil {
 		return &GenerateError{Tool: tool, Component: "hooks", Err: err}
 	}
 
 	return nil
 }
 
+// selectedHooks returns the bundle's hooks limited to HookGroups, with
+// SharedEnv exported before each command.
+func (b *Bundle) selectedHooks() *hookscore.Config {
+	if b.Hooks == nil {
+		return nil
+	}
+	return b.Hooks.FilterByGroup(b.HookGroups...).WithEnv(b.SharedEnv)
+}
+
+// pluginWithSharedEnv returns the plugin manifest with SharedEnv merged into
+// its MCP servers, leaving b.Plugin unchanged.
+func (b *Bundle) pluginWithSharedEnv() *pluginscore.Plugin {
+	if len(b.SharedEnv) == 0 || len(b.Plugin.MCPServers) == 0 {
+		return b.Plugin
+	}
+	plugin := *b.Plugin
+	plugin.MCPServers = make(map[string]pluginscore.MCPServer, len(b.Plugin.MCPServers))
+	for name, server := range b.Plugin.MCPServers {
+		env := make(map[string]string, len(b.SharedEnv)+len(server.Env))
+		for key, value := range b.SharedEnv {
+			env[key] = value
+		}
+		for key, value := range server.Env {
+			env[key] = value
+		}
+		server.Env = env
+		plugin.MCPServers[name] = server
+	}
+	return &plugin
+}
+
+// toolHooks returns the selected hooks as generated for tool: tools without
+// OS guards only receive the hooks for the target OS.
+func (b *Bundle) toolHooks(tool string) *hookscore.Config {
+	hooks := b.selectedHooks()
+	if !osGuardedHookTools[tool] {
+		hooks = hooks.FilterByOS(b.targetOS())
+	}
+	return hooks
+}
+
+// osGuardedHookTools lists tools whose hook adapters keep OS-specific hooks
+// by wrapping them in shell guards. Other tools only receive the hooks for
+// the bundle's target OS.
+var osGuardedHookTools = map[string]bool{
+	hooksclaude.AdapterName: true,
+}
+
+// targetOS returns the OS used to select hooks for tools without OS guards.
+func (b *Bundle) targetOS() string {
+	if b.TargetOS != "" {
+		return b.TargetOS
+	}
+	return runtime.GOOS
+}
+
 // generateAgents generates agents for a tool.
 func (b *Bundle) generateAgents(tool, outputDir string, config ToolConfig) error {
 	if len(b.Agents) == 0 || config.AgentsDir == "" {
@@ -301,6 +531,9 @@ func (b *Bundle) generateAgents(tool, outputDir string, config ToolConfig) error
 	if !ok {
 		return nil // No adapter for this tool
 	}
+	if err := agentscore.CheckFileNames(adapter, b.Agents); err != nil {
+		return &GenerateError{Tool: tool, Component: "agents", Err: err}
+	}
 
 	agentsDir := filepath.Join(outputDir, config.AgentsDir)
 	if err := os.MkdirAll(agentsDir, 0755); err != nil {
@@ -308,19 +541,87 @@ func (b *Bundle) generateAgents(tool, outputDir string, config ToolConfig) error
 	}
 
 	for _, agent := range b.Agents {
-		filename := agent.Name + adapter.FileExtension()
-		agentPath := filepath.Join(agentsDir, filename)
-		if err := adapter.WriteFile(agent, agentPath); err != nil {
+		if err := agent.Validate(); err != nil {
+			return &GenerateError{Tool: tool, Component: "agent:" + agent.Name, Err: err}
+		}
+		for _, name := range agent.MCPServers {
+			if !b.hasMCPServer(name) {
+				return &GenerateError{Tool: tool, Component: "agent:" + agent.Name, Err: fmt.Errorf("%w %q", ErrUnknownMCPServer, name)}
+			}
+		}
+
+		restricted, warnings, err := agentscore.RestrictTools(adapter, agent, b.Strict)
+		if err != nil {
 			return &GenerateError{Tool: tool, Component: "agent:" + agent.Name, Err: err}
 		}
+		b.Warnings = append(b.Warnings, warnings...)
+		b.Warnings = append(b.Warnings, agentscore.CheckResponseSchema(adapter, agent)...)
+		b.Warnings = append(b.Warnings, agentscore.CheckGuardrails(adapter, agent)...)
+		b.Warnings = append(b.Warnings, agentscore.CheckExperimental(adapter, agent)...)
+		b.warnDeprecated(agent.DeprecationWarning())
+
+		agentPath := filepath.Join(agentsDir, agentscore.FileName(adapter, agent))
+		if err := adapter.WriteFile(restricted, agentPath); err != nil {
+			return &GenerateError{Tool: tool, Component: "agent:" + agent.Name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// warnDeprecated records a deprecation warning once, however many tools
+// the deprecated component is generated for.
+func (b *Bundle) warnDeprecated(warning string) {
+	if warning == "" || slices.Contains(b.Warnings, warning) {
+		return
+	}
+	b.Warnings = append(b.Warnings, warning)
+}
+
+// checkMCPFeatures reports MCP servers, from the bundle or declared by
+// agents, that need a feature the targeted version of tool does not support
+// (see ToolVersions).
+func (b *Bundle) checkMCPFeatures(tool string) error {
+	toolVersion := b.ToolVersions[tool]
+	if toolVersion == "" {
+		return nil
+	}
+
+	adapter, ok := mcpcore.GetAdapter(tool)
+	if !ok {
+		return nil
+	}
+
+	cfg := mcpcore.NewConfig()
+	if b.MCP != nil {
+		for name, server := range b.MCP.Servers {
+			cfg.Servers[name] = server
+		}
+	}
+	for _, agent := range b.Agents {
+		for name, declared := range agent.MCP {
+			if _, ok := cfg.Servers[name]; !ok {
+				cfg.Servers[name] = agentMCPServer(declared)
+			}
+		}
 	}
 
+	unsupported, err := mcpcore.CheckFeatures(adapter, cfg, toolVersion)
+	if err != nil {
+		return &GenerateError{Tool: tool, Component: "mcp", Err: err}
+	}
+	for _, u := range unsupported {
+		if b.Strict {
+			return &GenerateError{Tool: tool, Component: "mcp", Err: u}
+		}
+		b.Warnings = append(b.Warnings, u.Error())
+	}
 	return nil
 }
 
 // generateMCP generates MCP server configuration for a tool.
 func (b *Bundle) generateMCP(tool, outputDir string, config ToolConfig) error {
-	if b.MCP == nil || len(b.MCP.Servers) == 0 || config.MCPDir == "" {
+	if b.MCP == nil || len(b.MCP.Servers) == 0 || config.MCPDir == "" || config.ConsolidatedMCP {
 		return nil
 	}
 
@@ -336,7 +637,7 @@ func (b *Bundle) generateMCP(tool, outputDir string, config ToolConfig) error {
 		return &GenerateError{Tool: tool, Component: "mcp", Err: err}
 	}
 
-	if err := adapter.WriteFile(b.MCP, mcpPath); err != nil {
+	if err := adapter.WriteFile(b.MCP.WithEnv(b.SharedEnv), mcpPath); err != nil {
 		return &GenerateError{Tool: tool, Component: "mcp", Err: err}
 	}
 
@@ -368,12 +669,57 @@ func (b *Bundle) generateContext(tool, outputDir string, config ToolConfig) erro
 	return nil
 }
 
+// generateCodexAgentsIndex appends an index of the agents to AGENTS.md:
+// to the project context written by generateContext, or otherwise to the
+// file already in outputDir, replacing the index a previous run appended.
+// The index is written alone only when there is no AGENTS.md yet.
+func (b *Bundle) generateCodexAgentsIndex(tool, outputDir string, config ToolConfig) error {
+	if tool != "codex" || !b.CodexAgentsIndex || len(b.Agents) == 0 || config.AgentsDir == "" {
+		return nil
+	}
+
+	indexPath := filepath.Join(outputDir, agentscodex.IndexFileName)
+	index := agentscodex.MarshalIndex(b.Agents, filepath.ToSlash(config.AgentsDir))
+
+	existing, err := os.ReadFile(indexPath)
+	if err != nil && !os.IsNotExist(err) {
+		return &GenerateError{Tool: tool, Component: "agents-index", Err: err}
+	}
+	existing = bytes.TrimRight(removeCodexAgentsIndex(existing), "\n")
+	if len(existing) > 0 {
+		index = append(append(existing, "\n\n"...), index...)
+	} else {
+		index = append([]byte("# AGENTS.md\n\n"), index...)
+	}
+
+	if err := os.WriteFile(indexPath, index, contextcore.DefaultFileMode); err != nil {
+		return &GenerateError{Tool: tool, Component: "agents-index", Err: err}
+	}
+	return nil
+}
+
+// removeCodexAgentsIndex returns data without the agents index section
+// MarshalIndex renders, which runs to the next level-two heading.
+func removeCodexAgentsIndex(data []byte) []byte {
+	marker := agentscodex.MarshalIndex(nil, "")
+	start := bytes.Index(data, marker)
+	if start < 0 {
+		return data
+	}
+	end := len(data)
+	if next := bytes.Index(data[start+len(marker):], []byte("\n## ")); next >= 0 {
+		end = start + len(marker) + next + 1
+	}
+	return append(data[:start:start], data[end:]...)
+}
+
 // generateClaudePlugin generates a consolidated plugin.json for Claude Code.
 // This format embeds MCP servers and hooks directly in plugin.json instead of
 // using separate files, providing a cleaner single-file configuration.
 func (b *Bundle) generateClaudePlugin(config ToolConfig, pluginPath string) error {
 	// Create Claude plugin from canonical plugin
 	claudePlugin := pluginsclaude.FromCanonical(b.Plugin)
+	claudePlugin.Keywords = agentscore.CollectCategories(b.Agents)
 
 	// Override component paths based on actual content
 	if len(b.Skills) > 0 && config.SkillsDir != "" {
@@ -386,23 +732,40 @@ func (b *Bundle) generateClaudePlugin(config ToolConfig, pluginPath string) erro
 		claudePlugin.Agents = "./" + config.AgentsDir + "/"
 	}
 
-	// Embed MCP servers directly in plugin.json
-	if b.MCP != nil && len(b.MCP.Servers) > 0 {
+	// Embed MCP servers directly in plugin.json, including those declared
+	// by individual agents
+	claudePlugin.MCPServers = nil
+	var servers map[string]mcpcore.Server
+	if config.ConsolidatedMCP {
+		var err error
+		servers, err = b.mergedMCPServers()
+		if err != nil {
+			return &GenerateError{Tool: "claude", Component: "plugin", Err: err}
+		}
+	}
+	if len(servers) > 0 {
 		claudePlugin.MCPServers = make(map[string]pluginsclaude.MCPServerConfig)
-		for name, server := range b.MCP.Servers {
-			claudePlugin.MCPServers[name] = pluginsclaude.MCPServerConfig{
+		for name, server := range servers {
+			server.MergeEnv(b.SharedEnv)
+			cfg := pluginsclaude.MCPServerConfig{
 				Command:  server.Command,
 				Args:     server.Args,
 				Env:      server.Env,
 				Cwd:      server.Cwd,
+				URL:      server.URL,
+				Headers:  server.Headers,
 				Disabled: !server.IsEnabled(),
 			}
+			if server.Transport != "" && server.Transport != mcpcore.TransportStdio {
+				cfg.Type = server.Transport.String()
+			}
+			claudePlugin.MCPServers[name] = cfg
 		}
 	}
 
 	// Embed hooks directly in plugin.json
-	if b.Hooks != nil && b.Hooks.HasHooks() {
-		claudePlugin.Hooks = convertHooksToClaudeFormat(b.Hooks)
+	if hooks := b.selectedHooks(); hooks != nil && hooks.HasHooks() && config.ConsolidatedHooks {
+		claudePlugin.Hooks = convertHooksToClaudeFormat(hooks)
 	}
 
 	// Ensure directory exists
@@ -410,19 +773,113 @@ func (b *Bundle) generateClaudePlugin(config ToolConfig, pluginPath string) erro
 		return &GenerateError{Tool: "claude", Component: "plugin", Err: err}
 	}
 
-	// Write plugin.json
-	data, err := json.MarshalIndent(claudePlugin, "", "  ")
+	// Write plugin.json, keeping keys added by hand to an existing file.
+	// Servers are written in Order, as in the standalone MCP config.
+	order := (&mcpcore.Config{Servers: servers}).OrderedServerNames()
+	data, err := canonicaljson.MarshalOrdered(claudePlugin, "mcpServers", order)
+	if err != nil {
+		return &GenerateError{Tool: "claude", Component: "plugin", Err: err}
+	}
+	data, err = mergePluginManifest(pluginPath, data, claudePlugin, order)
 	if err != nil {
 		return &GenerateError{Tool: "claude", Component: "plugin", Err: err}
 	}
 
-	if err := os.WriteFile(pluginPath, data, 0600); err != nil {
+	if err := os.WriteFile(pluginPath, data, pluginscore.DefaultFileMode); err != nil {
 		return &GenerateError{Tool: "claude", Component: "plugin", Err: err}
 	}
 
 	return nil
 }
 
+// mergedMCPServers returns the bundle's MCP servers merged with the servers
+// declared by each agent, in agent order. A server already declared with
+// the same definition is deduplicated; a conflicting definition keeps the
+// earlier one and is reported as a warning (an error when Strict is set).
+func (b *Bundle) mergedMCPServers() (map[string]mcpcore.Server, error) {
+	servers := make(map[string]mcpcore.Server)
+	sources := make(map[string]string)
+	if b.MCP != nil {
+		for name, server := range b.MCP.Servers {
+			servers[name] = server
+			sources[name] = "the bundle"
+		}
+	}
+
+	for _, agent := range b.Agents {
+		names := make([]string, 0, len(agent.MCP))
+		for name := range agent.MCP {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			server := agentMCPServer(agent.MCP[name])
+			existing, ok := servers[name]
+			if !ok {
+				servers[name] = server
+				sources[name] = fmt.Sprintf("agent %q", agent.Name)
+				continue
+			}
+			if sameServer(existing, server) {
+				continue
+			}
+			conflict := &MCPConflictError{Server: name, Agent: agent.Name, Source: sources[name]}
+			if b.Strict {
+				return nil, conflict
+			}
+			b.Warnings = append(b.Warnings, conflict.Error())
+		}
+	}
+	return servers, nil
+}
+
+// agentMCPServer converts an agent's MCP declaration to a canonical server:
+// remote over HTTP when it has a URL, otherwise stdio.
+func agentMCPServer(declared agentscore.MCPServer) mcpcore.Server {
+	if declared.URL != "" {
+		return mcpcore.Server{
+			Transport: mcpcore.TransportHTTP,
+			URL:       declared.URL,
+			Headers:   declared.Headers,
+		}
+	}
+	return mcpcore.Server{
+		Transport: mcpcore.TransportStdio,
+		Command:   declared.Command,
+		Args:      declared.Args,
+		Cwd:       declared.Cwd,
+		Env:       declared.Env,
+	}
+}
+
+// hasMCPServer reports whether the bundle or any agent declares the named
+// MCP server.
+func (b *Bundle) hasMCPServer(name string) bool {
+	if b.MCP != nil {
+		if _, ok := b.MCP.Servers[name]; ok {
+			return true
+		}
+	}
+	for _, agent := range b.Agents {
+		if _, ok := agent.MCP[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sameServer reports whether two servers launch the same process or reach
+// the same endpoint.
+func sameServer(a, b mcpcore.Server) bool {
+	return a.Command == b.Command &&
+		a.Cwd == b.Cwd &&
+		slices.Equal(a.Args, b.Args) &&
+		maps.Equal(a.Env, b.Env) &&
+		a.URL == b.URL &&
+		maps.Equal(a.Headers, b.Headers)
+}
+
 // convertHooksToClaudeFormat converts canonical hooks config to Claude's embedded format.
 func convertHooksToClaudeFormat(hooks *hookscore.Config) *pluginsclaude.HooksConfig {
 	// Use the Claude hooks adapter to convert canonical to Claude format
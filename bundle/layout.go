@@ -0,0 +1,162 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Built-in layout names.
+const (
+	// LayoutDefault writes each tool's files where DefaultToolConfigs puts them.
+	LayoutDefault = "default"
+
+	// LayoutFlat drops the tool's dot-directory, so Kiro agents go to
+	// agents/ rather than .kiro/agents/.
+	LayoutFlat = "flat"
+
+	// LayoutNested writes each tool's files under its project dot-directory
+	// (.claude/, .kiro/, .codex/, ...).
+	LayoutNested = "nested"
+
+	// LayoutXDG writes each tool's files to <tool>/ under the user's XDG
+	// config directory ($XDG_CONFIG_HOME, or ~/.config).
+	LayoutXDG = "xdg"
+)
+
+// Layout is a named profile of output paths for every tool.
+type Layout struct {
+	// Tools overrides the output paths per tool. Tools not listed use
+	// DefaultToolConfigs.
+	Tools map[string]ToolConfig
+
+	// Roots maps tool names to the directory, relative to the output
+	// directory, that the tool's paths are relative to. Tools not listed
+	// write directly to the output directory. Plugin manifests reference
+	// components relative to the root.
+	Roots map[string]string
+
+	// Base returns the output directory to use when Generate is given
+	// none. Nil means the current directory.
+	Base func() (string, error)
+}
+
+// toolDirs are the project directories each tool reads its config from.
+var toolDirs = map[string]string{
+	"claude":    ".claude",
+	"kiro":      ".kiro",
+	"gemini":    ".gemini",
+	"cursor":    ".cursor",
+	"codex":     ".codex",
+	"vscode":    ".vscode",
+	"windsurf":  ".windsurf",
+	"jetbrains": ".junie",
+}
+
+// Layouts maps layout names to their profiles. Add entries to define
+// custom layouts.
+var Layouts = map[string]Layout{
+	LayoutDefault: {},
+	LayoutFlat:    {Tools: flatToolConfigs()},
+	LayoutNested: {
+		Tools: flatToolConfigs(),
+		Roots: toolDirs,
+	},
+	LayoutXDG: {
+		Tools: flatToolConfigs(),
+		Roots: toolNameRoots(),
+		Base:  XDGConfigHome,
+	},
+}
+
+// LayoutNames returns the names of all layouts, sorted alphabetically.
+func LayoutNames() []string {
+	names := make([]string, 0, len(Layouts))
+	for name := range Layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ToolConfig returns the layout's output paths for a tool.
+func (l Layout) ToolConfig(tool string) (ToolConfig, bool) {
+	if config, ok := l.Tools[tool]; ok {
+		return config, true
+	}
+	config, ok := DefaultToolConfigs[tool]
+	return config, ok
+}
+
+// Root returns the directory a tool's paths are relative to when
+// generating into outputDir. An empty outputDir uses Base.
+func (l Layout) Root(tool, outputDir string) (string, error) {
+	if outputDir == "" && l.Base != nil {
+		base, err := l.Base()
+		if err != nil {
+			return "", err
+		}
+		outputDir = base
+	}
+	if outputDir == "" {
+		outputDir = "."
+	}
+	return filepath.Join(outputDir, l.Roots[tool]), nil
+}
+
+// XDGConfigHome returns $XDG_CONFIG_HOME, or ~/.config when it is unset.
+func XDGConfigHome() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config"), nil
+}
+
+// flatToolConfigs returns DefaultToolConfigs with each tool's dot-directory
+// removed from its paths.
+func flatToolConfigs() map[string]ToolConfig {
+	configs := make(map[string]ToolConfig, len(DefaultToolConfigs))
+	for tool, c := range DefaultToolConfigs {
+		flat := func(dir string) string {
+			return flattenDir(toolDirs[tool], dir)
+		}
+		c.PluginDir = flat(c.PluginDir)
+		c.SkillsDir = flat(c.SkillsDir)
+		c.CommandsDir = flat(c.CommandsDir)
+		c.HooksDir = flat(c.HooksDir)
+		c.AgentsDir = flat(c.AgentsDir)
+		c.MCPDir = flat(c.MCPDir)
+		c.ContextDir = flat(c.ContextDir)
+		c.SettingsDir = flat(c.SettingsDir)
+		configs[tool] = c
+	}
+	return configs
+}
+
+// flattenDir strips toolDir from the front of dir.
+func flattenDir(toolDir, dir string) string {
+	switch {
+	case dir == "" || toolDir == "":
+		return dir
+	case dir == toolDir:
+		return "."
+	case strings.HasPrefix(dir, toolDir+"/"):
+		return strings.TrimPrefix(dir, toolDir+"/")
+	default:
+		return dir
+	}
+}
+
+// toolNameRoots roots each tool at a directory named after it.
+func toolNameRoots() map[string]string {
+	roots := make(map[string]string, len(toolDirs))
+	for tool := range toolDirs {
+		roots[tool] = tool
+	}
+	return roots
+}
@@ -0,0 +1,84 @@
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+)
+
+// mergePluginManifest merges a freshly generated plugin manifest into the
+// manifest already at path. Keys the generator owns (the JSON fields of
+// manifest's type) are replaced by the generated values, or dropped when
+// no longer generated; any other key in the existing file is kept. When
+// there is no existing file, or it has no keys of its own, generated is
+// returned unchanged. Merged mcpServers keep serverOrder.
+func mergePluginManifest(path string, generated []byte, manifest any, serverOrder []string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return generated, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := decodeManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for key := range jsonFieldNames(manifest) {
+		delete(existing, key)
+	}
+	if len(existing) == 0 {
+		return generated, nil
+	}
+
+	fields, err := decodeManifest(generated)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		existing[key] = value
+	}
+	return canonicaljson.MarshalOrdered(existing, "mcpServers", serverOrder)
+}
+
+// decodeManifest decodes a JSON object, keeping numbers as written.
+func decodeManifest(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var manifest map[string]any
+	if err := dec.Decode(&manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// jsonFieldNames returns the JSON names of the exported fields of v's
+// struct type.
+func jsonFieldNames(v any) map[string]bool {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
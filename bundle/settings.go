@@ -0,0 +1,110 @@
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+	"github.com/agentplexus/assistantkit/internal/jsonc"
+	mcpcore "github.com/agentplexus/assistantkit/mcp/core"
+)
+
+// RecommendedSettings maps tool names to the editor settings merged into
+// the tool's settings file (see ToolConfig.SettingsFile) when the bundle
+// has MCP servers. Add entries to recommend further settings.
+var RecommendedSettings = map[string]map[string]any{
+	// Let Copilot Chat start the servers in .vscode/mcp.json.
+	"vscode": {"chat.mcp.enabled": true},
+}
+
+// generateSettings adds the tool's recommended settings to its settings
+// file. Keys the file already sets are left as they are.
+func (b *Bundle) generateSettings(tool, outputDir string, config ToolConfig) error {
+	settings := RecommendedSettings[tool]
+	if len(settings) == 0 || config.SettingsFile == "" || b.MCP == nil || len(b.MCP.Servers) == 0 {
+		return nil
+	}
+
+	settingsPath := filepath.Join(outputDir, config.SettingsDir, config.SettingsFile)
+	if err := mergeSettingsFile(settingsPath, settings); err != nil {
+		return &GenerateError{Tool: tool, Component: "settings", Err: err}
+	}
+	return nil
+}
+
+// mergeSettingsFile adds the keys in settings that the JSON object in path
+// does not set, creating the file when it does not exist. The existing file
+// may contain comments and trailing commas; missing keys are inserted after
+// its last member, so the rest of the file is kept byte for byte. Keys the
+// file already sets, even to a different value, are left alone.
+func mergeSettingsFile(path string, settings map[string]any) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		out, err := canonicaljson.Marshal(settings)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, out, mcpcore.DefaultFileMode)
+	}
+	if err != nil {
+		return err
+	}
+
+	standard := jsonc.Standardize(data)
+	var existing map[string]json.RawMessage
+	if err := json.Unmarshal(standard, &existing); err != nil || existing == nil {
+		return fmt.Errorf("parsing %s: expected a JSON object", path)
+	}
+
+	var missing []string
+	for key := range settings {
+		if _, ok := existing[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+
+	var members bytes.Buffer
+	for i, key := range missing {
+		name, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(settings[key])
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			members.WriteString(",")
+		}
+		fmt.Fprintf(&members, "\n  %s: %s", name, value)
+	}
+
+	// Insert after the last member, or after { in an empty object. The
+	// standardized copy has comments blanked, so its last non-space byte
+	// before the closing brace ends real content.
+	end := bytes.LastIndexByte(standard, '}')
+	last := len(bytes.TrimRight(standard[:end], " \t\r\n")) - 1
+	insert := members.String()
+	if standard[last] == '{' {
+		insert += "\n"
+	} else {
+		insert = "," + insert
+	}
+
+	out := make([]byte, 0, len(data)+len(insert))
+	out = append(out, data[:last+1]...)
+	out = append(out, insert...)
+	out = append(out, data[last+1:]...)
+	return os.WriteFile(path, out, mcpcore.DefaultFileMode)
+}
@@ -0,0 +1,217 @@
+package bundle
+
+import (
+	"fmt"
+
+	agentscore "github.com/agentplexus/assistantkit/agents/core"
+	commandscore "github.com/agentplexus/assistantkit/commands/core"
+	contextcore "github.com/agentplexus/assistantkit/context/core"
+	hookscore "github.com/agentplexus/assistantkit/hooks/core"
+	mcpcore "github.com/agentplexus/assistantkit/mcp/core"
+	pluginscore "github.com/agentplexus/assistantkit/plugins/core"
+	skillscore "github.com/agentplexus/assistantkit/skills/core"
+)
+
+// BundleSummary is an overview of a bundle's components and the tools
+// that will receive each component when generated.
+type BundleSummary struct {
+	// Name is the plugin name.
+	Name string `json:"name"`
+
+	// Version is the plugin version.
+	Version string `json:"version,omitempty"`
+
+	// Skills is the number of skills.
+	Skills int `json:"skills"`
+
+	// Commands is the number of commands.
+	Commands int `json:"commands"`
+
+	// Agents is the number of agents.
+	Agents int `json:"agents"`
+
+	// Hooks is the total number of hook actions across all events.
+	Hooks int `json:"hooks"`
+
+	// MCPServers is the number of MCP servers.
+	MCPServers int `json:"mcpServers"`
+
+	// HasContext indicates whether project context is set.
+	HasContext bool `json:"hasContext"`
+
+	// Targets maps component names (skills, commands, agents, hooks, mcp,
+	// context) to the supported tools that will receive them.
+	Targets map[string][]string `json:"targets"`
+}
+
+// Summary returns component counts and the target tools for each component.
+// Only components present in the bundle appear in Targets.
+func (b *Bundle) Summary() BundleSummary {
+	s := BundleSummary{
+		Skills:     len(b.Skills),
+		Commands:   len(b.Commands),
+		Agents:     len(b.Agents),
+		HasContext: b.Context != nil,
+		Targets:    make(map[string][]string),
+	}
+
+	if b.Plugin != nil {
+		s.Name = b.Plugin.Name
+		s.Version = b.Plugin.Version
+	}
+
+	if b.Hooks != nil {
+		s.Hooks = b.Hooks.HookCount()
+	}
+
+	if b.MCP != nil {
+		s.MCPServers = len(b.MCP.Servers)
+	}
+
+	layout, err := b.layout()
+	if err != nil {
+		layout = Layouts[LayoutDefault]
+	}
+	for _, tool := range SupportedTools {
+		config, ok := layout.ToolConfig(tool)
+		if !ok {
+			continue
+		}
+		for _, component := range bundleComponents {
+			if b.hasComponent(component) && b.receives(component, tool, config) {
+				s.Targets[component] = append(s.Targets[component], tool)
+			}
+		}
+	}
+
+	return s
+}
+
+// ComponentGap is a component of the bundle that a tool does not receive,
+// because its layout has no place for the component or no adapter for it is
+// registered.
+type ComponentGap struct {
+	Tool      string
+	Component string
+}
+
+// String returns the gap as "tool/component".
+func (g ComponentGap) String() string {
+	return g.Tool + "/" + g.Component
+}
+
+// ComponentGaps returns the components of the bundle that tool does not
+// receive. Generate skips them with a warning, or fails when Strict is set.
+func (b *Bundle) ComponentGaps(tool string) []ComponentGap {
+	layout, err := b.layout()
+	if err != nil {
+		return nil
+	}
+	config, ok := layout.ToolConfig(tool)
+	if !ok {
+		return nil
+	}
+	var gaps []ComponentGap
+	for _, component := range bundleComponents {
+		if b.hasComponent(component) && !b.receives(component, tool, config) {
+			gaps = append(gaps, ComponentGap{Tool: tool, Component: component})
+		}
+	}
+	return gaps
+}
+
+// checkComponentGaps reports the gaps as warnings, or as an
+// *UnsupportedComponentsError when Strict is set.
+func (b *Bundle) checkComponentGaps(gaps []ComponentGap) error {
+	if len(gaps) == 0 {
+		return nil
+	}
+	if b.Strict {
+		return &UnsupportedComponentsError{Gaps: gaps}
+	}
+	for _, gap := range gaps {
+		b.Warnings = append(b.Warnings, fmt.Sprintf("%s does not support %s; skipped", gap.Tool, gap.Component))
+	}
+	return nil
+}
+
+// bundleComponents names the components of a bundle, as used in
+// BundleSummary.Targets and ComponentGap.
+var bundleComponents = []string{"skills", "commands", "agents", "hooks", "mcp", "context"}
+
+// hasComponent reports whether the bundle has any of component.
+func (b *Bundle) hasComponent(component string) bool {
+	switch component {
+	case "skills":
+		return len(b.Skills) > 0
+	case "commands":
+		return len(b.Commands) > 0
+	case "agents":
+		return len(b.Agents) > 0
+	case "hooks":
+		return b.Hooks != nil && b.Hooks.HookCount() > 0
+	case "mcp":
+		return b.MCP != nil && len(b.MCP.Servers) > 0
+	case "context":
+		return b.Context != nil
+	}
+	return false
+}
+
+// receives reports whether component is generated for the tool: the
+// tool's config has a place for it and an adapter is registered.
+func (b *Bundle) receives(component, tool string, config ToolConfig) bool {
+	switch component {
+	case "skills":
+		_, ok := skillscore.GetAdapter(tool)
+		return ok && config.SkillsDir != ""
+	case "commands":
+		_, ok := commandscore.GetAdapter(tool)
+		return ok && config.CommandsDir != ""
+	case "agents":
+		_, ok := agentscore.GetAdapter(tool)
+		return ok && config.AgentsDir != ""
+	case "hooks":
+		return b.receivesHooks(tool, config)
+	case "mcp":
+		return b.receivesMCP(tool, config)
+	case "context":
+		_, ok := contextcore.GetConverter(tool)
+		return ok && config.ContextFile != ""
+	}
+	return false
+}
+
+// receivesHooks reports whether hooks are generated for the tool, either
+// embedded in its plugin manifest (ConsolidatedHooks) or in a hooks file.
+func (b *Bundle) receivesHooks(tool string, config ToolConfig) bool {
+	if config.ConsolidatedHooks {
+		if config.PluginDir == "" || config.PluginFile == "" {
+			return false
+		}
+		if _, ok := pluginscore.GetAdapter(tool); !ok {
+			return false
+		}
+	} else if config.HooksDir == "" {
+		return false
+	}
+	_, ok := hookscore.GetAdapter(tool)
+	return ok
+}
+
+// receivesMCP reports whether MCP servers are generated for the tool, either
+// embedded in its plugin manifest (ConsolidatedMCP) or in an MCP config file.
+func (b *Bundle) receivesMCP(tool string, config ToolConfig) bool {
+	if config.ConsolidatedMCP {
+		if config.PluginDir == "" || config.PluginFile == "" {
+			return false
+		}
+		_, ok := pluginscore.GetAdapter(tool)
+		return ok
+	}
+	if config.MCPDir == "" {
+		return false
+	}
+	_, ok := mcpcore.GetAdapter(tool)
+	return ok
+}
@@ -0,0 +1,75 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+
+	pluginscore "github.com/agentplexus/assistantkit/plugins/core"
+)
+
+// VerifyComponents checks the component paths a plugin manifest references
+// against the files under dir: each skills, commands, and agents directory
+// must exist and be non-empty, and the hooks file must exist. Paths are
+// relative to dir. It returns one error per mismatch, in that order.
+func VerifyComponents(plugin *pluginscore.Plugin, dir string) []*ComponentError {
+	if plugin == nil {
+		return nil
+	}
+
+	var mismatches []*ComponentError
+	for _, ref := range []struct{ component, path string }{
+		{"skills", plugin.Skills},
+		{"commands", plugin.Commands},
+		{"agents", plugin.Agents},
+	} {
+		if ref.path == "" {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(dir, ref.path))
+		switch {
+		case err != nil:
+			mismatches = append(mismatches, &ComponentError{Component: ref.component, Path: ref.path, Err: ErrComponentMissing})
+		case len(entries) == 0:
+			mismatches = append(mismatches, &ComponentError{Component: ref.component, Path: ref.path, Err: ErrComponentEmpty})
+		}
+	}
+
+	if plugin.Hooks != "" {
+		if _, err := os.Stat(filepath.Join(dir, plugin.Hooks)); err != nil {
+			mismatches = append(mismatches, &ComponentError{Component: "hooks", Path: plugin.Hooks, Err: ErrComponentMissing})
+		}
+	}
+
+	return mismatches
+}
+
+// verifyComponents checks the generated output against the plugin manifest
+// for tools that write one. Only components the tool supports are checked,
+// since the manifest omits the rest.
+func (b *Bundle) verifyComponents(tool, outputDir string, config ToolConfig) error {
+	if b.Plugin == nil || config.PluginDir == "" || config.PluginFile == "" {
+		return nil
+	}
+
+	var refs pluginscore.Plugin
+	if config.SkillsDir != "" {
+		refs.Skills = b.Plugin.Skills
+	}
+	if config.CommandsDir != "" {
+		refs.Commands = b.Plugin.Commands
+	}
+	if config.AgentsDir != "" {
+		refs.Agents = b.Plugin.Agents
+	}
+	if config.HooksDir != "" && !config.ConsolidatedHooks {
+		refs.Hooks = b.Plugin.Hooks
+	}
+
+	for _, mismatch := range VerifyComponents(&refs, outputDir) {
+		if b.Strict {
+			return &GenerateError{Tool: tool, Component: "plugin", Err: mismatch}
+		}
+		b.Warnings = append(b.Warnings, tool+": "+mismatch.Error())
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/skills"
+	"github.com/spf13/cobra"
+)
+
+var (
+	convertKind   string
+	convertFrom   string
+	convertTo     string
+	convertInput  string
+	convertOutput string
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert an agent or skill between tool formats",
+	Long: `Convert a single agent or skill file from one tool format to another.
+
+Fields the target format cannot represent are reported as warnings on stderr.
+
+Example:
+  assistantkit convert --kind=skill --from=claude --to=kiro --input=skills/review/SKILL.md
+  assistantkit convert --kind=agent --from=claude --to=gemini --input=agents/reviewer.md --output=reviewer.toml`,
+	RunE: runConvert,
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+
+	convertCmd.Flags().StringVar(&convertKind, "kind", "agent", "Kind of definition to convert (agent, skill)")
+	convertCmd.Flags().StringVar(&convertFrom, "from", "", "Source format (e.g., claude, codex, kiro)")
+	convertCmd.Flags().StringVar(&convertTo, "to", "", "Target format (e.g., claude, codex, kiro)")
+	convertCmd.Flags().StringVar(&convertInput, "input", "", "Input file path")
+	convertCmd.Flags().StringVar(&convertOutput, "output", "", "Output file path (default: stdout)")
+	_ = convertCmd.MarkFlagRequired("from")
+	_ = convertCmd.MarkFlagRequired("to")
+	_ = convertCmd.MarkFlagRequired("input")
+}
+
+func runConvert(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(convertInput)
+	if err != nil {
+		return fmt.Errorf("reading input: %w", err)
+	}
+
+	var out []byte
+	var warnings []string
+	switch convertKind {
+	case "agent":
+		out, warnings, err = agents.ConvertWithWarnings(data, convertFrom, convertTo)
+	case "skill":
+		out, warnings, err = skills.ConvertWithWarnings(data, convertFrom, convertTo)
+	default:
+		return fmt.Errorf("unknown kind %q (expected agent or skill)", convertKind)
+	}
+	if err != nil {
+		return fmt.Errorf("converting: %w", err)
+	}
+
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+
+	if convertOutput == "" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+
+	if err := os.WriteFile(convertOutput, out, 0600); err != nil {
+		return fmt.Errorf("writing output: %w", err)
+	}
+	fmt.Printf("Converted %s -> %s: %s\n", convertFrom, convertTo, convertOutput)
+	return nil
+}
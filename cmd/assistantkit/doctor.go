@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/agentplexus/assistantkit/mcp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorMCPConfig string
+	doctorMCPFormat string
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that configured MCP servers respond",
+	Long: `Check each MCP server in a config. Servers with a healthCheck command run
+it; other stdio servers pass when their command is on PATH. Remote servers
+without a healthCheck and disabled servers are skipped.
+
+Exits with an error when any check fails.
+
+Example:
+  assistantkit doctor
+  assistantkit doctor --mcp-config=.mcp.json --format=claude`,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().StringVar(&doctorMCPConfig, "mcp-config", "mcp.json", "Path to the MCP configuration")
+	doctorCmd.Flags().StringVar(&doctorMCPFormat, "format", "canonical", "Config format (canonical, claude, cursor, ...)")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	cfg, err := readMCPConfig(doctorMCPConfig, doctorMCPFormat)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range cfg.CheckHealth(cmd.Context()) {
+		desc := ""
+		if server, ok := cfg.GetServer(result.Name); ok && server.Description != "" {
+			desc = " (" + server.Description + ")"
+		}
+		fmt.Printf("[%s] %s%s: %s\n", result.Status, result.Name, desc, result.Detail)
+		if result.Status == mcp.HealthFailed {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d MCP server(s) failed health checks", failed)
+	}
+	return nil
+}
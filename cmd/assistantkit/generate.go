@@ -5,15 +5,28 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/agentplexus/assistantkit/generate"
 	"github.com/spf13/cobra"
 )
 
 var (
-	genSpecsDir  string
-	genTarget    string
-	genOutputDir string
+	genSpecsDir      string
+	genTarget        string
+	genOutputDir     string
+	genMergeStrategy string
+	genExpandEnv     bool
+	genEnvFile       string
+	genToolAliases   string
+	genLocale        string
+	genOnly          []string
+	genOverview      bool
+	genFormatVers    map[string]string
+	genToolVers      map[string]string
+	genTimeout       time.Duration
+	genBackup        bool
+	genStrict        bool
 )
 
 var generateCmd = &cobra.Command{
@@ -36,17 +49,61 @@ Each deployment target receives a complete plugin:
   - kiro/kiro-cli: POWER.md + mcp.json or agents/*.json
   - gemini/gemini-cli: gemini-extension.json, commands/, agents/
 
+Existing output files are replaced by default. Use --merge-strategy=skip to
+leave them untouched, or --merge-strategy=merge to deep-merge generated JSON
+into existing JSON files.
+
+Use --only to regenerate a single spec while iterating on it. The named
+agents, skills, or commands are still resolved against the whole specs
+directory, so inheritance and includes work as usual. Outputs that list
+every spec (the Kiro POWER.md and README, the overview, and the generation
+manifest) are left as they are.
+
+Use --locale to emit each agent's localizedInstructions variant for a locale
+(e.g., fr). Agents without that variant keep their default instructions.
+
+Use --expand-env to resolve ${VAR} placeholders in MCP server commands, args,
+URLs, and headers from the environment. --env-file additionally reads values from a .env
+file; variables set in the process environment take precedence.
+
+Use --tool-version to name the tool release you are targeting (e.g.,
+claude=1.0.20). MCP servers, including those declared by agents, that need
+a feature the release does not support are reported as warnings, or fail
+generation with --strict.
+
+Use --timeout to bound a run. When it elapses generation stops, and the
+target being written is left untouched rather than half-written.
+
+Use --strict to fail, listing each gap, when a target's platform cannot
+receive a loaded component (e.g., commands for Kiro or skills for Gemini)
+instead of skipping it with a warning.
+
+Use --backup to snapshot the files each target's generation overwrites
+under .assistantkit/backups/; 'assistantkit rollback' restores the latest
+snapshot. Targets that write to the output directory itself, or to .git,
+cannot be backed up.
+
 Example:
   assistantkit generate
-  assistantkit generate --specs=specs --target=local --output=.`,
+  assistantkit generate --specs=specs --target=local --output=.
+  assistantkit generate --merge-strategy=skip
+  assistantkit generate --only=reviewer --only=release
+  assistantkit generate --expand-env --env-file=.env
+  assistantkit generate --locale=fr
+  assistantkit generate --tool-version=claude=1.0.20
+  assistantkit generate --timeout=30s
+  assistantkit generate --backup
+  assistantkit generate --strict`,
 	RunE: runGenerate,
 }
 
 var (
-	specDir    string
-	outputDir  string
-	platforms  []string
-	configFile string
+	specDir       string
+	outputDir     string
+	platforms     []string
+	configFile    string
+	pluginsOnly   []string
+	pluginsStrict bool
 )
 
 var generatePluginsCmd = &cobra.Command{
@@ -81,6 +138,8 @@ The specs directory should contain:
   - deployments/: Deployment definitions (*.json)
 
 Each target in the deployment file specifies a platform and output directory.
+Existing output files are always replaced; use 'generate agents' or
+'generate' with --merge-strategy to keep or merge them.
 
 Supported platforms:
   - claude-code: Claude Code agent markdown files
@@ -96,6 +155,12 @@ var (
 	agentsSpecDir   string
 	agentsTarget    string
 	agentsOutputDir string
+	agentsOnly      []string
+	agentsAliases   string
+	agentsLocale    string
+	agentsOverview  bool
+	agentsFormatVer map[string]string
+	agentsMerge     string
 )
 
 var generateAgentsCmd = &cobra.Command{
@@ -110,9 +175,12 @@ The specs directory should contain:
   - agents/: Agent definitions (*.md with YAML frontmatter)
   - deployments/: Deployment definitions (*.json, defaults to local.json)
 
+Existing output files are replaced by default; see --merge-strategy.
+
 Example:
   assistantkit generate agents
-  assistantkit generate agents --specs=specs --target=local --output=.`,
+  assistantkit generate agents --specs=specs --target=local --output=.
+  assistantkit generate agents --merge-strategy=skip`,
 	RunE: runGenerateAgents,
 }
 
@@ -121,6 +189,7 @@ var (
 	allTarget    string
 	allOutputDir string
 	allPlatforms []string
+	allStrict    bool
 )
 
 var generateAllCmd = &cobra.Command{
@@ -155,10 +224,24 @@ func init() {
 	generateCmd.Flags().StringVar(&genSpecsDir, "specs", "specs", "Path to unified specs directory")
 	generateCmd.Flags().StringVar(&genTarget, "target", "local", "Deployment target (looks for specs/deployments/<target>.json)")
 	generateCmd.Flags().StringVar(&genOutputDir, "output", ".", "Output base directory for relative paths")
+	generateCmd.Flags().BoolVar(&genExpandEnv, "expand-env", false, "Resolve ${VAR} placeholders in MCP servers and shared hooks from the environment")
+	generateCmd.Flags().StringVar(&genEnvFile, "env-file", "", "Path to a .env file used for placeholder expansion (implies --expand-env)")
+	generateCmd.Flags().StringVar(&genToolAliases, "tool-aliases", "", "YAML file of tool name aliases per adapter (default: specs/tool-aliases.yaml if exists)")
+	generateCmd.Flags().StringVar(&genLocale, "locale", "", "Locale of agent instructions to emit (default: the default instructions)")
+	generateCmd.Flags().StringSliceVar(&genOnly, "only", nil, "Only generate the named agents, skills, or commands (repeatable)")
+	generateCmd.Flags().BoolVar(&genOverview, "overview", false, "Write overview.md with a Mermaid diagram of agents and handoffs")
+	generateCmd.Flags().StringToStringVar(&genFormatVers, "format-version", nil, "Pin the config format version expected per tool (e.g., claude=1)")
+	generateCmd.Flags().StringToStringVar(&genToolVers, "tool-version", nil, "Target tool versions to check MCP server features against (e.g., claude=1.0.20)")
+	generateCmd.Flags().DurationVar(&genTimeout, "timeout", 0, "Abort generation after this duration (e.g., 30s; default: no timeout)")
+	generateCmd.Flags().BoolVar(&genStrict, "strict", false, "Fail when a target cannot receive a loaded component instead of skipping it")
+	generateCmd.Flags().BoolVar(&genBackup, "backup", false, "Snapshot existing target output to .assistantkit/backups before overwriting it")
+	generateCmd.Flags().StringVar(&genMergeStrategy, "merge-strategy", string(generate.MergeReplace), "How to handle existing output files (replace, skip, merge)")
 
 	generatePluginsCmd.Flags().StringVar(&specDir, "spec", "plugins/spec", "Path to canonical spec directory")
 	generatePluginsCmd.Flags().StringVar(&outputDir, "output", "plugins", "Output directory for generated plugins")
 	generatePluginsCmd.Flags().StringSliceVar(&platforms, "platforms", []string{"claude", "kiro"}, "Platforms to generate (claude,kiro,gemini)")
+	generatePluginsCmd.Flags().StringSliceVar(&pluginsOnly, "only", nil, "Only generate the named agents, skills, or commands (repeatable)")
+	generatePluginsCmd.Flags().BoolVar(&pluginsStrict, "strict", false, "Fail when a platform cannot receive a loaded component instead of skipping it")
 	generatePluginsCmd.Flags().StringVar(&configFile, "config", "", "Config file (default: assistantkit.yaml if exists)")
 
 	generateDeploymentCmd.Flags().StringVar(&deploymentSpecDir, "specs", "specs", "Path to multi-agent-spec directory")
@@ -168,11 +251,18 @@ func init() {
 	generateAgentsCmd.Flags().StringVar(&agentsSpecDir, "specs", "specs", "Path to specs directory")
 	generateAgentsCmd.Flags().StringVar(&agentsTarget, "target", "local", "Deployment target (looks for specs/deployments/<target>.json)")
 	generateAgentsCmd.Flags().StringVar(&agentsOutputDir, "output", ".", "Output base directory (repo root)")
+	generateAgentsCmd.Flags().StringSliceVar(&agentsOnly, "only", nil, "Only generate the named agents (repeatable)")
+	generateAgentsCmd.Flags().StringVar(&agentsAliases, "tool-aliases", "", "YAML file of tool name aliases per adapter (default: specs/tool-aliases.yaml if exists)")
+	generateAgentsCmd.Flags().StringVar(&agentsLocale, "locale", "", "Locale of agent instructions to emit (default: the default instructions)")
+	generateAgentsCmd.Flags().BoolVar(&agentsOverview, "overview", false, "Write overview.md with a Mermaid diagram of agents and handoffs")
+	generateAgentsCmd.Flags().StringToStringVar(&agentsFormatVer, "format-version", nil, "Pin the config format version expected per tool (e.g., claude=1)")
+	generateAgentsCmd.Flags().StringVar(&agentsMerge, "merge-strategy", string(generate.MergeReplace), "How to handle existing output files (replace, skip, merge)")
 
 	generateAllCmd.Flags().StringVar(&allSpecsDir, "specs", "specs", "Path to unified specs directory")
 	generateAllCmd.Flags().StringVar(&allTarget, "target", "local", "Deployment target (looks for specs/deployments/<target>.json)")
 	generateAllCmd.Flags().StringVar(&allOutputDir, "output", ".", "Output base directory (repo root)")
 	generateAllCmd.Flags().StringSliceVar(&allPlatforms, "platforms", []string{"claude", "kiro", "gemini"}, "Platforms to generate")
+	generateAllCmd.Flags().BoolVar(&allStrict, "strict", false, "Fail when a platform cannot receive a loaded component instead of skipping it")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
@@ -192,6 +282,11 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("specs directory not found: %s", absSpecsDir)
 	}
 
+	strategy, err := generate.ParseMergeStrategy(genMergeStrategy)
+	if err != nil {
+		return err
+	}
+
 	// Print header
 	fmt.Println("=== AssistantKit Generator ===")
 	fmt.Printf("Specs directory: %s\n", absSpecsDir)
@@ -200,7 +295,20 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Generate using the unified Generate function
-	result, err := generate.Generate(absSpecsDir, genTarget, absOutputDir)
+	result, err := generate.GenerateContext(cmd.Context(), absSpecsDir, genTarget, absOutputDir, generate.Options{
+		MergeStrategy:  strategy,
+		ExpandEnv:      genExpandEnv,
+		EnvFile:        genEnvFile,
+		Only:           genOnly,
+		ToolAliases:    genToolAliases,
+		Locale:         genLocale,
+		Overview:       genOverview,
+		FormatVersions: genFormatVers,
+		ToolVersions:   genToolVers,
+		Timeout:        genTimeout,
+		Backup:         genBackup,
+		Strict:         genStrict,
+	})
 	if err != nil {
 		return fmt.Errorf("generating: %w", err)
 	}
@@ -217,6 +325,15 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  - %s: %s\n", target, dir)
 	}
 
+	printWarnings(result.Warnings)
+	printMergeReport(result.SkippedFiles, result.MergedFiles)
+	if result.OverviewPath != "" {
+		fmt.Printf("\nOverview: %s\n", result.OverviewPath)
+	}
+	if result.BackupPath != "" {
+		fmt.Printf("\nBackup: %s\n", result.BackupPath)
+	}
+
 	fmt.Println("\nDone!")
 	return nil
 }
@@ -251,7 +368,7 @@ func runGenerateDeployment(cmd *cobra.Command, args []string) error {
 
 	// Generate deployment
 	result, err := generate.Deployment(absSpecsDir, absDeploymentFile)
-	if err != nil {
+	if result == nil {
 		return fmt.Errorf("generating deployment: %w", err)
 	}
 
@@ -259,10 +376,18 @@ func runGenerateDeployment(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Team: %s\n", result.TeamName)
 	fmt.Printf("Loaded: %d agents\n\n", result.AgentCount)
 
-	fmt.Println("Generated targets:")
-	for _, target := range result.TargetsGenerated {
-		dir := result.GeneratedDirs[target]
-		fmt.Printf("  - %s: %s\n", target, dir)
+	fmt.Println("Targets:")
+	for _, target := range result.Targets {
+		if target.Err != nil {
+			fmt.Printf("  - %s: FAILED: %v\n", target.Name, target.Err)
+			continue
+		}
+		fmt.Printf("  - %s: %s\n", target.Name, target.OutputDir)
+	}
+	printWarnings(result.Warnings)
+
+	if failed := result.Failed(); len(failed) > 0 {
+		return fmt.Errorf("%d of %d targets failed", len(failed), len(result.Targets))
 	}
 
 	fmt.Println("\nDone!")
@@ -297,7 +422,7 @@ func runGeneratePlugins(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Generate plugins
-	result, err := generate.Plugins(absSpecDir, absOutputDir, platforms)
+	result, err := generate.PluginsWithOptions(absSpecDir, absOutputDir, platforms, generate.Options{Only: pluginsOnly, Strict: pluginsStrict})
 	if err != nil {
 		return fmt.Errorf("generating plugins: %w", err)
 	}
@@ -309,6 +434,7 @@ func runGeneratePlugins(cmd *cobra.Command, args []string) error {
 	for platform, dir := range result.GeneratedDirs {
 		fmt.Printf("Generated %s: %s\n", platform, dir)
 	}
+	printWarnings(result.Warnings)
 
 	fmt.Println("\nDone!")
 	return nil
@@ -341,8 +467,13 @@ func runGenerateAgents(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Output directory: %s\n", absOutputDir)
 	fmt.Println()
 
+	strategy, err := generate.ParseMergeStrategy(agentsMerge)
+	if err != nil {
+		return err
+	}
+
 	// Generate agents
-	result, err := generate.Agents(absSpecsDir, agentsTarget, absOutputDir)
+	result, err := generate.AgentsWithOptions(absSpecsDir, agentsTarget, absOutputDir, generate.Options{MergeStrategy: strategy, Only: agentsOnly, ToolAliases: agentsAliases, Locale: agentsLocale, Overview: agentsOverview, FormatVersions: agentsFormatVer})
 	if err != nil {
 		return fmt.Errorf("generating agents: %w", err)
 	}
@@ -356,6 +487,11 @@ func runGenerateAgents(cmd *cobra.Command, args []string) error {
 		dir := result.GeneratedDirs[target]
 		fmt.Printf("  - %s: %s\n", target, dir)
 	}
+	if result.OverviewPath != "" {
+		fmt.Printf("\nOverview: %s\n", result.OverviewPath)
+	}
+	printWarnings(result.Warnings)
+	printMergeReport(result.SkippedFiles, result.MergedFiles)
 
 	fmt.Println("\nDone!")
 	return nil
@@ -393,7 +529,7 @@ func runGenerateAll(cmd *cobra.Command, args []string) error {
 	pluginsOutputDir := filepath.Join(absOutputDir, "plugins")
 	fmt.Println("1. Generating plugins (commands, skills, manifest)...")
 
-	pluginResult, err := generate.Plugins(absSpecsDir, pluginsOutputDir, allPlatforms)
+	pluginResult, err := generate.PluginsWithOptions(absSpecsDir, pluginsOutputDir, allPlatforms, generate.Options{Strict: allStrict})
 	if err != nil {
 		return fmt.Errorf("generating plugins: %w", err)
 	}
@@ -402,6 +538,9 @@ func runGenerateAll(cmd *cobra.Command, args []string) error {
 	for platform, dir := range pluginResult.GeneratedDirs {
 		fmt.Printf("   Generated %s: %s\n", platform, dir)
 	}
+	for _, warning := range pluginResult.Warnings {
+		fmt.Printf("   Warning: %s\n", warning)
+	}
 	fmt.Println()
 
 	// Step 2: Generate agents from deployment target
@@ -418,7 +557,38 @@ func runGenerateAll(cmd *cobra.Command, args []string) error {
 		dir := agentResult.GeneratedDirs[target]
 		fmt.Printf("   Generated %s: %s\n", target, dir)
 	}
+	for _, warning := range agentResult.Warnings {
+		fmt.Printf("   Warning: %s\n", warning)
+	}
 
 	fmt.Println("\nDone!")
 	return nil
 }
+
+// printWarnings prints generation warnings, if any.
+func printWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Println("\nWarnings:")
+	for _, warning := range warnings {
+		fmt.Printf("  - %s\n", warning)
+	}
+}
+
+// printMergeReport lists the existing files a merge strategy left untouched
+// or merged.
+func printMergeReport(skipped, merged []string) {
+	if len(skipped) > 0 {
+		fmt.Println("\nSkipped existing files:")
+		for _, path := range skipped {
+			fmt.Printf("  - %s\n", path)
+		}
+	}
+	if len(merged) > 0 {
+		fmt.Println("\nMerged existing files:")
+		for _, path := range merged {
+			fmt.Printf("  - %s\n", path)
+		}
+	}
+}
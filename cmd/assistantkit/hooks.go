@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/bundle"
+	"github.com/agentplexus/assistantkit/hooks"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksConfigPath string
+	hooksFormat     string
+	simulateEvent   string
+	simulateTool    string
+	hooksGenTool    string
+	hooksGenOutput  string
+	hooksGenGroups  []string
+	hooksGenLayout  string
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Inspect lifecycle hooks configurations",
+}
+
+var hooksSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Show which hooks would run for an event and tool invocation",
+	Long: `Simulate a tool invocation and list the hooks that would run for it.
+
+Hook matchers are evaluated against the tool name: an empty matcher or "*"
+matches every tool, otherwise the matcher is a regular expression that must
+match the whole name (e.g., "Bash|Write").
+
+The config is read as canonical hooks JSON by default; use --format to read a
+tool-specific file instead.
+
+Example:
+  assistantkit hooks simulate --event=before_command --tool=Bash
+  assistantkit hooks simulate --config=.claude/settings.json --format=claude --event=before_file_write --tool=Write`,
+	RunE: runHooksSimulate,
+}
+
+var hooksGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Write a hooks configuration in a tool's format",
+	Long: `Convert a hooks configuration to a tool's format and write it.
+
+Use --hook-group to emit only the hooks in the named groups. A hook's group is
+its own "group" or, when unset, its entry's. Ungrouped hooks are skipped
+whenever a group is selected. Repeat the flag or separate names with commas.
+
+Use --layout to write to the hooks path of an output layout profile (default,
+flat, nested, xdg) instead of the tool's project config path. Tools whose
+layout has no hooks file (Claude embeds hooks in its plugin manifest) keep
+the project config path.
+
+Example:
+  assistantkit hooks generate --tool=cursor --output=.cursor/hooks.json
+  assistantkit hooks generate --tool=claude --output=.claude/settings.json --hook-group=linting,security
+  assistantkit hooks generate --tool=windsurf --layout=xdg`,
+	RunE: runHooksGenerate,
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksSimulateCmd)
+
+	hooksSimulateCmd.Flags().StringVar(&hooksConfigPath, "config", "hooks.json", "Path to the hooks configuration")
+	hooksSimulateCmd.Flags().StringVar(&hooksFormat, "format", "canonical", "Config format (canonical, claude, cursor, windsurf)")
+	hooksSimulateCmd.Flags().StringVar(&simulateEvent, "event", "", "Canonical event to simulate (e.g., before_command)")
+	hooksSimulateCmd.Flags().StringVar(&simulateTool, "tool", "", "Tool name being invoked (e.g., Bash)")
+	_ = hooksSimulateCmd.MarkFlagRequired("event")
+
+	hooksCmd.AddCommand(hooksGenerateCmd)
+	hooksGenerateCmd.Flags().StringVar(&hooksConfigPath, "config", "hooks.json", "Path to the hooks configuration")
+	hooksGenerateCmd.Flags().StringVar(&hooksFormat, "format", "canonical", "Config format (canonical, claude, cursor, windsurf)")
+	hooksGenerateCmd.Flags().StringVar(&hooksGenTool, "tool", "", "Tool to generate hooks for (claude, cursor, windsurf)")
+	hooksGenerateCmd.Flags().StringVar(&hooksGenOutput, "output", "", "Output file (default: the tool's project config path)")
+	hooksGenerateCmd.Flags().StringSliceVar(&hooksGenGroups, "hook-group", nil, "Only emit hooks in these groups")
+	hooksGenerateCmd.Flags().StringVar(&hooksGenLayout, "layout", "", "Output layout profile for the default output path (default, flat, nested, xdg)")
+	_ = hooksGenerateCmd.MarkFlagRequired("tool")
+}
+
+func runHooksGenerate(cmd *cobra.Command, args []string) error {
+	adapter, ok := hooks.GetAdapter(hooksGenTool)
+	if !ok {
+		return fmt.Errorf("unknown hooks tool %q", hooksGenTool)
+	}
+
+	cfg, err := readHooksConfig(hooksConfigPath, hooksFormat)
+	if err != nil {
+		return err
+	}
+	cfg = cfg.FilterByGroup(hooksGenGroups...)
+
+	output := hooksGenOutput
+	if output == "" && hooksGenLayout != "" {
+		if output, err = layoutHooksPath(hooksGenLayout, hooksGenTool); err != nil {
+			return err
+		}
+	}
+	if output == "" {
+		output = adapter.DefaultPaths()[0]
+	}
+	if cfg, err = keepExistingSettings(adapter, cfg, output); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return err
+	}
+	if err := adapter.WriteFile(cfg, output); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %d hook(s) to %s\n", cfg.HookCount(), output)
+	return nil
+}
+
+// keepExistingSettings carries the non-hook settings of an existing output
+// file (e.g., Claude permissions or env) into cfg, so writing the hooks does
+// not drop them. Settings already in the output win over those of the source.
+func keepExistingSettings(adapter hooks.Adapter, cfg *hooks.Config, output string) (*hooks.Config, error) {
+	if _, err := os.Stat(output); os.IsNotExist(err) {
+		return cfg, nil
+	}
+	existing, err := adapter.ReadFile(output)
+	if err != nil {
+		return nil, fmt.Errorf("reading existing %s: %w", output, err)
+	}
+	if len(existing.RawExtra) == 0 {
+		return cfg, nil
+	}
+
+	merged := *cfg
+	merged.RawExtra = make(map[string]json.RawMessage, len(existing.RawExtra)+len(cfg.RawExtra))
+	for key, value := range cfg.RawExtra {
+		merged.RawExtra[key] = value
+	}
+	for key, value := range existing.RawExtra {
+		merged.RawExtra[key] = value
+	}
+	return &merged, nil
+}
+
+// layoutHooksPath returns the hooks file a layout profile assigns to tool,
+// or "" when the layout has none.
+func layoutHooksPath(name, tool string) (string, error) {
+	layout, ok := bundle.Layouts[name]
+	if !ok {
+		return "", fmt.Errorf("unknown layout %q (expected one of %s)", name, strings.Join(bundle.LayoutNames(), ", "))
+	}
+	config, ok := layout.ToolConfig(tool)
+	if !ok || config.HooksDir == "" || config.HooksFile == "" {
+		return "", nil
+	}
+	root, err := layout.Root(tool, "")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, config.HooksDir, config.HooksFile), nil
+}
+
+func runHooksSimulate(cmd *cobra.Command, args []string) error {
+	event, err := parseEvent(simulateEvent)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := readHooksConfig(hooksConfigPath, hooksFormat)
+	if err != nil {
+		return err
+	}
+
+	matched := cfg.Match(event, simulateTool)
+	if len(matched) == 0 {
+		fmt.Printf("No hooks would run for %s (tool: %s)\n", event, simulateTool)
+		return nil
+	}
+
+	fmt.Printf("%d hook(s) would run for %s (tool: %s):\n", len(matched), event, simulateTool)
+	for i, hook := range matched {
+		action := hook.Command
+		if hook.Type == hooks.HookTypePrompt {
+			action = hook.Prompt
+		}
+		fmt.Printf("  %d. [%s] %s\n", i+1, hook.Type, action)
+	}
+	return nil
+}
+
+// parseEvent validates a canonical event name.
+func parseEvent(name string) (hooks.Event, error) {
+	for _, event := range hooks.AllEvents() {
+		if string(event) == name {
+			return event, nil
+		}
+	}
+	return "", fmt.Errorf("unknown event %q", name)
+}
+
+// readHooksConfig reads a canonical or tool-specific hooks configuration.
+func readHooksConfig(path, format string) (*hooks.Config, error) {
+	if format == "" || format == "canonical" {
+		cfg, err := hooks.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		return cfg, nil
+	}
+
+	adapter, ok := hooks.GetAdapter(format)
+	if !ok {
+		return nil, fmt.Errorf("unknown hooks format %q", format)
+	}
+	return adapter.ReadFile(path)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunHooksGenerateKeepsExistingSettings(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "hooks.json")
+	canonical := `{"hooks": {"before_command": [{"matcher": "Bash", "hooks": [{"type": "command", "command": "echo check"}]}]}}`
+	if err := os.WriteFile(source, []byte(canonical), 0600); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, ".claude", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(output), 0700); err != nil {
+		t.Fatal(err)
+	}
+	existing := `{"permissions": {"allow": ["Bash(go test:*)"]}, "hooks": {}}`
+	if err := os.WriteFile(output, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	hooksConfigPath, hooksFormat = source, "canonical"
+	hooksGenTool, hooksGenOutput = "claude", output
+	hooksGenGroups, hooksGenLayout = nil, ""
+	if err := runHooksGenerate(nil, nil); err != nil {
+		t.Fatalf("runHooksGenerate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, data)
+	}
+	var permissions struct {
+		Allow []string `json:"allow"`
+	}
+	if err := json.Unmarshal(settings["permissions"], &permissions); err != nil || len(permissions.Allow) != 1 {
+		t.Errorf("expected permissions to survive, got:\n%s", data)
+	}
+	if _, ok := settings["hooks"]; !ok {
+		t.Errorf("expected hooks in output, got:\n%s", data)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/agentplexus/assistantkit/generate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	initCISpecs  string
+	initCITarget string
+	initCIOutput string
+	initCIBranch string
+	initCIForce  bool
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Scaffold project files for assistantkit",
+}
+
+var initCICmd = &cobra.Command{
+	Use:   "ci",
+	Short: "Generate a GitHub Actions workflow that checks for drift",
+	Long: `Generate a GitHub Actions workflow that regenerates outputs from the specs
+and fails when they differ from the committed files.
+
+The workflow is derived from specs/deployments/<target>.json: each target
+platform gets its own check over its output directory.
+
+Example:
+  assistantkit init ci
+  assistantkit init ci --specs=specs --target=local --force`,
+	RunE: runInitCI,
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.AddCommand(initCICmd)
+
+	initCICmd.Flags().StringVar(&initCISpecs, "specs", "specs", "Path to specs directory")
+	initCICmd.Flags().StringVar(&initCITarget, "target", "local", "Deployment target to check")
+	initCICmd.Flags().StringVar(&initCIOutput, "output", generate.CIWorkflowPath, "Path to write the workflow")
+	initCICmd.Flags().StringVar(&initCIBranch, "branch", "main", "Branch whose pushes run the check")
+	initCICmd.Flags().BoolVar(&initCIForce, "force", false, "Overwrite an existing workflow")
+}
+
+func runInitCI(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(initCIOutput); err == nil && !initCIForce {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", initCIOutput)
+	}
+
+	data, err := generate.CIWorkflow(generate.CIOptions{
+		SpecsDir: initCISpecs,
+		Target:   initCITarget,
+		Branch:   initCIBranch,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(initCIOutput), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	if err := os.WriteFile(initCIOutput, data, 0600); err != nil {
+		return fmt.Errorf("writing %s: %w", initCIOutput, err)
+	}
+
+	fmt.Printf("Wrote %s\n", initCIOutput)
+	return nil
+}
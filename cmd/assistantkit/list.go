@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/agentplexus/assistantkit/generate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listSpecsDir string
+	listFormat   string
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the agents, skills, and commands in a specs directory",
+	Long: `Read a specs directory with the same readers used by 'generate' and print
+each agent, skill, and command with its description, model, tags, and
+help URL.
+Nothing is generated.
+
+Example:
+  assistantkit list
+  assistantkit list --specs=specs --format=json`,
+	RunE: runList,
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringVar(&listSpecsDir, "specs", "specs", "Path to unified specs directory")
+	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format (table, json)")
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(listSpecsDir); os.IsNotExist(err) {
+		return fmt.Errorf("specs directory not found: %s", listSpecsDir)
+	}
+
+	specs, err := generate.List(listSpecsDir)
+	if err != nil {
+		return err
+	}
+
+	switch listFormat {
+	case "json":
+		if specs == nil {
+			specs = []generate.SpecSummary{}
+		}
+		data, err := json.MarshalIndent(specs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "table", "":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KIND\tNAME\tDESCRIPTION\tMODEL\tTAGS\tHELP")
+		for _, spec := range specs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				spec.Kind, spec.Name, spec.Description, spec.Model, strings.Join(spec.Tags, ", "), spec.HelpURL)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown format %q (expected table or json)", listFormat)
+	}
+	return nil
+}
@@ -15,8 +15,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
 	"github.com/spf13/cobra"
 )
@@ -32,7 +34,11 @@ platform-specific formats automatically.`,
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	// Cancel long-running commands such as generate on Ctrl-C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/mcp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mcpConfigPath string
+	mcpFormat     string
+	mcpShowTools  string
+	mcpToolVers   map[string]string
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Inspect MCP server configurations",
+}
+
+var mcpShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the rendered MCP config for each tool",
+	Long: `Render an MCP config in every target tool's format and print the results,
+so all servers for all tools can be audited in one place.
+
+The config is read as canonical MCP JSON by default; use --format to read a
+tool-specific file instead.
+
+Use --tool-version to name the tool release you are targeting. Servers that
+need a feature the release lacks (for example HTTP transport on an older
+Claude Code) are reported as warnings on stderr.
+
+Example:
+  assistantkit mcp show --tools=all
+  assistantkit mcp show --config=.mcp.json --format=claude --tools=cursor,vscode
+  assistantkit mcp show --tools=claude --tool-version=claude=1.0.20`,
+	RunE: runMCPShow,
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+	mcpCmd.AddCommand(mcpShowCmd)
+
+	mcpShowCmd.Flags().StringVar(&mcpConfigPath, "config", "mcp.json", "Path to the MCP configuration")
+	mcpShowCmd.Flags().StringVar(&mcpFormat, "format", "canonical", "Config format (canonical, claude, cursor, ...)")
+	mcpShowCmd.Flags().StringVar(&mcpShowTools, "tools", "all", "Comma-separated tools to render, or 'all'")
+	mcpShowCmd.Flags().StringToStringVar(&mcpToolVers, "tool-version", nil, "Target tool versions to check features against (e.g., claude=1.0.20)")
+}
+
+func runMCPShow(cmd *cobra.Command, args []string) error {
+	cfg, err := readMCPConfig(mcpConfigPath, mcpFormat)
+	if err != nil {
+		return err
+	}
+
+	var tools []string
+	if mcpShowTools != "" && mcpShowTools != "all" {
+		for _, tool := range strings.Split(mcpShowTools, ",") {
+			if tool = strings.TrimSpace(tool); tool != "" {
+				tools = append(tools, tool)
+			}
+		}
+	}
+
+	rendered, err := mcp.MarshalAll(cfg, tools)
+	if err != nil {
+		return err
+	}
+
+	if err := warnUnsupportedFeatures(cfg, mcpToolVers); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(rendered))
+	for name := range rendered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("=== %s ===\n", name)
+		fmt.Print(string(rendered[name]))
+		if !strings.HasSuffix(string(rendered[name]), "\n") {
+			fmt.Println()
+		}
+	}
+	return nil
+}
+
+// warnUnsupportedFeatures prints a warning on stderr for each server that
+// needs a feature the targeted tool version does not support.
+func warnUnsupportedFeatures(cfg *mcp.Config, toolVersions map[string]string) error {
+	tools := make([]string, 0, len(toolVersions))
+	for tool := range toolVersions {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+
+	for _, tool := range tools {
+		adapter, ok := mcp.GetAdapter(tool)
+		if !ok {
+			return fmt.Errorf("unknown MCP tool %q in --tool-version", tool)
+		}
+		unsupported, err := mcp.CheckFeatures(adapter, cfg, toolVersions[tool])
+		if err != nil {
+			return fmt.Errorf("%s: %w", tool, err)
+		}
+		for _, u := range unsupported {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", u)
+		}
+	}
+	return nil
+}
+
+// readMCPConfig reads an MCP config in canonical or tool-specific format.
+func readMCPConfig(path, format string) (*mcp.Config, error) {
+	if format == "" || format == "canonical" {
+		cfg, err := mcp.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		return cfg, nil
+	}
+
+	adapter, ok := mcp.GetAdapter(format)
+	if !ok {
+		return nil, fmt.Errorf("unknown MCP format %q", format)
+	}
+	return adapter.ReadFile(path)
+}
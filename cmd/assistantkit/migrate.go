@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentplexus/assistantkit/generate"
+	"github.com/spf13/cobra"
+)
+
+var migrateSpecsDir string
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade spec files to the current canonical schema",
+	Long: `Rewrite the agents, skills, commands, and plugin.json in a specs directory
+to the current canonical schema, in place, and print each change:
+
+  - kebab-case and snake_case keys (allowed-tools, working_dir) are renamed to
+    their camelCase fields (allowedTools, workingDir)
+  - comma-separated lists in YAML frontmatter (tools: Read, Grep) become
+    flow lists (tools: [Read, Grep])
+  - JSON specs gain a "$schema" reference
+
+Subdirectories are included and paths in .assistantkitignore are skipped.
+Every file is checked before any is written, so a spec that fails to parse
+leaves the directory unchanged. Files that are already current are left
+untouched.
+
+Example:
+  assistantkit migrate
+  assistantkit migrate --specs=specs`,
+	RunE: runMigrate,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().StringVar(&migrateSpecsDir, "specs", "specs", "Path to unified specs directory")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(migrateSpecsDir); os.IsNotExist(err) {
+		return fmt.Errorf("specs directory not found: %s", migrateSpecsDir)
+	}
+
+	result, err := generate.Migrate(migrateSpecsDir)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Files) == 0 {
+		fmt.Println("All specs are current.")
+		return nil
+	}
+	for _, file := range result.Files {
+		fmt.Println(file.Path)
+		for _, change := range file.Changes {
+			fmt.Printf("  %s\n", change)
+		}
+	}
+	fmt.Printf("\nMigrated %d file(s).\n", len(result.Files))
+	return nil
+}
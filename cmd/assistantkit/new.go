@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/generate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	newSpecs       string
+	newName        string
+	newDescription string
+	newModel       string
+	newTools       []string
+	newForce       bool
+)
+
+var newCmd = &cobra.Command{
+	Use:   "new <agent|skill|command|validation>",
+	Short: "Scaffold a new canonical spec",
+	Long: `Write a new canonical spec file with starter frontmatter and an
+instructions template:
+
+  agent       specs/agents/<name>.md
+  skill       specs/skills/<name>/skill.json
+  command     specs/commands/<name>.json
+  validation  specs/validation/<name>.json
+
+When --name or --description is omitted and stdin is a terminal, you are
+prompted for it.
+
+Example:
+  assistantkit new agent --name=reviewer --model=sonnet
+  assistantkit new skill --name=changelog --description="Maintains CHANGELOG.md"
+  assistantkit new command`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: generate.ScaffoldKinds,
+	RunE:      runNew,
+}
+
+func init() {
+	rootCmd.AddCommand(newCmd)
+
+	newCmd.Flags().StringVar(&newSpecs, "specs", "specs", "Path to specs directory")
+	newCmd.Flags().StringVar(&newName, "name", "", "Spec name (lowercase, dash-separated)")
+	newCmd.Flags().StringVar(&newDescription, "description", "", "One-line description")
+	newCmd.Flags().StringVar(&newModel, "model", "", "Preferred model for agents and validation areas (e.g., sonnet)")
+	newCmd.Flags().StringSliceVar(&newTools, "tools", nil, "Tools for agents and validation areas (e.g., Read,Grep)")
+	newCmd.Flags().BoolVar(&newForce, "force", false, "Overwrite an existing spec")
+}
+
+func runNew(cmd *cobra.Command, args []string) error {
+	if isTerminal(os.Stdin) {
+		in := bufio.NewReader(os.Stdin)
+		if newName == "" {
+			newName = prompt(in, "Name: ")
+		}
+		if newDescription == "" {
+			newDescription = prompt(in, "Description: ")
+		}
+	}
+	if newName == "" {
+		return fmt.Errorf("--name is required")
+	}
+
+	path, err := generate.Scaffold(generate.ScaffoldOptions{
+		SpecsDir:    newSpecs,
+		Kind:        args[0],
+		Name:        newName,
+		Description: newDescription,
+		Model:       newModel,
+		Tools:       newTools,
+		Force:       newForce,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %s\n", path)
+	return nil
+}
+
+// isTerminal reports whether f is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// prompt prints label and returns the trimmed line read from in.
+func prompt(in *bufio.Reader, label string) string {
+	fmt.Print(label)
+	line, _ := in.ReadString('\n')
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/agentplexus/assistantkit/generate"
+	"github.com/spf13/cobra"
+)
+
+var rollbackOutputDir string
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Restore generated output from the latest backup",
+	Long: `Restore the files written by the latest 'generate --backup' run. Files
+it overwrote get their previous content back and files it created are
+removed; other files in the target directories are left alone.
+
+The restored backup is deleted, so running rollback again restores the one
+before it.
+
+Example:
+  assistantkit rollback
+  assistantkit rollback --output=.`,
+	RunE: runRollback,
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+
+	rollbackCmd.Flags().StringVar(&rollbackOutputDir, "output", ".", "Output base directory passed to generate")
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	absOutputDir, err := filepath.Abs(rollbackOutputDir)
+	if err != nil {
+		return fmt.Errorf("resolving output dir: %w", err)
+	}
+
+	snapshot, err := generate.Rollback(absOutputDir)
+	if errors.Is(err, generate.ErrNoBackup) {
+		return fmt.Errorf("no backups in %s (run 'assistantkit generate --backup' first)", filepath.Join(absOutputDir, generate.BackupsDir))
+	}
+	if err != nil {
+		return fmt.Errorf("rolling back: %w", err)
+	}
+
+	fmt.Printf("Restored %s\n", filepath.Base(snapshot))
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/internal/roundtrip"
+	"github.com/spf13/cobra"
+)
+
+var (
+	roundtripTool string
+	roundtripKind string
+	roundtripFile string
+)
+
+var verifyRoundtripCmd = &cobra.Command{
+	Use:   "verify-roundtrip",
+	Short: "Check that importing and re-exporting a tool config is lossless",
+	Long: `Parse a tool-specific config into the canonical format, marshal it back to
+the same tool format, and report any differences from the original.
+
+JSON and TOML files are compared structurally, so key order and formatting
+do not count as differences. Markdown files are compared line by line.
+
+Kinds: ` + strings.Join(roundtrip.Kinds(), ", ") + `
+
+Example:
+  assistantkit verify-roundtrip --tool=claude --kind=hooks --file=.claude/settings.json
+  assistantkit verify-roundtrip --tool=kiro --kind=agent --file=.kiro/agents/reviewer.json`,
+	RunE: runVerifyRoundtrip,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyRoundtripCmd)
+
+	verifyRoundtripCmd.Flags().StringVar(&roundtripTool, "tool", "", "Tool format of the file (e.g., claude, kiro)")
+	verifyRoundtripCmd.Flags().StringVar(&roundtripKind, "kind", "", "Config kind ("+strings.Join(roundtrip.Kinds(), ", ")+")")
+	verifyRoundtripCmd.Flags().StringVar(&roundtripFile, "file", "", "Config file to verify")
+	_ = verifyRoundtripCmd.MarkFlagRequired("tool")
+	_ = verifyRoundtripCmd.MarkFlagRequired("kind")
+	_ = verifyRoundtripCmd.MarkFlagRequired("file")
+}
+
+func runVerifyRoundtrip(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(roundtripFile)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", roundtripFile, err)
+	}
+
+	result, err := roundtrip.Verify(roundtripKind, roundtripTool, data)
+	if err != nil {
+		return err
+	}
+
+	if result.Lossless() {
+		fmt.Printf("Round-trip of %s (%s %s) is lossless\n", roundtripFile, roundtripTool, roundtripKind)
+		return nil
+	}
+
+	fmt.Printf("Round-trip of %s (%s %s) differs from the original:\n", roundtripFile, roundtripTool, roundtripKind)
+	for _, diff := range result.Differences {
+		fmt.Printf("  %s\n", diff)
+	}
+
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return fmt.Errorf("round-trip found %d difference(s)", len(result.Differences))
+}
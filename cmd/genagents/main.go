@@ -4,12 +4,14 @@
 //
 //	genagents -spec=plugins/spec/agents -output=.claude/agents -format=claude
 //	genagents -spec=plugins/spec/agents -output=plugins/kiro/agents -format=kiro
+//	genagents -spec=plugins/spec/agents -output=orchestrator/agents -format=python
 //	genagents -spec=plugins/spec/agents -targets=claude:.claude/agents,kiro:plugins/kiro/agents
 //
 // Multi-agent-spec format (reads deployment.json for targets):
 //
 //	genagents -project=examples/stats-agent-team
 //	genagents -project=examples/stats-agent-team -priority=p1
+//	genagents -project=examples/stats-agent-team -env=prod
 package main
 
 import (
@@ -24,13 +26,15 @@ import (
 	"github.com/agentplexus/assistantkit/agents/agentkit"
 	"github.com/agentplexus/assistantkit/agents/awsagentcore"
 	"github.com/agentplexus/assistantkit/agents/core"
+	"github.com/agentplexus/assistantkit/agents/k8s"
 	"github.com/agentplexus/assistantkit/skills"
 	skillscore "github.com/agentplexus/assistantkit/skills/core"
+	skillskiro "github.com/agentplexus/assistantkit/skills/kiro"
 
 	// Import adapters to register them
 	_ "github.com/agentplexus/assistantkit/agents/claude"
 	_ "github.com/agentplexus/assistantkit/agents/kiro"
-	_ "github.com/agentplexus/assistantkit/skills/kiro"
+	_ "github.com/agentplexus/assistantkit/agents/python"
 )
 
 func main() {
@@ -38,10 +42,11 @@ func main() {
 	skillsDir := flag.String("skills", "", "Directory containing canonical skill specs (.md files)")
 	skillsOutput := flag.String("skills-output", "", "Output directory for generated skills/steering files")
 	outputDir := flag.String("output", "", "Output directory for generated agents")
-	format := flag.String("format", "claude", "Output format (claude, kiro, agentkit, aws-agentcore)")
+	format := flag.String("format", "claude", "Output format (claude, kiro, agentkit, aws-agentcore, python)")
 	targets := flag.String("targets", "", "Multiple targets as format:dir pairs (e.g., claude:.claude/agents,kiro:plugins/kiro/agents)")
 	project := flag.String("project", "", "Multi-agent-spec project directory (reads deployment.json)")
 	priority := flag.String("priority", "", "Filter by priority (p1, p2, p3) - only with -project")
+	env := flag.String("env", "", "Environment whose target overrides apply (e.g., dev, prod) - only with -project")
 	install := flag.Bool("install", false, "Install generated files to user config directory (e.g., ~/.kiro/)")
 	prefix := flag.String("prefix", "", "Prefix for installed files (e.g., 'myteam' -> 'myteam_agent.json')")
 	verbose := flag.Bool("verbose", false, "Verbose output")
@@ -49,7 +54,7 @@ func main() {
 
 	// Handle multi-agent-spec project mode
 	if *project != "" {
-		if err := runProjectMode(*project, *priority, *verbose); err != nil {
+		if err := runProjectMode(*project, *priority, *env, *verbose); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -75,6 +80,16 @@ func main() {
 		}
 	}
 
+	// Skills referenced by Kiro agents are written as steering files
+	var skillList []*skillscore.Skill
+	if *skillsDir != "" {
+		skillList, err = skills.ReadCanonicalDir(*skillsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading skills directory %s: %v\n", *skillsDir, err)
+			os.Exit(1)
+		}
+	}
+
 	// Handle multiple targets
 	if *targets != "" {
 		targetPairs := strings.Split(*targets, ",")
@@ -87,7 +102,7 @@ func main() {
 			targetFormat := strings.TrimSpace(parts[0])
 			targetDir := strings.TrimSpace(parts[1])
 
-			if err := generateAgents(agentList, targetFormat, targetDir, *verbose); err != nil {
+			if err := generateAgents(agentList, skillList, targetFormat, targetDir, *verbose); err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating %s agents: %v\n", targetFormat, err)
 				os.Exit(1)
 			}
@@ -103,7 +118,7 @@ func main() {
 	}
 
 	if *outputDir != "" {
-		if err := generateAgents(agentList, *format, *outputDir, *verbose); err != nil {
+		if err := generateAgents(agentList, skillList, *format, *outputDir, *verbose); err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating agents: %v\n", err)
 			os.Exit(1)
 		}
@@ -188,7 +203,7 @@ func runSkillsGeneration(skillsDir, outputDir, format string, verbose bool) erro
 	return nil
 }
 
-func generateAgents(agentList []*core.Agent, format, outputDir string, verbose bool) error {
+func generateAgents(agentList []*core.Agent, skillList []*skillscore.Skill, format, outputDir string, verbose bool) error {
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
@@ -201,10 +216,20 @@ func generateAgents(agentList []*core.Agent, format, outputDir string, verbose b
 		return fmt.Errorf("unknown format %q (available: %s)", format, strings.Join(available, ", "))
 	}
 
+	if err := core.CheckFileNames(adapter, agentList); err != nil {
+		return err
+	}
+
 	// Write each agent
 	for _, agent := range agentList {
-		filename := agent.Name + adapter.FileExtension()
-		path := filepath.Join(outputDir, filename)
+		path := filepath.Join(outputDir, core.FileName(adapter, agent))
+
+		// Kiro loads an agent's steering resources in the order listed
+		if format == "kiro" && len(agent.Skills) > 1 {
+			ordered := *agent
+			ordered.Skills = skillscore.SortNamesByOrder(agent.Skills, skillList)
+			agent = &ordered
+		}
 
 		if err := adapter.WriteFile(agent, path); err != nil {
 			return fmt.Errorf("failed to write %s: %w", path, err)
@@ -216,6 +241,52 @@ func generateAgents(agentList []*core.Agent, format, outputDir string, verbose b
 	}
 
 	fmt.Printf("Generated %d %s agents in %s\n", len(agentList), format, outputDir)
+
+	if format == "kiro" {
+		steeringDir := filepath.Join(filepath.Dir(outputDir), skillskiro.SteeringDir)
+		return writeKiroSteering(agentList, skillList, steeringDir, verbose)
+	}
+	return nil
+}
+
+// writeKiroSteering writes each skill referenced by a Kiro agent as a steering
+// file in steeringDir, named by skillskiro.SteeringFileName to match the
+// file://.kiro/steering/<skill>.md resource the Kiro adapter emits. Referenced skills missing from skillList
+// are reported as warnings.
+func writeKiroSteering(agentList []*core.Agent, skillList []*skillscore.Skill, steeringDir string, verbose bool) error {
+	byName := make(map[string]*skillscore.Skill, len(skillList))
+	for _, skill := range skillList {
+		byName[skill.Name] = skill
+	}
+
+	adapter := &skillskiro.Adapter{}
+	written := make(map[string]bool)
+	for _, agent := range agentList {
+		for _, name := range agent.Skills {
+			if written[name] {
+				continue
+			}
+			skill, ok := byName[name]
+			if !ok {
+				fmt.Printf("  Warning: agent %s references unknown skill %s; steering file not generated\n", agent.Name, name)
+				continue
+			}
+
+			path := filepath.Join(steeringDir, skillskiro.SteeringFileName(skill))
+			if err := adapter.WriteFile(skill, path); err != nil {
+				return fmt.Errorf("failed to write steering file for skill %s: %w", skill.Name, err)
+			}
+			written[name] = true
+
+			if verbose {
+				fmt.Printf("Generated %s\n", path)
+			}
+		}
+	}
+
+	if len(written) > 0 {
+		fmt.Printf("Generated %d steering files in %s\n", len(written), steeringDir)
+	}
 	return nil
 }
 
@@ -233,10 +304,44 @@ type Target struct {
 	Priority string                 `json:"priority"`
 	Output   string                 `json:"output"`
 	Config   map[string]interface{} `json:"config"`
+
+	// Overrides maps an environment name (e.g., "dev", "prod") to config
+	// keys that replace the matching keys in Config for that environment.
+	Overrides map[string]map[string]interface{} `json:"overrides,omitempty"`
+}
+
+// ForEnv returns the target with the overrides for env applied to Config.
+// Targets without overrides for env are returned unchanged.
+func (t Target) ForEnv(env string) Target {
+	overrides, ok := t.Overrides[env]
+	if env == "" || !ok {
+		return t
+	}
+	config := make(map[string]interface{}, len(t.Config)+len(overrides))
+	for key, value := range t.Config {
+		config[key] = value
+	}
+	for key, value := range overrides {
+		config[key] = value
+	}
+	t.Config = config
+	return t
+}
+
+// hasEnv reports whether any target defines overrides for env.
+func (d *Deployment) hasEnv(env string) bool {
+	for _, target := range d.Targets {
+		if _, ok := target.Overrides[env]; ok {
+			return true
+		}
+	}
+	return false
 }
 
 // runProjectMode processes a multi-agent-spec project directory.
-func runProjectMode(projectDir, priorityFilter string, verbose bool) error {
+// When env is set, each target's overrides for that environment are applied
+// before generation.
+func runProjectMode(projectDir, priorityFilter, env string, verbose bool) error {
 	// Read deployment.json
 	deploymentPath := filepath.Join(projectDir, "deployment.json")
 	deploymentData, err := os.ReadFile(deploymentPath)
@@ -248,6 +353,9 @@ func runProjectMode(projectDir, priorityFilter string, verbose bool) error {
 	if err := json.Unmarshal(deploymentData, &deployment); err != nil {
 		return fmt.Errorf("failed to parse deployment.json: %w", err)
 	}
+	if env != "" && !deployment.hasEnv(env) {
+		return fmt.Errorf("no target in deployment.json has overrides for environment %q", env)
+	}
 
 	if verbose {
 		fmt.Printf("Processing project: %s\n", deployment.Team)
@@ -272,6 +380,16 @@ func runProjectMode(projectDir, priorityFilter string, verbose bool) error {
 		}
 	}
 
+	// Read skills from skills/ directory if present
+	var skillList []*skillscore.Skill
+	skillsDir := filepath.Join(projectDir, "skills")
+	if info, err := os.Stat(skillsDir); err == nil && info.IsDir() {
+		skillList, err = skills.ReadCanonicalDir(skillsDir)
+		if err != nil {
+			return fmt.Errorf("failed to read skills: %w", err)
+		}
+	}
+
 	// Process each target
 	for _, target := range deployment.Targets {
 		// Filter by priority if specified
@@ -282,6 +400,7 @@ func runProjectMode(projectDir, priorityFilter string, verbose bool) error {
 			continue
 		}
 
+		target = target.ForEnv(env)
 		outputDir := filepath.Join(projectDir, target.Output)
 
 		if verbose {
@@ -289,7 +408,7 @@ func runProjectMode(projectDir, priorityFilter string, verbose bool) error {
 			fmt.Printf("  Output: %s\n", outputDir)
 		}
 
-		if err := generateForPlatform(deployment.Team, agentList, target, outputDir, verbose); err != nil {
+		if err := generateForPlatform(deployment.Team, agentList, skillList, target, outputDir, verbose); err != nil {
 			return fmt.Errorf("failed to generate %s: %w", target.Name, err)
 		}
 	}
@@ -298,13 +417,13 @@ func runProjectMode(projectDir, priorityFilter string, verbose bool) error {
 }
 
 // generateForPlatform generates output for a specific platform.
-func generateForPlatform(teamName string, agentList []*core.Agent, target Target, outputDir string, verbose bool) error {
+func generateForPlatform(teamName string, agentList []*core.Agent, skillList []*skillscore.Skill, target Target, outputDir string, verbose bool) error {
 	switch target.Platform {
 	case "claude-code":
-		return generateAgents(agentList, "claude", outputDir, verbose)
+		return generateAgents(agentList, nil, "claude", outputDir, verbose)
 
 	case "kiro-cli":
-		return generateAgents(agentList, "kiro", outputDir, verbose)
+		return generateAgents(agentList, skillList, "kiro", outputDir, verbose)
 
 	case "agentkit-local":
 		// Generate full agentkit config
@@ -317,9 +436,8 @@ func generateForPlatform(teamName string, agentList []*core.Agent, target Target
 
 	case "aws-agentcore":
 		// Generate CDK project
-		config := &awsagentcore.AgentCoreConfig{
-			StackName: toPascalCase(teamName) + "Stack",
-		}
+		config := awsagentcore.DefaultAgentCoreConfig()
+		config.StackName = toPascalCase(teamName) + "Stack"
 		// Apply config from deployment.json if present
 		if region, ok := target.Config["region"].(string); ok {
 			config.Region = region
@@ -330,6 +448,24 @@ func generateForPlatform(teamName string, agentList []*core.Agent, target Target
 		if runtime, ok := target.Config["lambdaRuntime"].(string); ok {
 			config.LambdaRuntime = runtime
 		}
+		if handler, ok := target.Config["handler"].(string); ok {
+			config.Handler = handler
+		}
+		if timeout, ok := target.Config["timeout"].(float64); ok {
+			config.Timeout = int(timeout)
+		}
+		if retries, ok := target.Config["retries"].(float64); ok {
+			config.Retries = int(retries)
+		}
+		if memory, ok := target.Config["memorySize"].(float64); ok {
+			config.MemorySize = int(memory)
+		}
+		if concurrency, ok := target.Config["reservedConcurrency"].(float64); ok {
+			config.ReservedConcurrency = int(concurrency)
+		}
+		if rateLimit, ok := target.Config["rateLimit"].(float64); ok {
+			config.RateLimit = int(rateLimit)
+		}
 
 		if err := awsagentcore.WriteCDKProject(teamName, agentList, outputDir, config); err != nil {
 			return err
@@ -338,8 +474,32 @@ func generateForPlatform(teamName string, agentList []*core.Agent, target Target
 		return nil
 
 	case "aws-eks", "azure-aks", "gcp-gke", "kubernetes":
-		// TODO: Implement Helm chart generation
-		fmt.Printf("Kubernetes deployment not yet implemented for %s\n", target.Platform)
+		// Generate Helm chart
+		config := k8s.DefaultHelmConfig()
+		// Apply config from deployment.json if present
+		if image, ok := target.Config["image"].(string); ok {
+			config.Image = image
+		}
+		if tag, ok := target.Config["imageTag"].(string); ok {
+			config.ImageTag = tag
+		}
+		if policy, ok := target.Config["imagePullPolicy"].(string); ok {
+			config.ImagePullPolicy = policy
+		}
+		if replicas, ok := target.Config["replicas"].(float64); ok {
+			config.Replicas = int(replicas)
+		}
+		if cpu, ok := target.Config["cpu"].(string); ok {
+			config.CPU = cpu
+		}
+		if memory, ok := target.Config["memory"].(string); ok {
+			config.Memory = memory
+		}
+
+		if err := k8s.WriteHelmChart(teamName, agentList, outputDir, config); err != nil {
+			return err
+		}
+		fmt.Printf("Generated Helm chart for %s in %s\n", target.Platform, outputDir)
 		return nil
 
 	default:
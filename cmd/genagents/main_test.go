@@ -0,0 +1,168 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+	skillscore "github.com/agentplexus/assistantkit/skills/core"
+)
+
+func TestGenerateKiroWritesSteeringForReferencedSkills(t *testing.T) {
+	agent := core.NewAgent("release-coordinator", "Coordinates releases")
+	agent.Skills = []string{"version-analysis"}
+
+	used := skillscore.NewSkill("version-analysis", "Analyze git history")
+	used.Instructions = "Suggest the next semantic version."
+	used.Order = 2
+	unused := skillscore.NewSkill("unused", "Not referenced")
+
+	root := t.TempDir()
+	agentsDir := filepath.Join(root, ".kiro", "agents")
+	if err := generateAgents([]*core.Agent{agent}, []*skillscore.Skill{used, unused}, "kiro", agentsDir, false); err != nil {
+		t.Fatalf("generateAgents failed: %v", err)
+	}
+
+	agentData, err := os.ReadFile(filepath.Join(agentsDir, "release_coordinator.json"))
+	if err != nil {
+		t.Fatalf("expected agent file: %v", err)
+	}
+	if !strings.Contains(string(agentData), "file://.kiro/steering/version-analysis.md") {
+		t.Errorf("expected steering resource in agent, got:\n%s", agentData)
+	}
+
+	steering, err := os.ReadFile(filepath.Join(root, ".kiro", "steering", "version-analysis.md"))
+	if err != nil {
+		t.Fatalf("expected steering file for referenced skill: %v", err)
+	}
+	if !strings.Contains(string(steering), "Suggest the next semantic version.") {
+		t.Errorf("unexpected steering content:\n%s", steering)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, ".kiro", "steering", "unused.md")); !os.IsNotExist(err) {
+		t.Errorf("expected no steering file for unreferenced skill, got err=%v", err)
+	}
+}
+
+func TestGenerateKiroOrdersSteeringResources(t *testing.T) {
+	agent := core.NewAgent("reviewer", "Reviews changes")
+	agent.Skills = []string{"style", "notes", "setup"}
+
+	setup := skillscore.NewSkill("setup", "Project setup")
+	setup.Order = 1
+	style := skillscore.NewSkill("style", "Code style")
+	style.Order = 100
+	notes := skillscore.NewSkill("notes", "Unordered notes")
+
+	root := t.TempDir()
+	agentsDir := filepath.Join(root, ".kiro", "agents")
+	if err := generateAgents([]*core.Agent{agent}, []*skillscore.Skill{setup, style, notes}, "kiro", agentsDir, false); err != nil {
+		t.Fatalf("generateAgents failed: %v", err)
+	}
+
+	agentData, err := os.ReadFile(filepath.Join(agentsDir, "reviewer.json"))
+	if err != nil {
+		t.Fatalf("expected agent file: %v", err)
+	}
+	data := string(agentData)
+	setupAt := strings.Index(data, "file://.kiro/steering/setup.md")
+	styleAt := strings.Index(data, "file://.kiro/steering/style.md")
+	notesAt := strings.Index(data, "file://.kiro/steering/notes.md")
+	if setupAt < 0 || styleAt < setupAt || notesAt < styleAt {
+		t.Errorf("expected steering resources in skill order setup, style, notes, got:\n%s", data)
+	}
+
+	// Every resource resolves to a steering file
+	for _, name := range []string{"setup", "style", "notes"} {
+		if _, err := os.Stat(filepath.Join(root, ".kiro", "steering", name+".md")); err != nil {
+			t.Errorf("expected steering file for %s: %v", name, err)
+		}
+	}
+	if len(agent.Skills) != 3 || agent.Skills[0] != "style" {
+		t.Errorf("expected the source agent to be unchanged, got %v", agent.Skills)
+	}
+}
+
+func TestRunProjectModeAppliesEnvOverrides(t *testing.T) {
+	project := t.TempDir()
+	deployment := `{
+  "team": "stats-team",
+  "targets": [{
+    "name": "aws",
+    "platform": "aws-agentcore",
+    "output": "cdk",
+    "config": {"region": "us-east-1"},
+    "overrides": {"prod": {"region": "eu-west-1"}}
+  }]
+}`
+	if err := os.WriteFile(filepath.Join(project, "deployment.json"), []byte(deployment), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(project, "agents"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	agent := "---\nname: researcher\ndescription: Finds statistics\n---\n\nFind sources.\n"
+	if err := os.WriteFile(filepath.Join(project, "agents", "researcher.md"), []byte(agent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	appPath := filepath.Join(project, "cdk", "bin", "stats-team.ts")
+	for env, region := range map[string]string{"": "us-east-1", "prod": "eu-west-1"} {
+		if err := runProjectMode(project, "", env, false); err != nil {
+			t.Fatalf("runProjectMode(env=%q) failed: %v", env, err)
+		}
+		app, err := os.ReadFile(appPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(app), "?? '"+region+"'") {
+			t.Errorf("env %q: expected region %s, got:\n%s", env, region, app)
+		}
+	}
+
+	if err := runProjectMode(project, "", "staging", false); err == nil {
+		t.Error("expected an error for an environment without overrides")
+	}
+}
+
+func TestRunProjectModeWritesHelmChart(t *testing.T) {
+	project := t.TempDir()
+	deployment := `{
+  "team": "stats-team",
+  "targets": [{
+    "name": "eks",
+    "platform": "aws-eks",
+    "output": "chart",
+    "config": {"image": "registry.example.com/stats", "imageTag": "2.0.0", "replicas": 2}
+  }]
+}`
+	if err := os.WriteFile(filepath.Join(project, "deployment.json"), []byte(deployment), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(project, "agents"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	agent := "---\nname: researcher\ndescription: Finds statistics\n---\n\nFind sources.\n"
+	if err := os.WriteFile(filepath.Join(project, "agents", "researcher.md"), []byte(agent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runProjectMode(project, "", "", false); err != nil {
+		t.Fatalf("runProjectMode failed: %v", err)
+	}
+
+	values, err := os.ReadFile(filepath.Join(project, "chart", "values.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"replicaCount: 2", `repository: "registry.example.com/stats"`, `tag: "2.0.0"`} {
+		if !strings.Contains(string(values), want) {
+			t.Errorf("values.yaml missing %q:\n%s", want, values)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(project, "chart", "templates", "researcher-deployment.yaml")); err != nil {
+		t.Errorf("expected a Deployment template for the agent: %v", err)
+	}
+}
@@ -8,12 +8,15 @@ import (
 
 	"github.com/agentplexus/assistantkit/context"
 	_ "github.com/agentplexus/assistantkit/context/claude"
+	_ "github.com/agentplexus/assistantkit/context/cursor"
+	_ "github.com/agentplexus/assistantkit/context/jetbrains"
+	_ "github.com/agentplexus/assistantkit/context/makefile"
 )
 
 func main() {
 	input := flag.String("input", "CONTEXT.json", "Input context file")
 	output := flag.String("output", "", "Output file (default: format-specific)")
-	format := flag.String("format", "claude", "Output format (claude)")
+	format := flag.String("format", "claude", "Output format (claude, cursor, jetbrains, makefile)")
 	flag.Parse()
 
 	ctx, err := context.ReadFile(*input)
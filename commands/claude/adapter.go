@@ -6,15 +6,20 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/agentplexus/assistantkit/commands/core"
+	"github.com/agentplexus/assistantkit/internal/passthrough"
 )
 
 func init() {
 	core.Register(&Adapter{})
 }
 
+// shellLine matches a bash execution line (!`command`) in a command body.
+var shellLine = regexp.MustCompile("(?m)^!`([^`]+)`[ \t]*$")
+
 // Adapter converts between canonical Command and Claude Code command format.
 type Adapter struct{}
 
@@ -33,23 +38,48 @@ func (a *Adapter) DefaultDir() string {
 	return "commands"
 }
 
+// SupportsExperimental reports that experimental options are written to the
+// command frontmatter.
+func (a *Adapter) SupportsExperimental() bool {
+	return true
+}
+
 // Parse converts Claude command Markdown bytes to canonical Command.
 func (a *Adapter) Parse(data []byte) (*core.Command, error) {
 	frontmatter, body := parseFrontmatter(data)
 
 	cmd := &core.Command{
-		Description:  frontmatter["description"],
-		Instructions: strings.TrimSpace(body),
+		Description: frontmatter["description"],
+	}
+
+	// A !`command` line makes this a shell command
+	if m := shellLine.FindStringSubmatchIndex(body); m != nil {
+		cmd.Type = core.TypeShell
+		cmd.Run = body[m[2]:m[3]]
+		body = body[:m[0]] + body[m[1]:]
 	}
+	cmd.Instructions = strings.TrimSpace(body)
 
 	// Extract name from frontmatter or infer from content
 	if name, ok := frontmatter["name"]; ok {
 		cmd.Name = name
 	}
 
+	if frontmatter["disable-model-invocation"] == "true" {
+		cmd.Approval = core.ApprovalNever
+	}
+
+	cmd.HelpURL = frontmatter["helpUrl"]
+
 	return cmd, nil
 }
 
+// SupportsDisabled reports that commands can ship disabled, with
+// disable-model-invocation.
+func (a *Adapter) SupportsDisabled() bool {
+	return true
+}
+
 // Marshal converts canonical Command to Claude command Markdown bytes.
 func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
 	var buf bytes.Buffer
@@ -57,6 +87,24 @@ func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
 	// Write YAML frontmatter
 	buf.WriteString("---\n")
 	buf.WriteString(fmt.Sprintf("description: %s\n", cmd.Description))
+	if cmd.IsShell() {
+		buf.WriteString(fmt.Sprintf("allowed-tools: %s\n", AllowedBash(cmd.Run)))
+	}
+	// Only the user can invoke the command; Claude has no auto-approve
+	// setting, and a command disabled by default is one Claude never runs
+	// on its own
+	if cmd.Approval == core.ApprovalNever || !cmd.IsEnabledByDefault() {
+		buf.WriteString("disable-model-invocation: true\n")
+	}
+	if cmd.HelpURL != "" {
+		buf.WriteString(fmt.Sprintf("helpUrl: %s\n", cmd.HelpURL))
+	}
+	// Experimental options follow the keys written above, which they never override
+	experimental, err := passthrough.Frontmatter(buf.String(), cmd.Experimental)
+	if err != nil {
+		return nil, &core.MarshalError{Format: "claude", Err: err}
+	}
+	buf.WriteString(experimental)
 	buf.WriteString("---\n\n")
 
 	// Write title
@@ -67,6 +115,11 @@ func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
 	// Write description
 	buf.WriteString(fmt.Sprintf("%s\n\n", cmd.Description))
 
+	// Shell commands run before the prompt and inject their output
+	if cmd.IsShell() {
+		buf.WriteString(fmt.Sprintf("!`%s`\n\n", cmd.Run))
+	}
+
 	// Write usage section if there are arguments
 	if len(cmd.Arguments) > 0 {
 		buf.WriteString("## Usage\n\n")
@@ -125,6 +178,16 @@ func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// AllowedBash returns the allowed-tools entry permitting a shell command to
+// run, e.g. "Bash(git:*)" for "git status --short".
+func AllowedBash(run string) string {
+	fields := strings.Fields(run)
+	if len(fields) == 0 {
+		return "Bash"
+	}
+	return fmt.Sprintf("Bash(%s:*)", fields[0])
+}
+
 // ReadFile reads a Claude command Markdown file and returns canonical Command.
 func (a *Adapter) ReadFile(path string) (*core.Command, error) {
 	data, err := os.ReadFile(path)
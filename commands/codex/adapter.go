@@ -74,8 +74,15 @@ func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
 
 	buf.WriteString("---\n\n")
 
-	// Write main instructions
-	if cmd.Instructions != "" {
+	// Codex prompts cannot execute commands, so ask the assistant to run it
+	if cmd.IsShell() {
+		buf.WriteString("Run this command and use its output:\n\n")
+		buf.WriteString(fmt.Sprintf("```bash\n%s\n```\n\n", cmd.Run))
+		if cmd.Instructions != "" {
+			buf.WriteString(cmd.Instructions)
+			buf.WriteString("\n\n")
+		}
+	} else if cmd.Instructions != "" {
 		buf.WriteString(cmd.Instructions)
 		buf.WriteString("\n\n")
 	} else {
@@ -4,6 +4,7 @@
 //   - Claude Code: commands/*.md (Markdown with YAML frontmatter)
 //   - Gemini CLI: commands/*.toml (TOML format)
 //   - OpenAI Codex: prompts/*.md (Markdown with YAML frontmatter)
+//   - Windsurf: .windsurf/workflows/*.md (Markdown with YAML frontmatter)
 //
 // Example usage:
 //
@@ -43,26 +44,60 @@ import (
 	_ "github.com/agentplexus/assistantkit/commands/claude"
 	_ "github.com/agentplexus/assistantkit/commands/codex"
 	_ "github.com/agentplexus/assistantkit/commands/gemini"
+	_ "github.com/agentplexus/assistantkit/commands/windsurf"
 )
 
 // Re-export core types for convenience
 type (
-	Command  = core.Command
-	Argument = core.Argument
-	Example  = core.Example
-	Adapter  = core.Adapter
+	Command     = core.Command
+	CommandType = core.CommandType
+	Approval    = core.Approval
+	Argument    = core.Argument
+	Example     = core.Example
+	Adapter     = core.Adapter
+
+	ExperimentalAdapter = core.ExperimentalAdapter
+	DisabledAdapter     = core.DisabledAdapter
+)
+
+// Re-export command types
+const (
+	TypePrompt = core.TypePrompt
+	TypeShell  = core.TypeShell
+)
+
+// Re-export approval policies
+const (
+	ApprovalAuto  = core.ApprovalAuto
+	ApprovalAsk   = core.ApprovalAsk
+	ApprovalNever = core.ApprovalNever
 )
 
 // Re-export core functions
 var (
 	NewCommand         = core.NewCommand
+	NewShellCommand    = core.NewShellCommand
 	GetAdapter         = core.GetAdapter
 	AdapterNames       = core.AdapterNames
 	Convert            = core.Convert
 	ReadCanonicalFile  = core.ReadCanonicalFile
 	WriteCanonicalFile = core.WriteCanonicalFile
 	ReadCanonicalDir   = core.ReadCanonicalDir
+	ResolveIncludes    = core.ResolveIncludes
 	WriteCommandsToDir = core.WriteCommandsToDir
+
+	SupportsExperimental  = core.SupportsExperimental
+	CheckExperimental     = core.CheckExperimental
+	SupportsDisabled      = core.SupportsDisabled
+	CheckEnabledByDefault = core.CheckEnabledByDefault
+)
+
+// Re-export errors
+var (
+	ErrInvalidType     = core.ErrInvalidType
+	ErrMissingRun      = core.ErrMissingRun
+	ErrInvalidApproval = core.ErrInvalidApproval
+	ErrInvalidHelpURL  = core.ErrInvalidHelpURL
 )
 
 // Re-export error types
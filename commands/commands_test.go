@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"errors"
+	"os"
 	"strings"
 	"testing"
 )
@@ -116,6 +118,9 @@ func TestGeminiAdapter(t *testing.T) {
 	if !strings.Contains(content, "[content]") {
 		t.Error("expected [content] section")
 	}
+	if !strings.Contains(content, "arguments_schema") || !strings.Contains(content, `"required"`) {
+		t.Errorf("expected arguments_schema with required arguments, got:\n%s", content)
+	}
 
 	// Test round-trip
 	parsed, err := adapter.Parse(data)
@@ -203,3 +208,228 @@ Test command description
 		t.Errorf("expected description in converted output, got:\n%s", content)
 	}
 }
+
+func TestShellCommandExecutableForms(t *testing.T) {
+	cmd := NewShellCommand("status", "Show git status", "git status --short")
+	cmd.Instructions = "Summarize the changes."
+
+	tests := []struct {
+		adapter string
+		want    []string
+	}{
+		{"claude", []string{"allowed-tools: Bash(git:*)", "!`git status --short`"}},
+		{"windsurf", []string{"\n\n1. Run `git status --short`"}},
+		{"gemini", []string{"!{git status --short}"}},
+		{"codex", []string{"```bash\ngit status --short\n```"}},
+	}
+
+	for _, tt := range tests {
+		adapter, ok := GetAdapter(tt.adapter)
+		if !ok {
+			t.Fatalf("%s adapter not registered", tt.adapter)
+		}
+		data, err := adapter.Marshal(cmd)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", tt.adapter, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("%s: expected output to contain %q, got:\n%s", tt.adapter, want, data)
+			}
+		}
+		if !strings.Contains(string(data), cmd.Instructions) {
+			t.Errorf("%s: expected output to keep instructions, got:\n%s", tt.adapter, data)
+		}
+
+		if tt.adapter == "codex" {
+			continue // Codex prompts cannot express shell commands
+		}
+		parsed, err := adapter.Parse(data)
+		if err != nil {
+			t.Fatalf("%s: Parse failed: %v", tt.adapter, err)
+		}
+		if !parsed.IsShell() || parsed.Run != cmd.Run {
+			t.Errorf("%s: round-trip: expected shell command %q, got type %q run %q", tt.adapter, cmd.Run, parsed.Type, parsed.Run)
+		}
+	}
+}
+
+func TestWindsurfShellCommandApproval(t *testing.T) {
+	adapter, ok := GetAdapter("windsurf")
+	if !ok {
+		t.Fatal("windsurf adapter not registered")
+	}
+
+	for _, approval := range []Approval{"", ApprovalAsk, ApprovalNever, ApprovalAuto} {
+		cmd := NewShellCommand("deploy", "Deploy the service", "make deploy")
+		cmd.Approval = approval
+
+		data, err := adapter.Marshal(cmd)
+		if err != nil {
+			t.Fatalf("%q: Marshal failed: %v", approval, err)
+		}
+		auto := approval == ApprovalAuto
+		if strings.Contains(string(data), "// turbo") != auto {
+			t.Errorf("%q: expected turbo annotation only for auto, got:\n%s", approval, data)
+		}
+
+		parsed, err := adapter.Parse(data)
+		if err != nil {
+			t.Fatalf("%q: Parse failed: %v", approval, err)
+		}
+		if !parsed.IsShell() || parsed.Run != cmd.Run {
+			t.Errorf("%q: expected shell command to round-trip, got type %q run %q", approval, parsed.Type, parsed.Run)
+		}
+		if (parsed.Approval == ApprovalAuto) != auto {
+			t.Errorf("%q: expected auto approval only for auto, got %q", approval, parsed.Approval)
+		}
+	}
+}
+
+func TestShellCommandRequiresRun(t *testing.T) {
+	cmd := NewCommand("status", "Show git status")
+	cmd.Type = TypeShell
+	if err := cmd.Validate(); !errors.Is(err, ErrMissingRun) {
+		t.Errorf("expected ErrMissingRun, got %v", err)
+	}
+
+	cmd.Type = "script"
+	if err := cmd.Validate(); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("expected ErrInvalidType, got %v", err)
+	}
+}
+
+func TestApprovalRoundTrip(t *testing.T) {
+	tests := []struct {
+		adapter  string
+		approval Approval
+		want     string
+	}{
+		{"claude", ApprovalNever, "disable-model-invocation: true\n"},
+		{"windsurf", ApprovalAuto, "// turbo-all\n"},
+	}
+
+	for _, tt := range tests {
+		adapter, ok := GetAdapter(tt.adapter)
+		if !ok {
+			t.Fatalf("%s adapter not registered", tt.adapter)
+		}
+		cmd := NewCommand("deploy", "Deploy the service")
+		cmd.Instructions = "Deploy to staging."
+		cmd.Approval = tt.approval
+
+		data, err := adapter.Marshal(cmd)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", tt.adapter, err)
+		}
+		if !strings.Contains(string(data), tt.want) {
+			t.Errorf("%s: expected output to contain %q, got:\n%s", tt.adapter, tt.want, data)
+		}
+
+		parsed, err := adapter.Parse(data)
+		if err != nil {
+			t.Fatalf("%s: Parse failed: %v", tt.adapter, err)
+		}
+		if parsed.Approval != tt.approval {
+			t.Errorf("%s: expected approval %q to round-trip, got %q", tt.adapter, tt.approval, parsed.Approval)
+		}
+		if strings.Contains(parsed.Instructions, "turbo") {
+			t.Errorf("%s: expected the approval marker to be stripped, got %q", tt.adapter, parsed.Instructions)
+		}
+	}
+
+	cmd := NewCommand("deploy", "Deploy the service")
+	cmd.Approval = "sometimes"
+	if err := cmd.Validate(); !errors.Is(err, ErrInvalidApproval) {
+		t.Errorf("expected ErrInvalidApproval, got %v", err)
+	}
+}
+
+func TestHelpURLRoundTrip(t *testing.T) {
+	adapter, ok := GetAdapter("claude")
+	if !ok {
+		t.Fatal("claude adapter not registered")
+	}
+	cmd := NewCommand("deploy", "Deploy the service")
+	cmd.Instructions = "Deploy to staging."
+	cmd.HelpURL = "https://example.com/docs/deploy"
+
+	data, err := adapter.Marshal(cmd)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), "helpUrl: https://example.com/docs/deploy\n") {
+		t.Errorf("expected helpUrl in frontmatter, got:\n%s", data)
+	}
+	parsed, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if parsed.HelpURL != cmd.HelpURL {
+		t.Errorf("expected help URL to round-trip, got %q", parsed.HelpURL)
+	}
+
+	cmd.HelpURL = "docs/deploy.md"
+	if err := cmd.Validate(); !errors.Is(err, ErrInvalidHelpURL) {
+		t.Errorf("expected ErrInvalidHelpURL, got %v", err)
+	}
+}
+
+func TestExperimentalPassthrough(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/deploy.md"
+	md := "---\nname: deploy\ndescription: Deploy the service\nexperimental:\n  model: opus\n  argument-hint: \"[env]\"\n---\n\nDeploy.\n"
+	if err := os.WriteFile(path, []byte(md), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cmd, err := ReadCanonicalFile(path)
+	if err != nil {
+		t.Fatalf("ReadCanonicalFile failed: %v", err)
+	}
+	if cmd.Experimental["model"] != "opus" || cmd.Experimental["argument-hint"] != "[env]" {
+		t.Fatalf("expected experimental options from frontmatter, got %v", cmd.Experimental)
+	}
+
+	claude, _ := GetAdapter("claude")
+	data, err := claude.Marshal(cmd)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, want := range []string{"argument-hint: \"[env]\"\n", "model: \"opus\"\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q in Claude output, got:\n%s", want, data)
+		}
+	}
+	if warnings := CheckExperimental(claude, cmd); len(warnings) != 1 || !strings.Contains(warnings[0], "unvalidated") {
+		t.Errorf("expected an unvalidated warning, got %v", warnings)
+	}
+
+	gemini, _ := GetAdapter("gemini")
+	if warnings := CheckExperimental(gemini, cmd); len(warnings) != 1 || !strings.Contains(warnings[0], "ignored") {
+		t.Errorf("expected an ignored warning for gemini, got %v", warnings)
+	}
+}
+
+func TestDisabledByDefaultCommand(t *testing.T) {
+	enabled := false
+	cmd := NewCommand("deploy", "Deploy the service")
+	cmd.Instructions = "Deploy."
+	cmd.EnabledByDefault = &enabled
+
+	claude, _ := GetAdapter("claude")
+	data, err := claude.Marshal(cmd)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), "disable-model-invocation: true\n") {
+		t.Errorf("expected the disabled marker in Claude output, got:\n%s", data)
+	}
+	if len(CheckEnabledByDefault(claude, cmd)) != 0 {
+		t.Error("expected no warning for claude")
+	}
+
+	gemini, _ := GetAdapter("gemini")
+	if warnings := CheckEnabledByDefault(gemini, cmd); len(warnings) != 1 || !strings.Contains(warnings[0], "generated enabled") {
+		t.Errorf("expected a warning for gemini, got %v", warnings)
+	}
+}
@@ -7,8 +7,14 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/agentplexus/assistantkit/internal/ignore"
+	"github.com/agentplexus/assistantkit/internal/include"
+	"github.com/agentplexus/assistantkit/internal/jsonc"
+	"gopkg.in/yaml.v3"
 )
 
 // DefaultFileMode is the default permission for generated files.
@@ -147,9 +153,9 @@ func ReadCanonicalFile(path string) (*Command, error) {
 		return cmd, nil
 	}
 
-	// Fall back to JSON
+	// Fall back to JSON. Comments and trailing commas are allowed.
 	var cmd Command
-	if err := json.Unmarshal(data, &cmd); err != nil {
+	if err := jsonc.Unmarshal(data, &cmd); err != nil {
 		return nil, &ParseError{Format: "canonical", Path: path, Err: err}
 	}
 
@@ -176,7 +182,16 @@ func WriteCanonicalFile(cmd *Command, path string) error {
 }
 
 // ReadCanonicalDir reads all command files (.json or .md) from a directory.
+// Include directives are resolved relative to the parent of dir, and files
+// matched by a .assistantkitignore file there are skipped.
 func ReadCanonicalDir(dir string) ([]*Command, error) {
+	root := filepath.Dir(filepath.Clean(dir))
+
+	ignored, err := ignore.Load(root)
+	if err != nil {
+		return nil, &ReadError{Path: filepath.Join(root, ignore.FileName), Err: err}
+	}
+
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, &ReadError{Path: dir, Err: err}
@@ -194,16 +209,36 @@ func ReadCanonicalDir(dir string) ([]*Command, error) {
 		}
 
 		path := filepath.Join(dir, entry.Name())
+		if rel, err := filepath.Rel(root, path); err == nil && ignored.Match(rel, false) {
+			continue
+		}
 		cmd, err := ReadCanonicalFile(path)
 		if err != nil {
 			return nil, err
 		}
+		if err := ResolveIncludes(cmd, root); err != nil {
+			return nil, &ReadError{Path: path, Err: err}
+		}
+		if err := cmd.Validate(); err != nil {
+			return nil, &ReadError{Path: path, Err: err}
+		}
 		commands = append(commands, cmd)
 	}
 
 	return commands, nil
 }
 
+// ResolveIncludes inlines {{include: path}} fragments in the command's
+// instructions. Paths are relative to root, normally the specs directory.
+func ResolveIncludes(cmd *Command, root string) error {
+	instructions, err := include.Resolve(cmd.Instructions, root)
+	if err != nil {
+		return err
+	}
+	cmd.Instructions = instructions
+	return nil
+}
+
 // WriteCommandsToDir writes multiple commands to a directory using the specified adapter.
 func WriteCommandsToDir(commands []*Command, dir string, adapterName string) error {
 	adapter, ok := GetAdapter(adapterName)
@@ -255,6 +290,17 @@ func ParseCommandMarkdown(data []byte) (*Command, error) {
 			continue
 		}
 
+		// Indented key: value lines under experimental are passthrough options
+		if currentKey == "experimental" && (line[0] == ' ' || line[0] == '\t') {
+			if idx := strings.Index(trimmed, ":"); idx > 0 {
+				if cmd.Experimental == nil {
+					cmd.Experimental = make(map[string]any)
+				}
+				cmd.Experimental[strings.TrimSpace(trimmed[:idx])] = parseValue(strings.TrimSpace(trimmed[idx+1:]))
+			}
+			continue
+		}
+
 		// Check if this is a list item (starts with -)
 		if strings.HasPrefix(trimmed, "- ") {
 			if currentKey != "" {
@@ -290,6 +336,22 @@ func ParseCommandMarkdown(data []byte) (*Command, error) {
 			cmd.Name = value
 		case "description":
 			cmd.Description = value
+		case "type":
+			cmd.Type = CommandType(value)
+		case "run":
+			cmd.Run = value
+		case "approval":
+			cmd.Approval = Approval(value)
+		case "helpUrl":
+			cmd.HelpURL = value
+		case "enabledByDefault":
+			if enabled, err := strconv.ParseBool(value); err == nil {
+				cmd.EnabledByDefault = &enabled
+			}
+		case "deprecated":
+			cmd.Deprecated = value == "true"
+		case "deprecationMessage":
+			cmd.DeprecationMessage = value
 		case "dependencies":
 			if value != "" {
 				cmd.Dependencies = parseList(value)
@@ -325,6 +387,16 @@ func ParseCommandMarkdown(data []byte) (*Command, error) {
 	return cmd, nil
 }
 
+// parseValue parses a YAML scalar or flow collection, falling back to the
+// raw string.
+func parseValue(s string) any {
+	var v any
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil || v == nil {
+		return s
+	}
+	return v
+}
+
 // parseList parses a comma-separated or bracket-enclosed list.
 func parseList(s string) []string {
 	s = strings.Trim(s, "[]")
@@ -1,6 +1,38 @@
 // Package core provides canonical types for AI assistant command/prompt definitions.
 package core
 
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CommandType distinguishes prompt commands from shell commands.
+type CommandType string
+
+const (
+	// TypePrompt injects the instructions as a prompt (the default).
+	TypePrompt CommandType = "prompt"
+
+	// TypeShell runs a shell command and feeds its output to the assistant.
+	TypeShell CommandType = "shell"
+)
+
+// Approval is a policy for confirming a command or agent's actions.
+type Approval string
+
+const (
+	// ApprovalAuto runs without asking for confirmation.
+	ApprovalAuto Approval = "auto"
+
+	// ApprovalAsk asks for confirmation before running (the usual default).
+	ApprovalAsk Approval = "ask"
+
+	// ApprovalNever never runs on the assistant's initiative; only the user
+	// can invoke it.
+	ApprovalNever Approval = "never"
+)
+
 // Command represents a canonical command/prompt definition that can be
 // converted to tool-specific formats (Claude, Gemini, Codex).
 type Command struct {
@@ -8,6 +40,42 @@ type Command struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 
+	// Type is "prompt" (default) or "shell".
+	Type CommandType `json:"type,omitempty"`
+
+	// Run is the shell command executed by shell-type commands. Instructions,
+	// if set, tell the assistant what to do with its output.
+	Run string `json:"run,omitempty"`
+
+	// Approval is "auto", "ask", or "never". Tools without an equivalent
+	// setting ignore it.
+	Approval Approval `json:"approval,omitempty"`
+
+	// HelpURL links to the command's documentation (an http or https URL).
+	// It is assistantkit metadata, not a tool setting: the Claude adapter
+	// keeps it in frontmatter as helpUrl so it round-trips, and other
+	// adapters drop it.
+	HelpURL string `json:"helpUrl,omitempty"`
+
+	// EnabledByDefault set to false ships the command disabled, so users
+	// opt in to it. Nil means enabled. Tools with a disabled marker emit it
+	// (see DisabledAdapter).
+	EnabledByDefault *bool `json:"enabledByDefault,omitempty"`
+
+	// Deprecated marks a command being phased out. It is still generated,
+	// but generation warns, and the generation manifest records it.
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// DeprecationMessage tells users what to use instead. It is included in
+	// the deprecation warning.
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+
+	// Experimental holds tool options the library does not model, written
+	// verbatim into the command's native format by adapters with
+	// passthrough (see ExperimentalAdapter). They are not validated, and
+	// never override a setting the adapter already writes.
+	Experimental map[string]any `json:"experimental,omitempty"`
+
 	// Arguments
 	Arguments []Argument `json:"arguments,omitempty"`
 
@@ -50,6 +118,66 @@ func NewCommand(name, description string) *Command {
 	}
 }
 
+// NewShellCommand creates a shell-type Command that runs the given shell command.
+func NewShellCommand(name, description, run string) *Command {
+	return &Command{
+		Name:        name,
+		Description: description,
+		Type:        TypeShell,
+		Run:         run,
+	}
+}
+
+// IsShell reports whether the command runs a shell command.
+func (c *Command) IsShell() bool {
+	return c.Type == TypeShell
+}
+
+// IsEnabledByDefault reports whether the command ships enabled.
+func (c *Command) IsEnabledByDefault() bool {
+	return c.EnabledByDefault == nil || *c.EnabledByDefault
+}
+
+// DeprecationWarning returns the warning printed when a deprecated command is
+// generated, or "" when the command is not deprecated.
+func (c *Command) DeprecationWarning() string {
+	if !c.Deprecated {
+		return ""
+	}
+	if c.DeprecationMessage == "" {
+		return fmt.Sprintf("command %q is deprecated", c.Name)
+	}
+	return fmt.Sprintf("command %q is deprecated: %s", c.Name, c.DeprecationMessage)
+}
+
+// Validate checks the command type, approval policy, and help URL, and
+// that shell commands have a Run command.
+func (c *Command) Validate() error {
+	switch c.Approval {
+	case "", ApprovalAuto, ApprovalAsk, ApprovalNever:
+	default:
+		return fmt.Errorf("%w %q", ErrInvalidApproval, c.Approval)
+	}
+
+	if c.HelpURL != "" {
+		if u, err := url.Parse(c.HelpURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w %q", ErrInvalidHelpURL, c.HelpURL)
+		}
+	}
+
+	switch c.Type {
+	case "", TypePrompt:
+		return nil
+	case TypeShell:
+		if strings.TrimSpace(c.Run) == "" {
+			return ErrMissingRun
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w %q", ErrInvalidType, c.Type)
+	}
+}
+
 // AddArgument adds an argument to the command.
 func (c *Command) AddArgument(arg Argument) {
 	c.Arguments = append(c.Arguments, arg)
@@ -1,6 +1,11 @@
 package core
 
-import "testing"
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestNewCommand(t *testing.T) {
 	cmd := NewCommand("release", "Execute release workflow")
@@ -93,3 +98,64 @@ func TestCommandAddExample(t *testing.T) {
 		t.Errorf("expected Input '/release v1.0.0', got '%s'", ex.Input)
 	}
 }
+
+func TestCommandArgumentsSchema(t *testing.T) {
+	cmd := NewCommand("release", "Execute release workflow")
+	cmd.AddRequiredArgument("version", "Semantic version", "v1.2.3")
+	cmd.AddOptionalArgument("branch", "Release branch", "main")
+	cmd.AddArgument(Argument{Name: "dry-run", Type: "boolean", Default: "true"})
+
+	var schema struct {
+		Type       string                            `json:"type"`
+		Required   []string                          `json:"required"`
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(cmd.ArgumentsSchema(), &schema); err != nil {
+		t.Fatalf("ArgumentsSchema returned invalid JSON: %v", err)
+	}
+
+	if schema.Type != "object" {
+		t.Errorf("expected type 'object', got '%s'", schema.Type)
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "version" {
+		t.Errorf("expected required [version], got %v", schema.Required)
+	}
+	if len(schema.Properties) != 3 {
+		t.Errorf("expected 3 properties, got %d", len(schema.Properties))
+	}
+	if schema.Properties["branch"]["default"] != "main" {
+		t.Errorf("expected branch default 'main', got %v", schema.Properties["branch"]["default"])
+	}
+	if schema.Properties["dry-run"]["type"] != "boolean" {
+		t.Errorf("expected dry-run type 'boolean', got %v", schema.Properties["dry-run"]["type"])
+	}
+	if schema.Properties["dry-run"]["default"] != true {
+		t.Errorf("expected dry-run default true, got %v", schema.Properties["dry-run"]["default"])
+	}
+}
+
+func TestReadCanonicalDirHonorsIgnoreFile(t *testing.T) {
+	specs := t.TempDir()
+	commandsDir := filepath.Join(specs, "commands")
+	if err := os.MkdirAll(commandsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		".assistantkitignore":    "# fragments\n_*.md\n",
+		"commands/release.md":    "---\nname: release\ndescription: Release\n---\n\nCut a release.\n",
+		"commands/_checklist.md": "- [ ] tag\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(specs, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commands, err := ReadCanonicalDir(commandsDir)
+	if err != nil {
+		t.Fatalf("ReadCanonicalDir failed: %v", err)
+	}
+	if len(commands) != 1 || commands[0].Name != "release" {
+		t.Errorf("expected only release to be read, got %d commands", len(commands))
+	}
+}
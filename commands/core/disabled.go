@@ -0,0 +1,21 @@
+package core
+
+import "github.com/agentplexus/assistantkit/internal/capability"
+
+// DisabledAdapter is implemented by adapters whose format can ship a
+// command disabled, so users opt in to it (see Command.EnabledByDefault).
+type DisabledAdapter interface {
+	SupportsDisabled() bool
+}
+
+// SupportsDisabled reports whether the adapter emits a disabled marker.
+func SupportsDisabled(adapter Adapter) bool {
+	da, ok := adapter.(DisabledAdapter)
+	return ok && da.SupportsDisabled()
+}
+
+// CheckEnabledByDefault returns a warning when the command is disabled by
+// default but the adapter has no way to ship it disabled.
+func CheckEnabledByDefault(adapter Adapter, cmd *Command) []string {
+	return capability.Disabled("command", cmd.Name, adapter.Name(), cmd.IsEnabledByDefault(), SupportsDisabled(adapter))
+}
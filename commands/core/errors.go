@@ -1,6 +1,25 @@
 package core
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidType is returned for a command type other than prompt or shell.
+	ErrInvalidType = errors.New("invalid command type")
+
+	// ErrMissingRun is returned for a shell command without a run command.
+	ErrMissingRun = errors.New("shell command requires run")
+
+	// ErrInvalidApproval is returned for an approval policy other than auto,
+	// ask, or never.
+	ErrInvalidApproval = errors.New("invalid approval policy")
+
+	// ErrInvalidHelpURL is returned for a help URL that is not an absolute
+	// http or https URL.
+	ErrInvalidHelpURL = errors.New("invalid help URL")
+)
 
 // ParseError occurs when parsing tool-specific format fails.
 type ParseError struct {
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect used for argument schemas.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ArgumentsSchema returns a JSON Schema describing the command's arguments.
+// Each argument becomes a property; required arguments are listed under
+// "required". Argument types map to JSON Schema types (string, number,
+// boolean), defaulting to string. Returns nil if the schema cannot be marshaled.
+func (c *Command) ArgumentsSchema() []byte {
+	properties := make(map[string]interface{}, len(c.Arguments))
+	required := make([]string, 0)
+
+	for _, arg := range c.Arguments {
+		prop := map[string]interface{}{
+			"type": schemaType(arg.Type),
+		}
+		if arg.Description != "" {
+			prop["description"] = arg.Description
+		} else if arg.Hint != "" {
+			prop["description"] = arg.Hint
+		}
+		if arg.Pattern != "" && prop["type"] == "string" {
+			prop["pattern"] = arg.Pattern
+		}
+		if arg.Hint != "" {
+			prop["examples"] = []string{arg.Hint}
+		}
+		if arg.Default != "" {
+			prop["default"] = schemaDefault(prop["type"].(string), arg.Default)
+		}
+		properties[arg.Name] = prop
+
+		if arg.Required {
+			required = append(required, arg.Name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"$schema":              JSONSchemaDraft,
+		"title":                c.Name,
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if c.Description != "" {
+		schema["description"] = c.Description
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// schemaType maps an argument type to a JSON Schema type.
+func schemaType(argType string) string {
+	switch argType {
+	case "number", "integer", "boolean":
+		return argType
+	case "int":
+		return "integer"
+	case "bool":
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// schemaDefault converts a string default to the schema type when possible.
+func schemaDefault(typ, value string) interface{} {
+	switch typ {
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "integer":
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
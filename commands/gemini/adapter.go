@@ -2,8 +2,11 @@
 package gemini
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/agentplexus/assistantkit/commands/core"
@@ -14,6 +17,9 @@ func init() {
 	core.Register(&Adapter{})
 }
 
+// shellInjection matches a leading !{command} shell injection in instructions.
+var shellInjection = regexp.MustCompile(`^!\{([^}]+)\}`)
+
 // Adapter converts between canonical Command and Gemini CLI command format.
 type Adapter struct{}
 
@@ -39,6 +45,9 @@ type GeminiCommand struct {
 	Content   ContentSection `toml:"content"`
 	Process   []string       `toml:"process,omitempty"`
 	Examples  []ExampleToml  `toml:"examples,omitempty"`
+
+	// ArgumentsSchema is the JSON Schema for the arguments, used for validation.
+	ArgumentsSchema string `toml:"arguments_schema,omitempty"`
 }
 
 // CommandSection contains command metadata.
@@ -83,6 +92,13 @@ func (a *Adapter) Parse(data []byte) (*core.Command, error) {
 		Process:      gc.Process,
 	}
 
+	// A leading !{command} makes this a shell command
+	if m := shellInjection.FindStringSubmatch(cmd.Instructions); m != nil {
+		cmd.Type = core.TypeShell
+		cmd.Run = m[1]
+		cmd.Instructions = strings.TrimSpace(cmd.Instructions[len(m[0]):])
+	}
+
 	// Convert arguments
 	for _, arg := range gc.Arguments {
 		cmd.Arguments = append(cmd.Arguments, core.Argument{
@@ -120,6 +136,11 @@ func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
 		Process: cmd.Process,
 	}
 
+	// Shell commands use Gemini's !{...} shell injection
+	if cmd.IsShell() {
+		gc.Content.Instructions = strings.TrimSpace("!{" + cmd.Run + "}\n\n" + cmd.Instructions)
+	}
+
 	// Convert arguments
 	for _, arg := range cmd.Arguments {
 		gc.Arguments = append(gc.Arguments, ArgumentToml{
@@ -132,6 +153,13 @@ func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
 		})
 	}
 
+	if len(cmd.Arguments) > 0 {
+		var schema bytes.Buffer
+		if err := json.Compact(&schema, cmd.ArgumentsSchema()); err == nil {
+			gc.ArgumentsSchema = schema.String()
+		}
+	}
+
 	// Convert examples
 	for _, ex := range cmd.Examples {
 		gc.Examples = append(gc.Examples, ExampleToml{
@@ -0,0 +1,159 @@
+// Package windsurf provides the Windsurf (Codeium) command adapter.
+//
+// Windsurf exposes reusable prompts as Cascade workflows in
+// .windsurf/workflows/<name>.md: Markdown with a description in YAML
+// frontmatter, invoked as /<name>. The format matches Claude Code commands,
+// except that shell commands become a "// turbo" step, which Cascade runs
+// without asking for confirmation, and the auto approval policy becomes a
+// "// turbo-all" annotation that auto-runs every step.
+package windsurf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/commands/claude"
+	"github.com/agentplexus/assistantkit/commands/core"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "windsurf"
+
+	// WorkflowsDir is the default workflows directory name.
+	WorkflowsDir = ".windsurf/workflows"
+)
+
+func init() {
+	core.Register(&Adapter{})
+}
+
+// Adapter converts between canonical Command and Windsurf workflow format.
+// Parsing and marshaling are shared with the Claude adapter apart from
+// shell commands.
+type Adapter struct {
+	claude.Adapter
+}
+
+// Name returns the adapter identifier.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultDir returns the default directory name for Windsurf workflows.
+func (a *Adapter) DefaultDir() string {
+	return WorkflowsDir
+}
+
+var (
+	// runStep matches the step written for shell commands, annotated to
+	// auto-run under the auto approval policy.
+	runStep = regexp.MustCompile("(?m)^(// turbo\n)?1\\. Run `([^`]+)`[ \t]*$")
+
+	// turboAll matches the annotation written for the auto approval policy.
+	turboAll = regexp.MustCompile(`(?m)^// turbo-all[ \t]*$`)
+)
+
+// Parse converts Windsurf workflow Markdown bytes to canonical Command.
+func (a *Adapter) Parse(data []byte) (*core.Command, error) {
+	cmd, err := a.Adapter.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if m := turboAll.FindStringIndex(cmd.Instructions); m != nil {
+		cmd.Approval = core.ApprovalAuto
+		cmd.Instructions = strings.TrimSpace(cmd.Instructions[:m[0]] + cmd.Instructions[m[1]:])
+	}
+	if m := runStep.FindStringSubmatchIndex(cmd.Instructions); m != nil {
+		cmd.Type = core.TypeShell
+		cmd.Run = cmd.Instructions[m[4]:m[5]]
+		if m[2] >= 0 {
+			cmd.Approval = core.ApprovalAuto
+		}
+		cmd.Instructions = strings.TrimSpace(cmd.Instructions[:m[0]] + cmd.Instructions[m[1]:])
+	}
+	return cmd, nil
+}
+
+// Marshal converts canonical Command to Windsurf workflow Markdown bytes.
+func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
+	// Workflows only run when the user invokes them, so "never" needs no
+	// marker
+	prompt := *cmd
+	if !cmd.IsShell() && cmd.Approval != core.ApprovalAuto {
+		prompt.Approval = ""
+		return a.Adapter.Marshal(&prompt)
+	}
+
+	prompt.Type = core.TypePrompt
+	prompt.Run = ""
+	prompt.Approval = ""
+	data, err := a.Adapter.Marshal(&prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimRight(data, "\n"))
+	if cmd.IsShell() {
+		// Only the auto policy may run the step without confirmation
+		buf.WriteString("\n\n")
+		if cmd.Approval == core.ApprovalAuto {
+			buf.WriteString("// turbo\n")
+		}
+		buf.WriteString(fmt.Sprintf("1. Run `%s`", cmd.Run))
+	}
+	if cmd.Approval == core.ApprovalAuto {
+		buf.WriteString("\n\n// turbo-all")
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// ReadFile reads a Windsurf workflow file and returns canonical Command.
+func (a *Adapter) ReadFile(path string) (*core.Command, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ReadError{Path: path, Err: err}
+	}
+
+	cmd, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+
+	// Infer name from filename if not set
+	if cmd.Name == "" {
+		base := filepath.Base(path)
+		cmd.Name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+
+	return cmd, nil
+}
+
+// WriteFile writes canonical Command to a Windsurf workflow file.
+func (a *Adapter) WriteFile(cmd *core.Command, path string) error {
+	data, err := a.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, core.DefaultDirMode); err != nil {
+		return &core.WriteError{Path: path, Err: err}
+	}
+
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Path: path, Err: err}
+	}
+
+	return nil
+}
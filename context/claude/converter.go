@@ -37,6 +37,10 @@ func (c *Converter) Convert(ctx *core.Context) ([]byte, error) {
 	if ctx.Name == "" {
 		return nil, &core.ConversionError{Format: ConverterName, Err: core.ErrMissingName}
 	}
+	style := ctx.GetSeverityStyle()
+	if !style.Valid() {
+		return nil, &core.ConversionError{Format: ConverterName, Err: core.ErrUnsupportedSeverityStyle}
+	}
 
 	var b strings.Builder
 
@@ -197,14 +201,11 @@ func (c *Converter) Convert(ctx *core.Context) ([]byte, error) {
 	if len(ctx.Notes) > 0 {
 		b.WriteString("## Notes\n\n")
 		for _, note := range ctx.Notes {
-			severity := note.GetSeverity()
-			prefix := ""
-			switch severity {
-			case "warning":
-				prefix = "**Warning:** "
-			case "critical":
-				prefix = "**CRITICAL:** "
+			if style == core.SeverityStyleAdmonition {
+				b.WriteString(core.FormatAdmonition(note) + "\n")
+				continue
 			}
+			prefix := core.SeverityPrefix(note.GetSeverity())
 			if note.Title != "" {
 				b.WriteString(fmt.Sprintf("### %s\n\n%s%s\n\n", note.Title, prefix, note.Content))
 			} else {
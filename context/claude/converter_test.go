@@ -1,6 +1,7 @@
 package claude
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -281,6 +282,45 @@ func TestConverterConvertWithNotes(t *testing.T) {
 	}
 }
 
+func TestConverterConvertWithAdmonitionNotes(t *testing.T) {
+	c := NewConverter()
+	ctx := core.NewContext("test")
+	ctx.SeverityStyle = core.SeverityStyleAdmonition
+	ctx.AddNote("Simple note")
+	ctx.AddNoteWithSeverity("Warning Title", "This is a warning", "warning")
+	ctx.AddNoteWithSeverity("Critical Issue", "This is critical", "critical")
+
+	data, err := c.Convert(ctx)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	md := string(data)
+	for _, want := range []string{
+		"> [!NOTE]\n> Simple note\n",
+		"> [!WARNING]\n> **Warning Title**\n>\n> This is a warning\n",
+		"> [!CRITICAL]\n> **Critical Issue**\n>\n> This is critical\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "**CRITICAL:**") {
+		t.Error("expected no plain severity prefix in admonition style")
+	}
+}
+
+func TestConverterConvertUnsupportedSeverityStyle(t *testing.T) {
+	c := NewConverter()
+	ctx := core.NewContext("test")
+	ctx.SeverityStyle = "emoji"
+
+	_, err := c.Convert(ctx)
+	if !errors.Is(err, core.ErrUnsupportedSeverityStyle) {
+		t.Errorf("expected ErrUnsupportedSeverityStyle, got %v", err)
+	}
+}
+
 func TestConverterConvertWithRelated(t *testing.T) {
 	c := NewConverter()
 	ctx := core.NewContext("test")
@@ -32,7 +32,10 @@
 // # Supported Formats
 //
 //   - claude: CLAUDE.md for Claude Code
-//   - (future) cursor: .cursorrules for Cursor IDE
+//   - cursor: .cursor/rules/project.mdc for Cursor (rules can also be
+//     imported with cursor.ReadFile and cursor.ReadDir)
+//   - jetbrains: .junie/guidelines.md for JetBrains Junie
+//   - makefile: context.mk makefile with a target per context command
 //   - (future) copilot: .github/copilot-instructions.md for GitHub Copilot
 package context
 
@@ -72,6 +75,9 @@ type (
 	// Related represents a related project or resource.
 	Related = core.Related
 
+	// SeverityStyle selects how converters render note severity.
+	SeverityStyle = core.SeverityStyle
+
 	// Converter is the interface for format converters.
 	Converter = core.Converter
 
@@ -90,6 +96,14 @@ var (
 	ErrEmptyContext      = core.ErrEmptyContext
 	ErrMissingName       = core.ErrMissingName
 	ErrUnsupportedFormat = core.ErrUnsupportedFormat
+
+	ErrUnsupportedSeverityStyle = core.ErrUnsupportedSeverityStyle
+)
+
+// Re-export severity styles.
+const (
+	SeverityStylePlain      = core.SeverityStylePlain
+	SeverityStyleAdmonition = core.SeverityStyleAdmonition
 )
 
 // NewContext creates a new empty Context with the given name.
@@ -102,6 +116,11 @@ func ReadFile(path string) (*Context, error) {
 	return core.ReadFile(path)
 }
 
+// ReadSplit reads a Context split across the JSON section files in dir.
+func ReadSplit(dir string) (*Context, error) {
+	return core.ReadSplit(dir)
+}
+
 // Parse parses JSON data into a Context.
 func Parse(data []byte) (*Context, error) {
 	return core.Parse(data)
@@ -5,6 +5,8 @@ package core
 import (
 	"encoding/json"
 	"os"
+
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
 )
 
 // Context represents the canonical project context that can be
@@ -49,6 +51,10 @@ type Context struct {
 	// Notes contains additional notes and gotchas.
 	Notes []Note `json:"notes,omitempty"`
 
+	// SeverityStyle selects how converters render note severity
+	// ("plain" or "admonition"). Defaults to plain.
+	SeverityStyle SeverityStyle `json:"severityStyle,omitempty"`
+
 	// Related lists related projects or resources.
 	Related []Related `json:"related,omitempty"`
 }
@@ -154,7 +160,7 @@ type Note struct {
 // GetSeverity returns the severity, defaulting to "info".
 func (n *Note) GetSeverity() string {
 	if n.Severity == "" {
-		return "info"
+		return SeverityInfo
 	}
 	return n.Severity
 }
@@ -199,7 +205,7 @@ func Parse(data []byte) (*Context, error) {
 
 // Marshal converts the Context to JSON.
 func (c *Context) Marshal() ([]byte, error) {
-	return json.MarshalIndent(c, "", "  ")
+	return canonicaljson.Marshal(c)
 }
 
 // WriteFile writes the Context to a JSON file using DefaultFileMode.
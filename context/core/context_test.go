@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -307,3 +308,54 @@ func TestWriteFileContents(t *testing.T) {
 		t.Errorf("expected name 'test', got '%s'", parsed.Name)
 	}
 }
+
+func TestReadSplit(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"context.json":      `{"name": "mono", "language": "go", "commands": {"build": "make"}}`,
+		"architecture.json": `{"pattern": "monorepo", "summary": "Services and shared libraries"}`,
+		"packages.json":     `[{"path": "svc/api", "purpose": "HTTP API"}, {"path": "lib/db", "purpose": "Storage"}]`,
+		"commands.json":     `{"build": "go build ./...", "test": "go test ./..."}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, err := ReadSplit(dir)
+	if err != nil {
+		t.Fatalf("ReadSplit failed: %v", err)
+	}
+
+	if ctx.Name != "mono" || ctx.Language != "go" {
+		t.Errorf("unexpected name/language %q/%q", ctx.Name, ctx.Language)
+	}
+	if ctx.Architecture == nil || ctx.Architecture.Pattern != "monorepo" {
+		t.Errorf("expected architecture from architecture.json, got %+v", ctx.Architecture)
+	}
+	if len(ctx.Packages) != 2 || ctx.Packages[1].Path != "lib/db" {
+		t.Errorf("expected packages from packages.json, got %+v", ctx.Packages)
+	}
+	// context.json sorts after commands.json, so its build command wins.
+	if ctx.Commands["build"] != "make" || ctx.Commands["test"] != "go test ./..." {
+		t.Errorf("unexpected commands %v", ctx.Commands)
+	}
+}
+
+func TestReadSplitErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ReadSplit(dir); !errors.Is(err, ErrEmptyContext) {
+		t.Errorf("expected ErrEmptyContext for empty dir, got %v", err)
+	}
+
+	path := filepath.Join(dir, "packages.json")
+	if err := os.WriteFile(path, []byte(`{"path": "x"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := ReadSplit(dir)
+	pe, ok := err.(*ParseError)
+	if !ok || pe.Path != path {
+		t.Errorf("expected ParseError for %s, got %v", path, err)
+	}
+}
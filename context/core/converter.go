@@ -9,6 +9,10 @@ import (
 // This can be used by converters or overridden with WriteFileWithDataAndMode.
 const DefaultFileMode fs.FileMode = 0600
 
+// DefaultDirMode is the default permission mode for directories created
+// for generated files, matching the other project config directories.
+const DefaultDirMode fs.FileMode = 0755
+
 // Converter defines the interface for converting project context
 // to tool-specific formats.
 type Converter interface {
@@ -15,6 +15,9 @@ var (
 
 	// ErrUnsupportedFormat is returned when a format is not supported.
 	ErrUnsupportedFormat = errors.New("unsupported output format")
+
+	// ErrUnsupportedSeverityStyle is returned when a severity style is not supported.
+	ErrUnsupportedSeverityStyle = errors.New("unsupported severity style")
 )
 
 // ParseError represents an error parsing a context file.
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Note severities.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// SeverityStyle selects how converters render note severity.
+type SeverityStyle string
+
+const (
+	// SeverityStylePlain renders a bold prefix such as "**Warning:** ".
+	SeverityStylePlain SeverityStyle = "plain"
+
+	// SeverityStyleAdmonition renders GitHub-style admonition blocks
+	// such as "> [!WARNING]".
+	SeverityStyleAdmonition SeverityStyle = "admonition"
+)
+
+// Valid reports whether the style is a known severity style.
+func (s SeverityStyle) Valid() bool {
+	return s == SeverityStylePlain || s == SeverityStyleAdmonition
+}
+
+// GetSeverityStyle returns the severity style, defaulting to plain.
+func (c *Context) GetSeverityStyle() SeverityStyle {
+	if c.SeverityStyle == "" {
+		return SeverityStylePlain
+	}
+	return c.SeverityStyle
+}
+
+// SeverityPrefix returns the plain-style prefix for a severity, or an empty
+// string for info notes.
+func SeverityPrefix(severity string) string {
+	switch severity {
+	case SeverityWarning:
+		return "**Warning:** "
+	case SeverityCritical:
+		return "**CRITICAL:** "
+	default:
+		return ""
+	}
+}
+
+// AdmonitionType returns the admonition marker for a severity
+// (NOTE, WARNING, or CRITICAL).
+func AdmonitionType(severity string) string {
+	switch severity {
+	case SeverityWarning:
+		return "WARNING"
+	case SeverityCritical:
+		return "CRITICAL"
+	default:
+		return "NOTE"
+	}
+}
+
+// FormatAdmonition renders a note as an admonition block. The title, if
+// any, is written in bold on the first line of the body. The block ends
+// with a newline; callers separate consecutive blocks with a blank line.
+func FormatAdmonition(note Note) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("> [!%s]\n", AdmonitionType(note.GetSeverity())))
+	if note.Title != "" {
+		b.WriteString(fmt.Sprintf("> **%s**\n>\n", note.Title))
+	}
+	for _, line := range strings.Split(strings.TrimRight(note.Content, "\n"), "\n") {
+		if line == "" {
+			b.WriteString(">\n")
+		} else {
+			b.WriteString("> " + line + "\n")
+		}
+	}
+	return b.String()
+}
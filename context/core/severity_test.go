@@ -0,0 +1,46 @@
+package core
+
+import "testing"
+
+func TestGetSeverityStyle(t *testing.T) {
+	ctx := NewContext("test")
+	if got := ctx.GetSeverityStyle(); got != SeverityStylePlain {
+		t.Errorf("expected default style %q, got %q", SeverityStylePlain, got)
+	}
+	ctx.SeverityStyle = SeverityStyleAdmonition
+	if got := ctx.GetSeverityStyle(); got != SeverityStyleAdmonition {
+		t.Errorf("expected style %q, got %q", SeverityStyleAdmonition, got)
+	}
+	if SeverityStyle("emoji").Valid() {
+		t.Error("expected unknown style to be invalid")
+	}
+}
+
+func TestFormatAdmonition(t *testing.T) {
+	tests := []struct {
+		note Note
+		want string
+	}{
+		{Note{Content: "Just info"}, "> [!NOTE]\n> Just info\n"},
+		{Note{Content: "Careful", Severity: SeverityWarning}, "> [!WARNING]\n> Careful\n"},
+		{
+			Note{Title: "Secrets", Content: "Never commit .env\n\nUse a vault.", Severity: SeverityCritical},
+			"> [!CRITICAL]\n> **Secrets**\n>\n> Never commit .env\n>\n> Use a vault.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := FormatAdmonition(tt.note); got != tt.want {
+			t.Errorf("FormatAdmonition(%+v) = %q, want %q", tt.note, got, tt.want)
+		}
+	}
+}
+
+func TestSeverityPrefix(t *testing.T) {
+	if got := SeverityPrefix(SeverityInfo); got != "" {
+		t.Errorf("expected no prefix for info, got %q", got)
+	}
+	if got := SeverityPrefix(SeverityCritical); got != "**CRITICAL:** " {
+		t.Errorf("unexpected critical prefix %q", got)
+	}
+}
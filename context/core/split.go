@@ -0,0 +1,125 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// sections are the Context fields that may be stored in a file of their own
+// when a context is split across a directory.
+var sections = map[string]bool{
+	"architecture": true,
+	"packages":     true,
+	"commands":     true,
+	"conventions":  true,
+	"dependencies": true,
+	"testing":      true,
+	"files":        true,
+	"notes":        true,
+	"related":      true,
+}
+
+// ReadSplit reads a Context split across the JSON files in dir and merges
+// them in file name order. A file named after a section (architecture.json,
+// packages.json, commands.json, ...) holds that section's value; any other
+// file, such as context.json, holds a partial Context.
+func ReadSplit(dir string) (*Context, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, &ParseError{Path: dir, Err: err}
+	}
+	if len(paths) == 0 {
+		return nil, &ParseError{Path: dir, Err: ErrEmptyContext}
+	}
+	sort.Strings(paths)
+
+	merged := &Context{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, &ParseError{Path: path, Err: err}
+		}
+		section := strings.TrimSuffix(filepath.Base(path), ".json")
+		if sections[section] {
+			data, err = json.Marshal(map[string]json.RawMessage{section: data})
+			if err != nil {
+				return nil, &ParseError{Path: path, Err: err}
+			}
+		}
+		part, err := Parse(data)
+		if err != nil {
+			if pe, ok := err.(*ParseError); ok {
+				pe.Path = path
+			}
+			return nil, err
+		}
+		merged.Merge(part)
+	}
+	return merged, nil
+}
+
+// Merge merges other into c. Scalar fields set in other replace those in c,
+// lists are appended, and commands in other override commands of the same
+// name.
+func (c *Context) Merge(other *Context) {
+	if other == nil {
+		return
+	}
+	mergeString(&c.Schema, other.Schema)
+	mergeString(&c.Name, other.Name)
+	mergeString(&c.Description, other.Description)
+	mergeString(&c.Version, other.Version)
+	mergeString(&c.Language, other.Language)
+	if other.SeverityStyle != "" {
+		c.SeverityStyle = other.SeverityStyle
+	}
+
+	if other.Architecture != nil {
+		if c.Architecture == nil {
+			c.Architecture = &Architecture{}
+		}
+		mergeString(&c.Architecture.Pattern, other.Architecture.Pattern)
+		mergeString(&c.Architecture.Summary, other.Architecture.Summary)
+		c.Architecture.Diagrams = append(c.Architecture.Diagrams, other.Architecture.Diagrams...)
+	}
+	if other.Dependencies != nil {
+		if c.Dependencies == nil {
+			c.Dependencies = &Dependencies{}
+		}
+		c.Dependencies.Runtime = append(c.Dependencies.Runtime, other.Dependencies.Runtime...)
+		c.Dependencies.Development = append(c.Dependencies.Development, other.Dependencies.Development...)
+	}
+	if other.Testing != nil {
+		if c.Testing == nil {
+			c.Testing = &Testing{}
+		}
+		mergeString(&c.Testing.Framework, other.Testing.Framework)
+		mergeString(&c.Testing.Coverage, other.Testing.Coverage)
+		c.Testing.Patterns = append(c.Testing.Patterns, other.Testing.Patterns...)
+	}
+	if other.Files != nil {
+		if c.Files == nil {
+			c.Files = &Files{}
+		}
+		c.Files.EntryPoints = append(c.Files.EntryPoints, other.Files.EntryPoints...)
+		c.Files.Config = append(c.Files.Config, other.Files.Config...)
+		c.Files.Ignore = append(c.Files.Ignore, other.Files.Ignore...)
+	}
+
+	for name, command := range other.Commands {
+		c.SetCommand(name, command)
+	}
+	c.Packages = append(c.Packages, other.Packages...)
+	c.Conventions = append(c.Conventions, other.Conventions...)
+	c.Notes = append(c.Notes, other.Notes...)
+	c.Related = append(c.Related, other.Related...)
+}
+
+func mergeString(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
@@ -0,0 +1,145 @@
+// Package cursor provides a converter between the canonical project context
+// format and Cursor project rules (.cursor/rules/*.mdc).
+//
+// A rule file is Markdown with a small frontmatter block:
+//
+//	---
+//	description: Go conventions
+//	globs: *.go
+//	alwaysApply: false
+//	---
+//
+//	- Wrap errors with %w.
+//
+// Convert writes the whole context as a single always-applied rule.
+// ReadFile and ReadDir import existing rules back into a Context.
+package cursor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+const (
+	// ConverterName is the identifier for this converter.
+	ConverterName = "cursor"
+
+	// RulesDir is the directory Cursor reads project rules from.
+	RulesDir = ".cursor/rules"
+
+	// RuleExtension is the file extension of Cursor rule files.
+	RuleExtension = ".mdc"
+
+	// OutputFile is the default output file name within RulesDir.
+	OutputFile = "project" + RuleExtension
+)
+
+// Converter implements core.Converter for Cursor project rules.
+type Converter struct {
+	core.BaseConverter
+}
+
+// NewConverter creates a new Cursor converter.
+func NewConverter() *Converter {
+	return &Converter{
+		BaseConverter: core.NewBaseConverter(ConverterName, filepath.Join(RulesDir, OutputFile)),
+	}
+}
+
+// Convert converts the context to a Cursor rule file.
+func (c *Converter) Convert(ctx *core.Context) ([]byte, error) {
+	if ctx == nil {
+		return nil, &core.ConversionError{Format: ConverterName, Err: core.ErrEmptyContext}
+	}
+	if ctx.Name == "" {
+		return nil, &core.ConversionError{Format: ConverterName, Err: core.ErrMissingName}
+	}
+	style := ctx.GetSeverityStyle()
+	if !style.Valid() {
+		return nil, &core.ConversionError{Format: ConverterName, Err: core.ErrUnsupportedSeverityStyle}
+	}
+
+	var b strings.Builder
+
+	// Frontmatter
+	b.WriteString("---\n")
+	b.WriteString(fmt.Sprintf("description: %s project context\n", ctx.Name))
+	b.WriteString("globs:\n")
+	b.WriteString("alwaysApply: true\n")
+	b.WriteString("---\n\n")
+
+	// Header
+	b.WriteString(fmt.Sprintf("# %s\n\n", ctx.Name))
+
+	if ctx.Description != "" {
+		b.WriteString(fmt.Sprintf("%s\n\n", ctx.Description))
+	}
+
+	// Commands
+	if len(ctx.Commands) > 0 {
+		b.WriteString("## Commands\n\n")
+		names := make([]string, 0, len(ctx.Commands))
+		for name := range ctx.Commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			b.WriteString(fmt.Sprintf("- %s: `%s`\n", name, ctx.Commands[name]))
+		}
+		b.WriteString("\n")
+	}
+
+	// Conventions
+	if len(ctx.Conventions) > 0 {
+		b.WriteString("## Conventions\n\n")
+		for _, conv := range ctx.Conventions {
+			b.WriteString(fmt.Sprintf("- %s\n", conv))
+		}
+		b.WriteString("\n")
+	}
+
+	// Notes
+	if len(ctx.Notes) > 0 {
+		b.WriteString("## Notes\n\n")
+		for _, note := range ctx.Notes {
+			if style == core.SeverityStyleAdmonition {
+				b.WriteString(core.FormatAdmonition(note) + "\n")
+				continue
+			}
+			prefix := core.SeverityPrefix(note.GetSeverity())
+			if note.Title != "" {
+				b.WriteString(fmt.Sprintf("- **%s**: %s%s\n", note.Title, prefix, note.Content))
+			} else {
+				b.WriteString(fmt.Sprintf("- %s%s\n", prefix, note.Content))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n"), nil
+}
+
+// WriteFile writes the converted context to a rule file, creating the
+// parent directory (e.g., .cursor/rules) if needed.
+func (c *Converter) WriteFile(ctx *core.Context, path string) error {
+	data, err := c.Convert(ctx)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, core.DefaultDirMode); err != nil {
+			return &core.WriteError{Format: ConverterName, Path: path, Err: err}
+		}
+	}
+	return c.WriteFileWithData(data, path)
+}
+
+// init registers the converter with the default registry.
+func init() {
+	core.RegisterConverter(NewConverter())
+}
@@ -0,0 +1,126 @@
+package cursor
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+func TestNewConverter(t *testing.T) {
+	c := NewConverter()
+
+	if c.Name() != ConverterName {
+		t.Errorf("expected name '%s', got '%s'", ConverterName, c.Name())
+	}
+	if c.OutputFileName() != filepath.Join(".cursor", "rules", "project.mdc") {
+		t.Errorf("expected output file '.cursor/rules/project.mdc', got '%s'", c.OutputFileName())
+	}
+}
+
+func TestConverterConvert(t *testing.T) {
+	ctx := core.NewContext("test-project")
+	ctx.Description = "A test project"
+	ctx.SetCommand("test", "go test ./...")
+	ctx.AddConvention("Use gofmt")
+	ctx.AddNoteWithSeverity("Secrets", "Never commit .env files", "critical")
+
+	data, err := NewConverter().Convert(ctx)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	md := string(data)
+	if !strings.HasPrefix(md, "---\ndescription: test-project project context\nglobs:\nalwaysApply: true\n---\n") {
+		t.Errorf("unexpected frontmatter:\n%s", md)
+	}
+	for _, want := range []string{"# test-project", "- test: `go test ./...`", "- Use gofmt", "- **Secrets**: **CRITICAL:** Never commit .env files"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected rule to contain %q, got:\n%s", want, md)
+		}
+	}
+
+	if _, err := NewConverter().Convert(core.NewContext("")); err == nil {
+		t.Error("expected error for missing name")
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule([]byte("---\ndescription: Go style\nglobs: *.go, \"cmd/**\"\nalwaysApply: false\n---\n\n- Wrap errors with %w.\n"))
+	if err != nil {
+		t.Fatalf("ParseRule failed: %v", err)
+	}
+	if rule.Description != "Go style" || rule.AlwaysApply {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+	if want := []string{"*.go", "cmd/**"}; !reflect.DeepEqual(rule.Globs, want) {
+		t.Errorf("Globs = %v, want %v", rule.Globs, want)
+	}
+	if rule.Body != "- Wrap errors with %w." {
+		t.Errorf("Body = %q", rule.Body)
+	}
+
+	if _, err := ParseRule([]byte("---\ndescription: broken\n")); err == nil {
+		t.Error("expected error for unterminated frontmatter")
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	project := filepath.Join(t.TempDir(), "shop")
+	rules := filepath.Join(project, ".cursor", "rules")
+	if err := os.MkdirAll(rules, 0700); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"go-style.mdc": "---\ndescription: Go style\nglobs: *.go\nalwaysApply: false\n---\n\n- Wrap errors with %w.\n- Use table-driven tests.\n",
+		"testing.mdc":  "---\ndescription: Testing\nalwaysApply: true\n---\n\n## Commands\n\n- test: `go test ./...`\n\nRun the race detector before merging.\n",
+		"README.md":    "- not a rule\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(rules, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, err := ReadDir(rules)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+
+	if ctx.Name != "shop" {
+		t.Errorf("Name = %q, want project directory name", ctx.Name)
+	}
+	if want := []string{"Wrap errors with %w.", "Use table-driven tests."}; !reflect.DeepEqual(ctx.Conventions, want) {
+		t.Errorf("Conventions = %v, want %v", ctx.Conventions, want)
+	}
+	if ctx.Commands["test"] != "go test ./..." {
+		t.Errorf("Commands = %v", ctx.Commands)
+	}
+	if want := []core.Note{{Title: "Testing", Content: "Run the race detector before merging."}}; !reflect.DeepEqual(ctx.Notes, want) {
+		t.Errorf("Notes = %+v, want %+v", ctx.Notes, want)
+	}
+}
+
+func TestReadFileRoundTrip(t *testing.T) {
+	ctx := core.NewContext("test-project")
+	ctx.Description = "A test project"
+	ctx.SetCommand("build", "go build ./...")
+	ctx.AddConvention("Use gofmt")
+	ctx.AddNoteWithSeverity("Secrets", "Never commit .env files", "warning")
+
+	path := filepath.Join(t.TempDir(), RulesDir, OutputFile)
+	if err := NewConverter().WriteFile(ctx, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	got, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, ctx) {
+		t.Errorf("round trip mismatch:\ngot  %+v\nwant %+v", got, ctx)
+	}
+}
@@ -0,0 +1,278 @@
+package cursor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+// ErrUnterminatedFrontmatter is returned for a rule whose frontmatter
+// block has no closing delimiter.
+var ErrUnterminatedFrontmatter = errors.New("unterminated rule frontmatter")
+
+// Rule is a parsed Cursor rule file.
+type Rule struct {
+	// Description tells Cursor when the rule is relevant.
+	Description string
+
+	// Globs are the file patterns the rule is attached to.
+	Globs []string
+
+	// AlwaysApply includes the rule in every request.
+	AlwaysApply bool
+
+	// Body is the Markdown content after the frontmatter.
+	Body string
+}
+
+// ParseRule parses a .mdc rule file. Files without frontmatter are treated
+// as body-only rules.
+//
+// Frontmatter values are read line by line rather than as YAML, because
+// Cursor writes unquoted globs (e.g., "globs: *.go") that are not valid YAML.
+func ParseRule(data []byte) (*Rule, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+
+	rule := &Rule{}
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		rule.Body = strings.TrimSpace(text)
+		return rule, nil
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil, &core.ParseError{Err: ErrUnterminatedFrontmatter}
+	}
+
+	for _, line := range lines[1:end] {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = unquote(strings.TrimSpace(value))
+		switch strings.TrimSpace(key) {
+		case "description":
+			rule.Description = value
+		case "globs":
+			rule.Globs = splitGlobs(value)
+		case "alwaysApply":
+			rule.AlwaysApply = value == "true"
+		}
+	}
+
+	rule.Body = strings.TrimSpace(strings.Join(lines[end+1:], "\n"))
+	return rule, nil
+}
+
+// ReadFile reads a single .mdc rule file into a Context.
+func ReadFile(path string) (*core.Context, error) {
+	ctx := core.NewContext("")
+	if err := readRule(ctx, path); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// ReadDir reads every .mdc rule in dir, in file name order, into a single
+// Context. When dir is a project's .cursor/rules directory, the context is
+// named after the project directory unless a rule provides a title.
+func ReadDir(dir string) (*core.Context, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, &core.ParseError{Path: dir, Err: err}
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == RuleExtension {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	ctx := core.NewContext("")
+	for _, name := range names {
+		if err := readRule(ctx, filepath.Join(dir, name)); err != nil {
+			return nil, err
+		}
+	}
+
+	if ctx.Name == "" {
+		ctx.Name = projectName(dir)
+	}
+	return ctx, nil
+}
+
+// readRule parses the rule at path and merges it into ctx.
+func readRule(ctx *core.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &core.ParseError{Path: path, Err: err}
+	}
+	rule, err := ParseRule(data)
+	if err != nil {
+		var pe *core.ParseError
+		if errors.As(err, &pe) {
+			pe.Path = path
+		}
+		return err
+	}
+
+	title := rule.Description
+	if title == "" {
+		base := filepath.Base(path)
+		title = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	mergeRule(ctx, rule, title)
+	return nil
+}
+
+// mergeRule adds a rule body to ctx:
+//   - a top-level heading names the context if it has no name yet, and the
+//     paragraph under it becomes the description if none is set;
+//   - bullets under a "Commands" heading of the form "name: `command`"
+//     become commands, and bullets under a "Notes" heading become notes;
+//   - all other bullets become conventions;
+//   - other paragraphs become notes titled after the rule.
+func mergeRule(ctx *core.Context, rule *Rule, title string) {
+	var section string
+	var topLevel bool
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		text := strings.TrimSpace(strings.Join(paragraph, "\n"))
+		paragraph = nil
+		if text == "" {
+			return
+		}
+		if topLevel && ctx.Description == "" {
+			ctx.Description = text
+			return
+		}
+		ctx.Notes = append(ctx.Notes, core.Note{Title: title, Content: text})
+	}
+
+	inFence := false
+	for _, line := range strings.Split(rule.Body, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			paragraph = append(paragraph, line)
+			continue
+		}
+		if inFence {
+			paragraph = append(paragraph, line)
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case strings.HasPrefix(trimmed, "# "):
+			flush()
+			if ctx.Name == "" {
+				ctx.Name = strings.TrimSpace(trimmed[2:])
+			}
+			section = ""
+			topLevel = true
+
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+			section = strings.ToLower(strings.TrimSpace(strings.TrimLeft(trimmed, "#")))
+			topLevel = false
+
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+			flush()
+			addBullet(ctx, section, strings.TrimSpace(trimmed[2:]))
+
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+}
+
+// addBullet adds a bullet item according to the section it appears in.
+func addBullet(ctx *core.Context, section, item string) {
+	switch section {
+	case "commands":
+		if name, cmd, ok := strings.Cut(item, ": "); ok && strings.HasPrefix(cmd, "`") && strings.HasSuffix(cmd, "`") && len(cmd) > 1 {
+			ctx.SetCommand(name, strings.Trim(cmd, "`"))
+			return
+		}
+	case "notes":
+		ctx.Notes = append(ctx.Notes, parseNote(item))
+		return
+	}
+	ctx.Conventions = append(ctx.Conventions, item)
+}
+
+// parseNote parses a note bullet written by Convert in the plain severity
+// style: an optional "**Title**: " followed by an optional severity prefix.
+func parseNote(item string) core.Note {
+	var note core.Note
+	if strings.HasPrefix(item, "**") {
+		if end := strings.Index(item[2:], "**: "); end >= 0 {
+			note.Title = item[2 : 2+end]
+			item = item[2+end+len("**: "):]
+		}
+	}
+	for _, severity := range []string{core.SeverityWarning, core.SeverityCritical} {
+		if prefix := core.SeverityPrefix(severity); strings.HasPrefix(item, prefix) {
+			note.Severity = severity
+			item = strings.TrimPrefix(item, prefix)
+			break
+		}
+	}
+	note.Content = item
+	return note
+}
+
+// splitGlobs splits a comma-separated globs value.
+func splitGlobs(value string) []string {
+	value = strings.Trim(value, "[]")
+	var globs []string
+	for _, glob := range strings.Split(value, ",") {
+		if glob = unquote(strings.TrimSpace(glob)); glob != "" {
+			globs = append(globs, glob)
+		}
+	}
+	return globs
+}
+
+// unquote strips matching single or double quotes.
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// projectName returns the project directory name for a .cursor/rules
+// directory, or an empty string for any other directory.
+func projectName(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	if filepath.Base(abs) != "rules" || filepath.Base(filepath.Dir(abs)) != ".cursor" {
+		return ""
+	}
+	return filepath.Base(filepath.Dir(filepath.Dir(abs)))
+}
@@ -0,0 +1,183 @@
+// Package jetbrains provides a converter for generating JetBrains Junie
+// guidelines (.junie/guidelines.md) from the canonical project context format.
+//
+// Junie, the JetBrains AI coding agent, reads project guidelines from
+// .junie/guidelines.md in the project root.
+package jetbrains
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+const (
+	// ConverterName is the identifier for this converter.
+	ConverterName = "jetbrains"
+
+	// OutputDir is the directory Junie reads guidelines from.
+	OutputDir = ".junie"
+
+	// OutputFile is the default output file name.
+	OutputFile = "guidelines.md"
+)
+
+// Converter implements core.Converter for JetBrains Junie guidelines.
+type Converter struct {
+	core.BaseConverter
+}
+
+// NewConverter creates a new JetBrains converter.
+func NewConverter() *Converter {
+	return &Converter{
+		BaseConverter: core.NewBaseConverter(ConverterName, filepath.Join(OutputDir, OutputFile)),
+	}
+}
+
+// Convert converts the context to Junie guidelines format.
+func (c *Converter) Convert(ctx *core.Context) ([]byte, error) {
+	if ctx == nil {
+		return nil, &core.ConversionError{Format: ConverterName, Err: core.ErrEmptyContext}
+	}
+	if ctx.Name == "" {
+		return nil, &core.ConversionError{Format: ConverterName, Err: core.ErrMissingName}
+	}
+	style := ctx.GetSeverityStyle()
+	if !style.Valid() {
+		return nil, &core.ConversionError{Format: ConverterName, Err: core.ErrUnsupportedSeverityStyle}
+	}
+
+	var b strings.Builder
+
+	// Header
+	b.WriteString(fmt.Sprintf("# %s Development Guidelines\n\n", ctx.Name))
+
+	if ctx.Description != "" {
+		b.WriteString(fmt.Sprintf("%s\n\n", ctx.Description))
+	}
+
+	// Tech stack
+	if ctx.Language != "" || ctx.Version != "" || ctx.Dependencies != nil {
+		b.WriteString("## Tech Stack\n\n")
+		if ctx.Language != "" {
+			b.WriteString(fmt.Sprintf("- Language: %s\n", ctx.Language))
+		}
+		if ctx.Version != "" {
+			b.WriteString(fmt.Sprintf("- Project version: %s\n", ctx.Version))
+		}
+		if ctx.Dependencies != nil {
+			for _, dep := range ctx.Dependencies.Runtime {
+				writeDependency(&b, dep)
+			}
+			for _, dep := range ctx.Dependencies.Development {
+				writeDependency(&b, dep)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	// Project structure
+	if ctx.Architecture != nil || len(ctx.Packages) > 0 {
+		b.WriteString("## Project Structure\n\n")
+		if ctx.Architecture != nil && ctx.Architecture.Summary != "" {
+			b.WriteString(fmt.Sprintf("%s\n\n", ctx.Architecture.Summary))
+		}
+		for _, pkg := range ctx.Packages {
+			b.WriteString(fmt.Sprintf("- `%s` - %s\n", pkg.Path, pkg.Purpose))
+		}
+		if len(ctx.Packages) > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	// Build and test commands
+	if len(ctx.Commands) > 0 {
+		b.WriteString("## Build and Test\n\n")
+		names := make([]string, 0, len(ctx.Commands))
+		for name := range ctx.Commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			b.WriteString(fmt.Sprintf("- %s: `%s`\n", name, ctx.Commands[name]))
+		}
+		b.WriteString("\n")
+	}
+
+	// Testing
+	if ctx.Testing != nil {
+		b.WriteString("## Testing\n\n")
+		if ctx.Testing.Framework != "" {
+			b.WriteString(fmt.Sprintf("- Framework: %s\n", ctx.Testing.Framework))
+		}
+		if ctx.Testing.Coverage != "" {
+			b.WriteString(fmt.Sprintf("- Coverage: %s\n", ctx.Testing.Coverage))
+		}
+		for _, pattern := range ctx.Testing.Patterns {
+			b.WriteString(fmt.Sprintf("- %s\n", pattern))
+		}
+		b.WriteString("\n")
+	}
+
+	// Conventions
+	if len(ctx.Conventions) > 0 {
+		b.WriteString("## Code Style\n\n")
+		for _, conv := range ctx.Conventions {
+			b.WriteString(fmt.Sprintf("- %s\n", conv))
+		}
+		b.WriteString("\n")
+	}
+
+	// Notes
+	if len(ctx.Notes) > 0 {
+		b.WriteString("## Notes\n\n")
+		for _, note := range ctx.Notes {
+			if style == core.SeverityStyleAdmonition {
+				b.WriteString(core.FormatAdmonition(note) + "\n")
+				continue
+			}
+			prefix := core.SeverityPrefix(note.GetSeverity())
+			if note.Title != "" {
+				b.WriteString(fmt.Sprintf("- **%s**: %s%s\n", note.Title, prefix, note.Content))
+			} else {
+				b.WriteString(fmt.Sprintf("- %s%s\n", prefix, note.Content))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n"), nil
+}
+
+// WriteFile writes the converted context to a file, creating the parent
+// directory (e.g., .junie) if needed.
+func (c *Converter) WriteFile(ctx *core.Context, path string) error {
+	data, err := c.Convert(ctx)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, core.DefaultDirMode); err != nil {
+			return &core.WriteError{Format: ConverterName, Path: path, Err: err}
+		}
+	}
+	return c.WriteFileWithData(data, path)
+}
+
+// writeDependency writes a dependency bullet.
+func writeDependency(b *strings.Builder, dep core.Dependency) {
+	if dep.Purpose != "" {
+		b.WriteString(fmt.Sprintf("- %s - %s\n", dep.Name, dep.Purpose))
+	} else {
+		b.WriteString(fmt.Sprintf("- %s\n", dep.Name))
+	}
+}
+
+// init registers the converter with the default registry.
+func init() {
+	core.RegisterConverter(NewConverter())
+}
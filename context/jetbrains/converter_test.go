@@ -0,0 +1,117 @@
+package jetbrains
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+func TestNewConverter(t *testing.T) {
+	c := NewConverter()
+
+	if c.Name() != ConverterName {
+		t.Errorf("expected name '%s', got '%s'", ConverterName, c.Name())
+	}
+	if c.OutputFileName() != filepath.Join(".junie", "guidelines.md") {
+		t.Errorf("expected output file '.junie/guidelines.md', got '%s'", c.OutputFileName())
+	}
+}
+
+func TestConverterConvert(t *testing.T) {
+	c := NewConverter()
+	ctx := core.NewContext("test-project")
+	ctx.Description = "A test project"
+	ctx.Language = "go"
+	ctx.AddPackage("cmd/", "CLI entry points")
+	ctx.SetCommand("test", "go test ./...")
+	ctx.SetCommand("build", "go build ./...")
+	ctx.AddConvention("Use gofmt")
+	ctx.AddNoteWithSeverity("Secrets", "Never commit .env files", "critical")
+
+	data, err := c.Convert(ctx)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	md := string(data)
+	for _, want := range []string{
+		"# test-project Development Guidelines",
+		"A test project",
+		"- Language: go",
+		"- `cmd/` - CLI entry points",
+		"- build: `go build ./...`",
+		"- test: `go test ./...`",
+		"- Use gofmt",
+		"**CRITICAL:** Never commit .env files",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected guidelines to contain %q, got:\n%s", want, md)
+		}
+	}
+
+	// Commands are sorted for stable output
+	if strings.Index(md, "- build:") > strings.Index(md, "- test:") {
+		t.Error("expected commands in sorted order")
+	}
+}
+
+func TestConverterConvertErrors(t *testing.T) {
+	c := NewConverter()
+
+	if _, err := c.Convert(nil); err == nil {
+		t.Error("expected error for nil context")
+	}
+	if _, err := c.Convert(&core.Context{}); err == nil {
+		t.Error("expected error for missing name")
+	}
+}
+
+func TestConverterWriteFile(t *testing.T) {
+	c := NewConverter()
+	ctx := core.NewContext("test-project")
+
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, OutputDir, OutputFile)
+
+	if err := c.WriteFile(ctx, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read written file: %v", err)
+	}
+	if !strings.Contains(string(data), "# test-project") {
+		t.Error("written file should contain project header")
+	}
+}
+
+func TestConverterRegistered(t *testing.T) {
+	converter, ok := core.GetConverter(ConverterName)
+	if !ok {
+		t.Fatal("jetbrains converter should be registered")
+	}
+	if converter.Name() != ConverterName {
+		t.Errorf("expected name '%s', got '%s'", ConverterName, converter.Name())
+	}
+}
+
+func TestConverterConvertWithAdmonitionNotes(t *testing.T) {
+	c := NewConverter()
+	ctx := core.NewContext("test-project")
+	ctx.SeverityStyle = core.SeverityStyleAdmonition
+	ctx.AddNoteWithSeverity("Secrets", "Never commit .env files", "critical")
+
+	data, err := c.Convert(ctx)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	want := "> [!CRITICAL]\n> **Secrets**\n>\n> Never commit .env files\n"
+	if !strings.Contains(string(data), want) {
+		t.Errorf("expected guidelines to contain %q, got:\n%s", want, data)
+	}
+}
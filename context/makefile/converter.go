@@ -0,0 +1,148 @@
+// Package makefile provides a converter for generating a Makefile from the
+// canonical project context format.
+//
+// Each entry in Context.Commands becomes a phony target, so a project's
+// build, test, and lint commands can be run with make. Dependencies are
+// listed in the header comment.
+//
+// The default output is context.mk rather than Makefile, so generating never
+// replaces a project's own Makefile. Run it with make -f context.mk, or pull
+// the targets into a Makefile with include context.mk.
+package makefile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+const (
+	// ConverterName is the identifier for this converter.
+	ConverterName = "makefile"
+
+	// OutputFile is the default output file name.
+	OutputFile = "context.mk"
+)
+
+// orderedTargets lists common targets first, matching the CLAUDE.md command order.
+var orderedTargets = []string{"build", "test", "lint", "format", "run"}
+
+// Converter implements core.Converter for Makefiles.
+type Converter struct {
+	core.BaseConverter
+}
+
+// NewConverter creates a new Makefile converter.
+func NewConverter() *Converter {
+	return &Converter{
+		BaseConverter: core.NewBaseConverter(ConverterName, OutputFile),
+	}
+}
+
+// Convert converts the context to a Makefile.
+func (c *Converter) Convert(ctx *core.Context) ([]byte, error) {
+	if ctx == nil {
+		return nil, &core.ConversionError{Format: ConverterName, Err: core.ErrEmptyContext}
+	}
+	if ctx.Name == "" {
+		return nil, &core.ConversionError{Format: ConverterName, Err: core.ErrMissingName}
+	}
+
+	var b strings.Builder
+
+	// Header
+	b.WriteString(fmt.Sprintf("# Makefile for %s\n", ctx.Name))
+	b.WriteString("# Generated from CONTEXT.json\n")
+	if ctx.Dependencies != nil && (len(ctx.Dependencies.Runtime) > 0 || len(ctx.Dependencies.Development) > 0) {
+		b.WriteString("#\n# Dependencies:\n")
+		for _, dep := range ctx.Dependencies.Runtime {
+			writeDependency(&b, dep, "")
+		}
+		for _, dep := range ctx.Dependencies.Development {
+			writeDependency(&b, dep, " (development)")
+		}
+	}
+	b.WriteString("\n")
+
+	targets := targetNames(ctx.Commands)
+	if len(targets) == 0 {
+		return []byte(b.String()), nil
+	}
+
+	names := make([]string, len(targets))
+	for i, key := range targets {
+		names[i] = targetName(key)
+	}
+	b.WriteString(fmt.Sprintf(".PHONY: %s\n", strings.Join(names, " ")))
+	b.WriteString(fmt.Sprintf(".DEFAULT_GOAL := %s\n", names[0]))
+
+	for i, key := range targets {
+		b.WriteString(fmt.Sprintf("\n%s:\n", names[i]))
+		for _, line := range strings.Split(strings.TrimSpace(ctx.Commands[key]), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				b.WriteString("\t" + strings.ReplaceAll(line, "$", "$$") + "\n")
+			}
+		}
+	}
+
+	return []byte(b.String()), nil
+}
+
+// WriteFile writes the converted context to a file.
+func (c *Converter) WriteFile(ctx *core.Context, path string) error {
+	data, err := c.Convert(ctx)
+	if err != nil {
+		return err
+	}
+	return c.WriteFileWithData(data, path)
+}
+
+// targetNames returns the command keys with common targets first and the
+// rest sorted alphabetically.
+func targetNames(commands map[string]string) []string {
+	var names []string
+	written := make(map[string]bool)
+	for _, key := range orderedTargets {
+		if _, ok := commands[key]; ok {
+			names = append(names, key)
+			written[key] = true
+		}
+	}
+
+	var rest []string
+	for key := range commands {
+		if !written[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// targetName converts a command key to a make target name, replacing
+// whitespace and characters make treats specially with hyphens.
+func targetName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', ':', '#', '=', '%', '$':
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(key))
+}
+
+// writeDependency writes a dependency comment line.
+func writeDependency(b *strings.Builder, dep core.Dependency, suffix string) {
+	if dep.Purpose != "" {
+		b.WriteString(fmt.Sprintf("#   %s - %s%s\n", dep.Name, dep.Purpose, suffix))
+	} else {
+		b.WriteString(fmt.Sprintf("#   %s%s\n", dep.Name, suffix))
+	}
+}
+
+// init registers the converter with the default registry.
+func init() {
+	core.RegisterConverter(NewConverter())
+}
@@ -0,0 +1,100 @@
+package makefile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+func TestNewConverter(t *testing.T) {
+	c := NewConverter()
+
+	if c.Name() != ConverterName {
+		t.Errorf("expected name '%s', got '%s'", ConverterName, c.Name())
+	}
+	if c.OutputFileName() != "context.mk" {
+		t.Errorf("expected output file 'context.mk', got '%s'", c.OutputFileName())
+	}
+}
+
+func TestConverterConvert(t *testing.T) {
+	c := NewConverter()
+	ctx := core.NewContext("test-project")
+	ctx.SetCommand("test", "go test ./...")
+	ctx.SetCommand("build", "go build ./...")
+	ctx.SetCommand("release check", "goreleaser check\necho $HOME")
+	ctx.Dependencies = &core.Dependencies{
+		Runtime:     []core.Dependency{{Name: "cobra", Purpose: "CLI framework"}},
+		Development: []core.Dependency{{Name: "golangci-lint"}},
+	}
+
+	data, err := c.Convert(ctx)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	mk := string(data)
+	for _, want := range []string{
+		"# Makefile for test-project\n",
+		"#   cobra - CLI framework\n",
+		"#   golangci-lint (development)\n",
+		".PHONY: build test release-check\n",
+		".DEFAULT_GOAL := build\n",
+		"\nbuild:\n\tgo build ./...\n",
+		"\ntest:\n\tgo test ./...\n",
+		"\nrelease-check:\n\tgoreleaser check\n\techo $$HOME\n",
+	} {
+		if !strings.Contains(mk, want) {
+			t.Errorf("expected Makefile to contain %q, got:\n%s", want, mk)
+		}
+	}
+}
+
+func TestConverterConvertNoCommands(t *testing.T) {
+	c := NewConverter()
+	data, err := c.Convert(core.NewContext("test"))
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if strings.Contains(string(data), ".PHONY") {
+		t.Errorf("expected no targets, got:\n%s", data)
+	}
+}
+
+func TestConverterConvertErrors(t *testing.T) {
+	c := NewConverter()
+
+	if _, err := c.Convert(nil); err == nil {
+		t.Error("expected error for nil context")
+	}
+	if _, err := c.Convert(&core.Context{}); err == nil {
+		t.Error("expected error for missing name")
+	}
+}
+
+func TestConverterWriteFile(t *testing.T) {
+	c := NewConverter()
+	ctx := core.NewContext("test")
+	ctx.SetCommand("build", "make all")
+
+	path := filepath.Join(t.TempDir(), "Makefile")
+	if err := c.WriteFile(ctx, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "build:\n\tmake all\n") {
+		t.Errorf("unexpected Makefile:\n%s", data)
+	}
+}
+
+func TestConverterRegistered(t *testing.T) {
+	if _, ok := core.GetConverter(ConverterName); !ok {
+		t.Error("expected makefile converter to be registered")
+	}
+}
@@ -0,0 +1,309 @@
+package generate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+)
+
+// BackupsDir is where Options.Backup snapshots are kept, relative to the
+// output base directory. Each snapshot is a subdirectory named after the
+// time it was taken.
+const BackupsDir = ".assistantkit/backups"
+
+// backupIndexFile records the files held by a snapshot.
+const backupIndexFile = "backup.json"
+
+// backupTimeFormat names snapshots so they sort chronologically.
+const backupTimeFormat = "20060102T150405.000000000Z"
+
+// ErrNoBackup is returned by Rollback when there is no snapshot to restore.
+var ErrNoBackup = errors.New("no backup to restore")
+
+// ErrUnsafeBackupTarget is returned by Options.Backup and Rollback for a
+// target whose output directory is, or contains, the output base directory
+// or a .git directory. Restoring such a target could touch files that
+// generation never wrote.
+var ErrUnsafeBackupTarget = errors.New("target cannot be backed up")
+
+// backupIndex is the backup.json in a snapshot.
+type backupIndex struct {
+	Targets []backupTarget `json:"targets"`
+}
+
+// backupTarget is one target in a snapshot. Output is relative to the
+// output base directory when the target lies inside it.
+type backupTarget struct {
+	Name    string       `json:"name"`
+	Output  string       `json:"output"`
+	Existed bool         `json:"existed"`
+	Files   []backupFile `json:"files"`
+}
+
+// backupFile is a file written by generation. Path is slash-separated and
+// relative to the target's output directory; Existed records whether
+// generation overwrote a file, whose previous content is in the snapshot.
+type backupFile struct {
+	Path    string `json:"path"`
+	Existed bool   `json:"existed"`
+}
+
+// backup is a snapshot being taken. Before generation writes a file, the
+// file's previous content is copied into the snapshot, so that only the
+// files generation wrote are restored.
+type backup struct {
+	dir    string
+	index  backupIndex
+	target *backupTarget
+	root   string
+}
+
+// backupTargets checks the deployment's target directories and starts a
+// snapshot under outputDir/BackupsDir. Files are added to it by
+// writeOutputs; writeIndex records them.
+func backupTargets(outputDir string, targets []DeploymentTarget) (*backup, error) {
+	for _, tgt := range targets {
+		if err := checkBackupTarget(outputDir, tgt.Name, targetDir(outputDir, tgt.Output)); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot := filepath.Join(outputDir, BackupsDir, time.Now().UTC().Format(backupTimeFormat))
+	if err := os.MkdirAll(snapshot, 0755); err != nil {
+		return nil, fmt.Errorf("creating backup dir: %w", err)
+	}
+	b := &backup{dir: snapshot, index: backupIndex{Targets: []backupTarget{}}}
+	if err := b.writeIndex(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// checkBackupTarget fails for a target directory that equals or contains
+// the output base directory or a .git directory, or lies inside one.
+func checkBackupTarget(outputDir, name, dir string) error {
+	base, err := filepath.Abs(outputDir)
+	if err != nil {
+		return err
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	gitDir := filepath.Join(base, ".git")
+	switch {
+	case within(abs, base):
+		return fmt.Errorf("%w: %s output %s contains the output directory", ErrUnsafeBackupTarget, name, dir)
+	case within(abs, gitDir) || within(gitDir, abs):
+		return fmt.Errorf("%w: %s output %s overlaps .git", ErrUnsafeBackupTarget, name, dir)
+	}
+	if _, err := os.Stat(filepath.Join(abs, ".git")); err == nil {
+		return fmt.Errorf("%w: %s output %s contains .git", ErrUnsafeBackupTarget, name, dir)
+	}
+	return nil
+}
+
+// targetDir resolves a target's output relative to outputDir.
+func targetDir(outputDir, output string) string {
+	if filepath.IsAbs(output) {
+		return output
+	}
+	return filepath.Join(outputDir, output)
+}
+
+// begin starts recording the files written for a target.
+func (b *backup) begin(tgt DeploymentTarget, dir string) {
+	if b == nil {
+		return
+	}
+	entry := backupTarget{Name: tgt.Name, Output: tgt.Output, Files: []backupFile{}}
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		entry.Existed = true
+	}
+	b.index.Targets = append(b.index.Targets, entry)
+	b.target = &b.index.Targets[len(b.index.Targets)-1]
+	b.root = dir
+}
+
+// save records that generation is about to write dest, copying the file's
+// current content into the snapshot if it exists.
+func (b *backup) save(dest string) error {
+	if b == nil || b.target == nil {
+		return nil
+	}
+	rel, err := filepath.Rel(b.root, dest)
+	if err != nil {
+		return err
+	}
+	file := backupFile{Path: filepath.ToSlash(rel)}
+
+	info, err := os.Stat(dest)
+	switch {
+	case err == nil:
+		file.Existed = true
+		if err := copyFile(dest, filepath.Join(b.dir, b.target.Name, rel), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("backing up %s: %w", dest, err)
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+	b.target.Files = append(b.target.Files, file)
+	return nil
+}
+
+// writeIndex writes the files recorded so far to the snapshot's index. It
+// runs after each target, so a run that fails part way can still be rolled
+// back.
+func (b *backup) writeIndex() error {
+	if b == nil {
+		return nil
+	}
+	data, err := canonicaljson.Marshal(b.index)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(b.dir, backupIndexFile), data, 0600); err != nil {
+		return fmt.Errorf("writing backup index: %w", err)
+	}
+	return nil
+}
+
+// path returns the snapshot directory, or "" when no backup is taken.
+func (b *backup) path() string {
+	if b == nil {
+		return ""
+	}
+	return b.dir
+}
+
+// Backups lists the snapshots under outputDir, oldest first.
+func Backups(outputDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(outputDir, BackupsDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			snapshots = append(snapshots, filepath.Join(outputDir, BackupsDir, entry.Name()))
+		}
+	}
+	sort.Strings(snapshots)
+	return snapshots, nil
+}
+
+// Rollback restores the latest snapshot taken by Options.Backup in
+// outputDir: each file generation overwrote gets its previous content back,
+// and files it created are removed, along with directories left empty. No
+// other file is touched. The restored snapshot is deleted, so a second
+// Rollback restores the one before it. It returns the restored snapshot's
+// directory.
+func Rollback(outputDir string) (string, error) {
+	snapshots, err := Backups(outputDir)
+	if err != nil {
+		return "", err
+	}
+	if len(snapshots) == 0 {
+		return "", ErrNoBackup
+	}
+	snapshot := snapshots[len(snapshots)-1]
+
+	data, err := os.ReadFile(filepath.Join(snapshot, backupIndexFile))
+	if err != nil {
+		return "", fmt.Errorf("reading backup index: %w", err)
+	}
+	var index backupIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return "", fmt.Errorf("parsing %s: %w", backupIndexFile, err)
+	}
+
+	for _, tgt := range index.Targets {
+		dir := targetDir(outputDir, tgt.Output)
+		if err := checkBackupTarget(outputDir, tgt.Name, dir); err != nil {
+			return "", err
+		}
+		for _, file := range tgt.Files {
+			if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+				return "", fmt.Errorf("restoring target %s: invalid path %q", tgt.Name, file.Path)
+			}
+		}
+	}
+
+	for _, tgt := range index.Targets {
+		dir := targetDir(outputDir, tgt.Output)
+		if err := restoreTarget(snapshot, dir, tgt); err != nil {
+			return "", fmt.Errorf("restoring target %s: %w", tgt.Name, err)
+		}
+	}
+
+	if err := os.RemoveAll(snapshot); err != nil {
+		return "", fmt.Errorf("removing restored backup: %w", err)
+	}
+	return snapshot, nil
+}
+
+// restoreTarget restores the files of one snapshot target into dir.
+func restoreTarget(snapshot, dir string, tgt backupTarget) error {
+	for _, file := range tgt.Files {
+		rel := filepath.FromSlash(file.Path)
+		dest := filepath.Join(dir, rel)
+		if file.Existed {
+			src := filepath.Join(snapshot, tgt.Name, rel)
+			info, err := os.Stat(src)
+			if err != nil {
+				return err
+			}
+			if err := copyFile(src, dest, info.Mode().Perm()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.Remove(dest); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		removeEmptyDirs(filepath.Dir(dest), dir)
+	}
+	if !tgt.Existed {
+		removeEmptyDirs(dir, filepath.Dir(dir))
+	}
+	return nil
+}
+
+// removeEmptyDirs removes dir and its empty parents, stopping at stop.
+// Directories that are not empty are left in place.
+func removeEmptyDirs(dir, stop string) {
+	for dir != stop && within(stop, dir) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// copyFile copies the file at src to dst with the given permissions,
+// creating dst's parent directories.
+func copyFile(src, dst string, perm os.FileMode) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, perm)
+}
+
+// within reports whether path lies inside dir.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && filepath.IsLocal(rel)
+}
@@ -0,0 +1,114 @@
+package generate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBackupAndRollback(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"agents"}]}`,
+	})
+	out := t.TempDir()
+	agentPath := filepath.Join(out, "agents", "reviewer.md")
+
+	first, err := AgentsWithOptions(specs, "local", out, Options{Backup: true})
+	if err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+	if first.BackupPath == "" {
+		t.Fatal("expected a backup path")
+	}
+
+	if err := os.WriteFile(filepath.Join(specs, "agents", "reviewer.md"), []byte("---\nname: reviewer\ndescription: Reviews code\n---\n\nReview everything.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	second, err := AgentsWithOptions(specs, "local", out, Options{Backup: true})
+	if err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(second.BackupPath, "claude", "reviewer.md")); err != nil {
+		t.Fatalf("expected the previous output in the backup: %v", err)
+	}
+	if snapshots, _ := Backups(out); len(snapshots) != 2 {
+		t.Fatalf("expected 2 backups, got %v", snapshots)
+	}
+
+	restored, err := Rollback(out)
+	if err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if restored != second.BackupPath {
+		t.Errorf("expected the latest backup to be restored, got %s", restored)
+	}
+	data, err := os.ReadFile(agentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Review the diff.") {
+		t.Errorf("expected the previous output restored, got:\n%s", data)
+	}
+
+	// The first backup predates any output, so rolling back removes it.
+	if _, err := Rollback(out); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "agents")); !os.IsNotExist(err) {
+		t.Errorf("expected the agents dir removed, got %v", err)
+	}
+
+	if _, err := Rollback(out); !errors.Is(err, ErrNoBackup) {
+		t.Errorf("expected ErrNoBackup, got %v", err)
+	}
+}
+
+func TestRollbackRestoresOnlyGeneratedFiles(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"agents"}]}`,
+	})
+	out := t.TempDir()
+	notes := filepath.Join(out, "agents", "notes.txt")
+
+	if _, err := AgentsWithOptions(specs, "local", out, Options{Backup: true}); err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+	if err := os.WriteFile(notes, []byte("keep me"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AgentsWithOptions(specs, "local", out, Options{Backup: true}); err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := Rollback(out); err != nil {
+			t.Fatalf("Rollback failed: %v", err)
+		}
+		if data, err := os.ReadFile(notes); err != nil || string(data) != "keep me" {
+			t.Errorf("expected notes.txt kept, got %q, %v", data, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "agents", "reviewer.md")); !os.IsNotExist(err) {
+		t.Errorf("expected reviewer.md removed, got %v", err)
+	}
+}
+
+func TestBackupRefusesUnsafeTargets(t *testing.T) {
+	for _, output := range []string{".", "..", ".git/hooks"} {
+		specs := writeSpecs(t, map[string]string{
+			"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+			"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"` + output + `"}]}`,
+		})
+		out := t.TempDir()
+		if _, err := AgentsWithOptions(specs, "local", out, Options{Backup: true}); !errors.Is(err, ErrUnsafeBackupTarget) {
+			t.Errorf("output %q: expected ErrUnsafeBackupTarget, got %v", output, err)
+		}
+		if _, err := os.Stat(filepath.Join(out, "reviewer.md")); !os.IsNotExist(err) {
+			t.Errorf("output %q: expected nothing written, got %v", output, err)
+		}
+	}
+}
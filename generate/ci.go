@@ -0,0 +1,153 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CIWorkflowPath is the default location of the generated CI workflow.
+const CIWorkflowPath = ".github/workflows/assistantkit.yml"
+
+// CIOptions configures CIWorkflow.
+type CIOptions struct {
+	// SpecsDir is the specs directory as it appears in the repository
+	// (e.g., "specs"). It is used both to read the deployment and in the
+	// workflow's commands and path filters.
+	SpecsDir string
+
+	// Target is the deployment target to regenerate (default "local").
+	Target string
+
+	// OutputDir is the output base directory passed to generate, relative
+	// to the repository root (default ".").
+	OutputDir string
+
+	// Branch is the branch whose pushes run the check (default "main").
+	Branch string
+}
+
+// CIWorkflow returns a GitHub Actions workflow that regenerates outputs
+// from the specs and fails when they differ from the committed files.
+//
+// The workflow is derived from the deployment at
+// <SpecsDir>/deployments/<Target>.json: each target platform gets its own
+// drift check over that target's output directory, and the output
+// directories are added to the workflow's path filters.
+func CIWorkflow(opts CIOptions) ([]byte, error) {
+	if opts.SpecsDir == "" {
+		opts.SpecsDir = "specs"
+	}
+	if opts.Target == "" {
+		opts.Target = "local"
+	}
+	if opts.OutputDir == "" {
+		opts.OutputDir = "."
+	}
+	if opts.Branch == "" {
+		opts.Branch = "main"
+	}
+
+	deploymentFile := filepath.Join(opts.SpecsDir, "deployments", opts.Target+".json")
+	if _, err := os.Stat(deploymentFile); os.IsNotExist(err) {
+		return nil, fmt.Errorf("deployment file not found: %s", deploymentFile)
+	}
+	deployment, err := loadDeployment(deploymentFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading deployment: %w", err)
+	}
+	if len(deployment.Targets) == 0 {
+		return nil, fmt.Errorf("deployment %s has no targets", deploymentFile)
+	}
+
+	specs := path.Clean(filepath.ToSlash(opts.SpecsDir))
+	outputBase := path.Clean(filepath.ToSlash(opts.OutputDir))
+
+	var platforms []string
+	seen := make(map[string]bool)
+	outputs := make(map[string]bool)
+	for _, tgt := range deployment.Targets {
+		if !seen[tgt.Platform] {
+			seen[tgt.Platform] = true
+			platforms = append(platforms, tgt.Platform)
+		}
+		outputs[ciOutputPath(outputBase, tgt.Output)] = true
+	}
+	paths := make([]string, 0, len(outputs)+1)
+	paths = append(paths, specs)
+	for p := range outputs {
+		if p != specs {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths[1:])
+
+	var b strings.Builder
+	b.WriteString("# Generated by assistantkit init ci.\n")
+	b.WriteString("# Fails when generated assistant files drift from the specs.\n")
+	b.WriteString("name: assistantkit\n\n")
+
+	b.WriteString("on:\n")
+	b.WriteString("  push:\n")
+	b.WriteString(fmt.Sprintf("    branches: [%s]\n", opts.Branch))
+	b.WriteString("  pull_request:\n")
+	b.WriteString("    paths:\n")
+	for _, p := range paths {
+		b.WriteString(fmt.Sprintf("      - %s\n", yamlQuote(ciPathGlob(p))))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("jobs:\n")
+	b.WriteString("  check-generated:\n")
+	b.WriteString(fmt.Sprintf("    name: Check generated outputs (%s)\n", strings.Join(platforms, ", ")))
+	b.WriteString("    runs-on: ubuntu-latest\n")
+	b.WriteString("    steps:\n")
+	b.WriteString("      - uses: actions/checkout@v4\n")
+	b.WriteString("      - uses: actions/setup-go@v5\n")
+	b.WriteString("        with:\n")
+	b.WriteString("          go-version: stable\n")
+	b.WriteString("      - name: Install assistantkit\n")
+	b.WriteString("        run: go install github.com/agentplexus/assistantkit/cmd/assistantkit@latest\n")
+	b.WriteString("      - name: Generate\n")
+	b.WriteString(fmt.Sprintf("        run: assistantkit generate --specs=%s --target=%s --output=%s\n", specs, opts.Target, outputBase))
+
+	for _, tgt := range deployment.Targets {
+		out := ciOutputPath(outputBase, tgt.Output)
+		b.WriteString(fmt.Sprintf("      - name: Check %s output (%s)\n", tgt.Name, tgt.Platform))
+		b.WriteString("        run: |\n")
+		b.WriteString(fmt.Sprintf("          if [ -n \"$(git status --porcelain -- %s)\" ]; then\n", out))
+		b.WriteString(fmt.Sprintf("            echo \"::error::%s output in %s is out of date; run assistantkit generate and commit the result\"\n", tgt.Platform, out))
+		b.WriteString(fmt.Sprintf("            git status --porcelain -- %s\n", out))
+		b.WriteString(fmt.Sprintf("            git diff -- %s\n", out))
+		b.WriteString("            exit 1\n")
+		b.WriteString("          fi\n")
+	}
+
+	return []byte(b.String()), nil
+}
+
+// ciOutputPath returns a target's output directory relative to the
+// repository root.
+func ciOutputPath(outputBase, output string) string {
+	output = filepath.ToSlash(output)
+	if path.IsAbs(output) {
+		return path.Clean(output)
+	}
+	return path.Join(outputBase, output)
+}
+
+// ciPathGlob returns the path filter matching everything under dir.
+func ciPathGlob(dir string) string {
+	if dir == "." {
+		return "**"
+	}
+	return dir + "/**"
+}
+
+// yamlQuote double-quotes a YAML string value.
+func yamlQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
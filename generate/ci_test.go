@@ -0,0 +1,41 @@
+package generate
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCIWorkflow(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"deployments/local.json": `{"team":"t","targets":[
+			{"name":"claude","platform":"claude-code","output":".claude/agents"},
+			{"name":"kiro","platform":"kiro-cli","output":".kiro/agents"}
+		]}`,
+	})
+
+	data, err := CIWorkflow(CIOptions{SpecsDir: specs, Target: "local"})
+	if err != nil {
+		t.Fatalf("CIWorkflow failed: %v", err)
+	}
+
+	workflow := string(data)
+	for _, want := range []string{
+		"name: Check generated outputs (claude-code, kiro-cli)",
+		`- ".claude/agents/**"`,
+		`- ".kiro/agents/**"`,
+		"assistantkit generate --specs=" + filepath.ToSlash(specs) + " --target=local --output=.",
+		"- name: Check claude output (claude-code)",
+		"git status --porcelain -- .claude/agents",
+		"- name: Check kiro output (kiro-cli)",
+		"git diff -- .kiro/agents",
+	} {
+		if !strings.Contains(workflow, want) {
+			t.Errorf("expected workflow to contain %q, got:\n%s", want, workflow)
+		}
+	}
+
+	if _, err := CIWorkflow(CIOptions{SpecsDir: specs, Target: "prod"}); err == nil {
+		t.Error("expected an error for a missing deployment")
+	}
+}
@@ -0,0 +1,49 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedFormatVersion is returned when Options.FormatVersions pins a
+// tool format version other than the one generated.
+var ErrUnsupportedFormatVersion = errors.New("unsupported format version")
+
+// ErrUnsupportedComponent is returned when Options.Strict is set and a
+// target's platform cannot receive a loaded component.
+var ErrUnsupportedComponent = errors.New("unsupported component")
+
+// UnsupportedComponentsError lists every component that the targets'
+// platforms cannot receive. It is returned when Options.Strict is set,
+// before any output is written.
+type UnsupportedComponentsError struct {
+	Gaps []ComponentGap
+}
+
+func (e *UnsupportedComponentsError) Error() string {
+	gaps := make([]string, len(e.Gaps))
+	for i, gap := range e.Gaps {
+		gaps[i] = gap.String()
+	}
+	return fmt.Sprintf("%v: %s", ErrUnsupportedComponent, strings.Join(gaps, ", "))
+}
+
+func (e *UnsupportedComponentsError) Unwrap() error {
+	return ErrUnsupportedComponent
+}
+
+// TargetError reports a failure generating one deployment target.
+type TargetError struct {
+	Target   string
+	Platform string
+	Err      error
+}
+
+func (e *TargetError) Error() string {
+	return fmt.Sprintf("generating target %s (%s): %v", e.Target, e.Platform, e.Err)
+}
+
+func (e *TargetError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,73 @@
+package generate
+
+import (
+	"fmt"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/mcp"
+)
+
+// mcpConfig returns the MCP servers generation writes: the plugin's
+// servers, followed by servers agents declare that the plugin does not.
+func mcpConfig(plugin *PluginSpec, agts []*agents.Agent) *mcp.Config {
+	cfg := mcp.NewConfig()
+	for name, srv := range plugin.MCPServers {
+		cfg.Servers[name] = mcpServer(srv.Command, srv.Args, srv.Env, srv.URL, srv.Headers)
+	}
+	for _, agt := range agts {
+		for name, srv := range agt.MCP {
+			if _, ok := cfg.Servers[name]; !ok {
+				cfg.Servers[name] = mcpServer(srv.Command, srv.Args, srv.Env, srv.URL, srv.Headers)
+			}
+		}
+	}
+	return cfg
+}
+
+// mcpServer returns a canonical server: remote over HTTP when url is set,
+// otherwise stdio.
+func mcpServer(command string, args []string, env map[string]string, url string, headers map[string]string) mcp.Server {
+	if url != "" {
+		return mcp.Server{Transport: mcp.TransportHTTP, URL: url, Headers: headers}
+	}
+	return mcp.Server{Transport: mcp.TransportStdio, Command: command, Args: args, Env: env}
+}
+
+// checkMCPFeatures reports MCP servers, from the plugin or declared by
+// agents, that need a feature the release of a target's tool named in
+// Options.ToolVersions does not support. They are returned as warnings, or
+// as an *mcp.UnsupportedFeatureError when Options.Strict is set.
+func (o Options) checkMCPFeatures(tools []string, plugin *PluginSpec, agts []*agents.Agent) ([]string, error) {
+	if len(o.ToolVersions) == 0 {
+		return nil, nil
+	}
+	cfg := mcpConfig(plugin, agts)
+	if len(cfg.Servers) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool, len(tools))
+	var warnings []string
+	for _, tool := range tools {
+		toolVersion := o.ToolVersions[tool]
+		if toolVersion == "" || seen[tool] {
+			continue
+		}
+		seen[tool] = true
+		adapter, ok := mcp.GetAdapter(tool)
+		if !ok {
+			continue
+		}
+		unsupported, err := mcp.CheckFeatures(adapter, cfg, toolVersion)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tool, err)
+		}
+		for _, u := range unsupported {
+			if o.Strict {
+				return nil, u
+			}
+			warnings = append(warnings, u.Error())
+		}
+	}
+	return warnings, nil
+}
@@ -14,17 +14,23 @@
 package generate
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/agentplexus/assistantkit/agents"
 	"github.com/agentplexus/assistantkit/commands"
+	"github.com/agentplexus/assistantkit/hooks"
+	"github.com/agentplexus/assistantkit/internal/envfile"
+	"github.com/agentplexus/assistantkit/internal/ignore"
 	"github.com/agentplexus/assistantkit/plugins"
 	powercore "github.com/agentplexus/assistantkit/powers/core"
 	"github.com/agentplexus/assistantkit/powers/kiro"
 	"github.com/agentplexus/assistantkit/skills"
+	skillskiro "github.com/agentplexus/assistantkit/skills/kiro"
 )
 
 // Result contains the results of plugin generation.
@@ -40,6 +46,10 @@ type Result struct {
 
 	// GeneratedDirs maps platform names to their output directories.
 	GeneratedDirs map[string]string
+
+	// Warnings lists components skipped because a platform cannot
+	// receive them (see Options.Strict), and deprecated components.
+	Warnings []string
 }
 
 // PluginSpec extends the base Plugin with power-specific fields.
@@ -48,13 +58,30 @@ type PluginSpec struct {
 	DisplayName string               `json:"displayName,omitempty"`
 	Keywords    []string             `json:"keywords,omitempty"`
 	MCPServers  map[string]MCPServer `json:"mcpServers,omitempty"`
+
+	// sharedMCP names the MCPServers added from Team.SharedMCP, and
+	// sharedHooks holds Team.SharedHooks; see addTeamShared.
+	sharedMCP   []string
+	sharedHooks *hooks.Config
 }
 
-// MCPServer defines an MCP server configuration.
+// MCPServer defines an MCP server configuration: a stdio server launched
+// with Command, or a remote HTTP server at URL.
 type MCPServer struct {
-	Command     string   `json:"command"`
+	Command     string   `json:"command,omitempty"`
 	Args        []string `json:"args,omitempty"`
 	Description string   `json:"description,omitempty"`
+
+	// Env contains environment variables for a stdio server process.
+	Env map[string]string `json:"env,omitempty"`
+
+	// URL is the endpoint of a remote HTTP server.
+	URL string `json:"url,omitempty"`
+
+	// Headers are sent with each request to URL, e.g. an Authorization
+	// header. Values may reference ${VAR} placeholders, which are kept for
+	// the tool to resolve unless env expansion is enabled.
+	Headers map[string]string `json:"headers,omitempty"`
 }
 
 // Plugins generates platform-specific plugins from a canonical spec directory.
@@ -67,6 +94,22 @@ type MCPServer struct {
 //
 // Generated plugins are written to outputDir/<platform>/.
 func Plugins(specDir, outputDir string, platforms []string) (*Result, error) {
+	return PluginsWithOptions(specDir, outputDir, platforms, Options{})
+}
+
+// PluginsWithOptions is Plugins with generation options. Only Options.Only,
+// Options.Timeout, and Options.Strict apply.
+func PluginsWithOptions(specDir, outputDir string, platforms []string, opts Options) (*Result, error) {
+	return PluginsContext(context.Background(), specDir, outputDir, platforms, opts)
+}
+
+// PluginsContext is PluginsWithOptions with a context. When ctx is canceled
+// or its deadline passes, generation stops and the platform being written is
+// left untouched; platforms completed before that are kept.
+func PluginsContext(ctx context.Context, specDir, outputDir string, platforms []string, opts Options) (*Result, error) {
+	ctx, cancel := opts.withTimeout(ctx)
+	defer cancel()
+
 	result := &Result{
 		GeneratedDirs: make(map[string]string),
 	}
@@ -77,51 +120,107 @@ func Plugins(specDir, outputDir string, platforms []string) (*Result, error) {
 		return nil, fmt.Errorf("loading plugin spec: %w", err)
 	}
 
+	if err := expandPluginEnv(plugin, opts); err != nil {
+		return nil, err
+	}
+
 	cmds, err := loadCommands(filepath.Join(specDir, "commands"))
 	if err != nil {
 		return nil, fmt.Errorf("loading commands: %w", err)
 	}
-	result.CommandCount = len(cmds)
 
 	skls, err := loadSkills(filepath.Join(specDir, "skills"))
 	if err != nil {
 		return nil, fmt.Errorf("loading skills: %w", err)
 	}
-	result.SkillCount = len(skls)
 
 	agts, err := loadAgents(filepath.Join(specDir, "agents"))
 	if err != nil {
 		return nil, fmt.Errorf("loading agents: %w", err)
 	}
+
+	cmds, skls, agts, err = opts.selectSpecs(cmds, skls, agts)
+	if err != nil {
+		return nil, err
+	}
+	result.CommandCount = len(cmds)
+	result.SkillCount = len(skls)
 	result.AgentCount = len(agts)
 
+	var gaps []ComponentGap
+	for _, platform := range platforms {
+		for _, component := range platformGaps(platform, plugin, cmds, skls, agts) {
+			gaps = append(gaps, ComponentGap{Target: platform, Platform: platform, Component: component})
+		}
+	}
+	if result.Warnings, err = opts.checkGaps(gaps); err != nil {
+		return nil, err
+	}
+	featureWarnings, err := opts.checkMCPFeatures(platforms, plugin, agts)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = append(result.Warnings, featureWarnings...)
+	result.Warnings = append(result.Warnings, deprecationWarnings(cmds, skls, agts)...)
+
 	// Generate each platform
 	for _, platform := range platforms {
 		platformDir := filepath.Join(outputDir, platform)
 
+		var warnings []string
+		var gen func(dir string) error
 		switch platform {
 		case "claude":
-			if err := generateClaude(platformDir, plugin, cmds, skls, agts); err != nil {
-				return nil, fmt.Errorf("generating claude: %w", err)
+			gen = func(dir string) (err error) {
+				warnings, err = generateClaude(ctx, dir, plugin, cmds, skls, agts, opts.Strict)
+				return err
 			}
 		case "kiro":
-			if err := generateKiro(platformDir, plugin, skls, agts); err != nil {
-				return nil, fmt.Errorf("generating kiro: %w", err)
+			gen = func(dir string) (err error) {
+				warnings, err = generateKiro(ctx, dir, plugin, skls, agts, opts.Strict, opts.partial())
+				return err
 			}
 		case "gemini":
-			if err := generateGemini(platformDir, plugin, cmds); err != nil {
-				return nil, fmt.Errorf("generating gemini: %w", err)
+			gen = func(dir string) (err error) {
+				warnings, err = generateGemini(ctx, dir, plugin, cmds)
+				return err
 			}
 		default:
 			return nil, fmt.Errorf("unknown platform: %s", platform)
 		}
 
+		if _, err := writeOutputs(ctx, platformDir, MergeReplace, nil, gen); err != nil {
+			return nil, fmt.Errorf("generating %s: %w", platform, err)
+		}
+		result.Warnings = append(result.Warnings, warnings...)
+
 		result.GeneratedDirs[platform] = platformDir
 	}
 
 	return result, nil
 }
 
+// expandPluginEnv resolves ${VAR} placeholders in the plugin's MCP servers
+// and the team's shared hook commands when opts enable env expansion.
+func expandPluginEnv(plugin *PluginSpec, opts Options) error {
+	lookup, err := opts.envLookup()
+	if err != nil || lookup == nil {
+		return err
+	}
+	for name, srv := range plugin.MCPServers {
+		srv.Command = envfile.Expand(srv.Command, lookup)
+		srv.Args = envfile.ExpandAll(srv.Args, lookup)
+		srv.Env = envfile.ExpandMap(srv.Env, lookup)
+		srv.URL = envfile.Expand(srv.URL, lookup)
+		srv.Headers = envfile.ExpandMap(srv.Headers, lookup)
+		plugin.MCPServers[name] = srv
+	}
+	if plugin.sharedHooks != nil {
+		plugin.sharedHooks = plugin.sharedHooks.ExpandEnv(lookup)
+	}
+	return nil
+}
+
 func loadPlugin(path string) (*PluginSpec, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -160,46 +259,57 @@ func loadAgents(dir string) ([]*agents.Agent, error) {
 	}
 
 	// Use agents.ReadCanonicalDir which supports both .md (multi-agent-spec) and .json files
-	return agents.ReadCanonicalDir(dir)
+	agts, err := agents.ReadCanonicalDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return agents.ApplyHandoffs(agts)
 }
 
-func generateClaude(dir string, plugin *PluginSpec, cmds []*commands.Command, skls []*skills.Skill, agts []*agents.Agent) error {
+// generateClaude writes a Claude Code plugin and returns the warnings for
+// command options Claude ignores and from writing its agents (see
+// writeAgent).
+func generateClaude(ctx context.Context, dir string, plugin *PluginSpec, cmds []*commands.Command, skls []*skills.Skill, agts []*agents.Agent, strict bool) ([]string, error) {
 	// Get adapters
 	pluginAdapter, ok := plugins.GetAdapter("claude")
 	if !ok {
-		return fmt.Errorf("claude plugin adapter not found")
+		return nil, fmt.Errorf("claude plugin adapter not found")
 	}
 
 	cmdAdapter, ok := commands.GetAdapter("claude")
 	if !ok {
-		return fmt.Errorf("claude command adapter not found")
+		return nil, fmt.Errorf("claude command adapter not found")
 	}
 
 	skillAdapter, ok := skills.GetAdapter("claude")
 	if !ok {
-		return fmt.Errorf("claude skill adapter not found")
-	}
-
-	agentAdapter, ok := agents.GetAdapter("claude")
-	if !ok {
-		return fmt.Errorf("claude agent adapter not found")
+		return nil, fmt.Errorf("claude skill adapter not found")
 	}
 
 	// Write plugin structure
-	if err := pluginAdapter.WritePlugin(&plugin.Plugin, dir); err != nil {
-		return fmt.Errorf("write plugin: %w", err)
+	if err := pluginAdapter.WritePlugin(plugin.manifestPlugin(), dir); err != nil {
+		return nil, fmt.Errorf("write plugin: %w", err)
+	}
+	if err := plugin.writeSharedHooks("claude", dir); err != nil {
+		return nil, err
 	}
 
 	// Write commands
+	var warnings []string
 	if len(cmds) > 0 {
 		commandsDir := filepath.Join(dir, "commands")
 		if err := os.MkdirAll(commandsDir, 0755); err != nil {
-			return err
+			return nil, err
 		}
 		for _, cmd := range cmds {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			warnings = append(warnings, commands.CheckExperimental(cmdAdapter, cmd)...)
+			warnings = append(warnings, commands.CheckEnabledByDefault(cmdAdapter, cmd)...)
 			path := filepath.Join(commandsDir, cmd.Name+".md")
 			if err := cmdAdapter.WriteFile(cmd, path); err != nil {
-				return fmt.Errorf("write command %s: %w", cmd.Name, err)
+				return nil, fmt.Errorf("write command %s: %w", cmd.Name, err)
 			}
 		}
 	}
@@ -207,43 +317,52 @@ func generateClaude(dir string, plugin *PluginSpec, cmds []*commands.Command, sk
 	// Write skills
 	if len(skls) > 0 {
 		skillsDir := filepath.Join(dir, "skills")
-		for _, skl := range skls {
+		for _, skl := range skills.SortByOrder(skls) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			warnings = append(warnings, skills.CheckExperimental(skillAdapter, skl)...)
+			warnings = append(warnings, skills.CheckEnabledByDefault(skillAdapter, skl)...)
 			if err := skillAdapter.WriteSkillDir(skl, skillsDir); err != nil {
-				return fmt.Errorf("write skill %s: %w", skl.Name, err)
+				return nil, fmt.Errorf("write skill %s: %w", skl.Name, err)
 			}
 		}
 	}
 
 	// Write agents
-	if len(agts) > 0 {
-		agentsDir := filepath.Join(dir, "agents")
-		if err := os.MkdirAll(agentsDir, 0755); err != nil {
-			return err
-		}
-		for _, agt := range agts {
-			path := filepath.Join(agentsDir, agt.Name+".md")
-			if err := agentAdapter.WriteFile(agt, path); err != nil {
-				return fmt.Errorf("write agent %s: %w", agt.Name, err)
-			}
-		}
+	if len(agts) == 0 {
+		return warnings, nil
+	}
+	agentWarnings, err := writeAgents(ctx, "claude", agts, filepath.Join(dir, "agents"), strict)
+	if err != nil {
+		return nil, err
 	}
+	return append(warnings, agentWarnings...), nil
+}
 
-	return nil
+// isKiroPower reports whether Kiro output is a Power rather than Kiro
+// Agents format: when keywords or MCP servers are present. Servers shared
+// by the team alone do not make a power; Kiro Agents format lists them on
+// each agent instead.
+func (p *PluginSpec) isKiroPower() bool {
+	return len(p.Keywords) > 0 || len(p.MCPServers) > len(p.sharedMCP)
 }
 
-func generateKiro(dir string, plugin *PluginSpec, skls []*skills.Skill, agts []*agents.Agent) error {
+// generateKiro writes a Kiro Power or Kiro agents and returns the warnings
+// from writing the agents (see writeAgent). When partial is set, the specs
+// are a subset and POWER.md and README.md, which list every spec, are not
+// written.
+func generateKiro(ctx context.Context, dir string, plugin *PluginSpec, skls []*skills.Skill, agts []*agents.Agent, strict, partial bool) ([]string, error) {
 	// Determine Kiro format based on plugin spec:
 	// - If keywords or MCP servers are present, generate a Kiro Power
 	// - Otherwise, generate Kiro Agents format
-	isPower := len(plugin.Keywords) > 0 || len(plugin.MCPServers) > 0
-
-	if isPower {
-		return generateKiroPower(dir, plugin, skls)
+	if plugin.isKiroPower() {
+		return nil, generateKiroPower(ctx, dir, plugin, skls, partial)
 	}
-	return generateKiroAgents(dir, plugin, skls, agts)
+	return generateKiroAgents(ctx, dir, plugin, skls, agts, strict, partial)
 }
 
-func generateKiroPower(dir string, plugin *PluginSpec, skls []*skills.Skill) error {
+func generateKiroPower(ctx context.Context, dir string, plugin *PluginSpec, skls []*skills.Skill, partial bool) error {
 	// Create Power from plugin spec
 	power := &powercore.Power{
 		Name:        plugin.Name,
@@ -262,6 +381,9 @@ func generateKiroPower(dir string, plugin *PluginSpec, skls []*skills.Skill) err
 		power.MCPServers[name] = powercore.MCPServer{
 			Command:     srv.Command,
 			Args:        srv.Args,
+			Env:         srv.Env,
+			URL:         srv.URL,
+			Headers:     srv.Headers,
 			Description: srv.Description,
 		}
 	}
@@ -270,7 +392,7 @@ func generateKiroPower(dir string, plugin *PluginSpec, skls []*skills.Skill) err
 	power.SteeringFiles = make(map[string]powercore.SteeringFile)
 	for _, skl := range skls {
 		power.SteeringFiles[skl.Name] = powercore.SteeringFile{
-			Path:        filepath.Join("steering", skl.Name+".md"),
+			Path:        filepath.Join("steering", skillskiro.OrderedSteeringFileName(skl)),
 			Keywords:    skl.Triggers,
 			Description: skl.Description,
 			Content:     skl.Instructions,
@@ -283,8 +405,18 @@ func generateKiroPower(dir string, plugin *PluginSpec, skls []*skills.Skill) err
 	// Build onboarding instructions
 	power.Onboarding = buildOnboarding(plugin)
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Use Kiro adapter to write the power
 	adapter := &kiro.Adapter{}
+	if partial {
+		if _, err := adapter.GenerateSteeringFiles(power, dir); err != nil {
+			return fmt.Errorf("write power steering: %w", err)
+		}
+		return nil
+	}
 	if _, err := adapter.GeneratePowerDir(power, dir); err != nil {
 		return fmt.Errorf("write power: %w", err)
 	}
@@ -292,27 +424,23 @@ func generateKiroPower(dir string, plugin *PluginSpec, skls []*skills.Skill) err
 	return nil
 }
 
-func generateKiroAgents(dir string, plugin *PluginSpec, skls []*skills.Skill, agts []*agents.Agent) error {
+func generateKiroAgents(ctx context.Context, dir string, plugin *PluginSpec, skls []*skills.Skill, agts []*agents.Agent, strict, partial bool) ([]string, error) {
 	// Create output directory
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
+		return nil, err
 	}
 
-	// Write agents as JSON files
+	// Write agents as JSON files, each listing the team's shared MCP servers
+	var warnings []string
 	if len(agts) > 0 {
-		agentsDir := filepath.Join(dir, "agents")
-		if err := os.MkdirAll(agentsDir, 0755); err != nil {
-			return err
+		withShared := make([]*agents.Agent, len(agts))
+		for i, agt := range agts {
+			withShared[i] = plugin.withSharedMCP(agt)
 		}
-		for _, agt := range agts {
-			path := filepath.Join(agentsDir, agt.Name+".json")
-			data, err := json.MarshalIndent(convertToKiroAgent(agt), "", "  ")
-			if err != nil {
-				return fmt.Errorf("marshal agent %s: %w", agt.Name, err)
-			}
-			if err := os.WriteFile(path, data, 0600); err != nil {
-				return fmt.Errorf("write agent %s: %w", agt.Name, err)
-			}
+		var err error
+		warnings, err = writeAgents(ctx, "kiro", withShared, filepath.Join(dir, "agents"), strict)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -320,27 +448,36 @@ func generateKiroAgents(dir string, plugin *PluginSpec, skls []*skills.Skill, ag
 	if len(skls) > 0 {
 		steeringDir := filepath.Join(dir, "steering")
 		if err := os.MkdirAll(steeringDir, 0755); err != nil {
-			return err
+			return nil, err
 		}
-		for _, skl := range skls {
-			path := filepath.Join(steeringDir, skl.Name+".md")
+		for _, skl := range skills.SortByOrder(skls) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			path := filepath.Join(steeringDir, skillskiro.SteeringFileName(skl))
 			content := buildSteeringContent(skl)
 			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
-				return fmt.Errorf("write steering %s: %w", skl.Name, err)
+				return nil, fmt.Errorf("write steering %s: %w", skl.Name, err)
 			}
 		}
 	}
 
 	// Write README
+	if partial {
+		return warnings, nil
+	}
 	readme := buildKiroAgentsReadme(plugin, agts, skls)
 	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(readme), 0644); err != nil {
-		return fmt.Errorf("write README: %w", err)
+		return nil, fmt.Errorf("write README: %w", err)
 	}
 
-	return nil
+	return warnings, nil
 }
 
 // KiroAgent represents a Kiro CLI agent definition.
+//
+// Deprecated: Kiro agents are written with the kiro agents adapter; use
+// its AgentConfig instead.
 type KiroAgent struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
@@ -348,17 +485,12 @@ type KiroAgent struct {
 	Model       string `json:"model,omitempty"`
 }
 
-func convertToKiroAgent(agt *agents.Agent) *KiroAgent {
-	return &KiroAgent{
-		Name:        agt.Name,
-		Description: agt.Description,
-		Prompt:      agt.Instructions,
-		Model:       string(agt.Model),
-	}
-}
-
 func buildSteeringContent(skl *skills.Skill) string {
 	var sb stringBuilder
+	if !skl.IsEnabledByDefault() {
+		// Manually included steering loads only when referenced with #name
+		sb.WriteString("---\ninclusion: manual\n---\n\n")
+	}
 	sb.WriteString("# " + toTitleCase(skl.Name) + "\n\n")
 	sb.WriteString(skl.Description + "\n\n")
 	if skl.Instructions != "" {
@@ -399,38 +531,46 @@ func buildKiroAgentsReadme(plugin *PluginSpec, agts []*agents.Agent, skls []*ski
 	return sb.String()
 }
 
-func generateGemini(dir string, plugin *PluginSpec, cmds []*commands.Command) error {
+// generateGemini writes a Gemini CLI extension and returns the warnings for
+// command options Gemini ignores.
+func generateGemini(ctx context.Context, dir string, plugin *PluginSpec, cmds []*commands.Command) ([]string, error) {
 	// Get adapters
 	pluginAdapter, ok := plugins.GetAdapter("gemini")
 	if !ok {
-		return fmt.Errorf("gemini plugin adapter not found")
+		return nil, fmt.Errorf("gemini plugin adapter not found")
 	}
 
 	cmdAdapter, ok := commands.GetAdapter("gemini")
 	if !ok {
-		return fmt.Errorf("gemini command adapter not found")
+		return nil, fmt.Errorf("gemini command adapter not found")
 	}
 
 	// Write plugin structure
-	if err := pluginAdapter.WritePlugin(&plugin.Plugin, dir); err != nil {
-		return fmt.Errorf("write plugin: %w", err)
+	if err := pluginAdapter.WritePlugin(plugin.manifestPlugin(), dir); err != nil {
+		return nil, fmt.Errorf("write plugin: %w", err)
 	}
 
 	// Write commands (Gemini uses TOML)
+	var warnings []string
 	if len(cmds) > 0 {
 		commandsDir := filepath.Join(dir, "commands")
 		if err := os.MkdirAll(commandsDir, 0755); err != nil {
-			return err
+			return nil, err
 		}
 		for _, cmd := range cmds {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			warnings = append(warnings, commands.CheckExperimental(cmdAdapter, cmd)...)
+			warnings = append(warnings, commands.CheckEnabledByDefault(cmdAdapter, cmd)...)
 			path := filepath.Join(commandsDir, cmd.Name+".toml")
 			if err := cmdAdapter.WriteFile(cmd, path); err != nil {
-				return fmt.Errorf("write command %s: %w", cmd.Name, err)
+				return nil, fmt.Errorf("write command %s: %w", cmd.Name, err)
 			}
 		}
 	}
 
-	return nil
+	return warnings, nil
 }
 
 func buildPowerInstructions(plugin *PluginSpec, skls []*skills.Skill) string {
@@ -471,6 +611,12 @@ func buildOnboarding(plugin *PluginSpec) string {
 		if srv.Description != "" {
 			sb.WriteString(srv.Description + "\n\n")
 		}
+		if srv.Command == "" {
+			if srv.URL != "" {
+				sb.WriteString(fmt.Sprintf("Remote server at `%s`.\n\n", srv.URL))
+			}
+			continue
+		}
 		sb.WriteString("Verify the server is available:\n\n")
 		sb.WriteString("```bash\n")
 		sb.WriteString(fmt.Sprintf("which %s || echo \"%s not found in PATH\"\n", srv.Command, srv.Command))
@@ -560,11 +706,45 @@ type DeploymentResult struct {
 	// TeamName is the name of the team being deployed.
 	TeamName string
 
-	// TargetsGenerated lists the names of generated targets.
+	// TargetsGenerated lists the names of successfully generated targets.
 	TargetsGenerated []string
 
-	// GeneratedDirs maps target names to their output directories.
+	// GeneratedDirs maps successfully generated target names to their
+	// output directories.
 	GeneratedDirs map[string]string
+
+	// Targets reports the outcome of every target, in deployment order.
+	Targets []TargetResult
+
+	// Warnings lists agent tools and options a target cannot represent,
+	// and targets skipped because their platform is not supported.
+	Warnings []string
+}
+
+// TargetResult is the outcome of generating one deployment target.
+type TargetResult struct {
+	// Name is the target name.
+	Name string
+
+	// Platform is the target platform.
+	Platform string
+
+	// OutputDir is the resolved output directory.
+	OutputDir string
+
+	// Err is the *TargetError for a failed target, or nil on success.
+	Err error
+}
+
+// Failed returns the targets that failed to generate.
+func (r *DeploymentResult) Failed() []TargetResult {
+	var failed []TargetResult
+	for _, t := range r.Targets {
+		if t.Err != nil {
+			failed = append(failed, t)
+		}
+	}
+	return failed
 }
 
 // Deployment generates platform-specific output from multi-agent-spec definitions.
@@ -574,15 +754,31 @@ type DeploymentResult struct {
 //   - teams/: Team definitions (*.json)
 //   - deployments/: Deployment definitions (*.json)
 //
-// Each deployment target specifies a platform and output directory.
+// Each deployment target specifies a platform and output directory. A
+// failing target does not stop the others: every outcome is recorded in
+// DeploymentResult.Targets, and the returned error joins the *TargetError of
+// each failed target alongside the non-nil result.
 func Deployment(specsDir string, deploymentFile string) (*DeploymentResult, error) {
+	return DeploymentContext(context.Background(), specsDir, deploymentFile)
+}
+
+// DeploymentContext is Deployment with a context. Cancellation stops
+// generation before the next target and leaves the target being written
+// untouched; the context error is joined into the returned error.
+func DeploymentContext(ctx context.Context, specsDir string, deploymentFile string) (*DeploymentResult, error) {
 	result := &DeploymentResult{
 		GeneratedDirs: make(map[string]string),
 	}
 
+	restore, err := Options{}.useToolAliases(specsDir)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
 	// Load agents from multi-agent-spec format
 	agentsDir := filepath.Join(specsDir, "agents")
-	agts, err := loadMultiAgentSpecAgents(agentsDir)
+	agts, err := loadMultiAgentSpecAgents(agentsDir, "")
 	if err != nil {
 		return nil, fmt.Errorf("loading agents: %w", err)
 	}
@@ -600,31 +796,56 @@ func Deployment(specsDir string, deploymentFile string) (*DeploymentResult, erro
 		return nil, fmt.Errorf("loading deployment: %w", err)
 	}
 	result.TeamName = deployment.Team
+	result.Warnings = deprecationWarnings(nil, nil, agts)
 
-	// Generate each target
+	// Generate each target, recording failures instead of stopping
+	var errs []error
 	for _, target := range deployment.Targets {
+		if err := ctx.Err(); err != nil {
+			return result, errors.Join(append(errs, err)...)
+		}
+
 		outputDir := target.Output
 		if !filepath.IsAbs(outputDir) {
 			outputDir = filepath.Join(specsDir, "..", outputDir)
 		}
 
-		if err := generateDeploymentTarget(target, agts, outputDir); err != nil {
-			return nil, fmt.Errorf("generating target %s: %w", target.Name, err)
+		status := TargetResult{Name: target.Name, Platform: target.Platform, OutputDir: outputDir}
+		var warnings []string
+		if _, err := writeOutputs(ctx, outputDir, MergeReplace, nil, func(dir string) error {
+			var err error
+			warnings, err = generateDeploymentTarget(ctx, target, agts, dir, false)
+			return err
+		}); err != nil {
+			status.Err = &TargetError{Target: target.Name, Platform: target.Platform, Err: err}
+			errs = append(errs, status.Err)
+		} else {
+			result.TargetsGenerated = append(result.TargetsGenerated, target.Name)
+			result.GeneratedDirs[target.Name] = outputDir
+			result.Warnings = append(result.Warnings, warnings...)
 		}
-
-		result.TargetsGenerated = append(result.TargetsGenerated, target.Name)
-		result.GeneratedDirs[target.Name] = outputDir
+		result.Targets = append(result.Targets, status)
 	}
 
-	return result, nil
+	return result, errors.Join(errs...)
 }
 
-// loadMultiAgentSpecAgents loads agents from markdown files with YAML frontmatter.
-func loadMultiAgentSpecAgents(dir string) ([]*agents.Agent, error) {
+// loadMultiAgentSpecAgents loads agents from markdown files with YAML frontmatter,
+// selecting each agent's instructions for locale, resolving inheritance, and
+// rendering handoff routing guidance into the instructions of coordinating
+// agents. Files matched by a .assistantkitignore file in the parent of dir
+// are skipped.
+func loadMultiAgentSpecAgents(dir, locale string) ([]*agents.Agent, error) {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		return nil, nil
 	}
 
+	root := filepath.Dir(filepath.Clean(dir))
+	ignored, err := ignore.Load(root)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", ignore.FileName, err)
+	}
+
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, err
@@ -640,6 +861,9 @@ func loadMultiAgentSpecAgents(dir string) ([]*agents.Agent, error) {
 		}
 
 		path := filepath.Join(dir, entry.Name())
+		if rel, err := filepath.Rel(root, path); err == nil && ignored.Match(rel, false) {
+			continue
+		}
 		data, err := os.ReadFile(path)
 		if err != nil {
 			return nil, fmt.Errorf("reading %s: %w", entry.Name(), err)
@@ -649,6 +873,9 @@ func loadMultiAgentSpecAgents(dir string) ([]*agents.Agent, error) {
 		if err != nil {
 			return nil, fmt.Errorf("parsing %s: %w", entry.Name(), err)
 		}
+		if err := agents.ResolveIncludes(agt, filepath.Dir(dir)); err != nil {
+			return nil, fmt.Errorf("resolving includes in %s: %w", entry.Name(), err)
+		}
 
 		// Infer name from filename if not set
 		if agt.Name == "" {
@@ -659,7 +886,11 @@ func loadMultiAgentSpecAgents(dir string) ([]*agents.Agent, error) {
 		agts = append(agts, agt)
 	}
 
-	return agts, nil
+	resolved, err := agents.ResolveInheritance(agts)
+	if err != nil {
+		return nil, err
+	}
+	return agents.ApplyHandoffs(agents.Localize(resolved, locale))
 }
 
 // DeploymentTarget represents a deployment target configuration.
@@ -692,72 +923,74 @@ func loadDeployment(path string) (*DeploymentSpec, error) {
 	return &deployment, nil
 }
 
-func generateDeploymentTarget(target DeploymentTarget, agts []*agents.Agent, outputDir string) error {
+func generateDeploymentTarget(ctx context.Context, target DeploymentTarget, agts []*agents.Agent, outputDir string, strict bool) ([]string, error) {
 	// Create output directory
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("creating output dir: %w", err)
+		return nil, fmt.Errorf("creating output dir: %w", err)
 	}
 
 	switch target.Platform {
 	case "claude-code":
-		return generateClaudeCodeDeployment(agts, outputDir)
+		return writeAgents(ctx, "claude", agts, outputDir, strict)
 	case "kiro-cli":
-		return generateKiroCLIDeployment(agts, outputDir)
+		return writeAgents(ctx, "kiro", agts, outputDir, strict)
 	case "gemini-cli":
-		return generateGeminiCLIDeployment(agts, outputDir)
+		return writeAgents(ctx, "gemini", agts, outputDir, strict)
 	default:
-		// For unsupported platforms, log a warning but don't fail
-		fmt.Printf("  Warning: platform %s not yet supported, skipping target %s\n", target.Platform, target.Name)
-		return nil
+		// For unsupported platforms, warn but don't fail
+		return []string{fmt.Sprintf("platform %s not yet supported, skipping target %s", target.Platform, target.Name)}, nil
 	}
 }
 
-func generateClaudeCodeDeployment(agts []*agents.Agent, outputDir string) error {
-	adapter, ok := agents.GetAdapter("claude")
+// writeAgents writes each agent to outputDir with the named adapter and
+// returns the warnings reported by writeAgent.
+func writeAgents(ctx context.Context, adapterName string, agts []*agents.Agent, outputDir string, strict bool) ([]string, error) {
+	adapter, ok := agents.GetAdapter(adapterName)
 	if !ok {
-		return fmt.Errorf("claude adapter not found")
+		return nil, fmt.Errorf("%s adapter not found", adapterName)
+	}
+	if err := agents.CheckFileNames(adapter, agts); err != nil {
+		return nil, err
 	}
 
+	var warnings []string
 	for _, agt := range agts {
-		path := filepath.Join(outputDir, agt.Name+".md")
-		if err := adapter.WriteFile(agt, path); err != nil {
-			return fmt.Errorf("writing %s: %w", agt.Name, err)
+		path := filepath.Join(outputDir, agents.FileName(adapter, agt))
+		w, err := writeAgent(ctx, adapter, agt, path, strict)
+		if err != nil {
+			return nil, fmt.Errorf("writing %s: %w", agt.Name, err)
 		}
+		warnings = append(warnings, w...)
 	}
 
-	return nil
+	return warnings, nil
 }
 
-func generateKiroCLIDeployment(agts []*agents.Agent, outputDir string) error {
-	adapter, ok := agents.GetAdapter("kiro")
-	if !ok {
-		return fmt.Errorf("kiro adapter not found")
+// writeAgent validates and writes an agent with the adapter, dropping tools
+// the adapter does not support. It returns a warning for each dropped tool,
+// for response schemas, guardrails, loop limits, and experimental options
+// the adapter ignores. In strict mode an unsupported tool fails with an
+// *agents.UnsupportedToolsError instead. It returns ctx's error without
+// writing once ctx is done.
+func writeAgent(ctx context.Context, adapter agents.Adapter, agt *agents.Agent, path string, strict bool) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-
-	for _, agt := range agts {
-		path := filepath.Join(outputDir, agt.Name+".json")
-		if err := adapter.WriteFile(agt, path); err != nil {
-			return fmt.Errorf("writing %s: %w", agt.Name, err)
-		}
+	if err := agt.Validate(); err != nil {
+		return nil, err
 	}
-
-	return nil
-}
-
-func generateGeminiCLIDeployment(agts []*agents.Agent, outputDir string) error {
-	adapter, ok := agents.GetAdapter("gemini")
-	if !ok {
-		return fmt.Errorf("gemini adapter not found")
+	restricted, warnings, err := agents.RestrictTools(adapter, agt, strict)
+	if err != nil {
+		return nil, err
 	}
-
-	for _, agt := range agts {
-		path := filepath.Join(outputDir, agt.Name+".toml")
-		if err := adapter.WriteFile(agt, path); err != nil {
-			return fmt.Errorf("writing %s: %w", agt.Name, err)
-		}
+	warnings = append(warnings, agents.CheckResponseSchema(adapter, agt)...)
+	warnings = append(warnings, agents.CheckGuardrails(adapter, agt)...)
+	warnings = append(warnings, agents.CheckLoopControl(adapter, agt)...)
+	warnings = append(warnings, agents.CheckExperimental(adapter, agt)...)
+	if err := adapter.WriteFile(restricted, path); err != nil {
+		return nil, err
 	}
-
-	return nil
+	return warnings, nil
 }
 
 // AgentsResult contains the results of simplified agent generation.
@@ -773,6 +1006,28 @@ type AgentsResult struct {
 
 	// GeneratedDirs maps target names to their output directories.
 	GeneratedDirs map[string]string
+
+	// SkippedFiles lists existing files left untouched by MergeSkip.
+	SkippedFiles []string
+
+	// MergedFiles lists existing JSON files merged by MergeMerge.
+	MergedFiles []string
+
+	// OverviewPath is the overview.md written when Options.Overview is set.
+	// Empty when Options.Only is set.
+	OverviewPath string
+
+	// ManifestPath is the generation manifest recording each target's tool
+	// format version. Empty when Options.Only is set.
+	ManifestPath string
+
+	// BackupPath is the snapshot taken when Options.Backup is set.
+	BackupPath string
+
+	// Warnings lists deprecated agents that were generated, tools dropped
+	// because a target cannot represent them (see Options.Strict), and
+	// agent options a target ignores.
+	Warnings []string
 }
 
 // Agents generates platform-specific agents from a specs directory with simplified options.
@@ -784,17 +1039,49 @@ type AgentsResult struct {
 // The target parameter specifies which deployment file to use (looks for {target}.json).
 // The outputDir is the base directory for resolving relative output paths in the deployment.
 func Agents(specsDir, target, outputDir string) (*AgentsResult, error) {
+	return AgentsWithOptions(specsDir, target, outputDir, Options{})
+}
+
+// AgentsWithOptions is Agents with generation options. Only Options.Only,
+// Options.ToolAliases, Options.Locale, Options.Overview,
+// Options.FormatVersions, Options.Timeout, and Options.Strict apply.
+func AgentsWithOptions(specsDir, target, outputDir string, opts Options) (*AgentsResult, error) {
+	return AgentsContext(context.Background(), specsDir, target, outputDir, opts)
+}
+
+// AgentsContext is AgentsWithOptions with a context. When ctx is canceled or
+// its deadline passes, generation stops and the target being written is left
+// untouched; targets completed before that are kept.
+func AgentsContext(ctx context.Context, specsDir, target, outputDir string, opts Options) (*AgentsResult, error) {
+	ctx, cancel := opts.withTimeout(ctx)
+	defer cancel()
+
 	result := &AgentsResult{
 		GeneratedDirs: make(map[string]string),
 	}
 
+	if err := opts.checkFormatVersions(); err != nil {
+		return nil, err
+	}
+
+	restore, err := opts.useToolAliases(specsDir)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
 	// Load agents from multi-agent-spec format
 	agentsDir := filepath.Join(specsDir, "agents")
-	agts, err := loadMultiAgentSpecAgents(agentsDir)
+	agts, err := loadMultiAgentSpecAgents(agentsDir, opts.Locale)
 	if err != nil {
 		return nil, fmt.Errorf("loading agents: %w", err)
 	}
+	_, _, agts, err = opts.selectSpecs(nil, nil, agts)
+	if err != nil {
+		return nil, err
+	}
 	result.AgentCount = len(agts)
+	result.Warnings = deprecationWarnings(nil, nil, agts)
 
 	// Construct deployment file path
 	deploymentFile := filepath.Join(specsDir, "deployments", target+".json")
@@ -809,6 +1096,14 @@ func Agents(specsDir, target, outputDir string) (*AgentsResult, error) {
 	}
 	result.TeamName = deployment.Team
 
+	var bak *backup
+	if opts.Backup {
+		if bak, err = backupTargets(outputDir, deployment.Targets); err != nil {
+			return nil, err
+		}
+		result.BackupPath = bak.path()
+	}
+
 	// Generate each target
 	for _, tgt := range deployment.Targets {
 		// Resolve output path relative to outputDir (not specsDir)
@@ -817,14 +1112,39 @@ func Agents(specsDir, target, outputDir string) (*AgentsResult, error) {
 			targetOutputDir = filepath.Join(outputDir, targetOutputDir)
 		}
 
-		if err := generateDeploymentTarget(tgt, agts, targetOutputDir); err != nil {
-			return nil, fmt.Errorf("generating target %s: %w", tgt.Name, err)
+		bak.begin(tgt, targetOutputDir)
+		var warnings []string
+		report, err := writeOutputs(ctx, targetOutputDir, opts.MergeStrategy, bak, func(dir string) error {
+			var err error
+			warnings, err = generateDeploymentTarget(ctx, tgt, agts, dir, opts.Strict)
+			return err
+		})
+		if indexErr := bak.writeIndex(); err == nil {
+			err = indexErr
 		}
+		if err != nil {
+			return nil, &TargetError{Target: tgt.Name, Platform: tgt.Platform, Err: err}
+		}
+		result.Warnings = append(result.Warnings, warnings...)
+		result.SkippedFiles = append(result.SkippedFiles, report.Skipped...)
+		result.MergedFiles = append(result.MergedFiles, report.Merged...)
 
 		result.TargetsGenerated = append(result.TargetsGenerated, tgt.Name)
 		result.GeneratedDirs[tgt.Name] = targetOutputDir
 	}
 
+	if opts.Overview && !opts.partial() {
+		if result.OverviewPath, err = writeOverview(outputDir, deployment.Team, agts); err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.partial() {
+		if result.ManifestPath, err = writeManifest(outputDir, deployment.Targets, result.GeneratedDirs, deprecations(nil, nil, agts), opts); err != nil {
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
@@ -847,6 +1167,28 @@ type GenerateResult struct {
 
 	// GeneratedDirs maps target names to their output directories.
 	GeneratedDirs map[string]string
+
+	// SkippedFiles lists existing files left untouched by MergeSkip.
+	SkippedFiles []string
+
+	// MergedFiles lists existing JSON files merged by MergeMerge.
+	MergedFiles []string
+
+	// OverviewPath is the overview.md written when Options.Overview is set.
+	// Empty when Options.Only is set.
+	OverviewPath string
+
+	// ManifestPath is the generation manifest recording each target's tool
+	// format version. Empty when Options.Only is set.
+	ManifestPath string
+
+	// BackupPath is the snapshot taken when Options.Backup is set.
+	BackupPath string
+
+	// Warnings lists components skipped because a target's platform cannot
+	// receive them (see Options.Strict), deprecated components, and agent
+	// tools and options a target cannot represent.
+	Warnings []string
 }
 
 // Generate generates platform-specific plugins from a unified specs directory.
@@ -868,10 +1210,36 @@ type GenerateResult struct {
 // The target parameter specifies which deployment file to use (looks for {target}.json).
 // The outputDir is the base directory for resolving relative output paths in the deployment.
 func Generate(specsDir, target, outputDir string) (*GenerateResult, error) {
+	return GenerateWithOptions(specsDir, target, outputDir, Options{})
+}
+
+// GenerateWithOptions is like Generate but accepts options controlling how
+// output is written.
+func GenerateWithOptions(specsDir, target, outputDir string, opts Options) (*GenerateResult, error) {
+	return GenerateContext(context.Background(), specsDir, target, outputDir, opts)
+}
+
+// GenerateContext is GenerateWithOptions with a context. When ctx is canceled
+// or its deadline passes, generation stops and the target being written is
+// left untouched; targets completed before that are kept.
+func GenerateContext(ctx context.Context, specsDir, target, outputDir string, opts Options) (*GenerateResult, error) {
+	ctx, cancel := opts.withTimeout(ctx)
+	defer cancel()
+
 	result := &GenerateResult{
 		GeneratedDirs: make(map[string]string),
 	}
 
+	if err := opts.checkFormatVersions(); err != nil {
+		return nil, err
+	}
+
+	restore, err := opts.useToolAliases(specsDir)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
 	// Load plugin metadata
 	pluginPath := filepath.Join(specsDir, "plugin.json")
 	var plugin *PluginSpec
@@ -891,7 +1259,6 @@ func Generate(specsDir, target, outputDir string) (*GenerateResult, error) {
 	if err != nil {
 		return nil, fmt.Errorf("loading commands: %w", err)
 	}
-	result.CommandCount = len(cmds)
 
 	// Load skills
 	skillsDir := filepath.Join(specsDir, "skills")
@@ -899,14 +1266,20 @@ func Generate(specsDir, target, outputDir string) (*GenerateResult, error) {
 	if err != nil {
 		return nil, fmt.Errorf("loading skills: %w", err)
 	}
-	result.SkillCount = len(skls)
 
 	// Load agents from multi-agent-spec format (.md files)
 	agentsDir := filepath.Join(specsDir, "agents")
-	agts, err := loadMultiAgentSpecAgents(agentsDir)
+	agts, err := loadMultiAgentSpecAgents(agentsDir, opts.Locale)
 	if err != nil {
 		return nil, fmt.Errorf("loading agents: %w", err)
 	}
+
+	cmds, skls, agts, err = opts.selectSpecs(cmds, skls, agts)
+	if err != nil {
+		return nil, err
+	}
+	result.CommandCount = len(cmds)
+	result.SkillCount = len(skls)
 	result.AgentCount = len(agts)
 
 	// Load deployment
@@ -921,6 +1294,45 @@ func Generate(specsDir, target, outputDir string) (*GenerateResult, error) {
 	}
 	result.TeamName = deployment.Team
 
+	// Merge the team's shared MCP servers and hooks into every target
+	team, err := loadTeam(specsDir, deployment.Team)
+	if err != nil {
+		return nil, err
+	}
+	plugin.addTeamShared(team)
+
+	if err := expandPluginEnv(plugin, opts); err != nil {
+		return nil, err
+	}
+
+	var gaps []ComponentGap
+	for _, tgt := range deployment.Targets {
+		for _, component := range platformGaps(tgt.Platform, plugin, cmds, skls, agts) {
+			gaps = append(gaps, ComponentGap{Target: tgt.Name, Platform: tgt.Platform, Component: component})
+		}
+	}
+	if result.Warnings, err = opts.checkGaps(gaps); err != nil {
+		return nil, err
+	}
+	tools := make([]string, len(deployment.Targets))
+	for i, tgt := range deployment.Targets {
+		tools[i] = platformTool(tgt.Platform)
+	}
+	featureWarnings, err := opts.checkMCPFeatures(tools, plugin, agts)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = append(result.Warnings, featureWarnings...)
+	result.Warnings = append(result.Warnings, deprecationWarnings(cmds, skls, agts)...)
+
+	var bak *backup
+	if opts.Backup {
+		if bak, err = backupTargets(outputDir, deployment.Targets); err != nil {
+			return nil, err
+		}
+		result.BackupPath = bak.path()
+	}
+
 	// Generate each target
 	for _, tgt := range deployment.Targets {
 		// Resolve output path relative to outputDir
@@ -929,75 +1341,83 @@ func Generate(specsDir, target, outputDir string) (*GenerateResult, error) {
 			targetOutputDir = filepath.Join(outputDir, targetOutputDir)
 		}
 
-		if err := generatePlatformPlugin(tgt.Platform, targetOutputDir, plugin, cmds, skls, agts); err != nil {
-			return nil, fmt.Errorf("generating target %s: %w", tgt.Name, err)
+		bak.begin(tgt, targetOutputDir)
+		var warnings []string
+		report, err := writeOutputs(ctx, targetOutputDir, opts.MergeStrategy, bak, func(dir string) error {
+			var err error
+			warnings, err = generatePlatformPlugin(ctx, tgt.Platform, dir, plugin, cmds, skls, agts, opts.Strict, opts.partial())
+			return err
+		})
+		if indexErr := bak.writeIndex(); err == nil {
+			err = indexErr
+		}
+		if err != nil {
+			return nil, &TargetError{Target: tgt.Name, Platform: tgt.Platform, Err: err}
 		}
+		result.Warnings = append(result.Warnings, warnings...)
+		result.SkippedFiles = append(result.SkippedFiles, report.Skipped...)
+		result.MergedFiles = append(result.MergedFiles, report.Merged...)
 
 		result.TargetsGenerated = append(result.TargetsGenerated, tgt.Name)
 		result.GeneratedDirs[tgt.Name] = targetOutputDir
 	}
 
+	if opts.Overview && !opts.partial() {
+		if result.OverviewPath, err = writeOverview(outputDir, deployment.Team, agts); err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.partial() {
+		if result.ManifestPath, err = writeManifest(outputDir, deployment.Targets, result.GeneratedDirs, deprecations(cmds, skls, agts), opts); err != nil {
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
 // generatePlatformPlugin generates a complete plugin for a specific platform.
 // It combines agents, commands, skills, and plugin manifest into a platform-specific format.
+// Platforms without plugin support get agents only, and the returned
+// warnings say so.
 func generatePlatformPlugin(
+	ctx context.Context,
 	platform string,
 	outputDir string,
 	plugin *PluginSpec,
 	cmds []*commands.Command,
 	skls []*skills.Skill,
 	agts []*agents.Agent,
-) error {
+	strict bool,
+	partial bool,
+) ([]string, error) {
 	// Create output directory
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("creating output dir: %w", err)
+		return nil, fmt.Errorf("creating output dir: %w", err)
 	}
 
 	switch platform {
 	case "claude", "claude-code":
-		return generateClaude(outputDir, plugin, cmds, skls, agts)
+		return generateClaude(ctx, outputDir, plugin, cmds, skls, agts, strict)
 	case "kiro", "kiro-cli":
-		return generateKiro(outputDir, plugin, skls, agts)
+		return generateKiro(ctx, outputDir, plugin, skls, agts, strict, partial)
 	case "gemini", "gemini-cli":
-		return generateGemini(outputDir, plugin, cmds)
+		return generateGemini(ctx, outputDir, plugin, cmds)
 	default:
-		// For unsupported platforms, log a warning but don't fail
-		fmt.Printf("  Warning: platform %s not fully supported, generating agents only\n", platform)
-		return generateDeploymentTargetAgentsOnly(platform, agts, outputDir)
+		// For unsupported platforms, warn but don't fail
+		warnings := []string{fmt.Sprintf("platform %s not fully supported, generating agents only", platform)}
+		agentWarnings, err := generateDeploymentTargetAgentsOnly(ctx, platform, agts, outputDir, strict)
+		return append(warnings, agentWarnings...), err
 	}
 }
 
 // generateDeploymentTargetAgentsOnly generates only agents for unsupported platforms.
-func generateDeploymentTargetAgentsOnly(platform string, agts []*agents.Agent, outputDir string) error {
+func generateDeploymentTargetAgentsOnly(ctx context.Context, platform string, agts []*agents.Agent, outputDir string, strict bool) ([]string, error) {
 	if len(agts) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	// Map platform names to adapter names
-	adapterName := platform
-	switch platform {
-	case "claude-code":
-		adapterName = "claude"
-	case "kiro-cli":
-		adapterName = "kiro"
-	case "gemini-cli":
-		adapterName = "gemini"
-	}
-
-	adapter, ok := agents.GetAdapter(adapterName)
-	if !ok {
-		return fmt.Errorf("%s adapter not found", adapterName)
-	}
-
-	for _, agt := range agts {
-		ext := adapter.FileExtension()
-		path := filepath.Join(outputDir, agt.Name+ext)
-		if err := adapter.WriteFile(agt, path); err != nil {
-			return fmt.Errorf("writing %s: %w", agt.Name, err)
-		}
-	}
-
-	return nil
+	return writeAgents(ctx, platformTool(platform), agts, outputDir, strict)
 }
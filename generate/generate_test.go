@@ -0,0 +1,666 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/mcp"
+)
+
+func writeSpecs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	specs := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(specs, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return specs
+}
+
+func TestAgentsWithOptionsOnly(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/base.md":         "---\nname: base\ndescription: Shared reviewer setup\n---\n\nFollow the style guide.\n",
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\ninherits: base\n---\n\nReview the diff.\n",
+		"agents/writer.md":       "---\nname: writer\ndescription: Writes docs\n---\n\nWrite docs.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"agents"}]}`,
+	})
+	out := t.TempDir()
+
+	result, err := AgentsWithOptions(specs, "local", out, Options{Only: []string{"reviewer"}})
+	if err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+	if result.AgentCount != 1 {
+		t.Errorf("expected 1 agent, got %d", result.AgentCount)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(out, "agents"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "reviewer.md" {
+		t.Fatalf("expected only reviewer.md to be written, got %v", entries)
+	}
+
+	data := readFile(t, filepath.Join(out, "agents", "reviewer.md"))
+	if !strings.Contains(data, "Follow the style guide.") {
+		t.Errorf("expected inherited instructions to be resolved, got:\n%s", data)
+	}
+
+	if _, err := AgentsWithOptions(specs, "local", out, Options{Only: []string{"missing"}}); err == nil {
+		t.Error("expected an error for an unknown spec name")
+	}
+}
+
+func TestAgentsSkipsIgnoredSpecs(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		".assistantkitignore":    "agents/_*.md\n",
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"agents/_draft.md":       "---\nname: draft\ndescription: Not ready\n---\n\nDraft.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"agents"}]}`,
+	})
+	out := t.TempDir()
+
+	result, err := AgentsWithOptions(specs, "local", out, Options{})
+	if err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+	if result.AgentCount != 1 {
+		t.Errorf("expected the ignored agent to be skipped, got %d agents", result.AgentCount)
+	}
+	if _, err := os.Stat(filepath.Join(out, "agents", "draft.md")); !os.IsNotExist(err) {
+		t.Errorf("expected no output for the ignored agent, got err %v", err)
+	}
+}
+
+func TestAgentsRendersHandoffRouting(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/coordinator.md":  "---\nname: coordinator\ndescription: Routes work\nhandoffs:\n  - to: researcher\n    when: the task needs background on an unfamiliar API\n  - to: writer\n    when: findings are ready to be written up\n---\n\nCoordinate the team.\n",
+		"agents/researcher.md":   "---\nname: researcher\ndescription: Researches\n---\n\nResearch.\n",
+		"agents/writer.md":       "---\nname: writer\ndescription: Writes docs\n---\n\nWrite docs.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"agents"}]}`,
+	})
+	out := t.TempDir()
+
+	if _, err := AgentsWithOptions(specs, "local", out, Options{}); err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+
+	data := readFile(t, filepath.Join(out, "agents", "coordinator.md"))
+	for _, want := range []string{
+		"## Routing",
+		"- **researcher**: the task needs background on an unfamiliar API",
+		"- **writer**: findings are ready to be written up",
+	} {
+		if !strings.Contains(data, want) {
+			t.Errorf("expected coordinator to contain %q, got:\n%s", want, data)
+		}
+	}
+	if strings.Contains(readFile(t, filepath.Join(out, "agents", "writer.md")), "## Routing") {
+		t.Error("expected routing guidance only on the coordinator")
+	}
+
+	bad := writeSpecs(t, map[string]string{
+		"agents/coordinator.md":  "---\nname: coordinator\ndescription: Routes work\nhandoffs: [missing]\n---\n\nCoordinate.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"agents"}]}`,
+	})
+	if _, err := AgentsWithOptions(bad, "local", t.TempDir(), Options{}); err == nil {
+		t.Error("expected an error for a handoff to an unknown agent")
+	}
+}
+
+func TestPluginsRendersHandoffRouting(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"plugin.json":           `{"name":"tracker","version":"1.0.0","description":"Issue tracker"}`,
+		"agents/coordinator.md": "---\nname: coordinator\ndescription: Routes work\nhandoffs:\n  - to: writer\n    when: findings are ready\n---\n\nCoordinate the team.\n",
+		"agents/writer.md":      "---\nname: writer\ndescription: Writes docs\n---\n\nWrite docs.\n",
+	})
+	out := t.TempDir()
+
+	if _, err := Plugins(specs, out, []string{"claude"}); err != nil {
+		t.Fatalf("Plugins failed: %v", err)
+	}
+	data := readFile(t, filepath.Join(out, "claude", "agents", "coordinator.md"))
+	if !strings.Contains(data, "- **writer**: findings are ready") {
+		t.Errorf("expected routing guidance on the coordinator, got:\n%s", data)
+	}
+}
+
+func TestAgentsWithOptionsLocale(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\nlocalizedInstructions:\n  fr: |-\n    Relisez le diff.\n---\n\nReview the diff.\n",
+		"agents/writer.md":       "---\nname: writer\ndescription: Writes docs\n---\n\nWrite docs.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"agents"}]}`,
+	})
+
+	out := t.TempDir()
+	if _, err := AgentsWithOptions(specs, "local", out, Options{Locale: "fr"}); err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+	reviewer := readFile(t, filepath.Join(out, "agents", "reviewer.md"))
+	if !strings.Contains(reviewer, "Relisez le diff.") || strings.Contains(reviewer, "Review the diff.") {
+		t.Errorf("expected French instructions only, got:\n%s", reviewer)
+	}
+	if writer := readFile(t, filepath.Join(out, "agents", "writer.md")); !strings.Contains(writer, "Write docs.") {
+		t.Errorf("expected default instructions without a French variant, got:\n%s", writer)
+	}
+
+	out = t.TempDir()
+	if _, err := AgentsWithOptions(specs, "local", out, Options{}); err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+	if reviewer := readFile(t, filepath.Join(out, "agents", "reviewer.md")); !strings.Contains(reviewer, "Review the diff.") {
+		t.Errorf("expected default instructions without a locale, got:\n%s", reviewer)
+	}
+}
+
+func TestAgentsAppliesToolAliases(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/deployer.md":     "---\nname: deployer\ndescription: Deploys\ntools: [Read, Bash, DeployTool]\n---\n\nDeploy.\n",
+		"tool-aliases.yaml":      "kiro:\n  DeployTool: deploy_tool\n  Bash: shell\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"kiro","platform":"kiro-cli","output":"kiro"}]}`,
+	})
+	out := t.TempDir()
+
+	if _, err := AgentsWithOptions(specs, "local", out, Options{}); err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+
+	data := readFile(t, filepath.Join(out, "kiro", "deployer.json"))
+	for _, want := range []string{`"fs_read"`, `"shell"`, `"deploy_tool"`} {
+		if !strings.Contains(data, want) {
+			t.Errorf("expected %s in kiro agent, got:\n%s", want, data)
+		}
+	}
+	if strings.Contains(data, "execute_bash") {
+		t.Errorf("expected user alias to override the built-in Bash mapping, got:\n%s", data)
+	}
+}
+
+func TestAgentsStrictUnsupportedTools(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/researcher.md":   "---\nname: researcher\ndescription: Researches\ntools: [Read, Task]\n---\n\nResearch.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"gemini","platform":"gemini-cli","output":"gemini"}]}`,
+	})
+	out := t.TempDir()
+
+	result, err := AgentsWithOptions(specs, "local", out, Options{})
+	if err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], `"Task"`) {
+		t.Errorf("expected a warning about the dropped Task tool, got %v", result.Warnings)
+	}
+
+	_, err = AgentsWithOptions(specs, "local", t.TempDir(), Options{Strict: true})
+	var toolsErr *agents.UnsupportedToolsError
+	if !errors.As(err, &toolsErr) {
+		t.Fatalf("expected UnsupportedToolsError in strict mode, got %v", err)
+	}
+	if len(toolsErr.Tools) != 1 || toolsErr.Tools[0] != "Task" {
+		t.Errorf("expected unsupported tools [Task], got %v", toolsErr.Tools)
+	}
+}
+
+func TestGeneratePluginAgentsRestrictTools(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/researcher.md": "---\nname: researcher\ndescription: Researches\ntools: [Read, NotebookEdit, Knowledge]\nresources: [\"file://docs/guide.md\"]\n---\n\nResearch.\n",
+		"deployments/local.json": `{"team":"t","targets":[` +
+			`{"name":"claude","platform":"claude-code","output":"claude"},` +
+			`{"name":"kiro","platform":"kiro-cli","output":"kiro"}]}`,
+	})
+	out := t.TempDir()
+
+	result, err := GenerateWithOptions(specs, "local", out, Options{})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	warnings := strings.Join(result.Warnings, "\n")
+	if !strings.Contains(warnings, `"Knowledge"`) || !strings.Contains(warnings, `"NotebookEdit"`) {
+		t.Errorf("expected warnings for the tools each target drops, got %v", result.Warnings)
+	}
+
+	claude := readFile(t, filepath.Join(out, "claude", "agents", "researcher.md"))
+	if strings.Contains(claude, "Knowledge") || !strings.Contains(claude, "NotebookEdit") {
+		t.Errorf("expected Claude agent without Knowledge, got:\n%s", claude)
+	}
+	kiro := readFile(t, filepath.Join(out, "kiro", "agents", "researcher.json"))
+	for _, want := range []string{`"fs_read"`, `"knowledge"`, `"file://docs/guide.md"`} {
+		if !strings.Contains(kiro, want) {
+			t.Errorf("expected %s in Kiro agent, got:\n%s", want, kiro)
+		}
+	}
+
+	_, err = GenerateWithOptions(specs, "local", t.TempDir(), Options{Strict: true})
+	var toolsErr *agents.UnsupportedToolsError
+	if !errors.As(err, &toolsErr) {
+		t.Fatalf("expected UnsupportedToolsError in strict mode, got %v", err)
+	}
+}
+
+func TestGenerateReturnsCommandWarnings(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"commands/deploy.json": `{"name":"deploy","description":"Deploys","instructions":"Deploy.","experimental":{"sandbox":true}}`,
+		"deployments/local.json": `{"team":"t","targets":[` +
+			`{"name":"claude","platform":"claude-code","output":"claude"},` +
+			`{"name":"gemini","platform":"gemini-cli","output":"gemini"}]}`,
+	})
+
+	result, err := GenerateWithOptions(specs, "local", t.TempDir(), Options{})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	var claude, gemini bool
+	for _, w := range result.Warnings {
+		if strings.Contains(w, `command "deploy": experimental options`) {
+			claude = claude || strings.Contains(w, "claude")
+			gemini = gemini || strings.Contains(w, "gemini")
+		}
+	}
+	if !claude || !gemini {
+		t.Errorf("expected experimental option warnings for both targets, got %v", result.Warnings)
+	}
+}
+
+func TestDeploymentReportsPerTargetResults(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, []byte("not a directory"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "claude")
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md": "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[` +
+			`{"name":"kiro","platform":"kiro-cli","output":` + strconv.Quote(blocker) + `},` +
+			`{"name":"claude","platform":"claude-code","output":` + strconv.Quote(out) + `}]}`,
+	})
+
+	result, err := Deployment(specs, filepath.Join(specs, "deployments", "local.json"))
+	if result == nil {
+		t.Fatalf("expected a result alongside the error, got %v", err)
+	}
+
+	var targetErr *TargetError
+	if !errors.As(err, &targetErr) || targetErr.Target != "kiro" || targetErr.Platform != "kiro-cli" {
+		t.Fatalf("expected a TargetError for kiro, got %v", err)
+	}
+
+	if len(result.Targets) != 2 {
+		t.Fatalf("expected 2 target results, got %+v", result.Targets)
+	}
+	if result.Targets[0].Name != "kiro" || result.Targets[0].Err == nil {
+		t.Errorf("expected kiro to be reported as failed, got %+v", result.Targets[0])
+	}
+	if result.Targets[1].Name != "claude" || result.Targets[1].Err != nil {
+		t.Errorf("expected claude to be reported as succeeded, got %+v", result.Targets[1])
+	}
+	if len(result.TargetsGenerated) != 1 || result.TargetsGenerated[0] != "claude" {
+		t.Errorf("expected only claude in TargetsGenerated, got %v", result.TargetsGenerated)
+	}
+	if failed := result.Failed(); len(failed) != 1 || failed[0].Name != "kiro" {
+		t.Errorf("expected Failed to return kiro, got %+v", failed)
+	}
+	if _, err := os.Stat(filepath.Join(out, "reviewer.md")); err != nil {
+		t.Errorf("expected the claude target to be written: %v", err)
+	}
+}
+
+func TestAgentsWithOptionsOverview(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/coordinator.md":     "---\nname: coordinator\ndescription: Routes work\nhandoffs:\n  - to: code-researcher\n    when: the task needs \"background\" research\n  - writer\n---\n\nCoordinate the team.\n",
+		"agents/code-researcher.md": "---\nname: code-researcher\ndescription: Researches\n---\n\nResearch.\n",
+		"agents/writer.md":          "---\nname: writer\ndescription: Writes docs\n---\n\nWrite docs.\n",
+		"deployments/local.json":    `{"team":"docs-team","targets":[{"name":"claude","platform":"claude-code","output":"agents"}]}`,
+	})
+	out := t.TempDir()
+
+	result, err := AgentsWithOptions(specs, "local", out, Options{Overview: true})
+	if err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+	if result.OverviewPath != filepath.Join(out, OverviewFileName) {
+		t.Errorf("expected overview at %s, got %q", filepath.Join(out, OverviewFileName), result.OverviewPath)
+	}
+
+	data := readFile(t, filepath.Join(out, OverviewFileName))
+	for _, want := range []string{
+		"# docs-team Overview",
+		"```mermaid\ngraph TD\n",
+		`coordinator["coordinator"]`,
+		`code_researcher["code-researcher"]`,
+		`writer["writer"]`,
+		`coordinator -->|"the task needs #quot;background#quot; research"| code_researcher`,
+		"coordinator --> writer",
+		"- **writer**: Writes docs",
+	} {
+		if !strings.Contains(data, want) {
+			t.Errorf("expected overview to contain %q, got:\n%s", want, data)
+		}
+	}
+
+	plain := t.TempDir()
+	if _, err := AgentsWithOptions(specs, "local", plain, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(plain, OverviewFileName)); !os.IsNotExist(err) {
+		t.Error("expected no overview without Options.Overview")
+	}
+}
+
+func TestGenerateOnlyLeavesAggregates(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"plugin.json":            `{"name":"tracker","version":"1.0.0","description":"Issue tracker","keywords":["issues"]}`,
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"agents/writer.md":       "---\nname: writer\ndescription: Writes docs\n---\n\nWrite docs.\n",
+		"skills/lint/skill.json": `{"name":"lint","description":"Lints code","instructions":"Run the linter."}`,
+		"skills/docs/skill.json": `{"name":"docs","description":"Writes docs","instructions":"Update the docs."}`,
+		"deployments/local.json": `{"team":"t","targets":[{"name":"kiro","platform":"kiro","output":"kiro"}]}`,
+	})
+	out := t.TempDir()
+	if _, err := GenerateWithOptions(specs, "local", out, Options{Overview: true}); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	aggregates := []string{
+		filepath.Join(out, "kiro", "POWER.md"),
+		filepath.Join(out, OverviewFileName),
+		filepath.Join(out, ManifestFileName),
+	}
+	before := make([]string, len(aggregates))
+	for i, path := range aggregates {
+		before[i] = readFile(t, path)
+	}
+
+	result, err := GenerateWithOptions(specs, "local", out, Options{Overview: true, Only: []string{"reviewer", "lint"}})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	for i, path := range aggregates {
+		if got := readFile(t, path); got != before[i] {
+			t.Errorf("expected %s to be left untouched by --only, got:\n%s", filepath.Base(path), got)
+		}
+	}
+	if result.OverviewPath != "" || result.ManifestPath != "" {
+		t.Errorf("expected no overview or manifest paths, got %q %q", result.OverviewPath, result.ManifestPath)
+	}
+	if !strings.Contains(before[0], "docs") {
+		t.Errorf("expected the full POWER.md to list every skill, got:\n%s", before[0])
+	}
+}
+
+func TestGeneratePowerPrefixesOrderedSteering(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"plugin.json":            `{"name":"tracker","version":"1.0.0","description":"Issue tracker","keywords":["issues"]}`,
+		"skills/lint/skill.json": `{"name":"lint","description":"Lints code","instructions":"Run the linter.","order":2}`,
+		"skills/docs/skill.json": `{"name":"docs","description":"Writes docs","instructions":"Update the docs."}`,
+		"deployments/local.json": `{"team":"t","targets":[{"name":"kiro","platform":"kiro","output":"kiro"}]}`,
+	})
+	out := t.TempDir()
+	if _, err := GenerateWithOptions(specs, "local", out, Options{}); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	for _, name := range []string{"02-lint.md", "docs.md"} {
+		if _, err := os.Stat(filepath.Join(out, "kiro", "steering", name)); err != nil {
+			t.Errorf("expected power steering file %s: %v", name, err)
+		}
+	}
+}
+
+func TestGenerateMCPHeadersExpandEnv(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"plugin.json": `{"name":"tracker","version":"1.0.0","description":"Issue tracker","keywords":["issues"],"mcpServers":{` +
+			`"issues":{"url":"https://${ISSUES_HOST}/mcp","headers":{"Authorization":"Bearer ${ISSUES_TOKEN}"}}}}`,
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"kiro","platform":"kiro","output":"kiro"}]}`,
+	})
+	t.Setenv("ISSUES_HOST", "issues.example.com")
+	t.Setenv("ISSUES_TOKEN", "s3cret")
+
+	out := t.TempDir()
+	if _, err := GenerateWithOptions(specs, "local", out, Options{}); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	mcp := readFile(t, filepath.Join(out, "kiro", "mcp.json"))
+	for _, want := range []string{`"url": "https://${ISSUES_HOST}/mcp"`, `"Authorization": "Bearer ${ISSUES_TOKEN}"`} {
+		if !strings.Contains(mcp, want) {
+			t.Errorf("expected placeholders to be kept (%s), got:\n%s", want, mcp)
+		}
+	}
+
+	out = t.TempDir()
+	if _, err := GenerateWithOptions(specs, "local", out, Options{ExpandEnv: true}); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	mcp = readFile(t, filepath.Join(out, "kiro", "mcp.json"))
+	for _, want := range []string{`"url": "https://issues.example.com/mcp"`, `"Authorization": "Bearer s3cret"`} {
+		if !strings.Contains(mcp, want) {
+			t.Errorf("expected expanded values (%s), got:\n%s", want, mcp)
+		}
+	}
+}
+
+func TestGenerateChecksMCPFeaturesForToolVersion(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"plugin.json":            `{"name":"tracker","version":"1.0.0","description":"Issue tracker"}`,
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\nmcp:\n  docs:\n    url: https://docs.example.com/mcp\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"claude"}]}`,
+	})
+
+	result, err := GenerateWithOptions(specs, "local", t.TempDir(), Options{ToolVersions: map[string]string{"claude": "1.0.20"}})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "http-transport") {
+		t.Errorf("expected a warning about the agent's HTTP server, got %q", result.Warnings)
+	}
+
+	_, err = GenerateWithOptions(specs, "local", t.TempDir(), Options{ToolVersions: map[string]string{"claude": "1.0.20"}, Strict: true})
+	if !errors.Is(err, mcp.ErrUnsupportedFeature) {
+		t.Errorf("expected ErrUnsupportedFeature in strict mode, got %v", err)
+	}
+}
+
+func TestGenerateSharedHooksExpandEnv(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"plugin.json":        `{"name":"tracker","version":"1.0.0","description":"Issue tracker"}`,
+		"agents/reviewer.md": "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"teams/release.json": `{"name":"release","process":"sequential","tasks":[{"name":"review","agent":"reviewer"}],` +
+			`"sharedHooks":{"hooks":{"before_command":[{"hooks":[{"type":"command","command":"${AUDIT_DIR}/audit.sh"}]}]}}}`,
+		"deployments/local.json": `{"team":"release","targets":[{"name":"claude","platform":"claude","output":"claude"}]}`,
+	})
+	t.Setenv("AUDIT_DIR", "/opt/audit")
+
+	out := t.TempDir()
+	if _, err := GenerateWithOptions(specs, "local", out, Options{ExpandEnv: true}); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	hooksFile := readFile(t, filepath.Join(out, "claude", "hooks", "hooks.json"))
+	if !strings.Contains(hooksFile, "/opt/audit/audit.sh") {
+		t.Errorf("expected the shared hook command to be expanded, got:\n%s", hooksFile)
+	}
+}
+
+func TestGenerateMergesTeamShared(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"plugin.json":        `{"name":"tracker","version":"1.0.0","description":"Issue tracker"}`,
+		"agents/reviewer.md": "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"teams/release.json": `{"name":"release","process":"sequential","tasks":[{"name":"review","agent":"reviewer"}],` +
+			`"sharedMcp":{"docs":{"command":"docs-mcp","args":["--stdio"]},"issues":{"command":"other-mcp"}},` +
+			`"sharedHooks":{"hooks":{"before_command":[{"hooks":[{"type":"command","command":"./audit.sh"}]},` +
+			`{"hooks":[{"type":"command","command":"./audit.sh"}]}]}}}`,
+		"deployments/local.json": `{"team":"release","targets":[` +
+			`{"name":"claude","platform":"claude","output":"claude"},` +
+			`{"name":"gemini","platform":"gemini","output":"gemini"},` +
+			`{"name":"kiro","platform":"kiro","output":"kiro"}]}`,
+	})
+
+	// Team servers alone leave the Kiro target in agents format, with the
+	// servers listed on each agent.
+	out := t.TempDir()
+	if _, err := GenerateWithOptions(specs, "local", out, Options{}); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	kiroAgent := readFile(t, filepath.Join(out, "kiro", "agents", "reviewer.json"))
+	if !strings.Contains(kiroAgent, `"docs-mcp"`) {
+		t.Errorf("expected team MCP server on the Kiro agent, got:\n%s", kiroAgent)
+	}
+	hooksFile := readFile(t, filepath.Join(out, "claude", "hooks", "hooks.json"))
+	if strings.Count(hooksFile, "./audit.sh") != 1 {
+		t.Errorf("expected one deduplicated team hook, got:\n%s", hooksFile)
+	}
+
+	plugin := `{"name":"tracker","version":"1.0.0","description":"Issue tracker","keywords":["issues"],` +
+		`"mcpServers":{"issues":{"command":"issues-mcp"}}}`
+	if err := os.WriteFile(filepath.Join(specs, "plugin.json"), []byte(plugin), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out = t.TempDir()
+	if _, err := GenerateWithOptions(specs, "local", out, Options{}); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	for _, path := range []string{
+		filepath.Join(out, "claude", ".claude-plugin", "plugin.json"),
+		filepath.Join(out, "gemini", "gemini-extension.json"),
+		filepath.Join(out, "kiro", "mcp.json"),
+	} {
+		content := readFile(t, path)
+		if !strings.Contains(content, `"docs-mcp"`) {
+			t.Errorf("expected team MCP server in %s, got:\n%s", path, content)
+		}
+		if strings.Contains(content, "other-mcp") {
+			t.Errorf("expected the plugin's issues server to win in %s, got:\n%s", path, content)
+		}
+	}
+}
+
+func TestGenerateStrictComponentGaps(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"commands/release.md":    "---\nname: release\ndescription: Cut a release\n---\n\nRelease it.\n",
+		"skills/review.md":       "---\nname: review\ndescription: Review code\n---\n\nReview it.\n",
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"kiro","platform":"kiro","output":"kiro"},{"name":"gemini","platform":"gemini","output":"gemini"}]}`,
+	})
+
+	out := t.TempDir()
+	result, err := GenerateWithOptions(specs, "local", out, Options{})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	want := []string{
+		"kiro does not support commands; skipped",
+		"gemini does not support skills; skipped",
+		"gemini does not support agents; skipped",
+	}
+	if strings.Join(result.Warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected warnings %q, got %q", want, result.Warnings)
+	}
+
+	out = t.TempDir()
+	_, err = GenerateWithOptions(specs, "local", out, Options{Strict: true})
+	var gapsErr *UnsupportedComponentsError
+	if !errors.As(err, &gapsErr) || !errors.Is(err, ErrUnsupportedComponent) {
+		t.Fatalf("expected UnsupportedComponentsError, got %v", err)
+	}
+	if len(gapsErr.Gaps) != 3 || gapsErr.Gaps[0] != (ComponentGap{Target: "kiro", Platform: "kiro", Component: "commands"}) {
+		t.Errorf("expected every gap listed, got %+v", gapsErr.Gaps)
+	}
+	if entries, _ := os.ReadDir(out); len(entries) != 0 {
+		t.Errorf("expected nothing written in strict mode, got %v", entries)
+	}
+}
+
+// countdownContext reports cancellation once Err has been consulted n times,
+// simulating a cancel that arrives in the middle of a write loop.
+type countdownContext struct {
+	context.Context
+	n int
+}
+
+func (c *countdownContext) Err() error {
+	c.n--
+	if c.n <= 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestGenerateContextCanceledMidway(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/a.md":            "---\nname: a\ndescription: First\n---\n\nA.\n",
+		"agents/b.md":            "---\nname: b\ndescription: Second\n---\n\nB.\n",
+		"agents/c.md":            "---\nname: c\ndescription: Third\n---\n\nC.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"claude"}]}`,
+	})
+	out := t.TempDir()
+
+	parent, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx := &countdownContext{Context: parent, n: 2}
+
+	if _, err := GenerateContext(ctx, specs, "local", out, Options{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	entries, err := os.ReadDir(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no output after cancellation, got %v", entries)
+	}
+}
+
+func TestAgentsContextCanceled(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"agents"}]}`,
+	})
+	out := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := AgentsContext(ctx, specs, "local", out, Options{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "agents")); !os.IsNotExist(err) {
+		t.Errorf("expected no agents dir after cancellation, got %v", err)
+	}
+
+	result, err := DeploymentContext(ctx, specs, filepath.Join(specs, "deployments", "local.json"))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled from DeploymentContext, got %v", err)
+	}
+	if result == nil || len(result.TargetsGenerated) != 0 {
+		t.Errorf("expected no generated targets, got %+v", result)
+	}
+}
+
+func TestPluginsContextTimeout(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"plugin.json":        `{"name":"p","version":"1.0.0","description":"Plugin"}`,
+		"agents/reviewer.md": "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+	})
+	out := t.TempDir()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	if _, err := PluginsContext(ctx, specs, out, []string{"claude"}, Options{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "claude")); !os.IsNotExist(err) {
+		t.Errorf("expected no claude dir after the deadline, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// Spec kinds reported by List.
+const (
+	SpecKindAgent   = "agent"
+	SpecKindSkill   = "skill"
+	SpecKindCommand = "command"
+)
+
+// SpecSummary describes a single spec found in a specs directory.
+type SpecSummary struct {
+	Kind        string   `json:"kind"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Model       string   `json:"model,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	HelpURL     string   `json:"helpUrl,omitempty"`
+}
+
+// List reads the agents, skills, and commands in specsDir with the same
+// readers used by Generate and summarizes them without writing any output.
+// Results are grouped by kind (agents, skills, commands) and sorted by name.
+// Skill tags are the skill's triggers.
+func List(specsDir string) ([]SpecSummary, error) {
+	agts, err := loadMultiAgentSpecAgents(filepath.Join(specsDir, "agents"), "")
+	if err != nil {
+		return nil, fmt.Errorf("loading agents: %w", err)
+	}
+	skls, err := loadSkills(filepath.Join(specsDir, "skills"))
+	if err != nil {
+		return nil, fmt.Errorf("loading skills: %w", err)
+	}
+	cmds, err := loadCommands(filepath.Join(specsDir, "commands"))
+	if err != nil {
+		return nil, fmt.Errorf("loading commands: %w", err)
+	}
+
+	var agentSpecs, skillSpecs, commandSpecs []SpecSummary
+	for _, agt := range agts {
+		agentSpecs = append(agentSpecs, SpecSummary{
+			Kind:        SpecKindAgent,
+			Name:        agt.Name,
+			Description: agt.Description,
+			Model:       string(agt.Model),
+			HelpURL:     agt.HelpURL,
+		})
+	}
+	for _, skl := range skls {
+		skillSpecs = append(skillSpecs, SpecSummary{
+			Kind:        SpecKindSkill,
+			Name:        skl.Name,
+			Description: skl.Description,
+			Tags:        skl.Triggers,
+		})
+	}
+	for _, cmd := range cmds {
+		commandSpecs = append(commandSpecs, SpecSummary{
+			Kind:        SpecKindCommand,
+			Name:        cmd.Name,
+			Description: cmd.Description,
+			HelpURL:     cmd.HelpURL,
+		})
+	}
+
+	var specs []SpecSummary
+	for _, group := range [][]SpecSummary{agentSpecs, skillSpecs, commandSpecs} {
+		sort.Slice(group, func(i, j int) bool { return group[i].Name < group[j].Name })
+		specs = append(specs, group...)
+	}
+	return specs, nil
+}
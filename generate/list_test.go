@@ -0,0 +1,49 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	specs := t.TempDir()
+	files := map[string]string{
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews pull requests\nmodel: sonnet\n---\n\nReview carefully.\n",
+		"agents/author.md":       "---\nname: author\ndescription: Writes release notes\n---\n\nWrite clearly.\n",
+		"skills/changelog.md":    "---\nname: changelog\ndescription: Maintains CHANGELOG.md\ntriggers: [release, changelog]\n---\n\nKeep a changelog.\n",
+		"commands/release.md":    "---\nname: release\ndescription: Cuts a release\nhelpUrl: https://example.com/docs/release\n---\n\nTag and push.\n",
+		"deployments/local.json": `{"team":"t","targets":[]}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(specs, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := List(specs)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	want := []SpecSummary{
+		{Kind: SpecKindAgent, Name: "author", Description: "Writes release notes"},
+		{Kind: SpecKindAgent, Name: "reviewer", Description: "Reviews pull requests", Model: "sonnet"},
+		{Kind: SpecKindSkill, Name: "changelog", Description: "Maintains CHANGELOG.md", Tags: []string{"release", "changelog"}},
+		{Kind: SpecKindCommand, Name: "release", Description: "Cuts a release", HelpURL: "https://example.com/docs/release"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("List returned %d specs, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Kind != want[i].Kind || got[i].Name != want[i].Name ||
+			got[i].Description != want[i].Description || got[i].Model != want[i].Model || got[i].HelpURL != want[i].HelpURL ||
+			len(got[i].Tags) != len(want[i].Tags) {
+			t.Errorf("spec %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
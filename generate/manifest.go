@@ -0,0 +1,196 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/commands"
+	"github.com/agentplexus/assistantkit/skills"
+)
+
+// ManifestFileName is the generation manifest written to the output base
+// directory, recording the config format version emitted for each target.
+const ManifestFileName = ".assistantkit-manifest.json"
+
+// FormatVersions maps each tool to the version of its config format that
+// this release of assistantkit generates. A version changes when an
+// adapter's output changes shape in a way the tool reads differently.
+var FormatVersions = map[string]string{
+	"claude": "1",
+	"codex":  "1",
+	"gemini": "1",
+	"kiro":   "1",
+}
+
+// Manifest records how a target's output was generated.
+type Manifest struct {
+	// Generator is always "assistantkit".
+	Generator string `json:"generator"`
+
+	// Targets lists each generated target in deployment order.
+	Targets []ManifestTarget `json:"targets"`
+
+	// Deprecated lists the generated components marked deprecated.
+	Deprecated []ManifestDeprecation `json:"deprecated,omitempty"`
+}
+
+// ManifestTarget is the generation record for one deployment target.
+type ManifestTarget struct {
+	Name          string `json:"name"`
+	Platform      string `json:"platform"`
+	Tool          string `json:"tool"`
+	FormatVersion string `json:"formatVersion,omitempty"`
+	Output        string `json:"output"`
+}
+
+// ManifestDeprecation records a deprecated component that was generated.
+type ManifestDeprecation struct {
+	Kind    string `json:"kind"` // "command", "skill", or "agent"
+	Name    string `json:"name"`
+	Message string `json:"message,omitempty"`
+}
+
+// deprecations returns the deprecated components among the loaded specs,
+// commands first, then skills, then agents.
+func deprecations(cmds []*commands.Command, skls []*skills.Skill, agts []*agents.Agent) []ManifestDeprecation {
+	var out []ManifestDeprecation
+	for _, cmd := range cmds {
+		if cmd.Deprecated {
+			out = append(out, ManifestDeprecation{Kind: "command", Name: cmd.Name, Message: cmd.DeprecationMessage})
+		}
+	}
+	for _, skl := range skls {
+		if skl.Deprecated {
+			out = append(out, ManifestDeprecation{Kind: "skill", Name: skl.Name, Message: skl.DeprecationMessage})
+		}
+	}
+	for _, agt := range agts {
+		if agt.Deprecated {
+			out = append(out, ManifestDeprecation{Kind: "agent", Name: agt.Name, Message: agt.DeprecationMessage})
+		}
+	}
+	return out
+}
+
+// deprecationWarnings returns a warning for each deprecated component,
+// in the same order as deprecations.
+func deprecationWarnings(cmds []*commands.Command, skls []*skills.Skill, agts []*agents.Agent) []string {
+	var warnings []string
+	for _, cmd := range cmds {
+		if w := cmd.DeprecationWarning(); w != "" {
+			warnings = append(warnings, w)
+		}
+	}
+	for _, skl := range skls {
+		if w := skl.DeprecationWarning(); w != "" {
+			warnings = append(warnings, w)
+		}
+	}
+	for _, agt := range agts {
+		if w := agt.DeprecationWarning(); w != "" {
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
+
+// ReadManifest reads the generation manifest in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ManifestFileName, err)
+	}
+	return &m, nil
+}
+
+// platformTool maps a deployment platform name to its tool (adapter) name.
+func platformTool(platform string) string {
+	switch platform {
+	case "claude-code":
+		return "claude"
+	case "kiro-cli":
+		return "kiro"
+	case "gemini-cli":
+		return "gemini"
+	default:
+		return platform
+	}
+}
+
+// formatVersion returns the format version generated for tool, checking
+// it against a pinned Options.FormatVersions entry.
+func (o Options) formatVersion(tool string) (string, error) {
+	current := FormatVersions[tool]
+	pinned, ok := o.FormatVersions[tool]
+	if !ok || pinned == current {
+		return current, nil
+	}
+	if current == "" {
+		return "", fmt.Errorf("%w: %s has no versioned format (pinned %q)", ErrUnsupportedFormatVersion, tool, pinned)
+	}
+	return "", fmt.Errorf("%w: %s format %q is pinned, but this release generates %q", ErrUnsupportedFormatVersion, tool, pinned, current)
+}
+
+// checkFormatVersions fails before anything is written when a pinned format
+// version is not the one generated, or pins a tool with no versioned format.
+func (o Options) checkFormatVersions() error {
+	tools := make([]string, 0, len(o.FormatVersions))
+	for tool := range o.FormatVersions {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	for _, tool := range tools {
+		if _, err := o.formatVersion(tool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeManifest writes the generation manifest for targets to dir and
+// returns its path. Output paths are recorded relative to dir when possible.
+func writeManifest(dir string, targets []DeploymentTarget, dirs map[string]string, deprecated []ManifestDeprecation, opts Options) (string, error) {
+	m := Manifest{Generator: "assistantkit", Targets: []ManifestTarget{}, Deprecated: deprecated}
+	for _, tgt := range targets {
+		out, ok := dirs[tgt.Name]
+		if !ok {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, out); err == nil {
+			out = filepath.ToSlash(rel)
+		}
+		tool := platformTool(tgt.Platform)
+		version, err := opts.formatVersion(tool)
+		if err != nil {
+			return "", err
+		}
+		m.Targets = append(m.Targets, ManifestTarget{
+			Name:          tgt.Name,
+			Platform:      tgt.Platform,
+			Tool:          tool,
+			FormatVersion: version,
+			Output:        out,
+		})
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating output dir: %w", err)
+	}
+	path := filepath.Join(dir, ManifestFileName)
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", ManifestFileName, err)
+	}
+	return path, nil
+}
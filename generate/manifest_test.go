@@ -0,0 +1,110 @@
+package generate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateWritesManifestWithFormatVersions(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md": "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[` +
+			`{"name":"claude","platform":"claude-code","output":"plugins/claude"},` +
+			`{"name":"gemini","platform":"gemini","output":"plugins/gemini"}]}`,
+	})
+	out := t.TempDir()
+
+	result, err := GenerateWithOptions(specs, "local", out, Options{FormatVersions: map[string]string{"claude": FormatVersions["claude"]}})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	if result.ManifestPath != filepath.Join(out, ManifestFileName) {
+		t.Errorf("expected manifest at %s, got %q", filepath.Join(out, ManifestFileName), result.ManifestPath)
+	}
+
+	manifest, err := ReadManifest(out)
+	if err != nil {
+		t.Fatalf("ReadManifest failed: %v", err)
+	}
+	if manifest.Generator != "assistantkit" {
+		t.Errorf("expected generator assistantkit, got %q", manifest.Generator)
+	}
+	want := []ManifestTarget{
+		{Name: "claude", Platform: "claude-code", Tool: "claude", FormatVersion: FormatVersions["claude"], Output: "plugins/claude"},
+		{Name: "gemini", Platform: "gemini", Tool: "gemini", FormatVersion: FormatVersions["gemini"], Output: "plugins/gemini"},
+	}
+	if len(manifest.Targets) != len(want) {
+		t.Fatalf("expected %d manifest targets, got %+v", len(want), manifest.Targets)
+	}
+	for i, tgt := range manifest.Targets {
+		if tgt != want[i] {
+			t.Errorf("target %d: expected %+v, got %+v", i, want[i], tgt)
+		}
+	}
+}
+
+func TestGeneratePinnedFormatVersionMismatch(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"plugins/claude"}]}`,
+	})
+
+	for _, pins := range []map[string]string{
+		{"claude": "99"},
+		{"notepad": "1"},
+	} {
+		_, err := GenerateWithOptions(specs, "local", t.TempDir(), Options{FormatVersions: pins})
+		if !errors.Is(err, ErrUnsupportedFormatVersion) {
+			t.Errorf("%v: expected ErrUnsupportedFormatVersion, got %v", pins, err)
+		}
+	}
+}
+
+func TestGenerateRecordsDeprecatedAgent(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\ndeprecated: true\ndeprecationMessage: use code-reviewer\n---\n\nReview the diff.\n",
+		"agents/writer.md":       "---\nname: writer\ndescription: Writes docs\n---\n\nWrite docs.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"plugins/claude"}]}`,
+	})
+	out := t.TempDir()
+
+	result, err := Generate(specs, "local", out)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "plugins", "claude", "agents", "reviewer.md")); err != nil {
+		t.Errorf("expected the deprecated agent to be generated: %v", err)
+	}
+	want := `agent "reviewer" is deprecated: use code-reviewer`
+	if len(result.Warnings) != 1 || result.Warnings[0] != want {
+		t.Errorf("expected warning %q, got %q", want, result.Warnings)
+	}
+
+	manifest, err := ReadManifest(out)
+	if err != nil {
+		t.Fatalf("ReadManifest failed: %v", err)
+	}
+	wantDeprecated := []ManifestDeprecation{{Kind: "agent", Name: "reviewer", Message: "use code-reviewer"}}
+	if !reflect.DeepEqual(manifest.Deprecated, wantDeprecated) {
+		t.Errorf("expected deprecated %+v, got %+v", wantDeprecated, manifest.Deprecated)
+	}
+}
+
+func TestDeploymentWarnsOnDeprecatedAgent(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\ndeprecated: true\ndeprecationMessage: use code-reviewer\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"plugins/claude"}]}`,
+	})
+
+	result, err := Deployment(specs, filepath.Join(specs, "deployments", "local.json"))
+	if err != nil {
+		t.Fatalf("Deployment failed: %v", err)
+	}
+	want := `agent "reviewer" is deprecated: use code-reviewer`
+	if len(result.Warnings) != 1 || result.Warnings[0] != want {
+		t.Errorf("expected warning %q, got %q", want, result.Warnings)
+	}
+}
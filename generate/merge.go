@@ -0,0 +1,171 @@
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+)
+
+// MergeStrategy controls how generated files that already exist in the
+// output directory are handled.
+type MergeStrategy string
+
+const (
+	// MergeReplace overwrites existing files (the default).
+	MergeReplace MergeStrategy = "replace"
+
+	// MergeSkip leaves existing files untouched and reports them.
+	MergeSkip MergeStrategy = "skip"
+
+	// MergeMerge deep-merges generated JSON objects into existing JSON files,
+	// with generated values winning on conflict. Other files are replaced.
+	MergeMerge MergeStrategy = "merge"
+)
+
+// MergeStrategies lists the supported merge strategies.
+var MergeStrategies = []MergeStrategy{MergeReplace, MergeSkip, MergeMerge}
+
+// ParseMergeStrategy parses a merge strategy name. An empty name yields MergeReplace.
+func ParseMergeStrategy(name string) (MergeStrategy, error) {
+	if name == "" {
+		return MergeReplace, nil
+	}
+	for _, s := range MergeStrategies {
+		if string(s) == name {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unknown merge strategy %q (expected replace, skip, or merge)", name)
+}
+
+// MergeReport lists existing output files that were not simply overwritten.
+type MergeReport struct {
+	// Skipped lists existing files left untouched by MergeSkip.
+	Skipped []string
+
+	// Merged lists existing JSON files merged by MergeMerge.
+	Merged []string
+}
+
+// writeOutputs runs gen and places its output in outputDir according to
+// strategy. For strategies other than MergeReplace, whenever ctx can be
+// canceled, and when bak is set, gen writes into a staging directory whose
+// files are then copied over one by one. A canceled run discards the
+// staging directory, so outputDir never receives half-written output. Each
+// file is added to bak before it is written.
+func writeOutputs(ctx context.Context, outputDir string, strategy MergeStrategy, bak *backup, gen func(dir string) error) (*MergeReport, error) {
+	report := &MergeReport{}
+	if (strategy == "" || strategy == MergeReplace) && ctx.Done() == nil && bak == nil {
+		return report, gen(outputDir)
+	}
+
+	stagingDir, err := os.MkdirTemp("", "assistantkit-generate-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating staging dir: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := gen(stagingDir); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	err = filepath.WalkDir(stagingDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(stagingDir, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(outputDir, rel)
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		existing, err := os.ReadFile(dest)
+		if os.IsNotExist(err) {
+			if err := bak.save(dest); err != nil {
+				return err
+			}
+			return os.WriteFile(dest, data, info.Mode().Perm())
+		}
+		if err != nil {
+			return err
+		}
+
+		if strategy == MergeSkip {
+			report.Skipped = append(report.Skipped, dest)
+			return nil
+		}
+		if err := bak.save(dest); err != nil {
+			return err
+		}
+		switch {
+		case strategy == MergeMerge && strings.EqualFold(filepath.Ext(dest), ".json"):
+			merged, err := MergeJSON(existing, data)
+			if err != nil {
+				return fmt.Errorf("merging %s: %w", dest, err)
+			}
+			report.Merged = append(report.Merged, dest)
+			return os.WriteFile(dest, merged, info.Mode().Perm())
+		default:
+			return os.WriteFile(dest, data, info.Mode().Perm())
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// MergeJSON deep-merges the generated JSON document into the existing one.
+// Objects are merged key by key; for any other value the generated one wins.
+func MergeJSON(existing, generated []byte) ([]byte, error) {
+	var base, overlay any
+	if err := json.Unmarshal(existing, &base); err != nil {
+		return nil, fmt.Errorf("parsing existing file: %w", err)
+	}
+	if err := json.Unmarshal(generated, &overlay); err != nil {
+		return nil, fmt.Errorf("parsing generated file: %w", err)
+	}
+	return canonicaljson.Marshal(mergeValues(base, overlay))
+}
+
+// mergeValues merges overlay into base, recursing into objects.
+func mergeValues(base, overlay any) any {
+	baseObj, ok := base.(map[string]any)
+	if !ok {
+		return overlay
+	}
+	overlayObj, ok := overlay.(map[string]any)
+	if !ok {
+		return overlay
+	}
+	for key, value := range overlayObj {
+		if existing, ok := baseObj[key]; ok {
+			baseObj[key] = mergeValues(existing, value)
+		} else {
+			baseObj[key] = value
+		}
+	}
+	return baseObj
+}
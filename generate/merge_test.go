@@ -0,0 +1,152 @@
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupExisting creates an output dir holding a pre-existing agent file and plugin.json.
+func setupExisting(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "agents"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "agents", "reviewer.md"), []byte("existing"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"name":"old","author":"team","mcpServers":{"local":{"command":"./local"}}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// generateFixture writes the generated counterparts of setupExisting plus a new file.
+func generateFixture(dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, "agents"), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "agents", "reviewer.md"), []byte("generated"), 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "agents", "writer.md"), []byte("new"), 0600); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"name":"new","mcpServers":{"remote":{"command":"./remote"}}}`), 0600)
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWriteOutputsReplace(t *testing.T) {
+	dir := setupExisting(t)
+
+	report, err := writeOutputs(context.Background(), dir, MergeReplace, nil, generateFixture)
+	if err != nil {
+		t.Fatalf("writeOutputs failed: %v", err)
+	}
+	if len(report.Skipped) != 0 || len(report.Merged) != 0 {
+		t.Errorf("expected empty report, got %+v", report)
+	}
+	if got := readFile(t, filepath.Join(dir, "agents", "reviewer.md")); got != "generated" {
+		t.Errorf("expected existing file to be replaced, got %q", got)
+	}
+}
+
+func TestWriteOutputsSkip(t *testing.T) {
+	dir := setupExisting(t)
+
+	report, err := writeOutputs(context.Background(), dir, MergeSkip, nil, generateFixture)
+	if err != nil {
+		t.Fatalf("writeOutputs failed: %v", err)
+	}
+	if got := readFile(t, filepath.Join(dir, "agents", "reviewer.md")); got != "existing" {
+		t.Errorf("expected existing file to be untouched, got %q", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "agents", "writer.md")); got != "new" {
+		t.Errorf("expected new file to be written, got %q", got)
+	}
+	if len(report.Skipped) != 2 {
+		t.Errorf("expected 2 skipped files, got %v", report.Skipped)
+	}
+}
+
+func TestWriteOutputsMerge(t *testing.T) {
+	dir := setupExisting(t)
+
+	report, err := writeOutputs(context.Background(), dir, MergeMerge, nil, generateFixture)
+	if err != nil {
+		t.Fatalf("writeOutputs failed: %v", err)
+	}
+	if len(report.Merged) != 1 || report.Merged[0] != filepath.Join(dir, "plugin.json") {
+		t.Errorf("expected plugin.json to be merged, got %v", report.Merged)
+	}
+	if got := readFile(t, filepath.Join(dir, "agents", "reviewer.md")); got != "generated" {
+		t.Errorf("expected non-JSON file to be replaced, got %q", got)
+	}
+
+	var merged struct {
+		Name       string         `json:"name"`
+		Author     string         `json:"author"`
+		MCPServers map[string]any `json:"mcpServers"`
+	}
+	if err := json.Unmarshal([]byte(readFile(t, filepath.Join(dir, "plugin.json"))), &merged); err != nil {
+		t.Fatalf("invalid merged JSON: %v", err)
+	}
+	if merged.Name != "new" {
+		t.Errorf("expected generated name to win, got %q", merged.Name)
+	}
+	if merged.Author != "team" {
+		t.Errorf("expected existing author to be kept, got %q", merged.Author)
+	}
+	if len(merged.MCPServers) != 2 {
+		t.Errorf("expected both MCP servers after merge, got %v", merged.MCPServers)
+	}
+}
+
+func TestParseMergeStrategy(t *testing.T) {
+	if s, err := ParseMergeStrategy(""); err != nil || s != MergeReplace {
+		t.Errorf("expected empty name to default to replace, got %q, %v", s, err)
+	}
+	if s, err := ParseMergeStrategy("skip"); err != nil || s != MergeSkip {
+		t.Errorf("expected skip, got %q, %v", s, err)
+	}
+	if _, err := ParseMergeStrategy("append"); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
+
+func TestAgentsWithOptionsMergeSkip(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\n---\n\nReview the diff.\n",
+		"deployments/local.json": `{"team":"t","targets":[{"name":"claude","platform":"claude-code","output":"out"}]}`,
+	})
+	out := t.TempDir()
+	existing := filepath.Join(out, "out", "reviewer.md")
+	if err := os.MkdirAll(filepath.Dir(existing), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("existing"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := AgentsWithOptions(specs, "local", out, Options{MergeStrategy: MergeSkip})
+	if err != nil {
+		t.Fatalf("AgentsWithOptions failed: %v", err)
+	}
+	if got := readFile(t, existing); got != "existing" {
+		t.Errorf("expected the existing agent to be kept, got:\n%s", got)
+	}
+	if len(result.SkippedFiles) != 1 {
+		t.Errorf("expected one skipped file, got %v", result.SkippedFiles)
+	}
+}
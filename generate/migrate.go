@@ -0,0 +1,440 @@
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/commands"
+	commandscore "github.com/agentplexus/assistantkit/commands/core"
+	"github.com/agentplexus/assistantkit/internal/ignore"
+	"github.com/agentplexus/assistantkit/internal/jsonc"
+	"github.com/agentplexus/assistantkit/skills"
+	skillscore "github.com/agentplexus/assistantkit/skills/core"
+)
+
+// schemaBaseURL prefixes the $id of the JSON schemas shipped with the module.
+const schemaBaseURL = "https://github.com/agentplexus/assistantkit/"
+
+// MigrateResult lists the spec files Migrate changed.
+type MigrateResult struct {
+	Files []MigratedFile
+}
+
+// MigratedFile is a spec file upgraded by Migrate.
+type MigratedFile struct {
+	// Path is the file path.
+	Path string
+
+	// Changes describe each edit, e.g. `renamed "allowed-tools" to "allowedTools"`.
+	Changes []string
+}
+
+// specKind describes the canonical fields of one kind of spec.
+type specKind struct {
+	schema string
+	typ    reflect.Type
+	fields map[string]bool
+	lists  map[string]bool
+
+	// parseMarkdown parses a Markdown spec of this kind; nil when the kind
+	// is JSON only.
+	parseMarkdown func(data []byte, path string) error
+}
+
+func newSpecKind(schemaPath string, v interface{}, parseMarkdown func(data []byte, path string) error) specKind {
+	k := specKind{
+		schema:        schemaBaseURL + schemaPath,
+		typ:           reflect.TypeOf(v),
+		fields:        make(map[string]bool),
+		lists:         make(map[string]bool),
+		parseMarkdown: parseMarkdown,
+	}
+	collectJSONFields(k.typ, k.fields, k.lists)
+	return k
+}
+
+var (
+	agentKind = newSpecKind("agents/schema/agent.schema.json", agents.Agent{}, func(data []byte, path string) error {
+		_, err := agents.ParseMarkdownAgent(data, path)
+		return err
+	})
+	skillKind = newSpecKind("skills/schema/skill.schema.json", skills.Skill{}, func(data []byte, _ string) error {
+		_, err := skillscore.ParseSkillMarkdown(data)
+		return err
+	})
+	commandKind = newSpecKind("commands/schema/command.schema.json", commands.Command{}, func(data []byte, _ string) error {
+		_, err := commandscore.ParseCommandMarkdown(data)
+		return err
+	})
+	pluginKind = newSpecKind("plugins/schema/plugin.schema.json", PluginSpec{}, nil)
+)
+
+// parse reports whether data, the content of the spec at path, still loads
+// as this kind.
+func (k specKind) parse(data []byte, path string) error {
+	if filepath.Ext(path) != ".json" {
+		return k.parseMarkdown(data, path)
+	}
+	return jsonc.Unmarshal(data, reflect.New(k.typ).Interface())
+}
+
+// Migrate upgrades the spec files in specsDir to the current canonical
+// schema, rewriting changed files in place:
+//   - Keys written in kebab-case or snake_case (allowed-tools, working_dir)
+//     are renamed to their canonical camelCase field (allowedTools, workingDir).
+//   - List fields written as comma-separated scalars in YAML frontmatter
+//     (tools: Read, Grep) become flow lists (tools: [Read, Grep]).
+//   - JSON specs gain a "$schema" reference to their JSON schema.
+//
+// The agents and commands directories are walked recursively, as are skill
+// directories, and paths matched by the specs directory's ignore file are
+// skipped. JSON specs may contain comments and trailing commas; a rewritten
+// JSON spec is plain JSON, so its comments are dropped.
+//
+// Every file is migrated and checked to still parse before any is written,
+// so a spec that fails leaves the whole directory unchanged. Markdown
+// frontmatter gets no $schema key, since tool adapters would carry it
+// through as metadata. Files that are already current are not touched.
+func Migrate(specsDir string) (*MigrateResult, error) {
+	files, err := migrateCandidates(specsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	type rewrite struct {
+		MigratedFile
+		data []byte
+	}
+	var rewrites []rewrite
+	for _, f := range files {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", f.path, err)
+		}
+
+		var migrated []byte
+		var changes []string
+		if filepath.Ext(f.path) == ".json" {
+			migrated, changes, err = migrateJSON(data, f.kind)
+		} else {
+			migrated, changes = migrateFrontmatter(data, f.kind)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("migrating %s: %w", f.path, err)
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if err := f.kind.parse(migrated, f.path); err != nil {
+			return nil, fmt.Errorf("migrating %s: result does not parse: %w", f.path, err)
+		}
+		rewrites = append(rewrites, rewrite{MigratedFile{Path: f.path, Changes: changes}, migrated})
+	}
+
+	result := &MigrateResult{}
+	for _, r := range rewrites {
+		info, err := os.Stat(r.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(r.Path, r.data, info.Mode().Perm()); err != nil {
+			return nil, fmt.Errorf("writing %s: %w", r.Path, err)
+		}
+		result.Files = append(result.Files, r.MigratedFile)
+	}
+	return result, nil
+}
+
+// migrateCandidate is a spec file Migrate considers.
+type migrateCandidate struct {
+	path string
+	kind specKind
+}
+
+// migrateCandidates lists the spec files under specsDir in walk order:
+// Markdown and JSON files anywhere under agents and commands, Markdown
+// files directly under skills plus SKILL.md and skill.json in skill
+// directories at any depth, and plugin.json.
+func migrateCandidates(specsDir string) ([]migrateCandidate, error) {
+	ignored, err := ignore.Load(specsDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", ignore.FileName, err)
+	}
+
+	var files []migrateCandidate
+	for _, d := range []struct {
+		name  string
+		kind  specKind
+		match func(rel string) bool
+	}{
+		{"agents", agentKind, isSpecFile},
+		{"commands", commandKind, isSpecFile},
+		{"skills", skillKind, func(rel string) bool {
+			base := filepath.Base(rel)
+			if base == "SKILL.md" || base == "skill.json" {
+				return true
+			}
+			return filepath.Dir(rel) == "skills" && filepath.Ext(base) == ".md"
+		}},
+	} {
+		dir := filepath.Join(specsDir, d.name)
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(specsDir, path)
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if path != dir && ignored.Match(filepath.ToSlash(rel), true) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.match(rel) && !ignored.Match(filepath.ToSlash(rel), false) {
+				files = append(files, migrateCandidate{path: path, kind: d.kind})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plugin := filepath.Join(specsDir, "plugin.json")
+	if _, err := os.Stat(plugin); err == nil {
+		files = append(files, migrateCandidate{path: plugin, kind: pluginKind})
+	}
+	return files, nil
+}
+
+// isSpecFile reports whether rel names a Markdown or JSON spec.
+func isSpecFile(rel string) bool {
+	ext := filepath.Ext(rel)
+	return ext == ".md" || ext == ".json"
+}
+
+var (
+	yamlKeyPattern = regexp.MustCompile(`^([A-Za-z0-9_$-]+)(\s*:)(.*)$`)
+	tomlKeyPattern = regexp.MustCompile(`^([A-Za-z0-9_-]+)(\s*=.*)$`)
+)
+
+// migrateFrontmatter renames and normalizes top-level frontmatter keys,
+// leaving every other line, and the body, byte for byte.
+func migrateFrontmatter(data []byte, kind specKind) ([]byte, []string) {
+	lines := strings.Split(string(data), "\n")
+	if len(lines) == 0 {
+		return data, nil
+	}
+	delimiter := strings.TrimSpace(lines[0])
+	if delimiter != "---" && delimiter != "+++" {
+		return data, nil
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == delimiter {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return data, nil
+	}
+
+	present := make(map[string]bool)
+	for _, line := range lines[1:end] {
+		if key := frontmatterKey(line, delimiter); key != "" {
+			present[key] = true
+		}
+	}
+
+	var changes []string
+	for i := 1; i < end; i++ {
+		line := lines[i]
+		key := frontmatterKey(line, delimiter)
+		if key == "" {
+			continue
+		}
+
+		if canonical := canonicalFieldName(key, kind); canonical != key {
+			if present[canonical] {
+				changes = append(changes, fmt.Sprintf("left %q: %q is already set", key, canonical))
+			} else {
+				line = canonical + strings.TrimPrefix(line, key)
+				present[canonical] = true
+				changes = append(changes, fmt.Sprintf("renamed %q to %q", key, canonical))
+				key = canonical
+			}
+		}
+
+		if delimiter == "---" && kind.lists[key] {
+			m := yamlKeyPattern.FindStringSubmatch(line)
+			value := strings.TrimSpace(m[3])
+			if value != "" && !strings.HasPrefix(value, "[") && !strings.HasPrefix(value, "#") &&
+				!strings.HasPrefix(value, "|") && !strings.HasPrefix(value, ">") {
+				line = m[1] + m[2] + " [" + value + "]"
+				changes = append(changes, fmt.Sprintf("converted %q to a list", key))
+			}
+		}
+		lines[i] = line
+	}
+
+	// Keep "left" notes out of the result when nothing was rewritten.
+	rewritten := false
+	for _, c := range changes {
+		if !strings.HasPrefix(c, "left ") {
+			rewritten = true
+		}
+	}
+	if !rewritten {
+		return data, nil
+	}
+	return []byte(strings.Join(lines, "\n")), changes
+}
+
+// frontmatterKey returns the top-level key on a frontmatter line, or "" for
+// indented lines, comments, and list items.
+func frontmatterKey(line, delimiter string) string {
+	pattern := yamlKeyPattern
+	if delimiter == "+++" {
+		pattern = tomlKeyPattern
+	}
+	m := pattern.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// migrateJSON renames top-level keys and adds "$schema", preserving key
+// order. Nested values are re-indented but otherwise unchanged. data may
+// contain comments and trailing commas.
+func migrateJSON(data []byte, kind specKind) ([]byte, []string, error) {
+	dec := json.NewDecoder(bytes.NewReader(jsonc.Standardize(data)))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, nil, fmt.Errorf("expected a JSON object")
+	}
+
+	type member struct {
+		key   string
+		value json.RawMessage
+	}
+	var members []member
+	present := make(map[string]bool)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		members = append(members, member{key: key, value: value})
+		present[key] = true
+	}
+
+	var changes []string
+	for i, m := range members {
+		canonical := canonicalFieldName(m.key, kind)
+		if canonical == m.key || present[canonical] {
+			continue
+		}
+		members[i].key = canonical
+		present[canonical] = true
+		changes = append(changes, fmt.Sprintf("renamed %q to %q", m.key, canonical))
+	}
+	if !present["$schema"] {
+		schema, _ := json.Marshal(kind.schema)
+		members = append([]member{{key: "$schema", value: schema}}, members...)
+		changes = append(changes, fmt.Sprintf("added \"$schema\": %q", kind.schema))
+	}
+	if len(changes) == 0 {
+		return data, nil, nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	for i, m := range members {
+		key, _ := json.Marshal(m.key)
+		var value bytes.Buffer
+		if err := json.Indent(&value, m.value, "  ", "  "); err != nil {
+			return nil, nil, err
+		}
+		buf.WriteString("  ")
+		buf.Write(key)
+		buf.WriteString(": ")
+		buf.Write(value.Bytes())
+		if i < len(members)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes(), changes, nil
+}
+
+// canonicalFieldName returns the canonical camelCase field for a kebab-case
+// or snake_case key, or key unchanged when it is not a known field alias.
+func canonicalFieldName(key string, kind specKind) string {
+	if kind.fields[key] || !strings.ContainsAny(key, "-_") {
+		return key
+	}
+	parts := strings.FieldsFunc(key, func(r rune) bool { return r == '-' || r == '_' })
+	if len(parts) == 0 {
+		return key
+	}
+	var b strings.Builder
+	b.WriteString(strings.ToLower(parts[0]))
+	for _, part := range parts[1:] {
+		b.WriteString(strings.ToUpper(part[:1]) + strings.ToLower(part[1:]))
+	}
+	if camel := b.String(); kind.fields[camel] {
+		return camel
+	}
+	return key
+}
+
+// collectJSONFields records the JSON field names of a struct type, following
+// embedded structs, and which of them are string lists.
+func collectJSONFields(t reflect.Type, fields, lists map[string]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			collectJSONFields(field.Type, fields, lists)
+			continue
+		}
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = true
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.String {
+			lists[name] = true
+		}
+	}
+}
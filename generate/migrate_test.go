@@ -0,0 +1,96 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md":     "---\nname: reviewer\ndescription: Reviews code\ntools: Read, Grep\nallowed-tools: [Read]\nworking_dir: services/api\n---\n\nReview the diff.\nallowed-tools: stays in the body\n",
+		"agents/current.md":      "---\nname: current\ndescription: Already migrated\ntools: [Read]\n---\n\nDo work.\n",
+		"agents/ops/deployer.md": "---\nname: deployer\ndescription: Deploys\nallowed-tools: [Bash]\n---\n\nDeploy.\n",
+		"skills/release/skill.json": `{
+  // Cuts tagged releases.
+  "name": "release",
+  "description": "Cuts releases",
+}
+`,
+	})
+
+	result, err := Migrate(specs)
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if len(result.Files) != 3 {
+		t.Fatalf("expected 2 migrated files, got %+v", result.Files)
+	}
+
+	reviewer := readFile(t, filepath.Join(specs, "agents", "reviewer.md"))
+	want := "---\nname: reviewer\ndescription: Reviews code\ntools: [Read, Grep]\nallowedTools: [Read]\nworkingDir: services/api\n---\n\nReview the diff.\nallowed-tools: stays in the body\n"
+	if reviewer != want {
+		t.Errorf("unexpected migrated agent:\n%s\nwant:\n%s", reviewer, want)
+	}
+	var changes string
+	for _, f := range result.Files {
+		if filepath.Base(f.Path) == "reviewer.md" {
+			changes = strings.Join(f.Changes, "\n")
+		}
+	}
+	for _, c := range []string{
+		`converted "tools" to a list`,
+		`renamed "allowed-tools" to "allowedTools"`,
+		`renamed "working_dir" to "workingDir"`,
+	} {
+		if !strings.Contains(changes, c) {
+			t.Errorf("expected change %q, got:\n%s", c, changes)
+		}
+	}
+
+	agts, err := loadMultiAgentSpecAgents(filepath.Join(specs, "agents"), "")
+	if err != nil {
+		t.Fatalf("migrated agents should load: %v", err)
+	}
+	for _, agt := range agts {
+		if agt.Name == "reviewer" && (len(agt.Tools) != 2 || agt.WorkingDir != "services/api") {
+			t.Errorf("unexpected migrated agent: %+v", agt)
+		}
+	}
+
+	if deployer := readFile(t, filepath.Join(specs, "agents", "ops", "deployer.md")); !strings.Contains(deployer, "\nallowedTools: [Bash]\n") {
+		t.Errorf("expected nested agents to be migrated, got:\n%s", deployer)
+	}
+
+	skill := readFile(t, filepath.Join(specs, "skills", "release", "skill.json"))
+	if !strings.HasPrefix(skill, "{\n  \"$schema\": \"https://github.com/agentplexus/assistantkit/skills/schema/skill.schema.json\",\n  \"name\": \"release\",") {
+		t.Errorf("expected $schema to be added first, got:\n%s", skill)
+	}
+
+	info, err := os.Stat(filepath.Join(specs, "agents", "current.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := Migrate(specs); err != nil || len(result.Files) != 0 {
+		t.Errorf("expected a second run to change nothing, got %+v, %v", result, err)
+	}
+	if after, _ := os.Stat(filepath.Join(specs, "agents", "current.md")); !after.ModTime().Equal(info.ModTime()) {
+		t.Error("expected a current spec to be left untouched")
+	}
+}
+
+func TestMigrateWritesNothingOnError(t *testing.T) {
+	reviewer := "---\nname: reviewer\ndescription: Reviews code\nallowed-tools: [Read]\n---\n\nReview the diff.\n"
+	specs := writeSpecs(t, map[string]string{
+		"agents/reviewer.md":   reviewer,
+		"commands/broken.json": `{"name": "broken", "allowed-tools": [`,
+	})
+
+	if _, err := Migrate(specs); err == nil || !strings.Contains(err.Error(), "broken.json") {
+		t.Fatalf("expected an error naming broken.json, got %v", err)
+	}
+	if got := readFile(t, filepath.Join(specs, "agents", "reviewer.md")); got != reviewer {
+		t.Errorf("expected no file to be written, got:\n%s", got)
+	}
+}
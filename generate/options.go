@@ -0,0 +1,182 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/commands"
+	"github.com/agentplexus/assistantkit/internal/envfile"
+	"github.com/agentplexus/assistantkit/skills"
+)
+
+// Options configures GenerateWithOptions, AgentsWithOptions, and
+// PluginsWithOptions.
+type Options struct {
+	// MergeStrategy controls how generated files that already exist in a
+	// target's output directory are handled. Defaults to MergeReplace.
+	// PluginsWithOptions ignores it and always replaces.
+	MergeStrategy MergeStrategy
+
+	// ExpandEnv resolves ${VAR} placeholders in MCP server commands, args,
+	// URLs, and headers, and in the commands and working directories of
+	// team shared hooks, from the process environment before writing output.
+	// Without it placeholders are written as-is for the tool to resolve.
+	ExpandEnv bool
+
+	// EnvFile is a .env file consulted during expansion when a variable is
+	// not set in the process environment. Setting it implies ExpandEnv.
+	EnvFile string
+
+	// Only limits generation to the agents, skills, and commands with these
+	// names. Specs are filtered after loading, so inheritance and includes
+	// still resolve against the full specs directory. Empty means all specs.
+	// Outputs that list every spec (the Kiro POWER.md and agents README, the
+	// overview, and the generation manifest) are left untouched when set.
+	Only []string
+
+	// ToolAliases is a YAML file of user tool aliases merged into each agent
+	// adapter's tool mapping (see agents.ToolAliases). Defaults to
+	// tool-aliases.yaml in the specs directory when that file exists.
+	ToolAliases string
+
+	// Locale selects which of each agent's LocalizedInstructions to emit
+	// (e.g., "fr"). Agents without a variant for the locale keep their
+	// default instructions. Empty means the default instructions.
+	Locale string
+
+	// Overview writes an overview.md with a Mermaid diagram of the agents
+	// and their handoffs to the output base directory.
+	Overview bool
+
+	// FormatVersions pins the config format version expected per tool
+	// (e.g., "claude": "1"). Generation fails with
+	// ErrUnsupportedFormatVersion when a pinned version is not the one in
+	// FormatVersions; matching pins are recorded in the manifest.
+	FormatVersions map[string]string
+
+	// ToolVersions names the release of each tool being targeted (e.g.,
+	// "claude": "1.0.20"). MCP servers, including those agents declare,
+	// that need a feature the release lacks (such as HTTP transport) are
+	// reported in Warnings, or fail generation when Strict is set.
+	ToolVersions map[string]string
+
+	// Timeout bounds a generation run. When it elapses, generation stops
+	// with context.DeadlineExceeded and the target being written is left
+	// untouched. Zero means no timeout.
+	Timeout time.Duration
+
+	// Backup snapshots the files generation writes to each target under
+	// BackupsDir in the output base directory, before they are overwritten.
+	// Rollback restores the latest snapshot. Targets whose output is, or
+	// contains, the output base directory or .git are refused with
+	// ErrUnsafeBackupTarget.
+	Backup bool
+
+	// Strict makes generation fail, before anything is written, when a
+	// target's platform cannot receive a loaded component (e.g., commands
+	// for Kiro, or skills for Gemini). The error lists every gap. It also
+	// fails a target with an *agents.UnsupportedToolsError when an agent
+	// lists a tool the target cannot represent. Without it such components
+	// and tools are skipped and reported in Warnings.
+	Strict bool
+}
+
+// withTimeout derives a context bounded by Options.Timeout.
+func (o Options) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, o.Timeout)
+}
+
+// partial reports whether Options.Only limits generation to a subset of
+// the specs.
+func (o Options) partial() bool {
+	return len(o.Only) > 0
+}
+
+// selectSpecs applies Options.Only to loaded specs. It returns an error
+// naming any requested spec that does not exist.
+func (o Options) selectSpecs(cmds []*commands.Command, skls []*skills.Skill, agts []*agents.Agent) ([]*commands.Command, []*skills.Skill, []*agents.Agent, error) {
+	if len(o.Only) == 0 {
+		return cmds, skls, agts, nil
+	}
+
+	only := make(map[string]bool, len(o.Only))
+	for _, name := range o.Only {
+		only[name] = true
+	}
+	found := make(map[string]bool, len(o.Only))
+	keep := func(name string) bool {
+		if only[name] {
+			found[name] = true
+			return true
+		}
+		return false
+	}
+
+	var selectedCmds []*commands.Command
+	for _, cmd := range cmds {
+		if keep(cmd.Name) {
+			selectedCmds = append(selectedCmds, cmd)
+		}
+	}
+	var selectedSkls []*skills.Skill
+	for _, skl := range skls {
+		if keep(skl.Name) {
+			selectedSkls = append(selectedSkls, skl)
+		}
+	}
+	var selectedAgts []*agents.Agent
+	for _, agt := range agts {
+		if keep(agt.Name) {
+			selectedAgts = append(selectedAgts, agt)
+		}
+	}
+
+	for _, name := range o.Only {
+		if !found[name] {
+			return nil, nil, nil, fmt.Errorf("no agent, skill, or command named %q", name)
+		}
+	}
+	return selectedCmds, selectedSkls, selectedAgts, nil
+}
+
+// envLookup returns the lookup used for env expansion, or nil when expansion
+// is disabled.
+func (o Options) envLookup() (envfile.LookupFunc, error) {
+	if !o.ExpandEnv && o.EnvFile == "" {
+		return nil, nil
+	}
+	vars := map[string]string{}
+	if o.EnvFile != "" {
+		loaded, err := envfile.Load(o.EnvFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading env file: %w", err)
+		}
+		vars = loaded
+	}
+	return envfile.Lookup(vars), nil
+}
+
+// useToolAliases registers the tool aliases for a generation run and
+// returns a function that restores the previously registered aliases.
+func (o Options) useToolAliases(specsDir string) (func(), error) {
+	path := o.ToolAliases
+	if path == "" {
+		path = filepath.Join(specsDir, agents.ToolAliasesFileName)
+		if _, err := os.Stat(path); err != nil {
+			return func() {}, nil
+		}
+	}
+	table, err := agents.ReadToolAliasesFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading tool aliases: %w", err)
+	}
+	previous := agents.SetToolAliases(table)
+	return func() { agents.SetToolAliases(previous) }, nil
+}
@@ -0,0 +1,52 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/agents"
+)
+
+// OverviewFileName is the file written by Options.Overview.
+const OverviewFileName = "overview.md"
+
+// Overview renders a Markdown overview of a team: a Mermaid diagram of the
+// agents and their handoffs, followed by a list of agent descriptions.
+func Overview(team string, agts []*agents.Agent) []byte {
+	title := "Agent Team"
+	if team != "" {
+		title = team
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# %s Overview\n\n", title))
+	b.WriteString("```mermaid\n")
+	b.WriteString(agents.MermaidGraph(agts))
+	b.WriteString("```\n")
+
+	if len(agts) > 0 {
+		b.WriteString("\n## Agents\n\n")
+		for _, agt := range agts {
+			if agt.Description == "" {
+				b.WriteString(fmt.Sprintf("- **%s**\n", agt.Name))
+				continue
+			}
+			b.WriteString(fmt.Sprintf("- **%s**: %s\n", agt.Name, agt.Description))
+		}
+	}
+	return []byte(b.String())
+}
+
+// writeOverview writes the team overview to dir and returns its path.
+func writeOverview(dir, team string, agts []*agents.Agent) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating output dir: %w", err)
+	}
+	path := filepath.Join(dir, OverviewFileName)
+	if err := os.WriteFile(path, Overview(team, agts), 0644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", OverviewFileName, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,127 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/commands"
+	"github.com/agentplexus/assistantkit/skills"
+	"github.com/agentplexus/assistantkit/validation"
+)
+
+// Spec kinds that Scaffold can create.
+const (
+	KindAgent      = "agent"
+	KindSkill      = "skill"
+	KindCommand    = "command"
+	KindValidation = "validation"
+)
+
+// ScaffoldKinds lists the spec kinds Scaffold can create.
+var ScaffoldKinds = []string{KindAgent, KindSkill, KindCommand, KindValidation}
+
+var (
+	// ErrUnknownKind is returned by Scaffold for a kind not in ScaffoldKinds.
+	ErrUnknownKind = errors.New("unknown spec kind")
+
+	// ErrInvalidName is returned by Scaffold for a name that is not a
+	// lowercase slug (e.g., "code-reviewer").
+	ErrInvalidName = errors.New("invalid spec name")
+
+	// ErrSpecExists is returned by Scaffold when the spec file already
+	// exists and Force is not set.
+	ErrSpecExists = errors.New("spec already exists")
+)
+
+var specNamePattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// ScaffoldOptions configures Scaffold.
+type ScaffoldOptions struct {
+	// SpecsDir is the specs directory to write into (default "specs").
+	SpecsDir string
+
+	// Kind is the spec kind: agent, skill, command, or validation.
+	Kind string
+
+	// Name is the spec name, a lowercase slug such as "reviewer".
+	Name string
+
+	// Description is a one-line description. A placeholder is used when empty.
+	Description string
+
+	// Model is the preferred model for agents and validation areas
+	// (e.g., "sonnet"). Ignored for other kinds.
+	Model string
+
+	// Tools are the tools an agent or validation area may use.
+	Tools []string
+
+	// Force overwrites an existing spec file.
+	Force bool
+}
+
+// Scaffold writes a new canonical spec with starter frontmatter and an
+// instructions template, using the same writers as the rest of the module,
+// and returns the path written:
+//   - agent: <SpecsDir>/agents/<name>.md
+//   - skill: <SpecsDir>/skills/<name>/skill.json
+//   - command: <SpecsDir>/commands/<name>.json
+//   - validation: <SpecsDir>/validation/<name>.json
+func Scaffold(opts ScaffoldOptions) (string, error) {
+	if opts.SpecsDir == "" {
+		opts.SpecsDir = "specs"
+	}
+	if !specNamePattern.MatchString(opts.Name) {
+		return "", fmt.Errorf("%w %q: use lowercase letters, digits, and dashes", ErrInvalidName, opts.Name)
+	}
+	description := opts.Description
+	if description == "" {
+		description = "TODO - describe what this " + opts.Kind + " does"
+	}
+
+	var path string
+	var write func(path string) error
+	switch opts.Kind {
+	case KindAgent:
+		path = filepath.Join(opts.SpecsDir, "agents", opts.Name+".md")
+		agent := agents.NewAgent(opts.Name, description)
+		agent.Model = agents.Model(opts.Model)
+		agent.Tools = opts.Tools
+		agent.Instructions = fmt.Sprintf("You are the %s agent. %s\n\n## Responsibilities\n\n- TODO\n\n## Output\n\nTODO: describe what the agent should return.", opts.Name, description)
+		write = func(path string) error { return agents.WriteCanonicalFile(agent, path) }
+	case KindSkill:
+		path = filepath.Join(opts.SpecsDir, "skills", opts.Name, "skill.json")
+		skill := skills.NewSkill(opts.Name, description)
+		skill.Instructions = fmt.Sprintf("# %s\n\n%s\n\n## When to use\n\nTODO\n\n## Steps\n\n1. TODO", opts.Name, description)
+		write = func(path string) error { return skills.WriteCanonicalFile(skill, path) }
+	case KindCommand:
+		path = filepath.Join(opts.SpecsDir, "commands", opts.Name+".json")
+		cmd := commands.NewCommand(opts.Name, description)
+		cmd.Instructions = fmt.Sprintf("%s\n\nTODO: describe the steps to take.", description)
+		write = func(path string) error { return commands.WriteCanonicalFile(cmd, path) }
+	case KindValidation:
+		path = filepath.Join(opts.SpecsDir, "validation", opts.Name+".json")
+		area := validation.NewValidationArea(opts.Name, description)
+		area.Model = opts.Model
+		area.Tools = opts.Tools
+		area.SignOffCriteria = "TODO: what must pass for GO status"
+		area.Checks = []validation.Check{{Name: "todo", Description: "TODO: describe the check", Required: true}}
+		area.Instructions = fmt.Sprintf("You validate %s. %s", opts.Name, description)
+		write = func(path string) error { return validation.WriteCanonicalFile(area, path) }
+	default:
+		return "", fmt.Errorf("%w %q (expected one of %s)", ErrUnknownKind, opts.Kind, strings.Join(ScaffoldKinds, ", "))
+	}
+
+	if _, err := os.Stat(path); err == nil && !opts.Force {
+		return "", fmt.Errorf("%w: %s", ErrSpecExists, path)
+	}
+	if err := write(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
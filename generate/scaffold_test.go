@@ -0,0 +1,108 @@
+package generate
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/commands"
+	"github.com/agentplexus/assistantkit/skills"
+	"github.com/agentplexus/assistantkit/validation"
+)
+
+func TestScaffoldAgent(t *testing.T) {
+	specs := t.TempDir()
+	path, err := Scaffold(ScaffoldOptions{
+		SpecsDir:    specs,
+		Kind:        KindAgent,
+		Name:        "reviewer",
+		Description: "Reviews pull requests",
+		Model:       "sonnet",
+		Tools:       []string{"Read", "Grep"},
+	})
+	if err != nil {
+		t.Fatalf("Scaffold failed: %v", err)
+	}
+	if want := filepath.Join(specs, "agents", "reviewer.md"); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+
+	agent, err := agents.ReadCanonicalFile(path)
+	if err != nil {
+		t.Fatalf("scaffolded agent does not parse: %v", err)
+	}
+	if err := agent.Validate(); err != nil {
+		t.Errorf("scaffolded agent is invalid: %v", err)
+	}
+	if agent.Name != "reviewer" || agent.Description != "Reviews pull requests" || agent.Model != "sonnet" {
+		t.Errorf("unexpected agent %q %q %q", agent.Name, agent.Description, agent.Model)
+	}
+	if len(agent.Tools) != 2 || agent.Instructions == "" {
+		t.Errorf("expected tools and an instructions template, got %v %q", agent.Tools, agent.Instructions)
+	}
+
+	if _, err := Scaffold(ScaffoldOptions{SpecsDir: specs, Kind: KindAgent, Name: "reviewer"}); !errors.Is(err, ErrSpecExists) {
+		t.Errorf("expected ErrSpecExists, got %v", err)
+	}
+	// Without a description, the placeholder must still parse.
+	if _, err := Scaffold(ScaffoldOptions{SpecsDir: specs, Kind: KindAgent, Name: "reviewer", Force: true}); err != nil {
+		t.Fatalf("expected Force to overwrite, got %v", err)
+	}
+	if agent, err := agents.ReadCanonicalFile(path); err != nil || agent.Description == "" {
+		t.Errorf("expected placeholder description to parse, got %v", err)
+	}
+	// A description with YAML indicators must survive a re-parse intact.
+	const tricky = `Reviews PRs: flags "unsafe" code #security`
+	if _, err := Scaffold(ScaffoldOptions{SpecsDir: specs, Kind: KindAgent, Name: "reviewer", Description: tricky, Force: true}); err != nil {
+		t.Fatalf("Scaffold failed: %v", err)
+	}
+	if agent, err := agents.ReadCanonicalFile(path); err != nil || agent.Description != tricky {
+		t.Errorf("expected description %q to round-trip, got %+v, %v", tricky, agent, err)
+	}
+}
+
+func TestScaffoldOtherKinds(t *testing.T) {
+	specs := t.TempDir()
+	for _, kind := range []string{KindSkill, KindCommand, KindValidation} {
+		path, err := Scaffold(ScaffoldOptions{SpecsDir: specs, Kind: kind, Name: "release-notes"})
+		if err != nil {
+			t.Fatalf("Scaffold(%s) failed: %v", kind, err)
+		}
+
+		var name string
+		switch kind {
+		case KindSkill:
+			s, err := skills.ReadCanonicalFile(path)
+			if err != nil {
+				t.Fatalf("scaffolded skill does not parse: %v", err)
+			}
+			name = s.Name
+		case KindCommand:
+			c, err := commands.ReadCanonicalFile(path)
+			if err != nil {
+				t.Fatalf("scaffolded command does not parse: %v", err)
+			}
+			name = c.Name
+		case KindValidation:
+			a, err := validation.ReadCanonicalFile(path)
+			if err != nil {
+				t.Fatalf("scaffolded validation area does not parse: %v", err)
+			}
+			name = a.Name
+		}
+		if name != "release-notes" {
+			t.Errorf("%s name = %q, want release-notes", kind, name)
+		}
+	}
+}
+
+func TestScaffoldErrors(t *testing.T) {
+	specs := t.TempDir()
+	if _, err := Scaffold(ScaffoldOptions{SpecsDir: specs, Kind: "team", Name: "x"}); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("expected ErrUnknownKind, got %v", err)
+	}
+	if _, err := Scaffold(ScaffoldOptions{SpecsDir: specs, Kind: KindAgent, Name: "Bad Name"}); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("expected ErrInvalidName, got %v", err)
+	}
+}
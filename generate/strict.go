@@ -0,0 +1,74 @@
+package generate
+
+import (
+	"fmt"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/commands"
+	"github.com/agentplexus/assistantkit/skills"
+)
+
+// ComponentGap is a loaded component that a target's platform does not
+// receive, because its output has no place for the component or no
+// adapter for it is registered.
+type ComponentGap struct {
+	Target    string
+	Platform  string
+	Component string
+}
+
+// String returns the gap as "target (platform): component".
+func (g ComponentGap) String() string {
+	return fmt.Sprintf("%s (%s): %s", g.Target, g.Platform, g.Component)
+}
+
+// platformGaps returns the components that generating platform would
+// drop: commands, skills, agents, and hooks.
+func platformGaps(platform string, plugin *PluginSpec, cmds []*commands.Command, skls []*skills.Skill, agts []*agents.Agent) []string {
+	var commandsOK, skillsOK, agentsOK bool
+	switch platform {
+	case "claude", "claude-code":
+		commandsOK, skillsOK, agentsOK = true, true, true
+	case "kiro", "kiro-cli":
+		// A Kiro Power carries skills only; Kiro Agents format adds agents
+		skillsOK, agentsOK = true, !plugin.isKiroPower()
+	case "gemini", "gemini-cli":
+		commandsOK = true
+	default:
+		_, agentsOK = agents.GetAdapter(platformTool(platform))
+	}
+
+	var gaps []string
+	if len(cmds) > 0 && !commandsOK {
+		gaps = append(gaps, "commands")
+	}
+	if len(skls) > 0 && !skillsOK {
+		gaps = append(gaps, "skills")
+	}
+	if len(agts) > 0 && !agentsOK {
+		gaps = append(gaps, "agents")
+	}
+	if _, ok := sharedHooksPaths[platformTool(platform)]; plugin.sharedHooks != nil && !ok {
+		gaps = append(gaps, "hooks")
+	}
+	return gaps
+}
+
+// checkGaps reports gaps as warnings, or as an *UnsupportedComponentsError
+// when Options.Strict is set.
+func (o Options) checkGaps(gaps []ComponentGap) ([]string, error) {
+	if len(gaps) == 0 {
+		return nil, nil
+	}
+	if o.Strict {
+		return nil, &UnsupportedComponentsError{Gaps: gaps}
+	}
+	warnings := make([]string, len(gaps))
+	for i, gap := range gaps {
+		warnings[i] = fmt.Sprintf("%s does not support %s; skipped", gap.Platform, gap.Component)
+		if gap.Target != gap.Platform {
+			warnings[i] = gap.Target + ": " + warnings[i]
+		}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,145 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/hooks"
+	"github.com/agentplexus/assistantkit/plugins"
+	"github.com/agentplexus/assistantkit/teams"
+)
+
+// sharedHooksPaths maps each tool whose generated output can carry hooks
+// to the hooks file written for Team.SharedHooks, relative to the target's
+// output directory.
+var sharedHooksPaths = map[string]string{
+	"claude": filepath.Join("hooks", "hooks.json"),
+}
+
+// loadTeam reads the team a deployment names from specsDir/teams, as
+// <name>.json, <name>.yaml, or <name>.yml. It returns nil when there is no
+// such file.
+func loadTeam(specsDir, name string) (*teams.Team, error) {
+	if name == "" {
+		return nil, nil
+	}
+	for _, ext := range []string{".json", ".yaml", ".yml"} {
+		path := filepath.Join(specsDir, "teams", name+ext)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		team, err := teams.ReadTeamFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("loading team: %w", err)
+		}
+		return team, nil
+	}
+	return nil, nil
+}
+
+// addTeamShared merges the team's shared MCP servers and hooks into the
+// plugin so that every target receives them. Servers the plugin already
+// defines keep their definition.
+func (p *PluginSpec) addTeamShared(team *teams.Team) {
+	if team == nil {
+		return
+	}
+	for name, srv := range team.SharedMCP {
+		if _, ok := p.MCPServers[name]; ok {
+			continue
+		}
+		if p.MCPServers == nil {
+			p.MCPServers = make(map[string]MCPServer)
+		}
+		p.MCPServers[name] = MCPServer{
+			Command:     srv.Command,
+			Args:        srv.Args,
+			Env:         srv.Env,
+			Description: srv.Description,
+			URL:         srv.URL,
+			Headers:     srv.Headers,
+		}
+		p.sharedMCP = append(p.sharedMCP, name)
+	}
+	if team.SharedHooks != nil && team.SharedHooks.HasHooks() {
+		p.sharedHooks = hooks.NewConfig()
+		p.sharedHooks.MergeDistinct(team.SharedHooks)
+	}
+}
+
+// withSharedMCP returns agt with the team's shared MCP servers added to its
+// declared servers. Servers the agent declares itself are kept. agt is
+// returned unchanged when the team shares no servers.
+func (p *PluginSpec) withSharedMCP(agt *agents.Agent) *agents.Agent {
+	if len(p.sharedMCP) == 0 {
+		return agt
+	}
+	merged := *agt
+	merged.MCP = make(map[string]agents.MCPServer, len(agt.MCP)+len(p.sharedMCP))
+	for name, srv := range agt.MCP {
+		merged.MCP[name] = srv
+	}
+	for _, name := range p.sharedMCP {
+		if _, ok := merged.MCP[name]; ok {
+			continue
+		}
+		srv := p.MCPServers[name]
+		merged.MCP[name] = agents.MCPServer{
+			Command: srv.Command,
+			Args:    srv.Args,
+			Env:     srv.Env,
+			URL:     srv.URL,
+			Headers: srv.Headers,
+		}
+	}
+	return &merged
+}
+
+// manifestPlugin returns the plugin written to tool manifests, with the
+// team's shared stdio MCP servers added. Remote servers have no place in
+// the manifest's server format and are left out.
+func (p *PluginSpec) manifestPlugin() *plugins.Plugin {
+	if len(p.sharedMCP) == 0 {
+		return &p.Plugin
+	}
+	plugin := p.Plugin
+	plugin.MCPServers = make(map[string]plugins.MCPServer, len(p.Plugin.MCPServers)+len(p.sharedMCP))
+	for name, srv := range p.Plugin.MCPServers {
+		plugin.MCPServers[name] = srv
+	}
+	for _, name := range p.sharedMCP {
+		srv := p.MCPServers[name]
+		if _, ok := plugin.MCPServers[name]; ok || srv.Command == "" {
+			continue
+		}
+		plugin.MCPServers[name] = plugins.MCPServer{
+			Command: srv.Command,
+			Args:    srv.Args,
+			Env:     srv.Env,
+		}
+	}
+	return &plugin
+}
+
+// writeSharedHooks writes the team's shared hooks into a target's output
+// directory for tools that support them.
+func (p *PluginSpec) writeSharedHooks(tool, dir string) error {
+	path, ok := sharedHooksPaths[tool]
+	if !ok || p.sharedHooks == nil {
+		return nil
+	}
+	adapter, ok := hooks.GetAdapter(tool)
+	if !ok {
+		return fmt.Errorf("%s hooks adapter not found", tool)
+	}
+	path = filepath.Join(dir, path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := adapter.WriteFile(p.sharedHooks, path); err != nil {
+		return fmt.Errorf("write shared hooks: %w", err)
+	}
+	return nil
+}
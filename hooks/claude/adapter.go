@@ -7,6 +7,7 @@ import (
 	"runtime"
 
 	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
 )
 
 const (
@@ -90,7 +91,7 @@ func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 // Marshal converts canonical config to Claude format.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
 	claudeCfg := a.FromCore(cfg)
-	return json.MarshalIndent(claudeCfg, "", "  ")
+	return canonicaljson.Marshal(claudeCfg)
 }
 
 // ReadFile reads a Claude hooks config file.
@@ -126,6 +127,7 @@ func (a *Adapter) ToCore(claudeCfg *Config) *core.Config {
 	cfg := core.NewConfig()
 	cfg.DisableAllHooks = claudeCfg.DisableAllHooks
 	cfg.AllowManagedHooksOnly = claudeCfg.AllowManagedHooksOnly
+	cfg.RawExtra = claudeCfg.RawExtra
 
 	for claudeEvent, entries := range claudeCfg.Hooks {
 		for _, entry := range entries {
@@ -136,10 +138,10 @@ func (a *Adapter) ToCore(claudeCfg *Config) *core.Config {
 			var coreHooks []core.Hook
 			for _, h := range entry.Hooks {
 				coreHook := core.Hook{
-					Command: h.Command,
-					Prompt:  h.Prompt,
+					Prompt:  parsePrompt(h.Prompt),
 					Timeout: h.Timeout,
 				}
+				coreHook.OS, coreHook.Command = core.UnguardCommand(h.Command)
 				if h.Type == "command" {
 					coreHook.Type = core.HookTypeCommand
 				} else if h.Type == "prompt" {
@@ -164,12 +166,16 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	claudeCfg := NewConfig()
 	claudeCfg.DisableAllHooks = cfg.DisableAllHooks
 	claudeCfg.AllowManagedHooksOnly = cfg.AllowManagedHooksOnly
+	claudeCfg.RawExtra = cfg.RawExtra
 
 	for event, entries := range cfg.Hooks {
 		claudeEvent, matcher := a.canonicalToClaudeEvent(event)
 		if claudeEvent == "" {
 			continue // Event not supported by Claude
 		}
+		if cfg.IsEventDisabled(event) {
+			continue // Claude has no per-event disable setting, so omit the hooks
+		}
 
 		for _, entry := range entries {
 			// Use entry matcher if provided, otherwise use default for event
@@ -180,9 +186,11 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 
 			var claudeHooks []Hook
 			for _, h := range entry.Hooks {
+				// Claude runs hooks through a shell, so OS-specific hooks
+				// are kept and guarded rather than filtered out.
 				claudeHook := Hook{
-					Command: h.Command,
-					Prompt:  h.Prompt,
+					Command: core.GuardCommand(h.OS, h.Command),
+					Prompt:  renderPrompt(h.Prompt),
 					Timeout: h.Timeout,
 				}
 				if h.Type == core.HookTypeCommand {
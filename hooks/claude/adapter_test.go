@@ -1,8 +1,10 @@
 package claude
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/agentplexus/assistantkit/hooks/core"
@@ -454,6 +456,30 @@ func TestAdapterFromCoreWithPromptHook(t *testing.T) {
 	}
 }
 
+func TestAdapterPromptPlaceholders(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddHook(core.OnPermission, core.NewPromptHook("Should {{tool}} be allowed to run {{command}}?"))
+
+	claudeCfg := adapter.FromCore(cfg)
+	got := claudeCfg.Hooks[PermissionRequest][0].Hooks[0].Prompt
+	want := "Should `tool_name` be allowed to run `tool_input.command`?\n\nRequest: $ARGUMENTS"
+	if got != want {
+		t.Errorf("prompt = %q, want %q", got, want)
+	}
+
+	back := adapter.ToCore(claudeCfg).GetAllHooksForEvent(core.OnPermission)
+	if len(back) != 1 || back[0].Prompt != "Should {{tool}} be allowed to run {{command}}?" {
+		t.Errorf("expected placeholders to parse back, got %+v", back)
+	}
+
+	// Prompts without placeholders are left alone.
+	if got := renderPrompt("Is this safe? $ARGUMENTS"); got != "Is this safe? $ARGUMENTS" {
+		t.Errorf("unexpected rewrite %q", got)
+	}
+}
+
 func TestAdapterFromCoreInferType(t *testing.T) {
 	adapter := NewAdapter()
 
@@ -612,3 +638,115 @@ func TestAdapterParseWithTimeout(t *testing.T) {
 		t.Errorf("Expected timeout 60, got %d", hooks[0].Timeout)
 	}
 }
+
+func TestAdapterPreservesNonHookSettings(t *testing.T) {
+	adapter := NewAdapter()
+
+	tmpDir, err := os.MkdirTemp("", "claude-hooks-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	settings := `{
+  "permissions": {
+    "allow": ["Bash(go test:*)"],
+    "deny": ["Read(./.env)"]
+  },
+  "additionalDirectories": ["../shared"],
+  "env": {"FOO": "bar"},
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "echo before"}]}
+    ]
+  }
+}`
+	filePath := filepath.Join(tmpDir, "settings.json")
+	if err := os.WriteFile(filePath, []byte(settings), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := adapter.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	// Modify hooks and write back through the adapter
+	cfg.AddHook(core.OnStop, core.NewCommandHook("echo done"))
+	if err := adapter.WriteFile(cfg, filePath); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written map[string]json.RawMessage
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("written settings are not valid JSON: %v", err)
+	}
+
+	var permissions struct {
+		Allow []string `json:"allow"`
+		Deny  []string `json:"deny"`
+	}
+	if err := json.Unmarshal(written["permissions"], &permissions); err != nil {
+		t.Fatalf("permissions missing or invalid: %v", err)
+	}
+	if len(permissions.Allow) != 1 || permissions.Allow[0] != "Bash(go test:*)" {
+		t.Errorf("permissions.allow = %v, want [Bash(go test:*)]", permissions.Allow)
+	}
+	if len(permissions.Deny) != 1 || permissions.Deny[0] != "Read(./.env)" {
+		t.Errorf("permissions.deny = %v, want [Read(./.env)]", permissions.Deny)
+	}
+	if _, ok := written["additionalDirectories"]; !ok {
+		t.Error("additionalDirectories should be preserved")
+	}
+	if _, ok := written["env"]; !ok {
+		t.Error("env should be preserved")
+	}
+
+	readCfg, err := adapter.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if readCfg.HookCount() != 2 {
+		t.Errorf("ReadFile() got %d hooks, want 2", readCfg.HookCount())
+	}
+}
+
+func TestAdapterOSGuardRoundTrip(t *testing.T) {
+	adapter := NewAdapter()
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("./check.sh").WithOS(core.OSLinux))
+
+	claudeCfg := adapter.FromCore(cfg)
+	got := claudeCfg.Hooks[PreToolUse][0].Hooks[0].Command
+	if got != `case "$(uname -s)" in Linux) ./check.sh ;; esac` {
+		t.Errorf("unexpected guarded command %q", got)
+	}
+
+	back := adapter.ToCore(claudeCfg).GetAllHooksForEvent(core.BeforeCommand)
+	if len(back) != 1 || back[0].OS != core.OSLinux || back[0].Command != "./check.sh" {
+		t.Errorf("expected guard to parse back to OS and command, got %+v", back)
+	}
+}
+
+func TestAdapterOmitsDisabledEvents(t *testing.T) {
+	adapter := NewAdapter()
+	cfg := core.NewConfig()
+	cfg.AddHook(core.OnStop, core.NewCommandHook("./notify.sh"))
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("./check.sh"))
+	cfg.DisableEvent(core.OnStop)
+	cfg.DisableEvent(core.BeforeCommand)
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, unwanted := range []string{"./notify.sh", "./check.sh", "disabledHookEvents"} {
+		if strings.Contains(string(data), unwanted) {
+			t.Errorf("expected disabled events to be omitted, found %s in:\n%s", unwanted, data)
+		}
+	}
+}
@@ -19,7 +19,12 @@
 //   - SubagentStop: When subagent stops
 package claude
 
-import "github.com/agentplexus/assistantkit/hooks/core"
+import (
+	"encoding/json"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+)
 
 // ClaudeEvent represents Claude-specific hook event names.
 type ClaudeEvent string
@@ -42,6 +47,68 @@ type Config struct {
 	Hooks                 map[ClaudeEvent][]HookEntry `json:"hooks,omitempty"`
 	DisableAllHooks       bool                        `json:"disableAllHooks,omitempty"`
 	AllowManagedHooksOnly bool                        `json:"allowManagedHooksOnly,omitempty"`
+
+	// RawExtra holds the other top-level settings.json keys (permissions,
+	// additionalDirectories, env, etc.) so they survive a read-modify-write.
+	RawExtra map[string]json.RawMessage `json:"-"`
+}
+
+// knownKeys are the settings.json keys handled by Config fields.
+var knownKeys = map[string]bool{
+	"hooks":                 true,
+	"disableAllHooks":       true,
+	"allowManagedHooksOnly": true,
+}
+
+// UnmarshalJSON implements json.Unmarshaler, capturing non-hook keys in RawExtra.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type Alias Config
+	if err := json.Unmarshal(data, (*Alias)(c)); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for key, value := range raw {
+		if knownKeys[key] {
+			continue
+		}
+		if c.RawExtra == nil {
+			c.RawExtra = make(map[string]json.RawMessage)
+		}
+		c.RawExtra[key] = value
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, re-emitting keys held in RawExtra.
+func (c *Config) MarshalJSON() ([]byte, error) {
+	type Alias Config
+	data, err := json.Marshal((*Alias)(c))
+	if err != nil {
+		return nil, err
+	}
+	if len(c.RawExtra) == 0 {
+		return data, nil
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range c.RawExtra {
+		if knownKeys[key] {
+			continue
+		}
+		sorted, err := canonicaljson.SortRaw(value)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = sorted
+	}
+	return json.Marshal(merged)
 }
 
 // HookEntry represents a Claude hook entry with matcher and hooks.
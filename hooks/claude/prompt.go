@@ -0,0 +1,48 @@
+package claude
+
+import (
+	"strings"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+// argumentsSuffix is appended to prompts that use placeholders so the hook
+// input JSON, which Claude substitutes for $ARGUMENTS, is in the prompt.
+const argumentsSuffix = "\n\nRequest: $ARGUMENTS"
+
+// promptFields maps canonical prompt placeholders to the hook input fields
+// that hold the same values.
+var promptFields = []struct {
+	placeholder string
+	field       string
+}{
+	{core.PlaceholderTool, "`tool_name`"},
+	{core.PlaceholderCommand, "`tool_input.command`"},
+}
+
+// renderPrompt translates canonical placeholders into references to the
+// hook input fields, appending the input itself via $ARGUMENTS unless the
+// prompt already includes it.
+func renderPrompt(prompt string) string {
+	rendered := prompt
+	for _, f := range promptFields {
+		rendered = strings.ReplaceAll(rendered, f.placeholder, f.field)
+	}
+	if rendered != prompt && !strings.Contains(prompt, "$ARGUMENTS") {
+		rendered += argumentsSuffix
+	}
+	return rendered
+}
+
+// parsePrompt reverses renderPrompt for prompts it generated, restoring the
+// canonical placeholders. Other prompts are returned unchanged.
+func parsePrompt(prompt string) string {
+	if !strings.HasSuffix(prompt, argumentsSuffix) {
+		return prompt
+	}
+	parsed := strings.TrimSuffix(prompt, argumentsSuffix)
+	for _, f := range promptFields {
+		parsed = strings.ReplaceAll(parsed, f.field, f.placeholder)
+	}
+	return parsed
+}
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"io/fs"
 	"os"
+	"reflect"
+
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+	"github.com/agentplexus/assistantkit/internal/envfile"
 )
 
 // DefaultFileMode is the default permission mode for configuration files.
@@ -22,8 +26,16 @@ type Config struct {
 	// DisableAllHooks disables all hooks when true (Claude-specific).
 	DisableAllHooks bool `json:"disableAllHooks,omitempty"`
 
+	// DisabledEvents disables the hooks for specific events. No tool has a
+	// per-event disable setting, so adapters omit the hooks for these events.
+	DisabledEvents []Event `json:"disabledEvents,omitempty"`
+
 	// AllowManagedHooksOnly restricts to enterprise-managed hooks only (Claude-specific).
 	AllowManagedHooksOnly bool `json:"allowManagedHooksOnly,omitempty"`
+
+	// RawExtra holds non-hook top-level settings from the source file
+	// (e.g., Claude permissions or env) so adapters can re-emit them on write.
+	RawExtra map[string]json.RawMessage `json:"-"`
 }
 
 // NewConfig creates a new empty hooks Config.
@@ -75,6 +87,147 @@ func (c *Config) GetAllHooksForEvent(event Event) []Hook {
 	return hooks
 }
 
+// Match returns the hooks that would run for the event when toolName is invoked,
+// in configuration order. No hooks run when DisableAllHooks is set or the
+// event is disabled.
+func (c *Config) Match(event Event, toolName string) []Hook {
+	if c.DisableAllHooks || c.IsEventDisabled(event) {
+		return nil
+	}
+	var hooks []Hook
+	for _, entry := range c.Hooks[event] {
+		if entry.Matches(toolName) {
+			hooks = append(hooks, entry.Hooks...)
+		}
+	}
+	return hooks
+}
+
+// ExpandEnv returns a copy of the config with ${VAR} placeholders in hook
+// commands and working directories resolved through lookup. Unresolved
+// placeholders and bare $VAR references are left for the shell.
+func (c *Config) ExpandEnv(lookup func(name string) (string, bool)) *Config {
+	expanded := *c
+	expanded.Hooks = make(map[Event][]HookEntry, len(c.Hooks))
+	for event, entries := range c.Hooks {
+		newEntries := make([]HookEntry, len(entries))
+		for i, entry := range entries {
+			newEntries[i] = entry
+			newEntries[i].Hooks = make([]Hook, len(entry.Hooks))
+			for j, hook := range entry.Hooks {
+				hook.Command = envfile.Expand(hook.Command, lookup)
+				hook.WorkingDir = envfile.Expand(hook.WorkingDir, lookup)
+				newEntries[i].Hooks[j] = hook
+			}
+		}
+		expanded.Hooks[event] = newEntries
+	}
+	return &expanded
+}
+
+// WithEnv returns a copy of the config with every command hook prefixed by
+// an export of env (see EnvCommand). Hooks restricted to Windows are left
+// unchanged, since the export is POSIX shell syntax.
+func (c *Config) WithEnv(env map[string]string) *Config {
+	merged := *c
+	merged.Hooks = make(map[Event][]HookEntry, len(c.Hooks))
+	for event, entries := range c.Hooks {
+		newEntries := make([]HookEntry, len(entries))
+		for i, entry := range entries {
+			newEntries[i] = entry
+			newEntries[i].Hooks = make([]Hook, len(entry.Hooks))
+			for j, hook := range entry.Hooks {
+				if hook.IsCommand() && hook.OS != OSWindows {
+					hook.Command = EnvCommand(env, hook.Command)
+				}
+				newEntries[i].Hooks[j] = hook
+			}
+		}
+		merged.Hooks[event] = newEntries
+	}
+	return &merged
+}
+
+// FilterByOS returns a copy of the config with only the hooks that run on
+// goos: hooks without an OS plus hooks for goos. Entries and events left
+// without hooks are dropped.
+func (c *Config) FilterByOS(goos string) *Config {
+	filtered := *c
+	filtered.Hooks = make(map[Event][]HookEntry, len(c.Hooks))
+	for event, entries := range c.Hooks {
+		var newEntries []HookEntry
+		for _, entry := range entries {
+			var hooks []Hook
+			for _, hook := range entry.Hooks {
+				if hook.MatchesOS(goos) {
+					hooks = append(hooks, hook)
+				}
+			}
+			if len(hooks) > 0 {
+				entry.Hooks = hooks
+				newEntries = append(newEntries, entry)
+			}
+		}
+		if len(newEntries) > 0 {
+			filtered.Hooks[event] = newEntries
+		}
+	}
+	return &filtered
+}
+
+// FilterByGroup returns a copy of the config with only the hooks in one of
+// the named groups (see Hook.GroupIn). Ungrouped hooks are dropped, as are
+// entries and events left without hooks. With no names the config is
+// returned unchanged.
+func (c *Config) FilterByGroup(names ...string) *Config {
+	if len(names) == 0 {
+		return c
+	}
+	want := make(map[string]bool, len(names))
+	for _, name := range names {
+		want[name] = true
+	}
+
+	filtered := *c
+	filtered.Hooks = make(map[Event][]HookEntry, len(c.Hooks))
+	for event, entries := range c.Hooks {
+		var newEntries []HookEntry
+		for _, entry := range entries {
+			var hooks []Hook
+			for _, hook := range entry.Hooks {
+				if want[hook.GroupIn(entry)] {
+					hooks = append(hooks, hook)
+				}
+			}
+			if len(hooks) > 0 {
+				entry.Hooks = hooks
+				newEntries = append(newEntries, entry)
+			}
+		}
+		if len(newEntries) > 0 {
+			filtered.Hooks[event] = newEntries
+		}
+	}
+	return &filtered
+}
+
+// DisableEvent marks an event as disabled.
+func (c *Config) DisableEvent(event Event) {
+	if !c.IsEventDisabled(event) {
+		c.DisabledEvents = append(c.DisabledEvents, event)
+	}
+}
+
+// IsEventDisabled reports whether the event is listed in DisabledEvents.
+func (c *Config) IsEventDisabled(event Event) bool {
+	for _, disabled := range c.DisabledEvents {
+		if disabled == event {
+			return true
+		}
+	}
+	return false
+}
+
 // RemoveHooks removes all hooks for an event.
 func (c *Config) RemoveHooks(event Event) {
 	delete(c.Hooks, event)
@@ -121,6 +274,70 @@ func (c *Config) Merge(other *Config) {
 	if other.AllowManagedHooksOnly {
 		c.AllowManagedHooksOnly = true
 	}
+	for _, event := range other.DisabledEvents {
+		c.DisableEvent(event)
+	}
+	// Keep existing extra settings, adding any the other config introduces
+	for key, value := range other.RawExtra {
+		if c.RawExtra == nil {
+			c.RawExtra = make(map[string]json.RawMessage)
+		}
+		if _, ok := c.RawExtra[key]; !ok {
+			c.RawExtra[key] = value
+		}
+	}
+}
+
+// MergeDistinct is Merge, except that a hook already registered for the
+// same event, matcher, and group is not added again. Hooks are added to
+// the existing entry with the same matcher and group when there is one.
+func (c *Config) MergeDistinct(other *Config) {
+	if other == nil {
+		return
+	}
+	if c.Hooks == nil {
+		c.Hooks = make(map[Event][]HookEntry)
+	}
+	for event, entries := range other.Hooks {
+		for _, entry := range entries {
+			c.addDistinct(event, entry)
+		}
+	}
+	settings := *other
+	settings.Hooks = nil
+	c.Merge(&settings)
+}
+
+// addDistinct adds the entry's hooks that are not yet registered.
+func (c *Config) addDistinct(event Event, entry HookEntry) {
+	entries := c.Hooks[event]
+	i := 0
+	for ; i < len(entries); i++ {
+		if entries[i].Matcher == entry.Matcher && entries[i].Group == entry.Group {
+			break
+		}
+	}
+	if i == len(entries) {
+		entries = append(entries, HookEntry{Matcher: entry.Matcher, Group: entry.Group})
+	}
+	for _, hook := range entry.Hooks {
+		if !containsHook(entries[i].Hooks, hook) {
+			entries[i].Hooks = append(entries[i].Hooks, hook)
+		}
+	}
+	if len(entries[i].Hooks) == 0 {
+		entries = entries[:i]
+	}
+	c.Hooks[event] = entries
+}
+
+func containsHook(hooks []Hook, hook Hook) bool {
+	for _, existing := range hooks {
+		if reflect.DeepEqual(existing, hook) {
+			return true
+		}
+	}
+	return false
 }
 
 // FilterByTool returns a new config with only hooks supported by the specified tool.
@@ -129,6 +346,8 @@ func (c *Config) FilterByTool(tool string) *Config {
 	filtered.Version = c.Version
 	filtered.DisableAllHooks = c.DisableAllHooks
 	filtered.AllowManagedHooksOnly = c.AllowManagedHooksOnly
+	filtered.DisabledEvents = c.DisabledEvents
+	filtered.RawExtra = c.RawExtra
 
 	for event, entries := range c.Hooks {
 		support := event.GetToolSupport()
@@ -140,6 +359,8 @@ func (c *Config) FilterByTool(tool string) *Config {
 			supported = support.Cursor
 		case "windsurf":
 			supported = support.Windsurf
+		case "gemini":
+			supported = support.Gemini
 		}
 		if supported {
 			filtered.Hooks[event] = entries
@@ -187,7 +408,7 @@ func (c *Config) WriteFile(path string) error {
 
 // WriteFileWithMode writes the config to a file in JSON format with the specified permission mode.
 func (c *Config) WriteFileWithMode(path string, mode fs.FileMode) error {
-	data, err := json.MarshalIndent(c, "", "  ")
+	data, err := canonicaljson.Marshal(c)
 	if err != nil {
 		return err
 	}
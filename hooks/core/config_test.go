@@ -325,3 +325,163 @@ func TestConfigFilterPreservesSettings(t *testing.T) {
 		t.Error("AllowManagedHooksOnly not preserved")
 	}
 }
+
+func TestConfigMatch(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddHookWithMatcher(BeforeCommand, "Bash", NewCommandHook("echo bash"))
+	cfg.AddHookWithMatcher(BeforeCommand, "Write|Edit", NewCommandHook("echo write"))
+	cfg.AddHookWithMatcher(BeforeCommand, "mcp__.*", NewCommandHook("echo mcp"))
+	cfg.AddHook(BeforeCommand, NewCommandHook("echo always"))
+	cfg.AddHookWithMatcher(AfterCommand, "Bash", NewCommandHook("echo after"))
+
+	hooks := cfg.Match(BeforeCommand, "Bash")
+	if len(hooks) != 2 {
+		t.Fatalf("expected 2 hooks for Bash, got %d: %v", len(hooks), hooks)
+	}
+	if hooks[0].Command != "echo bash" || hooks[1].Command != "echo always" {
+		t.Errorf("unexpected hooks for Bash: %v", hooks)
+	}
+
+	if hooks := cfg.Match(BeforeCommand, "Edit"); len(hooks) != 2 || hooks[0].Command != "echo write" {
+		t.Errorf("expected write hook and catch-all for Edit, got %v", hooks)
+	}
+	if hooks := cfg.Match(BeforeCommand, "mcp__github__search"); len(hooks) != 2 || hooks[0].Command != "echo mcp" {
+		t.Errorf("expected mcp hook and catch-all for mcp tool, got %v", hooks)
+	}
+	if hooks := cfg.Match(BeforeCommand, "BashOutput"); len(hooks) != 1 {
+		t.Errorf("expected matcher to match whole tool name only, got %v", hooks)
+	}
+
+	cfg.DisableAllHooks = true
+	if hooks := cfg.Match(BeforeCommand, "Bash"); len(hooks) != 0 {
+		t.Errorf("expected no hooks when all hooks are disabled, got %v", hooks)
+	}
+}
+
+func TestConfigFilterByOS(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddHook(BeforeCommand, NewCommandHook("./check.sh").WithOS(OSLinux))
+	cfg.AddHook(BeforeCommand, NewCommandHook("pwsh check.ps1").WithOS(OSWindows))
+	cfg.AddHook(OnStop, NewCommandHook("./notify.sh").WithOS(OSDarwin))
+
+	filtered := cfg.FilterByOS(OSWindows)
+	hooks := filtered.GetAllHooksForEvent(BeforeCommand)
+	if len(hooks) != 1 || hooks[0].Command != "pwsh check.ps1" {
+		t.Errorf("expected only the windows command, got %+v", hooks)
+	}
+	if _, ok := filtered.Hooks[OnStop]; ok {
+		t.Error("expected event with no matching hooks to be dropped")
+	}
+	if cfg.HookCount() != 3 {
+		t.Errorf("expected original config to be unchanged, got %d hooks", cfg.HookCount())
+	}
+}
+
+func TestConfigFilterByGroup(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddHook(BeforeCommand, NewCommandHook("golangci-lint run").WithGroup("linting"))
+	cfg.AddHook(BeforeCommand, NewCommandHook("gitleaks detect").WithGroup("security"))
+	cfg.AddHook(OnStop, NewCommandHook("./notify.sh"))
+	cfg.Hooks[AfterFileWrite] = []HookEntry{{
+		Matcher: "Write",
+		Group:   "linting",
+		Hooks:   []Hook{NewCommandHook("gofmt -l ."), NewCommandHook("semgrep").WithGroup("security")},
+	}}
+
+	filtered := cfg.FilterByGroup("linting")
+	if hooks := filtered.GetAllHooksForEvent(BeforeCommand); len(hooks) != 1 || hooks[0].Command != "golangci-lint run" {
+		t.Errorf("expected only the linting command, got %+v", hooks)
+	}
+	entries := filtered.Hooks[AfterFileWrite]
+	if len(entries) != 1 || len(entries[0].Hooks) != 1 || entries[0].Hooks[0].Command != "gofmt -l ." {
+		t.Errorf("expected the entry group to apply to its hooks, got %+v", entries)
+	}
+	if entries[0].Matcher != "Write" || entries[0].Group != "linting" {
+		t.Errorf("expected entry fields to be kept, got %+v", entries[0])
+	}
+	if _, ok := filtered.Hooks[OnStop]; ok {
+		t.Error("expected ungrouped hooks to be dropped")
+	}
+	if filtered.HookCount() != 2 {
+		t.Errorf("expected 2 linting hooks, got %d", filtered.HookCount())
+	}
+
+	if both := cfg.FilterByGroup("linting", "security"); both.HookCount() != 4 {
+		t.Errorf("expected 4 hooks in either group, got %d", both.HookCount())
+	}
+	if all := cfg.FilterByGroup(); all.HookCount() != 5 {
+		t.Errorf("expected no names to keep every hook, got %d", all.HookCount())
+	}
+}
+
+func TestConfigDisabledEvents(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddHook(OnStop, NewCommandHook("./notify.sh"))
+	cfg.DisableEvent(OnStop)
+	cfg.DisableEvent(OnStop)
+
+	if len(cfg.DisabledEvents) != 1 {
+		t.Errorf("expected DisableEvent to be idempotent, got %v", cfg.DisabledEvents)
+	}
+	if hooks := cfg.Match(OnStop, ""); len(hooks) != 0 {
+		t.Errorf("expected no hooks to run for a disabled event, got %v", hooks)
+	}
+
+	other := NewConfig()
+	other.DisableEvent(BeforeCommand)
+	cfg.Merge(other)
+	if !cfg.IsEventDisabled(BeforeCommand) {
+		t.Error("expected Merge to carry over disabled events")
+	}
+}
+
+func TestConfigWithEnv(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddHook(BeforeCommand, NewCommandHook("./check.sh"))
+	cfg.AddHook(BeforeCommand, NewPromptHook("Is this safe?"))
+
+	merged := cfg.WithEnv(map[string]string{"TEAM": "platform"})
+	hooks := merged.Hooks[BeforeCommand][0].Hooks
+	if hooks[0].Command != `export TEAM="platform"; ./check.sh` {
+		t.Errorf("expected command hook to export env, got %q", hooks[0].Command)
+	}
+	if hooks[1].Prompt != "Is this safe?" || hooks[1].Command != "" {
+		t.Errorf("expected prompt hook unchanged, got %+v", hooks[1])
+	}
+	if cfg.Hooks[BeforeCommand][0].Hooks[0].Command != "./check.sh" {
+		t.Error("expected original config to be unchanged")
+	}
+
+	windows := NewConfig()
+	windows.AddHook(BeforeCommand, Hook{Type: HookTypeCommand, Command: "check.cmd", OS: OSWindows})
+	if got := windows.WithEnv(map[string]string{"TEAM": "platform"}).Hooks[BeforeCommand][0].Hooks[0].Command; got != "check.cmd" {
+		t.Errorf("expected Windows hook without a POSIX export, got %q", got)
+	}
+}
+
+func TestConfigMergeDistinct(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddHook(BeforeCommand, NewCommandHook("./check.sh"))
+
+	other := NewConfig()
+	other.AddHook(BeforeCommand, NewCommandHook("./check.sh"))
+	other.AddHook(BeforeCommand, NewCommandHook("./lint.sh"))
+	other.AddHook(BeforeCommand, NewCommandHook("./lint.sh"))
+
+	other.AddHookWithMatcher(BeforeCommand, "Bash", NewCommandHook("./check.sh"))
+
+	cfg.MergeDistinct(other)
+	entries := cfg.Hooks[BeforeCommand]
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+	if got := entries[0].Hooks; len(got) != 2 || got[1].Command != "./lint.sh" {
+		t.Errorf("expected ./lint.sh added once, got %+v", got)
+	}
+	if entries[1].Matcher != "Bash" || len(entries[1].Hooks) != 1 {
+		t.Errorf("expected the Bash entry kept separate, got %+v", entries[1])
+	}
+	if len(other.Hooks[BeforeCommand][0].Hooks) != 3 {
+		t.Error("expected other config to be unchanged")
+	}
+}
@@ -19,6 +19,13 @@ var (
 	// ErrInvalidMatcher is returned when a matcher pattern is invalid.
 	ErrInvalidMatcher = errors.New("invalid matcher pattern")
 
+	// ErrInvalidOS is returned when a hook names an unsupported operating system.
+	ErrInvalidOS = errors.New("hook os must be darwin, linux, or windows")
+
+	// ErrInvalidEnvName is returned when an environment variable name is not
+	// a valid shell identifier.
+	ErrInvalidEnvName = errors.New("env name must be a shell identifier")
+
 	// ErrEmptyConfig is returned when configuration is empty.
 	ErrEmptyConfig = errors.New("configuration is empty")
 )
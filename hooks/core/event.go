@@ -99,51 +99,52 @@ type ToolSupport struct {
 	Claude   bool
 	Cursor   bool
 	Windsurf bool
+	Gemini   bool
 }
 
 // GetToolSupport returns which tools support the given event.
 func (e Event) GetToolSupport() ToolSupport {
 	switch e {
 	case BeforeFileRead:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
+		return ToolSupport{Claude: true, Cursor: true, Windsurf: true, Gemini: true}
 	case AfterFileRead:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: true}
+		return ToolSupport{Claude: true, Cursor: false, Windsurf: true, Gemini: true}
 	case BeforeFileWrite:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: true}
+		return ToolSupport{Claude: true, Cursor: false, Windsurf: true, Gemini: true}
 	case AfterFileWrite:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
+		return ToolSupport{Claude: true, Cursor: true, Windsurf: true, Gemini: true}
 	case BeforeCommand:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
+		return ToolSupport{Claude: true, Cursor: true, Windsurf: true, Gemini: true}
 	case AfterCommand:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
+		return ToolSupport{Claude: true, Cursor: true, Windsurf: true, Gemini: true}
 	case BeforeMCP:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
+		return ToolSupport{Claude: true, Cursor: true, Windsurf: true, Gemini: true}
 	case AfterMCP:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
+		return ToolSupport{Claude: true, Cursor: true, Windsurf: true, Gemini: true}
 	case BeforePrompt:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
+		return ToolSupport{Claude: true, Cursor: true, Windsurf: true, Gemini: true}
 	case OnStop:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: false}
+		return ToolSupport{Claude: true, Cursor: true, Windsurf: false, Gemini: true}
 	case OnSessionStart:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
+		return ToolSupport{Claude: true, Cursor: false, Windsurf: false, Gemini: true}
 	case OnSessionEnd:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
+		return ToolSupport{Claude: true, Cursor: false, Windsurf: false, Gemini: true}
 	case AfterResponse:
-		return ToolSupport{Claude: false, Cursor: true, Windsurf: false}
+		return ToolSupport{Claude: false, Cursor: true, Windsurf: false, Gemini: false}
 	case AfterThought:
-		return ToolSupport{Claude: false, Cursor: true, Windsurf: false}
+		return ToolSupport{Claude: false, Cursor: true, Windsurf: false, Gemini: false}
 	case OnPermission:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
+		return ToolSupport{Claude: true, Cursor: false, Windsurf: false, Gemini: false}
 	case OnNotification:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
+		return ToolSupport{Claude: true, Cursor: false, Windsurf: false, Gemini: true}
 	case BeforeCompact:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
+		return ToolSupport{Claude: true, Cursor: false, Windsurf: false, Gemini: true}
 	case OnSubagentStop:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
+		return ToolSupport{Claude: true, Cursor: false, Windsurf: false, Gemini: false}
 	case BeforeTabRead:
-		return ToolSupport{Claude: false, Cursor: true, Windsurf: false}
+		return ToolSupport{Claude: false, Cursor: true, Windsurf: false, Gemini: false}
 	case AfterTabEdit:
-		return ToolSupport{Claude: false, Cursor: true, Windsurf: false}
+		return ToolSupport{Claude: false, Cursor: true, Windsurf: false, Gemini: false}
 	default:
 		return ToolSupport{}
 	}
@@ -1,5 +1,12 @@
 package core
 
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
 // HookType represents the type of hook execution.
 type HookType string
 
@@ -11,6 +18,13 @@ const (
 	HookTypePrompt HookType = "prompt"
 )
 
+// Operating systems a hook can be restricted to. Values match runtime.GOOS.
+const (
+	OSDarwin  = "darwin"
+	OSLinux   = "linux"
+	OSWindows = "windows"
+)
+
 // Hook represents a single hook definition that can be triggered by an event.
 type Hook struct {
 	// Type specifies how the hook is executed (command or prompt).
@@ -20,6 +34,8 @@ type Hook struct {
 	Command string `json:"command,omitempty"`
 
 	// Prompt is the LLM prompt for context-aware decisions (Claude-specific).
+	// It may contain PlaceholderTool and PlaceholderCommand, which adapters
+	// translate to the tool's own reference to the request.
 	Prompt string `json:"prompt,omitempty"`
 
 	// Timeout in seconds for hook execution.
@@ -30,6 +46,15 @@ type Hook struct {
 
 	// WorkingDir is the working directory for command execution.
 	WorkingDir string `json:"workingDir,omitempty"`
+
+	// OS restricts the hook to one operating system (darwin, linux, or windows).
+	// Empty means the hook runs everywhere.
+	OS string `json:"os,omitempty"`
+
+	// Group names the set the hook belongs to (e.g., "linting", "security")
+	// so hooks can be enabled or disabled together. Empty inherits the
+	// entry's group.
+	Group string `json:"group,omitempty"`
 }
 
 // HookEntry represents a collection of hooks for a specific event,
@@ -39,10 +64,37 @@ type HookEntry struct {
 	// Examples: "Bash", "Write", "Edit", "Read", "Bash|Write"
 	Matcher string `json:"matcher,omitempty"`
 
+	// Group is the default group for the entry's hooks.
+	Group string `json:"group,omitempty"`
+
 	// Hooks is the list of hooks to execute for this entry.
 	Hooks []Hook `json:"hooks"`
 }
 
+// Matches reports whether the entry applies to a tool invocation.
+// An empty matcher or "*" matches every tool; otherwise the matcher is a
+// regular expression that must match the whole tool name (e.g., "Bash|Write",
+// "mcp__.*"). Matchers that are not valid expressions match literally.
+func (e HookEntry) Matches(toolName string) bool {
+	if e.Matcher == "" || e.Matcher == "*" {
+		return true
+	}
+	re, err := regexp.Compile("^(?:" + e.Matcher + ")$")
+	if err != nil {
+		return e.Matcher == toolName
+	}
+	return re.MatchString(toolName)
+}
+
+// Placeholders a prompt hook's text may use for details of the request.
+const (
+	// PlaceholderTool stands for the name of the tool being used.
+	PlaceholderTool = "{{tool}}"
+
+	// PlaceholderCommand stands for the shell command being run.
+	PlaceholderCommand = "{{command}}"
+)
+
 // NewCommandHook creates a new command-type hook.
 func NewCommandHook(command string) Hook {
 	return Hook{
@@ -77,6 +129,32 @@ func (h Hook) WithWorkingDir(dir string) Hook {
 	return h
 }
 
+// WithOS restricts the hook to an operating system.
+func (h Hook) WithOS(goos string) Hook {
+	h.OS = goos
+	return h
+}
+
+// MatchesOS reports whether the hook runs on goos.
+func (h *Hook) MatchesOS(goos string) bool {
+	return h.OS == "" || h.OS == goos
+}
+
+// WithGroup assigns the hook to a group.
+func (h Hook) WithGroup(group string) Hook {
+	h.Group = group
+	return h
+}
+
+// GroupIn returns the hook's group within entry: its own Group, or the
+// entry's Group when unset.
+func (h *Hook) GroupIn(entry HookEntry) string {
+	if h.Group != "" {
+		return h.Group
+	}
+	return entry.Group
+}
+
 // IsCommand returns true if this is a command-type hook.
 func (h *Hook) IsCommand() bool {
 	return h.Type == HookTypeCommand || (h.Type == "" && h.Command != "")
@@ -95,5 +173,92 @@ func (h *Hook) Validate() error {
 	if h.Command != "" && h.Prompt != "" {
 		return ErrBothCommandAndPrompt
 	}
+	switch h.OS {
+	case "", OSDarwin, OSLinux, OSWindows:
+	default:
+		return ErrInvalidOS
+	}
 	return nil
 }
+
+// osPatterns maps each OS to the `uname -s` case pattern that identifies it.
+// Windows matches the Git Bash, MSYS, and Cygwin shells.
+var osPatterns = map[string]string{
+	OSDarwin:  "Darwin",
+	OSLinux:   "Linux",
+	OSWindows: "MINGW*|MSYS*|CYGWIN*",
+}
+
+var guardedCommand = regexp.MustCompile(`^case "\$\(uname -s\)" in (\S+)\) (.*) ;; esac$`)
+
+// GuardCommand wraps command in a POSIX shell guard so it only runs on goos,
+// for tools that run every hook through a shell but cannot filter by OS.
+// Commands for an empty or unknown OS are returned unchanged.
+func GuardCommand(goos, command string) string {
+	pattern, ok := osPatterns[goos]
+	if !ok || command == "" {
+		return command
+	}
+	return `case "$(uname -s)" in ` + pattern + ") " + command + " ;; esac"
+}
+
+// UnguardCommand reverses GuardCommand, returning the OS and the inner
+// command. Commands without a recognized guard return an empty OS.
+func UnguardCommand(command string) (goos, inner string) {
+	m := guardedCommand.FindStringSubmatch(strings.TrimSpace(command))
+	if m == nil {
+		return "", command
+	}
+	for name, pattern := range osPatterns {
+		if pattern == m[1] {
+			return name, m[2]
+		}
+	}
+	return "", command
+}
+
+// EnvCommand prefixes command with an export of each variable in env, in
+// key order, for tools whose hooks cannot set an environment of their own.
+// Values are double-quoted so ${VAR} references still expand, and a
+// variable the command assigns itself takes precedence.
+func EnvCommand(env map[string]string, command string) string {
+	if len(env) == 0 || command == "" {
+		return command
+	}
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("export")
+	for _, key := range keys {
+		b.WriteString(" " + key + `="` + envQuoter.Replace(env[key]) + `"`)
+	}
+	b.WriteString("; " + command)
+	return b.String()
+}
+
+// envName matches a POSIX shell variable name.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateEnv returns an error wrapping ErrInvalidEnvName for the first key
+// in env, in key order, that EnvCommand cannot export.
+func ValidateEnv(env map[string]string) error {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !envName.MatchString(key) {
+			return fmt.Errorf("%w: %q", ErrInvalidEnvName, key)
+		}
+	}
+	return nil
+}
+
+// envQuoter escapes the characters that stay special inside double quotes,
+// except $.
+var envQuoter = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`")
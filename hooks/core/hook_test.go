@@ -1,6 +1,9 @@
 package core
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestNewCommandHook(t *testing.T) {
 	hook := NewCommandHook("echo hello")
@@ -110,3 +113,62 @@ func TestHookValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestHookMatchesOS(t *testing.T) {
+	hook := NewCommandHook("./check.sh").WithOS(OSLinux)
+	if !hook.MatchesOS(OSLinux) {
+		t.Error("expected linux hook to match linux")
+	}
+	if hook.MatchesOS(OSWindows) {
+		t.Error("expected linux hook not to match windows")
+	}
+	unrestricted := NewCommandHook("echo hi")
+	if !unrestricted.MatchesOS(OSDarwin) {
+		t.Error("expected hook without OS to match every OS")
+	}
+
+	bad := NewCommandHook("echo hi").WithOS("plan9")
+	if err := bad.Validate(); err != ErrInvalidOS {
+		t.Errorf("expected ErrInvalidOS, got %v", err)
+	}
+}
+
+func TestGuardCommandRoundTrip(t *testing.T) {
+	for _, os := range []string{OSDarwin, OSLinux, OSWindows} {
+		guarded := GuardCommand(os, "./hooks/check.sh --strict")
+		gotOS, inner := UnguardCommand(guarded)
+		if gotOS != os || inner != "./hooks/check.sh --strict" {
+			t.Errorf("UnguardCommand(%q) = %q, %q", guarded, gotOS, inner)
+		}
+	}
+
+	if got := GuardCommand("", "echo hi"); got != "echo hi" {
+		t.Errorf("expected unguarded command, got %q", got)
+	}
+	if os, inner := UnguardCommand("echo hi"); os != "" || inner != "echo hi" {
+		t.Errorf("UnguardCommand of a plain command = %q, %q", os, inner)
+	}
+}
+
+func TestEnvCommand(t *testing.T) {
+	env := map[string]string{"TEAM": "platform", "TOKEN": "${GH_TOKEN}", "QUOTE": `say "hi"`}
+	got := EnvCommand(env, "./check.sh")
+	want := `export QUOTE="say \"hi\"" TEAM="platform" TOKEN="${GH_TOKEN}"; ./check.sh`
+	if got != want {
+		t.Errorf("EnvCommand() = %q, want %q", got, want)
+	}
+	if got := EnvCommand(nil, "./check.sh"); got != "./check.sh" {
+		t.Errorf("expected command unchanged without env, got %q", got)
+	}
+}
+
+func TestValidateEnv(t *testing.T) {
+	if err := ValidateEnv(map[string]string{"TEAM": "platform", "_DEBUG2": "1"}); err != nil {
+		t.Errorf("expected valid names to pass, got %v", err)
+	}
+	for _, key := range []string{"2FA", "MY-VAR", "A B", "X;rm -rf /", ""} {
+		if err := ValidateEnv(map[string]string{key: "v"}); !errors.Is(err, ErrInvalidEnvName) {
+			t.Errorf("ValidateEnv(%q) = %v, want ErrInvalidEnvName", key, err)
+		}
+	}
+}
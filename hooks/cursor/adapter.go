@@ -7,6 +7,7 @@ import (
 	"runtime"
 
 	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
 )
 
 const (
@@ -69,19 +70,25 @@ func (a *Adapter) SupportedEvents() []core.Event {
 	}
 }
 
-// Parse parses Cursor hooks config data into the canonical format.
+// Parse parses Cursor hooks config data into the canonical format. Configs
+// written for an older hooks.json version are migrated first, so renamed
+// events still map; a config for a newer version than LatestVersion fails
+// with a *core.ParseError wrapping ErrUnsupportedVersion.
 func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 	var cursorCfg Config
 	if err := json.Unmarshal(data, &cursorCfg); err != nil {
 		return nil, &core.ParseError{Format: AdapterName, Err: err}
 	}
+	if _, err := Migrate(&cursorCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
 	return a.ToCore(&cursorCfg), nil
 }
 
 // Marshal converts canonical config to Cursor format.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
 	cursorCfg := a.FromCore(cfg)
-	return json.MarshalIndent(cursorCfg, "", "  ")
+	return canonicaljson.Marshal(cursorCfg)
 }
 
 // ReadFile reads a Cursor hooks config file.
@@ -151,6 +158,9 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 		if !ok {
 			continue // Event not supported by Cursor
 		}
+		if cfg.IsEventDisabled(event) {
+			continue // Cursor has no per-event disable setting, so omit the hooks
+		}
 
 		for _, entry := range entries {
 			for _, h := range entry.Hooks {
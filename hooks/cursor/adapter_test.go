@@ -242,7 +242,7 @@ func TestAdapterReadWriteFile(t *testing.T) {
 	// Create config
 	cfg := core.NewConfig()
 	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("echo test"))
-	cfg.Version = 2
+	cfg.Version = LatestVersion()
 
 	// Write file
 	filePath := filepath.Join(tmpDir, "hooks.json")
@@ -260,8 +260,8 @@ func TestAdapterReadWriteFile(t *testing.T) {
 	if readCfg.HookCount() != 1 {
 		t.Errorf("ReadFile() got %d hooks, want 1", readCfg.HookCount())
 	}
-	if readCfg.Version != 2 {
-		t.Errorf("Version should be 2, got %d", readCfg.Version)
+	if readCfg.Version != LatestVersion() {
+		t.Errorf("Version should be %d, got %d", LatestVersion(), readCfg.Version)
 	}
 }
 
@@ -545,3 +545,19 @@ func TestAdapterToCoreSkipsUnknownEvents(t *testing.T) {
 		t.Errorf("Expected 1 hook, got %d", cfg.HookCount())
 	}
 }
+
+func TestAdapterFromCoreOmitsDisabledEvents(t *testing.T) {
+	adapter := NewAdapter()
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("./check.sh"))
+	cfg.AddHook(core.OnStop, core.NewCommandHook("./notify.sh"))
+	cfg.DisableEvent(core.BeforeCommand)
+
+	cursorCfg := adapter.FromCore(cfg)
+	if _, ok := cursorCfg.Hooks[BeforeShellExecution]; ok {
+		t.Error("expected hooks for the disabled event to be omitted")
+	}
+	if len(cursorCfg.Hooks[Stop]) != 1 {
+		t.Errorf("expected the stop hook to be kept, got %v", cursorCfg.Hooks)
+	}
+}
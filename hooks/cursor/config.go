@@ -58,7 +58,7 @@ type Hook struct {
 // NewConfig creates a new empty Cursor hooks config.
 func NewConfig() *Config {
 	return &Config{
-		Version: 1,
+		Version: LatestVersion(),
 		Hooks:   make(map[CursorEvent][]Hook),
 	}
 }
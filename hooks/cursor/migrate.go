@@ -0,0 +1,71 @@
+package cursor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnsupportedVersion is returned by Migrate for a config written for a
+// newer hooks.json version than LatestVersion.
+var ErrUnsupportedVersion = errors.New("unsupported cursor hooks version")
+
+// migration upgrades a hooks.json config by one version.
+type migration struct {
+	// renames maps event names of the older version to their new names.
+	renames map[CursorEvent]CursorEvent
+}
+
+// migrations holds the upgrade from version i+1 to version i+2 at index i.
+// Cursor has not changed the hooks.json schema since version 1, so there
+// are no steps yet; append one whenever Cursor renames an event.
+var migrations []migration
+
+// LatestVersion returns the newest hooks.json version the adapter supports.
+func LatestVersion() int {
+	return len(migrations) + 1
+}
+
+// Migrate upgrades cfg in place to LatestVersion, renaming events along the
+// way, and returns a description of each change. A config without a version
+// is treated as version 1. Hooks for an event renamed onto one that is
+// already configured are appended after the existing hooks.
+func Migrate(cfg *Config) ([]string, error) {
+	version := cfg.Version
+	if version == 0 {
+		version = 1
+	}
+	if version > LatestVersion() {
+		return nil, fmt.Errorf("%w %d (latest is %d)", ErrUnsupportedVersion, version, LatestVersion())
+	}
+
+	var changes []string
+	for ; version < LatestVersion(); version++ {
+		step := migrations[version-1]
+		for _, event := range sortedEvents(cfg.Hooks) {
+			renamed, ok := step.renames[event]
+			if !ok {
+				continue
+			}
+			cfg.Hooks[renamed] = append(cfg.Hooks[renamed], cfg.Hooks[event]...)
+			delete(cfg.Hooks, event)
+			changes = append(changes, fmt.Sprintf("renamed %q to %q", event, renamed))
+		}
+	}
+
+	if cfg.Version != version {
+		changes = append(changes, fmt.Sprintf("upgraded version %d to %d", cfg.Version, version))
+		cfg.Version = version
+	}
+	return changes, nil
+}
+
+// sortedEvents returns the events configured in hooks, sorted by name.
+func sortedEvents(hooks map[CursorEvent][]Hook) []CursorEvent {
+	events := make([]CursorEvent, 0, len(hooks))
+	for event := range hooks {
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i] < events[j] })
+	return events
+}
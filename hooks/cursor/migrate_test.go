@@ -0,0 +1,78 @@
+package cursor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+// withMigrations replaces the migration steps for the duration of a test.
+func withMigrations(t *testing.T, steps []migration) {
+	t.Helper()
+	saved := migrations
+	migrations = steps
+	t.Cleanup(func() { migrations = saved })
+}
+
+func TestMigrateRenamedEvent(t *testing.T) {
+	// A hypothetical version 2 that renamed beforeShell.
+	withMigrations(t, []migration{{renames: map[CursorEvent]CursorEvent{"beforeShell": BeforeShellExecution}}})
+
+	cfg := &Config{
+		Version: 1,
+		Hooks: map[CursorEvent][]Hook{
+			"beforeShell": {{Command: "./check.sh"}},
+			Stop:          {{Command: "./notify.sh"}},
+		},
+	}
+	changes, err := Migrate(cfg)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if cfg.Version != 2 {
+		t.Errorf("expected version 2, got %d", cfg.Version)
+	}
+	if _, ok := cfg.Hooks["beforeShell"]; ok {
+		t.Error("expected the old event name to be removed")
+	}
+	if hooks := cfg.Hooks[BeforeShellExecution]; len(hooks) != 1 || hooks[0].Command != "./check.sh" {
+		t.Errorf("expected the hook under beforeShellExecution, got %v", hooks)
+	}
+	if len(cfg.Hooks[Stop]) != 1 {
+		t.Errorf("expected unrenamed events to be kept, got %v", cfg.Hooks)
+	}
+	want := []string{`renamed "beforeShell" to "beforeShellExecution"`, "upgraded version 1 to 2"}
+	if len(changes) != len(want) || changes[0] != want[0] || changes[1] != want[1] {
+		t.Errorf("changes = %q, want %q", changes, want)
+	}
+
+	// Parse migrates older configs so the renamed event maps.
+	parsed, err := NewAdapter().Parse([]byte(`{"version":1,"hooks":{"beforeShell":[{"command":"./check.sh"}]}}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if hooks := parsed.GetAllHooksForEvent(core.BeforeCommand); len(hooks) != 1 {
+		t.Errorf("expected the migrated hook under %s, got %v", core.BeforeCommand, parsed.Hooks)
+	}
+}
+
+func TestMigrateUnsupportedVersion(t *testing.T) {
+	cfg := &Config{Version: LatestVersion() + 1, Hooks: map[CursorEvent][]Hook{}}
+	if _, err := Migrate(cfg); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+
+	current := NewConfig()
+	changes, err := Migrate(current)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("expected a current config to be unchanged, got %q, %v", changes, err)
+	}
+
+	data := []byte(fmt.Sprintf(`{"version": %d, "hooks": {}}`, LatestVersion()+1))
+	var parseErr *core.ParseError
+	if _, err := NewAdapter().Parse(data); !errors.As(err, &parseErr) || !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected Parse to reject a newer version with a ParseError, got %v", err)
+	}
+}
@@ -0,0 +1,272 @@
+package gemini
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "gemini"
+
+	// SettingsFileName is the settings file name containing hooks.
+	SettingsFileName = "settings.json"
+
+	// ProjectConfigDir is the project config directory.
+	ProjectConfigDir = ".gemini"
+)
+
+// Adapter implements core.Adapter for Gemini CLI hooks.
+type Adapter struct{}
+
+// NewAdapter creates a new Gemini hooks adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default config file paths for Gemini hooks.
+func (a *Adapter) DefaultPaths() []string {
+	paths := []string{
+		filepath.Join(ProjectConfigDir, SettingsFileName),
+	}
+
+	// User config
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ProjectConfigDir, SettingsFileName))
+	}
+
+	// System config
+	switch runtime.GOOS {
+	case "darwin":
+		paths = append(paths, filepath.Join("/Library/Application Support/GeminiCli", SettingsFileName))
+	case "linux":
+		paths = append(paths, filepath.Join("/etc/gemini-cli", SettingsFileName))
+	case "windows":
+		paths = append(paths, filepath.Join("C:\\ProgramData\\gemini-cli", SettingsFileName))
+	}
+
+	return paths
+}
+
+// SupportedEvents returns the events supported by Gemini.
+func (a *Adapter) SupportedEvents() []core.Event {
+	return []core.Event{
+		core.BeforeFileRead, core.AfterFileRead,
+		core.BeforeFileWrite, core.AfterFileWrite,
+		core.BeforeCommand, core.AfterCommand,
+		core.BeforeMCP, core.AfterMCP,
+		core.BeforePrompt,
+		core.OnStop, core.OnSessionStart, core.OnSessionEnd,
+		core.OnNotification, core.BeforeCompact,
+	}
+}
+
+// Parse parses Gemini hooks config data into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var geminiCfg Config
+	if err := json.Unmarshal(data, &geminiCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&geminiCfg), nil
+}
+
+// Marshal converts canonical config to Gemini format.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	geminiCfg := a.FromCore(cfg)
+	return canonicaljson.Marshal(geminiCfg)
+}
+
+// ReadFile reads a Gemini hooks config file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to a Gemini format file.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts Gemini hooks config to canonical format.
+func (a *Adapter) ToCore(geminiCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+	cfg.RawExtra = geminiCfg.RawExtra
+
+	for geminiEvent, entries := range geminiCfg.Hooks {
+		for _, entry := range entries {
+			canonicalEvent := a.geminiToCanonicalEvent(geminiEvent, entry.Matcher)
+			if canonicalEvent == "" {
+				continue
+			}
+
+			var coreHooks []core.Hook
+			for _, h := range entry.Hooks {
+				coreHook := core.Hook{
+					Type:    core.HookTypeCommand,
+					Timeout: millisToSeconds(h.Timeout),
+				}
+				coreHook.OS, coreHook.Command = core.UnguardCommand(h.Command)
+				coreHooks = append(coreHooks, coreHook)
+			}
+
+			// Tool matchers are only kept for MCP events; the other events
+			// are fully described by the canonical event itself.
+			var matcher string
+			if canonicalEvent == core.BeforeMCP || canonicalEvent == core.AfterMCP {
+				matcher = entry.Matcher
+			}
+
+			cfg.Hooks[canonicalEvent] = append(cfg.Hooks[canonicalEvent], core.HookEntry{
+				Matcher: matcher,
+				Hooks:   coreHooks,
+			})
+		}
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to Gemini format.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	geminiCfg := NewConfig()
+	geminiCfg.RawExtra = cfg.RawExtra
+
+	for event, entries := range cfg.Hooks {
+		geminiEvent, matcher := a.canonicalToGeminiEvent(event)
+		if geminiEvent == "" {
+			continue // Event not supported by Gemini
+		}
+		if cfg.IsEventDisabled(event) {
+			continue // Gemini has no per-event disable setting, so omit the hooks
+		}
+
+		for _, entry := range entries {
+			// MCP events have no default matcher, so keep the entry's own
+			m := matcher
+			if m == "" {
+				m = entry.Matcher
+			}
+
+			var geminiHooks []Hook
+			for _, h := range entry.Hooks {
+				// Gemini only supports command hooks
+				if h.Command == "" {
+					continue
+				}
+				geminiHooks = append(geminiHooks, Hook{
+					Type:    "command",
+					Command: core.GuardCommand(h.OS, h.Command),
+					Timeout: h.Timeout * 1000,
+				})
+			}
+			if len(geminiHooks) == 0 {
+				continue
+			}
+
+			geminiCfg.Hooks[geminiEvent] = append(geminiCfg.Hooks[geminiEvent], HookEntry{
+				Matcher: m,
+				Hooks:   geminiHooks,
+			})
+		}
+	}
+
+	return geminiCfg
+}
+
+// geminiToCanonicalEvent converts a Gemini event to canonical event.
+func (a *Adapter) geminiToCanonicalEvent(geminiEvent GeminiEvent, matcher string) core.Event {
+	// Check direct mapping first
+	if event, ok := reverseEventMapping[geminiEvent]; ok {
+		return event
+	}
+
+	// Handle BeforeTool/AfterTool based on matcher
+	switch geminiEvent {
+	case BeforeTool:
+		if event, ok := matcherToCanonicalEventBefore[matcher]; ok {
+			return event
+		}
+		// Default to BeforeMCP for unknown matchers (likely MCP tools)
+		return core.BeforeMCP
+	case AfterTool:
+		if event, ok := matcherToCanonicalEventAfter[matcher]; ok {
+			return event
+		}
+		return core.AfterMCP
+	}
+
+	return ""
+}
+
+// canonicalToGeminiEvent converts a canonical event to Gemini event and matcher.
+func (a *Adapter) canonicalToGeminiEvent(event core.Event) (GeminiEvent, string) {
+	if !event.GetToolSupport().Gemini {
+		return "", ""
+	}
+	if geminiEvent, ok := eventMapping[event]; ok {
+		return geminiEvent, canonicalEventToMatcher[event]
+	}
+	return "", ""
+}
+
+// millisToSeconds converts a Gemini timeout to canonical seconds, rounding up
+// so a sub-second timeout is not lost.
+func millisToSeconds(ms int) int {
+	if ms <= 0 {
+		return 0
+	}
+	return (ms + 999) / 1000
+}
+
+// ProjectConfigPath returns the project hooks config path.
+func ProjectConfigPath() string {
+	return filepath.Join(ProjectConfigDir, SettingsFileName)
+}
+
+// ReadProjectConfig reads the project-level .gemini/settings.json hooks.
+func ReadProjectConfig() (*core.Config, error) {
+	adapter := NewAdapter()
+	return adapter.ReadFile(ProjectConfigPath())
+}
+
+// ReadUserConfig reads the user-level ~/.gemini/settings.json hooks.
+func ReadUserConfig() (*core.Config, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	adapter := NewAdapter()
+	return adapter.ReadFile(filepath.Join(home, ProjectConfigDir, SettingsFileName))
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
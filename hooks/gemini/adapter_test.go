@@ -0,0 +1,450 @@
+package gemini
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+func TestNewAdapter(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter == nil {
+		t.Fatal("NewAdapter returned nil")
+	}
+}
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "gemini" {
+		t.Errorf("Expected name 'gemini', got %q", adapter.Name())
+	}
+}
+
+func TestAdapterDefaultPaths(t *testing.T) {
+	adapter := NewAdapter()
+	paths := adapter.DefaultPaths()
+	if len(paths) < 1 {
+		t.Errorf("Expected at least 1 default path, got %d", len(paths))
+	}
+	// Check project path is present
+	if paths[0] != filepath.Join(ProjectConfigDir, SettingsFileName) {
+		t.Errorf("First path should be project config, got %q", paths[0])
+	}
+}
+
+func TestAdapterSupportedEvents(t *testing.T) {
+	adapter := NewAdapter()
+	events := adapter.SupportedEvents()
+
+	eventSet := make(map[core.Event]bool)
+	for _, e := range events {
+		eventSet[e] = true
+		// Every advertised event must be writable
+		if !e.GetToolSupport().Gemini {
+			t.Errorf("Event %q advertised but not marked as Gemini-supported", e)
+		}
+	}
+
+	requiredEvents := []core.Event{
+		core.BeforeFileRead, core.AfterFileRead,
+		core.BeforeFileWrite, core.AfterFileWrite,
+		core.BeforeCommand, core.AfterCommand,
+		core.BeforeMCP, core.AfterMCP,
+		core.BeforePrompt, core.OnStop,
+		core.OnSessionStart, core.OnSessionEnd,
+		core.OnNotification, core.BeforeCompact,
+	}
+	for _, e := range requiredEvents {
+		if !eventSet[e] {
+			t.Errorf("Expected event %q in supported events", e)
+		}
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	adapter := NewAdapter()
+
+	tests := []struct {
+		name      string
+		json      string
+		wantHooks int
+		wantError bool
+	}{
+		{
+			name: "valid BeforeTool hook",
+			json: `{
+				"hooks": {
+					"BeforeTool": [
+						{"matcher": "run_shell_command", "hooks": [{"type": "command", "command": "echo before"}]}
+					]
+				}
+			}`,
+			wantHooks: 1,
+			wantError: false,
+		},
+		{
+			name: "valid SessionStart hook",
+			json: `{
+				"hooks": {
+					"SessionStart": [
+						{"hooks": [{"type": "command", "command": "echo start"}]}
+					]
+				}
+			}`,
+			wantHooks: 1,
+			wantError: false,
+		},
+		{
+			name: "multiple hooks per entry",
+			json: `{
+				"hooks": {
+					"AfterTool": [
+						{"matcher": "write_file|replace", "hooks": [
+							{"type": "command", "command": "echo 1"},
+							{"type": "command", "command": "echo 2"}
+						]}
+					]
+				}
+			}`,
+			wantHooks: 2,
+			wantError: false,
+		},
+		{
+			name: "other settings keys",
+			json: `{
+				"model": {"name": "gemini-2.5-pro"},
+				"hooks": {
+					"AfterAgent": [
+						{"hooks": [{"type": "command", "command": "echo done"}]}
+					]
+				}
+			}`,
+			wantHooks: 1,
+			wantError: false,
+		},
+		{
+			name:      "invalid json",
+			json:      `{invalid`,
+			wantError: true,
+		},
+		{
+			name:      "empty config",
+			json:      `{"hooks": {}}`,
+			wantHooks: 0,
+			wantError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := adapter.Parse([]byte(tt.json))
+			if (err != nil) != tt.wantError {
+				t.Errorf("Parse() error = %v, wantError %v", err, tt.wantError)
+				return
+			}
+			if !tt.wantError && cfg.HookCount() != tt.wantHooks {
+				t.Errorf("Parse() got %d hooks, want %d", cfg.HookCount(), tt.wantHooks)
+			}
+		})
+	}
+}
+
+func TestAdapterMarshal(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("echo before"))
+	cfg.AddHook(core.AfterFileWrite, core.NewCommandHook("echo after"))
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if !strings.Contains(string(data), `"matcher":"run_shell_command"`) &&
+		!strings.Contains(string(data), `"matcher": "run_shell_command"`) {
+		t.Errorf("Marshal() should set the shell tool matcher, got %s", data)
+	}
+
+	// Parse back and verify
+	parsed, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Failed to parse marshaled data: %v", err)
+	}
+
+	if parsed.HookCount() != 2 {
+		t.Errorf("Round-trip got %d hooks, want 2", parsed.HookCount())
+	}
+}
+
+func TestAdapterRoundTrip(t *testing.T) {
+	adapter := NewAdapter()
+
+	original := `{
+		"general": {"vimMode": true},
+		"hooks": {
+			"BeforeTool": [
+				{"matcher": "run_shell_command", "hooks": [{"type": "command", "command": "echo before command", "timeout": 30000}]},
+				{"matcher": "mcp__github__.*", "hooks": [{"type": "command", "command": "echo before mcp"}]}
+			],
+			"AfterTool": [
+				{"matcher": "write_file|replace", "hooks": [{"type": "command", "command": "echo after write"}]}
+			],
+			"BeforeAgent": [
+				{"hooks": [{"type": "command", "command": "echo before prompt"}]}
+			],
+			"PreCompress": [
+				{"hooks": [{"type": "command", "command": "echo compress"}]}
+			]
+		}
+	}`
+
+	// Parse
+	cfg, err := adapter.Parse([]byte(original))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// Marshal
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	// Parse again
+	cfg2, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Second Parse() error = %v", err)
+	}
+
+	// Verify hook count matches
+	if cfg.HookCount() != cfg2.HookCount() {
+		t.Errorf("Hook count mismatch after round-trip: %d vs %d",
+			cfg.HookCount(), cfg2.HookCount())
+	}
+
+	// Timeout converts between milliseconds and seconds
+	hooks := cfg2.GetAllHooksForEvent(core.BeforeCommand)
+	if len(hooks) != 1 || hooks[0].Timeout != 30 {
+		t.Errorf("Expected BeforeCommand hook with 30s timeout, got %+v", hooks)
+	}
+
+	// MCP matcher is preserved
+	mcp := cfg2.Hooks[core.BeforeMCP]
+	if len(mcp) != 1 || mcp[0].Matcher != "mcp__github__.*" {
+		t.Errorf("Expected MCP matcher to survive round-trip, got %+v", mcp)
+	}
+
+	// Non-hook settings are preserved
+	if _, ok := cfg2.RawExtra["general"]; !ok {
+		t.Error("Expected non-hook settings to survive round-trip")
+	}
+}
+
+func TestAdapterReadWriteFile(t *testing.T) {
+	adapter := NewAdapter()
+
+	// Create temp directory
+	tmpDir, err := os.MkdirTemp("", "gemini-hooks-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cfg := core.NewConfig()
+	cfg.AddHook(core.OnSessionStart, core.NewCommandHook("echo start").WithTimeout(5))
+
+	// Write file
+	filePath := filepath.Join(tmpDir, "settings.json")
+	if err := adapter.WriteFile(cfg, filePath); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	// Read file
+	readCfg, err := adapter.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	hooks := readCfg.GetAllHooksForEvent(core.OnSessionStart)
+	if len(hooks) != 1 {
+		t.Fatalf("Expected 1 hook, got %d", len(hooks))
+	}
+	if hooks[0].Timeout != 5 {
+		t.Errorf("Timeout should be 5, got %d", hooks[0].Timeout)
+	}
+}
+
+func TestAdapterReadFileNotFound(t *testing.T) {
+	adapter := NewAdapter()
+
+	_, err := adapter.ReadFile("/nonexistent/path/settings.json")
+	if err == nil {
+		t.Error("ReadFile() should return error for nonexistent file")
+	}
+
+	// Check it's a ParseError
+	if _, ok := err.(*core.ParseError); !ok {
+		t.Errorf("Expected ParseError, got %T", err)
+	}
+}
+
+func TestAdapterToCoreEventMapping(t *testing.T) {
+	adapter := NewAdapter()
+
+	tests := []struct {
+		geminiEvent GeminiEvent
+		matcher     string
+		wantEvent   core.Event
+	}{
+		{BeforeTool, "read_file", core.BeforeFileRead},
+		{AfterTool, "read_many_files", core.AfterFileRead},
+		{BeforeTool, "write_file|replace", core.BeforeFileWrite},
+		{AfterTool, "replace", core.AfterFileWrite},
+		{BeforeTool, "run_shell_command", core.BeforeCommand},
+		{AfterTool, "run_shell_command", core.AfterCommand},
+		{BeforeTool, "mcp__github__create_issue", core.BeforeMCP},
+		{AfterTool, "mcp__github__create_issue", core.AfterMCP},
+		{BeforeAgent, "", core.BeforePrompt},
+		{AfterAgent, "", core.OnStop},
+		{SessionStart, "", core.OnSessionStart},
+		{SessionEnd, "", core.OnSessionEnd},
+		{Notification, "", core.OnNotification},
+		{PreCompress, "", core.BeforeCompact},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.geminiEvent)+"/"+tt.matcher, func(t *testing.T) {
+			geminiCfg := &Config{
+				Hooks: map[GeminiEvent][]HookEntry{
+					tt.geminiEvent: {{Matcher: tt.matcher, Hooks: []Hook{{Type: "command", Command: "echo test"}}}},
+				},
+			}
+
+			cfg := adapter.ToCore(geminiCfg)
+			hooks := cfg.GetAllHooksForEvent(tt.wantEvent)
+			if len(hooks) != 1 {
+				t.Errorf("Expected 1 hook for event %q, got %d", tt.wantEvent, len(hooks))
+			}
+		})
+	}
+}
+
+func TestAdapterFromCoreEventMapping(t *testing.T) {
+	adapter := NewAdapter()
+
+	tests := []struct {
+		event       core.Event
+		wantGemini  GeminiEvent
+		wantMatcher string
+		shouldExist bool
+	}{
+		{core.BeforeFileRead, BeforeTool, "read_file|read_many_files", true},
+		{core.AfterFileRead, AfterTool, "read_file|read_many_files", true},
+		{core.BeforeFileWrite, BeforeTool, "write_file|replace", true},
+		{core.AfterFileWrite, AfterTool, "write_file|replace", true},
+		{core.BeforeCommand, BeforeTool, "run_shell_command", true},
+		{core.AfterCommand, AfterTool, "run_shell_command", true},
+		{core.BeforePrompt, BeforeAgent, "", true},
+		{core.OnStop, AfterAgent, "", true},
+		{core.OnSessionStart, SessionStart, "", true},
+		{core.OnSessionEnd, SessionEnd, "", true},
+		{core.OnNotification, Notification, "", true},
+		{core.BeforeCompact, PreCompress, "", true},
+		// Events not supported by Gemini
+		{core.OnPermission, "", "", false},
+		{core.OnSubagentStop, "", "", false},
+		{core.AfterResponse, "", "", false},
+		{core.BeforeTabRead, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.event), func(t *testing.T) {
+			cfg := core.NewConfig()
+			cfg.AddHook(tt.event, core.NewCommandHook("echo test"))
+
+			geminiCfg := adapter.FromCore(cfg)
+
+			if tt.shouldExist {
+				entries := geminiCfg.Hooks[tt.wantGemini]
+				if len(entries) != 1 {
+					t.Fatalf("Expected 1 entry for gemini event %q, got %d", tt.wantGemini, len(entries))
+				}
+				if entries[0].Matcher != tt.wantMatcher {
+					t.Errorf("Matcher = %q, want %q", entries[0].Matcher, tt.wantMatcher)
+				}
+			} else if len(geminiCfg.Hooks) != 0 {
+				t.Errorf("Expected no hooks for unsupported event, got %v", geminiCfg.Hooks)
+			}
+		})
+	}
+}
+
+func TestAdapterPromptHooksIgnored(t *testing.T) {
+	adapter := NewAdapter()
+
+	// Gemini doesn't support prompt hooks, only command hooks
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeCommand, core.NewPromptHook("Is this safe?"))
+
+	geminiCfg := adapter.FromCore(cfg)
+	if len(geminiCfg.Hooks) != 0 {
+		t.Errorf("Expected 0 hooks (prompt hooks not supported), got %v", geminiCfg.Hooks)
+	}
+}
+
+func TestAdapterDisabledEventOmitted(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddHook(core.OnStop, core.NewCommandHook("echo stop"))
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("echo command"))
+	cfg.DisableEvent(core.OnStop)
+
+	geminiCfg := adapter.FromCore(cfg)
+	if _, ok := geminiCfg.Hooks[AfterAgent]; ok {
+		t.Error("Disabled event should be omitted")
+	}
+	if len(geminiCfg.Hooks[BeforeTool]) != 1 {
+		t.Errorf("Expected enabled BeforeTool hook, got %v", geminiCfg.Hooks)
+	}
+}
+
+func TestAdapterToCoreSkipsUnknownEvents(t *testing.T) {
+	adapter := NewAdapter()
+
+	geminiCfg := &Config{
+		Hooks: map[GeminiEvent][]HookEntry{
+			"UnknownEvent": {{Hooks: []Hook{{Type: "command", Command: "echo unknown"}}}},
+			SessionEnd:     {{Hooks: []Hook{{Type: "command", Command: "echo known"}}}},
+		},
+	}
+
+	cfg := adapter.ToCore(geminiCfg)
+
+	// Should only have 1 hook (unknown event skipped)
+	if cfg.HookCount() != 1 {
+		t.Errorf("Expected 1 hook, got %d", cfg.HookCount())
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	cfg := NewConfig()
+	if cfg == nil {
+		t.Fatal("NewConfig returned nil")
+	}
+	if cfg.Hooks == nil {
+		t.Error("Hooks map should be initialized")
+	}
+}
+
+func TestProjectConfigPath(t *testing.T) {
+	path := ProjectConfigPath()
+	expected := filepath.Join(ProjectConfigDir, SettingsFileName)
+	if path != expected {
+		t.Errorf("ProjectConfigPath() = %q, want %q", path, expected)
+	}
+}
@@ -0,0 +1,193 @@
+// Package gemini provides an adapter for Gemini CLI hooks configuration.
+//
+// Gemini hooks are configured in the "hooks" section of settings.json files:
+//   - Project: .gemini/settings.json
+//   - User: ~/.gemini/settings.json
+//   - System: /etc/gemini-cli/settings.json (Linux)
+//
+// Gemini hook events:
+//   - BeforeTool: Before tool execution (can block)
+//   - AfterTool: After tool execution
+//   - BeforeAgent: After the user submits a prompt, before planning
+//   - AfterAgent: When the agent loop ends
+//   - SessionStart: At session start
+//   - SessionEnd: At session end
+//   - Notification: When notifications are sent
+//   - PreCompress: Before context compression
+package gemini
+
+import (
+	"encoding/json"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+)
+
+// GeminiEvent represents Gemini-specific hook event names.
+type GeminiEvent string
+
+const (
+	BeforeTool   GeminiEvent = "BeforeTool"
+	AfterTool    GeminiEvent = "AfterTool"
+	BeforeAgent  GeminiEvent = "BeforeAgent"
+	AfterAgent   GeminiEvent = "AfterAgent"
+	SessionStart GeminiEvent = "SessionStart"
+	SessionEnd   GeminiEvent = "SessionEnd"
+	Notification GeminiEvent = "Notification"
+	PreCompress  GeminiEvent = "PreCompress"
+)
+
+// Config represents the hooks section of Gemini's settings.json.
+type Config struct {
+	Hooks map[GeminiEvent][]HookEntry `json:"hooks,omitempty"`
+
+	// RawExtra holds the other top-level settings.json keys (model, mcpServers,
+	// tools, etc.) so they survive a read-modify-write.
+	RawExtra map[string]json.RawMessage `json:"-"`
+}
+
+// knownKeys are the settings.json keys handled by Config fields.
+var knownKeys = map[string]bool{
+	"hooks": true,
+}
+
+// UnmarshalJSON implements json.Unmarshaler, capturing non-hook keys in RawExtra.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type Alias Config
+	if err := json.Unmarshal(data, (*Alias)(c)); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for key, value := range raw {
+		if knownKeys[key] {
+			continue
+		}
+		if c.RawExtra == nil {
+			c.RawExtra = make(map[string]json.RawMessage)
+		}
+		c.RawExtra[key] = value
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, re-emitting keys held in RawExtra.
+func (c *Config) MarshalJSON() ([]byte, error) {
+	type Alias Config
+	data, err := json.Marshal((*Alias)(c))
+	if err != nil {
+		return nil, err
+	}
+	if len(c.RawExtra) == 0 {
+		return data, nil
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range c.RawExtra {
+		if knownKeys[key] {
+			continue
+		}
+		sorted, err := canonicaljson.SortRaw(value)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = sorted
+	}
+	return json.Marshal(merged)
+}
+
+// HookEntry represents a Gemini hook entry with matcher and hooks.
+type HookEntry struct {
+	// Matcher filters which tools trigger BeforeTool/AfterTool hooks.
+	// Examples: "run_shell_command", "write_file|replace"
+	Matcher string `json:"matcher,omitempty"`
+
+	// Hooks is the list of hooks to execute.
+	Hooks []Hook `json:"hooks"`
+}
+
+// Hook represents a single Gemini hook definition.
+type Hook struct {
+	// Type is always "command"; Gemini has no prompt hooks.
+	Type string `json:"type"`
+
+	// Command is the shell command to execute.
+	Command string `json:"command"`
+
+	// Timeout in milliseconds for hook execution.
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// NewConfig creates a new empty Gemini hooks config.
+func NewConfig() *Config {
+	return &Config{
+		Hooks: make(map[GeminiEvent][]HookEntry),
+	}
+}
+
+// eventMapping maps canonical events to Gemini events.
+var eventMapping = map[core.Event]GeminiEvent{
+	core.BeforeFileRead:  BeforeTool, // with matcher "read_file|read_many_files"
+	core.AfterFileRead:   AfterTool,  // with matcher "read_file|read_many_files"
+	core.BeforeFileWrite: BeforeTool, // with matcher "write_file|replace"
+	core.AfterFileWrite:  AfterTool,  // with matcher "write_file|replace"
+	core.BeforeCommand:   BeforeTool, // with matcher "run_shell_command"
+	core.AfterCommand:    AfterTool,  // with matcher "run_shell_command"
+	core.BeforeMCP:       BeforeTool, // with MCP tool matcher
+	core.AfterMCP:        AfterTool,  // with MCP tool matcher
+	core.BeforePrompt:    BeforeAgent,
+	core.OnStop:          AfterAgent,
+	core.OnSessionStart:  SessionStart,
+	core.OnSessionEnd:    SessionEnd,
+	core.OnNotification:  Notification,
+	core.BeforeCompact:   PreCompress,
+}
+
+// reverseEventMapping maps Gemini events back to canonical events.
+// Note: BeforeTool/AfterTool need matcher context to determine exact canonical event.
+var reverseEventMapping = map[GeminiEvent]core.Event{
+	BeforeAgent:  core.BeforePrompt,
+	AfterAgent:   core.OnStop,
+	SessionStart: core.OnSessionStart,
+	SessionEnd:   core.OnSessionEnd,
+	Notification: core.OnNotification,
+	PreCompress:  core.BeforeCompact,
+}
+
+// matcherToCanonicalEventBefore maps matchers to canonical events for BeforeTool.
+var matcherToCanonicalEventBefore = map[string]core.Event{
+	"read_file":                 core.BeforeFileRead,
+	"read_many_files":           core.BeforeFileRead,
+	"read_file|read_many_files": core.BeforeFileRead,
+	"write_file":                core.BeforeFileWrite,
+	"replace":                   core.BeforeFileWrite,
+	"write_file|replace":        core.BeforeFileWrite,
+	"run_shell_command":         core.BeforeCommand,
+}
+
+// matcherToCanonicalEventAfter maps matchers to canonical events for AfterTool.
+var matcherToCanonicalEventAfter = map[string]core.Event{
+	"read_file":                 core.AfterFileRead,
+	"read_many_files":           core.AfterFileRead,
+	"read_file|read_many_files": core.AfterFileRead,
+	"write_file":                core.AfterFileWrite,
+	"replace":                   core.AfterFileWrite,
+	"write_file|replace":        core.AfterFileWrite,
+	"run_shell_command":         core.AfterCommand,
+}
+
+// canonicalEventToMatcher maps canonical events to Gemini matchers.
+var canonicalEventToMatcher = map[core.Event]string{
+	core.BeforeFileRead:  "read_file|read_many_files",
+	core.AfterFileRead:   "read_file|read_many_files",
+	core.BeforeFileWrite: "write_file|replace",
+	core.AfterFileWrite:  "write_file|replace",
+	core.BeforeCommand:   "run_shell_command",
+	core.AfterCommand:    "run_shell_command",
+}
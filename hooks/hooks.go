@@ -8,6 +8,7 @@
 //   - Claude Code (.claude/settings.json)
 //   - Cursor IDE (.cursor/hooks.json)
 //   - Windsurf / Codeium (.windsurf/hooks.json)
+//   - Gemini CLI (.gemini/settings.json)
 //
 // The package provides:
 //   - A canonical Config type that represents hook configuration
@@ -35,6 +36,7 @@ import (
 	// Import adapters to register them
 	_ "github.com/agentplexus/assistantkit/hooks/claude"
 	_ "github.com/agentplexus/assistantkit/hooks/cursor"
+	_ "github.com/agentplexus/assistantkit/hooks/gemini"
 	_ "github.com/agentplexus/assistantkit/hooks/windsurf"
 )
 
@@ -65,6 +67,19 @@ const (
 	HookTypePrompt  = core.HookTypePrompt
 )
 
+// Operating system constants for Hook.OS
+const (
+	OSDarwin  = core.OSDarwin
+	OSLinux   = core.OSLinux
+	OSWindows = core.OSWindows
+)
+
+// Prompt placeholder constants for Hook.Prompt
+const (
+	PlaceholderTool    = core.PlaceholderTool
+	PlaceholderCommand = core.PlaceholderCommand
+)
+
 // Event constants - File operations
 const (
 	BeforeFileRead  = core.BeforeFileRead
@@ -150,3 +165,8 @@ func SupportedTools() []string {
 func AllEvents() []Event {
 	return core.AllEvents()
 }
+
+// ReadFile reads a canonical hooks configuration from a JSON file.
+func ReadFile(path string) (*Config, error) {
+	return core.ReadFile(path)
+}
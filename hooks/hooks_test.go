@@ -5,7 +5,7 @@ import (
 )
 
 func TestGetAdapter(t *testing.T) {
-	adapters := []string{"claude", "cursor", "windsurf"}
+	adapters := []string{"claude", "cursor", "gemini", "windsurf"}
 
 	for _, name := range adapters {
 		t.Run(name, func(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"runtime"
 
 	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
 )
 
 const (
@@ -83,7 +84,7 @@ func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 // Marshal converts canonical config to Windsurf format.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
 	windsurfCfg := a.FromCore(cfg)
-	return json.MarshalIndent(windsurfCfg, "", "  ")
+	return canonicaljson.Marshal(windsurfCfg)
 }
 
 // ReadFile reads a Windsurf hooks config file.
@@ -151,6 +152,9 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 		if !ok {
 			continue // Event not supported by Windsurf
 		}
+		if cfg.IsEventDisabled(event) {
+			continue // Windsurf has no per-event disable setting, so omit the hooks
+		}
 
 		for _, entry := range entries {
 			for _, h := range entry.Hooks {
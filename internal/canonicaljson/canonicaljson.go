@@ -0,0 +1,167 @@
+// Package canonicaljson provides the deterministic JSON encoding shared by
+// the canonical and tool-specific JSON writers.
+//
+// Output is byte-stable across runs: struct fields keep their declaration
+// order, map keys are sorted at every nesting level, HTML characters are not
+// escaped (so shell commands such as "a && b" stay readable), and the
+// document ends with a newline.
+package canonicaljson
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// Indent is the indentation used for canonical JSON documents.
+const Indent = "  "
+
+// Marshal returns the canonical, indented JSON encoding of v.
+func Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", Indent)
+	// encoding/json sorts map keys, including maps nested in slices and structs.
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalOrdered is like Marshal, but emits the keys of the object held in
+// v's top-level field in the given order instead of sorting them. Keys not
+// listed in order follow in sorted order. Top-level fields keep the order
+// Marshal gives them.
+func MarshalOrdered(v any, field string, order []string) ([]byte, error) {
+	data, err := Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	top, err := decodeObject(data)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := top.values[field]
+	if !ok || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return data, nil
+	}
+	inner, err := decodeObject(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(inner.keys))
+	listed := make(map[string]bool, len(order))
+	for _, key := range order {
+		if _, ok := inner.values[key]; ok && !listed[key] {
+			keys = append(keys, key)
+			listed[key] = true
+		}
+	}
+	for _, key := range inner.keys {
+		if !listed[key] {
+			keys = append(keys, key)
+		}
+	}
+	inner.keys = keys
+
+	encoded, err := inner.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	top.values[field] = encoded
+	return Marshal(top)
+}
+
+// MergeObject adds the keys of extra to the JSON object in data. Keys data
+// already has are left unchanged; new keys follow the existing ones in
+// sorted order. The result is encoded as by Marshal.
+func MergeObject(data []byte, extra map[string]any) ([]byte, error) {
+	obj, err := decodeObject(data)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		if _, ok := obj.values[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, err := Marshal(extra[key])
+		if err != nil {
+			return nil, err
+		}
+		obj.keys = append(obj.keys, key)
+		obj.values[key] = value
+	}
+	return Marshal(obj)
+}
+
+// object is a JSON object that keeps its keys in a fixed order.
+type object struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// decodeObject decodes a JSON object, recording its keys in document order.
+func decodeObject(data []byte) (*object, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	obj := &object{values: make(map[string]json.RawMessage)}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		obj.keys = append(obj.keys, key)
+		obj.values[key] = value
+	}
+	return obj, nil
+}
+
+// MarshalJSON writes the object's keys in order.
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(key); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1) // Encode appends a newline
+		buf.WriteByte(':')
+		buf.Write(o.values[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// SortRaw re-encodes raw JSON with its object keys sorted. It is used for
+// values kept verbatim from input files, whose key order would otherwise be
+// whatever the source file used.
+func SortRaw(data json.RawMessage) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,100 @@
+package canonicaljson
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type server struct {
+	Command string            `json:"command"`
+	Env     map[string]string `json:"env,omitempty"`
+}
+
+type config struct {
+	Name    string            `json:"name"`
+	Servers map[string]server `json:"servers"`
+}
+
+func TestMarshalIsDeterministic(t *testing.T) {
+	build := func() config {
+		cfg := config{Name: "test", Servers: make(map[string]server)}
+		for _, name := range []string{"zeta", "alpha", "mid", "beta", "omega"} {
+			cfg.Servers[name] = server{
+				Command: "./" + name + " && echo ok",
+				Env:     map[string]string{"Z_VAR": "1", "A_VAR": "2", "M_VAR": "3"},
+			}
+		}
+		return cfg
+	}
+
+	first, err := Marshal(build())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		again, err := Marshal(build())
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if !bytes.Equal(first, again) {
+			t.Fatalf("expected identical output, got:\n%s\nvs\n%s", first, again)
+		}
+	}
+
+	out := string(first)
+	if strings.Index(out, `"alpha"`) > strings.Index(out, `"zeta"`) {
+		t.Error("expected map keys to be sorted")
+	}
+	if strings.Index(out, `"name"`) > strings.Index(out, `"servers"`) {
+		t.Error("expected struct fields to keep declaration order")
+	}
+	if !strings.Contains(out, "&&") {
+		t.Error("expected HTML characters to be left unescaped")
+	}
+	if !strings.HasSuffix(out, "}\n") {
+		t.Error("expected output to end with a newline")
+	}
+}
+
+func TestSortRaw(t *testing.T) {
+	sorted, err := SortRaw(json.RawMessage(`{"b": 1, "a": {"d": 2.5, "c": [3]}}`))
+	if err != nil {
+		t.Fatalf("SortRaw failed: %v", err)
+	}
+	if string(sorted) != `{"a":{"c":[3],"d":2.5},"b":1}` {
+		t.Errorf("unexpected output: %s", sorted)
+	}
+}
+
+func TestMarshalOrdered(t *testing.T) {
+	cfg := config{Name: "test", Servers: map[string]server{
+		"alpha": {Command: "a && b"},
+		"beta":  {Command: "b"},
+		"zeta":  {Command: "z"},
+	}}
+
+	got, err := MarshalOrdered(cfg, "servers", []string{"zeta", "missing"})
+	if err != nil {
+		t.Fatalf("MarshalOrdered failed: %v", err)
+	}
+	want := `{
+  "name": "test",
+  "servers": {
+    "zeta": {
+      "command": "z"
+    },
+    "alpha": {
+      "command": "a && b"
+    },
+    "beta": {
+      "command": "b"
+    }
+  }
+}
+`
+	if string(got) != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+// Package capability builds the warnings shared by agents, commands, and
+// skills when a spec uses a feature the target tool may not support.
+package capability
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/internal/passthrough"
+)
+
+// Experimental returns a warning when options are set: they are passed to
+// tool unvalidated, or ignored when tool has no passthrough. kind names the
+// component ("agent", "command", "skill").
+func Experimental(kind, name, tool string, options map[string]any, supported bool) []string {
+	if len(options) == 0 {
+		return nil
+	}
+	keys := strings.Join(passthrough.Keys(options), ", ")
+	if !supported {
+		return []string{fmt.Sprintf("%s %q: experimental options (%s) are not supported by %s and were ignored", kind, name, keys, tool)}
+	}
+	return []string{fmt.Sprintf("%s %q: experimental options (%s) are passed to %s unvalidated", kind, name, keys, tool)}
+}
+
+// Disabled returns a warning when a component that is disabled by default
+// is generated for a tool with no disabled marker.
+func Disabled(kind, name, tool string, enabled, supported bool) []string {
+	if enabled || supported {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s %q: disabled by default is not supported by %s; it is generated enabled", kind, name, tool)}
+}
@@ -0,0 +1,34 @@
+package capability
+
+import "testing"
+
+func TestExperimental(t *testing.T) {
+	if got := Experimental("agent", "reviewer", "claude", nil, true); got != nil {
+		t.Errorf("expected no warning without options, got %v", got)
+	}
+	options := map[string]any{"zeta": 1, "alpha": true}
+	got := Experimental("agent", "reviewer", "claude", options, true)
+	want := `agent "reviewer": experimental options (alpha, zeta) are passed to claude unvalidated`
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("Experimental() = %v, want %q", got, want)
+	}
+	got = Experimental("skill", "lint", "kiro", options, false)
+	want = `skill "lint": experimental options (alpha, zeta) are not supported by kiro and were ignored`
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("Experimental() = %v, want %q", got, want)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	if got := Disabled("command", "deploy", "gemini", true, false); got != nil {
+		t.Errorf("expected no warning when enabled, got %v", got)
+	}
+	if got := Disabled("command", "deploy", "claude", false, true); got != nil {
+		t.Errorf("expected no warning when supported, got %v", got)
+	}
+	got := Disabled("command", "deploy", "gemini", false, false)
+	want := `command "deploy": disabled by default is not supported by gemini; it is generated enabled`
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("Disabled() = %v, want %q", got, want)
+	}
+}
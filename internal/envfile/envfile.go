@@ -0,0 +1,120 @@
+// Package envfile loads dotenv-style files and expands ${VAR} placeholders.
+package envfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// LookupFunc resolves a variable name to its value.
+type LookupFunc func(name string) (string, bool)
+
+// placeholder matches ${VAR} references. Bare $VAR references are left alone
+// because hook commands rely on them being expanded by the shell at run time.
+var placeholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Load reads KEY=VALUE pairs from a .env file. Blank lines and # comments are
+// ignored, an optional "export " prefix is accepted, and values may be wrapped
+// in single or double quotes.
+func Load(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses .env file content.
+func Parse(data []byte) (map[string]string, error) {
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("line %d: empty key", lineNum)
+		}
+		vars[key] = unquote(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// unquote strips matching surrounding quotes.
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// Lookup returns a LookupFunc that resolves from the process environment
+// first and falls back to vars.
+func Lookup(vars map[string]string) LookupFunc {
+	return func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := vars[name]
+		return value, ok
+	}
+}
+
+// Expand replaces ${VAR} placeholders in s using lookup. Placeholders that
+// cannot be resolved are left unchanged.
+func Expand(s string, lookup LookupFunc) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	return placeholder.ReplaceAllStringFunc(s, func(match string) string {
+		name := match[2 : len(match)-1]
+		if value, ok := lookup(name); ok {
+			return value
+		}
+		return match
+	})
+}
+
+// ExpandAll expands placeholders in each string of values, returning a new slice.
+func ExpandAll(values []string, lookup LookupFunc) []string {
+	if values == nil {
+		return nil
+	}
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = Expand(v, lookup)
+	}
+	return out
+}
+
+// ExpandMap expands placeholders in each value of m, returning a new map.
+func ExpandMap(m map[string]string, lookup LookupFunc) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = Expand(v, lookup)
+	}
+	return out
+}
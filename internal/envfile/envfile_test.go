@@ -0,0 +1,52 @@
+package envfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAndExpand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	content := `# API settings
+API_TOKEN=from-file
+export REGION="us-east-1"
+GREETING='hello world'
+SHADOWED=file-value
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SHADOWED", "process-value")
+
+	vars, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if vars["REGION"] != "us-east-1" || vars["GREETING"] != "hello world" {
+		t.Errorf("unexpected parsed vars: %v", vars)
+	}
+
+	lookup := Lookup(vars)
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Bearer ${API_TOKEN}", "Bearer from-file"},
+		{"${REGION}", "us-east-1"},
+		{"${SHADOWED}", "process-value"},
+		{"${UNSET_VARIABLE_FOR_TEST}", "${UNSET_VARIABLE_FOR_TEST}"},
+		{"echo $API_TOKEN", "echo $API_TOKEN"},
+	}
+	for _, tt := range tests {
+		if got := Expand(tt.in, lookup); got != tt.want {
+			t.Errorf("Expand(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalidLine(t *testing.T) {
+	if _, err := Parse([]byte("VALID=1\nnot a pair\n")); err == nil {
+		t.Error("expected error for line without '='")
+	}
+}
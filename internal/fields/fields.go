@@ -0,0 +1,50 @@
+// Package fields compares the JSON fields of canonical definitions, to
+// report what a conversion between formats dropped.
+package fields
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Dropped returns warnings for fields set on original that are empty on
+// converted, the result of converting original to the target format.
+func Dropped(original, converted any, target string) []string {
+	before, err := NonEmpty(original)
+	if err != nil {
+		return nil
+	}
+	after, err := NonEmpty(converted)
+	if err != nil {
+		return nil
+	}
+
+	var warnings []string
+	for field := range before {
+		if _, ok := after[field]; !ok {
+			warnings = append(warnings, fmt.Sprintf("field %q is not supported by %s and was dropped", field, target))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// NonEmpty returns the non-empty JSON fields of v.
+func NonEmpty(v any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		switch string(value) {
+		case `""`, "null", "[]", "{}", "0", "false":
+			delete(fields, key)
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,37 @@
+package fields
+
+import (
+	"strings"
+	"testing"
+)
+
+type def struct {
+	Name  string   `json:"name"`
+	Tools []string `json:"tools"`
+	Model string   `json:"model,omitempty"`
+	Order int      `json:"order"`
+}
+
+func TestDropped(t *testing.T) {
+	original := &def{Name: "reviewer", Tools: []string{"Read"}, Model: "opus", Order: 1}
+	converted := &def{Name: "reviewer", Order: 1}
+
+	warnings := Dropped(original, converted, "codex")
+	want := []string{
+		`field "model" is not supported by codex and was dropped`,
+		`field "tools" is not supported by codex and was dropped`,
+	}
+	if strings.Join(warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Dropped = %v, want %v", warnings, want)
+	}
+}
+
+func TestNonEmpty(t *testing.T) {
+	fields, err := NonEmpty(&def{Name: "reviewer", Tools: []string{}})
+	if err != nil {
+		t.Fatalf("NonEmpty failed: %v", err)
+	}
+	if len(fields) != 1 || string(fields["name"]) != `"reviewer"` {
+		t.Errorf("NonEmpty = %v, want only name", fields)
+	}
+}
@@ -0,0 +1,39 @@
+// Package frontmatter splits Markdown documents into a frontmatter block and
+// a body, and quotes values written into YAML frontmatter.
+package frontmatter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Split splits frontmatter enclosed by delimiter lines (e.g., "---" or
+// "+++") from the body. Only a line consisting of the delimiter closes the
+// block, so the delimiter may appear inside values or the body.
+func Split(data []byte, delimiter string) (frontmatter, body []byte, err error) {
+	lines := strings.Split(string(data), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != delimiter {
+		return nil, nil, fmt.Errorf("missing frontmatter delimiter %q", delimiter)
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == delimiter {
+			frontmatter = []byte(strings.Join(lines[1:i], "\n"))
+			body = []byte(strings.Join(lines[i+1:], "\n"))
+			return frontmatter, body, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("missing closing frontmatter delimiter %q", delimiter)
+}
+
+// QuoteValue double-quotes a frontmatter value when it would otherwise be
+// ambiguous as a plain YAML scalar.
+func QuoteValue(s string) string {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s[:1], "#\"'") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") {
+		return strconv.Quote(s)
+	}
+	return s
+}
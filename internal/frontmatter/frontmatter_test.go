@@ -0,0 +1,41 @@
+package frontmatter
+
+import "testing"
+
+func TestSplit(t *testing.T) {
+	data := []byte("+++\ntitle = \"a+++b\"\n+++\n\nBody with +++ inside.\n+++\n")
+	frontmatter, body, err := Split(data, "+++")
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if string(frontmatter) != "title = \"a+++b\"" {
+		t.Errorf("frontmatter = %q", frontmatter)
+	}
+	if string(body) != "\nBody with +++ inside.\n+++\n" {
+		t.Errorf("body = %q", body)
+	}
+
+	if _, _, err := Split([]byte("no frontmatter"), "---"); err == nil {
+		t.Error("expected an error without an opening delimiter")
+	}
+	if _, _, err := Split([]byte("---\nname: x\n"), "---"); err == nil {
+		t.Error("expected an error without a closing delimiter")
+	}
+}
+
+func TestQuoteValue(t *testing.T) {
+	for in, want := range map[string]string{
+		"plain value": "plain value",
+		"":            `""`,
+		" padded":     `" padded"`,
+		"#tag":        `"#tag"`,
+		`"quoted"`:    `"\"quoted\""`,
+		"key: value":  `"key: value"`,
+		"see # note":  `"see # note"`,
+		"a#b and c:d": "a#b and c:d",
+	} {
+		if got := QuoteValue(in); got != want {
+			t.Errorf("QuoteValue(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
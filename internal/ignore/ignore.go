@@ -0,0 +1,167 @@
+// Package ignore matches spec paths against a .assistantkitignore file.
+//
+// The file uses gitignore syntax: blank lines and # comments are skipped,
+// a leading ! re-includes a path, a trailing / matches only directories,
+// a pattern containing a / is anchored to the specs directory, and * ? [..]
+// and ** wildcards are supported.
+package ignore
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FileName is the ignore file read from a specs directory.
+const FileName = ".assistantkitignore"
+
+// Matcher reports whether paths are ignored. A nil Matcher ignores nothing.
+type Matcher struct {
+	rules []rule
+}
+
+type rule struct {
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Load reads the ignore file in root. A missing file yields a nil Matcher.
+func Load(root string) (*Matcher, error) {
+	data, err := os.ReadFile(filepath.Join(root, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses ignore file content.
+func Parse(data []byte) (*Matcher, error) {
+	m := &Matcher{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var r rule
+		if strings.HasPrefix(line, "!") {
+			r.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			r.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		if line == "" {
+			continue
+		}
+
+		expr := globToRegexp(line)
+		if !anchored {
+			expr = "(?:.*/)?" + expr
+		}
+		re, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			return nil, err
+		}
+		r.pattern = re
+		m.rules = append(m.rules, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Match reports whether rel, a slash-separated path relative to the specs
+// directory, is ignored. As in git, a path inside an ignored directory is
+// ignored too.
+func (m *Matcher) Match(rel string, isDir bool) bool {
+	if m == nil {
+		return false
+	}
+	rel = strings.Trim(filepath.ToSlash(rel), "/")
+
+	// Check each parent directory first; files under an ignored directory
+	// cannot be re-included.
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		if m.match(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return m.match(rel, isDir)
+}
+
+// match applies the rules to a single path; the last matching rule wins.
+func (m *Matcher) match(rel string, isDir bool) bool {
+	ignored := false
+	for _, r := range m.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if r.pattern.MatchString(rel) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// globToRegexp converts a gitignore glob to a regular expression.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					// "**/" matches zero or more directories.
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
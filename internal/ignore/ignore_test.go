@@ -0,0 +1,69 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	m, err := Parse([]byte(`# editor backups
+*.bak
+*~
+
+_*.md
+!agents/_keep.md
+/drafts/
+agents/**/wip-*.md
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"agents/reviewer.md", false, false},
+		{"agents/reviewer.md.bak", false, true},
+		{"skills/review/skill.json~", false, true},
+		{"agents/_partial.md", false, true},
+		{"agents/_keep.md", false, false},
+		{"drafts", true, true},
+		{"drafts/agent.md", false, true},
+		{"agents/drafts", true, false},
+		{"agents/wip-reviewer.md", false, true},
+		{"agents/team/wip-reviewer.md", false, true},
+		{"commands/wip-release.md", false, false},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Match(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	m, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Match("agents/reviewer.md", false) {
+		t.Error("expected a missing ignore file to ignore nothing")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("*.bak\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	m, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Match("agents/reviewer.md.bak", false) {
+		t.Error("expected *.bak to be ignored")
+	}
+}
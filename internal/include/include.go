@@ -0,0 +1,108 @@
+// Package include resolves {{include: path}} directives that inline shared
+// markdown fragments into instruction text.
+package include
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MaxDepth is the deepest chain of nested includes Resolve follows.
+const MaxDepth = 10
+
+var (
+	// ErrCycle is returned when a fragment includes itself, directly or indirectly.
+	ErrCycle = errors.New("include cycle")
+
+	// ErrMaxDepth is returned when includes nest deeper than MaxDepth.
+	ErrMaxDepth = errors.New("include depth exceeded")
+
+	// ErrOutsideRoot is returned for include paths that are absolute or
+	// escape the spec root.
+	ErrOutsideRoot = errors.New("include path must stay within the spec root")
+)
+
+// directive matches {{include: path}}, allowing spaces around the path.
+var directive = regexp.MustCompile(`\{\{\s*include:\s*([^}]*?)\s*\}\}`)
+
+// Error reports a failed include with the chain of fragments that led to it.
+type Error struct {
+	// Chain lists the included paths, outermost first, ending with the
+	// fragment that failed.
+	Chain []string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("include %s: %v", strings.Join(e.Chain, " -> "), e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Resolve replaces each {{include: path}} directive in text with the contents
+// of the file at path, relative to root. Included fragments may contain
+// further directives; a trailing newline on each fragment is dropped so the
+// directive can sit inline.
+func Resolve(text, root string) (string, error) {
+	return resolve(text, root, nil)
+}
+
+func resolve(text, root string, chain []string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	var resolveErr error
+	out := directive.ReplaceAllStringFunc(text, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+
+		rel := filepath.ToSlash(filepath.Clean(directive.FindStringSubmatch(match)[1]))
+		next := append(append([]string(nil), chain...), rel)
+
+		switch {
+		case filepath.IsAbs(rel) || strings.HasPrefix(rel, "/") || rel == ".." || strings.HasPrefix(rel, "../"):
+			resolveErr = &Error{Chain: next, Err: ErrOutsideRoot}
+			return match
+		case contains(chain, rel):
+			resolveErr = &Error{Chain: next, Err: ErrCycle}
+			return match
+		case len(chain) >= MaxDepth:
+			resolveErr = &Error{Chain: next, Err: ErrMaxDepth}
+			return match
+		}
+
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			resolveErr = &Error{Chain: next, Err: err}
+			return match
+		}
+
+		content, err := resolve(strings.TrimRight(string(data), "\n"), root, next)
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+		return content
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return out, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
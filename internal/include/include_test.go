@@ -0,0 +1,73 @@
+package include
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFragments(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestResolveInlinesFragments(t *testing.T) {
+	root := writeFragments(t, map[string]string{
+		"fragments/style.md": "Use short sentences.\n{{include: fragments/tone.md}}\n",
+		"fragments/tone.md":  "Be direct.\n",
+	})
+
+	got, err := Resolve("# Reviewer\n\n{{include: fragments/style.md}}\n\nReview the diff.", root)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	want := "# Reviewer\n\nUse short sentences.\nBe direct.\n\nReview the diff."
+	if got != want {
+		t.Errorf("Resolve = %q, want %q", got, want)
+	}
+}
+
+func TestResolveCycle(t *testing.T) {
+	root := writeFragments(t, map[string]string{
+		"a.md": "A {{include: b.md}}",
+		"b.md": "B {{ include: a.md }}",
+	})
+
+	_, err := Resolve("{{include: a.md}}", root)
+	if !errors.Is(err, ErrCycle) {
+		t.Fatalf("expected ErrCycle, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "a.md -> b.md -> a.md") {
+		t.Errorf("expected the include chain in the error, got %q", err)
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	files := map[string]string{}
+	for i := 0; i <= MaxDepth; i++ {
+		files[filepath.Join("deep", string(rune('a'+i))+".md")] = "{{include: deep/" + string(rune('a'+i+1)) + ".md}}"
+	}
+	root := writeFragments(t, files)
+
+	if _, err := Resolve("{{include: deep/a.md}}", root); !errors.Is(err, ErrMaxDepth) {
+		t.Errorf("expected ErrMaxDepth, got %v", err)
+	}
+	if _, err := Resolve("{{include: ../secrets.md}}", root); !errors.Is(err, ErrOutsideRoot) {
+		t.Errorf("expected ErrOutsideRoot, got %v", err)
+	}
+	if _, err := Resolve("{{include: missing.md}}", root); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a not-exist error, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+// Package jsonc decodes relaxed JSON as written by hand: // line comments,
+// /* block */ comments, and trailing commas before } or ] are accepted.
+//
+// Comments and trailing commas are blanked out rather than removed, so
+// syntax error offsets still point into the original document.
+package jsonc
+
+import "encoding/json"
+
+// Standardize returns data with comments and trailing commas replaced by
+// spaces, leaving newlines in place. String literals are not changed.
+func Standardize(data []byte) []byte {
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	// lastComma is the offset of a comma that may turn out to be trailing,
+	// or -1 once anything other than whitespace or a comment follows it.
+	lastComma := -1
+	for i := 0; i < len(out); i++ {
+		switch c := out[i]; {
+		case c == '"':
+			lastComma = -1
+			for i++; i < len(out) && out[i] != '"'; i++ {
+				if out[i] == '\\' {
+					i++
+				}
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			out[i], out[i+1] = ' ', ' '
+			for i += 2; i < len(out); i++ {
+				if out[i] == '*' && i+1 < len(out) && out[i+1] == '/' {
+					out[i], out[i+1] = ' ', ' '
+					i++
+					break
+				}
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+		case c == ',':
+			lastComma = i
+		case c == '}' || c == ']':
+			if lastComma >= 0 {
+				out[lastComma] = ' '
+			}
+			lastComma = -1
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			lastComma = -1
+		}
+	}
+	return out
+}
+
+// Unmarshal decodes relaxed JSON data into v.
+func Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(Standardize(data), v)
+}
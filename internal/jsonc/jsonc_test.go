@@ -0,0 +1,56 @@
+package jsonc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnmarshalRelaxedJSON(t *testing.T) {
+	data := []byte(`{
+  // the agent name
+  "name": "reviewer", /* inline */
+  "url": "https://example.com/a//b",
+  "note": "keep /* this */ and \"quoted\", text",
+  "tools": [
+    "Read",
+    "Grep", // trailing comma follows
+  ],
+  /*
+   * multi-line
+   */
+}
+`)
+	var v struct {
+		Name  string   `json:"name"`
+		URL   string   `json:"url"`
+		Note  string   `json:"note"`
+		Tools []string `json:"tools"`
+	}
+	if err := Unmarshal(data, &v); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if v.Name != "reviewer" || v.URL != "https://example.com/a//b" || v.Note != `keep /* this */ and "quoted", text` {
+		t.Errorf("unexpected values: %+v", v)
+	}
+	if len(v.Tools) != 2 || v.Tools[1] != "Grep" {
+		t.Errorf("expected 2 tools, got %v", v.Tools)
+	}
+}
+
+func TestStandardizeKeepsOffsets(t *testing.T) {
+	data := []byte("{\n  // comment\n  \"a\": 1,\n  \"b\": x\n}")
+	out := Standardize(data)
+	if len(out) != len(data) {
+		t.Fatalf("expected length %d, got %d", len(data), len(out))
+	}
+
+	var v map[string]int
+	err := json.Unmarshal(out, &v)
+	syntaxErr, ok := err.(*json.SyntaxError)
+	if !ok {
+		t.Fatalf("expected a syntax error, got %v", err)
+	}
+	if data[syntaxErr.Offset-1] != 'x' {
+		t.Errorf("expected the error offset to point at the bad value, got %d", syntaxErr.Offset)
+	}
+}
@@ -0,0 +1,65 @@
+// Package passthrough writes experimental options verbatim into generated
+// tool configs. Options are not validated; a key the generator already
+// writes is never overridden.
+//
+// Agents, commands, and skills carry experimental options. Hooks and MCP
+// servers do not; their unmodeled tool settings are not passed through.
+package passthrough
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+)
+
+// Keys returns the option names, sorted.
+func Keys(options map[string]any) []string {
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MergeJSON adds options to the JSON object in data after its existing keys.
+// When there are no options, data is returned unchanged.
+func MergeJSON(data []byte, options map[string]any) ([]byte, error) {
+	if len(options) == 0 {
+		return data, nil
+	}
+	return canonicaljson.MergeObject(data, options)
+}
+
+// Frontmatter returns YAML frontmatter lines ("key: value\n") for options
+// whose keys are not already set in frontmatter. Values are written as JSON,
+// which YAML reads as flow scalars, sequences, and mappings.
+func Frontmatter(frontmatter string, options map[string]any) (string, error) {
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(frontmatter, "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '-' {
+			continue
+		}
+		if idx := strings.Index(line, ":"); idx > 0 {
+			existing[line[:idx]] = true
+		}
+	}
+
+	var buf strings.Builder
+	for _, key := range Keys(options) {
+		if existing[key] {
+			continue
+		}
+		var value bytes.Buffer
+		enc := json.NewEncoder(&value)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(options[key]); err != nil {
+			return "", err
+		}
+		buf.WriteString(key + ": " + strings.TrimSuffix(value.String(), "\n") + "\n")
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,35 @@
+package passthrough
+
+import "testing"
+
+func TestMergeJSON(t *testing.T) {
+	options := map[string]any{"zeta": 1, "name": "ignored", "beta": map[string]any{"on": true}}
+	got, err := MergeJSON([]byte(`{"name": "reviewer", "model": "sonnet"}`), options)
+	if err != nil {
+		t.Fatalf("MergeJSON failed: %v", err)
+	}
+	want := `{
+  "name": "reviewer",
+  "model": "sonnet",
+  "beta": {
+    "on": true
+  },
+  "zeta": 1
+}
+`
+	if string(got) != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFrontmatter(t *testing.T) {
+	options := map[string]any{"model": "opus", "effort": "high", "flags": []any{"a", "b"}}
+	got, err := Frontmatter("name: reviewer\nmodel: sonnet\ntools:\n  - Read\n", options)
+	if err != nil {
+		t.Fatalf("Frontmatter failed: %v", err)
+	}
+	want := "effort: \"high\"\nflags: [\"a\",\"b\"]\n"
+	if got != want {
+		t.Errorf("Frontmatter() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,320 @@
+// Package roundtrip checks that importing a tool-specific config into the
+// canonical form and exporting it back to the same tool is lossless.
+package roundtrip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/commands"
+	"github.com/agentplexus/assistantkit/hooks"
+	"github.com/agentplexus/assistantkit/mcp"
+	"github.com/agentplexus/assistantkit/plugins"
+	"github.com/agentplexus/assistantkit/skills"
+)
+
+var (
+	// ErrUnknownKind is returned for a config kind with no adapters.
+	ErrUnknownKind = errors.New("unknown kind")
+
+	// ErrUnknownTool is returned when no adapter of the kind exists for a tool.
+	ErrUnknownTool = errors.New("unknown tool")
+)
+
+// kind pairs adapter lookup with same-format conversion for one config kind.
+type kind struct {
+	known   func(tool string) bool
+	convert func(data []byte, from, to string) ([]byte, error)
+}
+
+var kinds = map[string]kind{
+	"agent": {
+		known:   func(tool string) bool { _, ok := agents.GetAdapter(tool); return ok },
+		convert: agents.Convert,
+	},
+	"command": {
+		known:   func(tool string) bool { _, ok := commands.GetAdapter(tool); return ok },
+		convert: commands.Convert,
+	},
+	"hooks": {
+		known:   func(tool string) bool { _, ok := hooks.GetAdapter(tool); return ok },
+		convert: hooks.Convert,
+	},
+	"mcp": {
+		known:   func(tool string) bool { _, ok := mcp.GetAdapter(tool); return ok },
+		convert: mcp.Convert,
+	},
+	"plugin": {
+		known:   func(tool string) bool { _, ok := plugins.GetAdapter(tool); return ok },
+		convert: plugins.Convert,
+	},
+	"skill": {
+		known:   func(tool string) bool { _, ok := skills.GetAdapter(tool); return ok },
+		convert: skills.Convert,
+	},
+}
+
+// Kinds returns the supported config kinds sorted alphabetically.
+func Kinds() []string {
+	names := make([]string, 0, len(kinds))
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Difference is a single mismatch between the original config and its
+// round-tripped form. An empty Original means the value was added; an empty
+// RoundTrip means it was dropped.
+type Difference struct {
+	// Path locates the value: a JSON/TOML key path (e.g., "hooks.PreToolUse[0].matcher")
+	// or a line reference for text formats (e.g., "line 4").
+	Path string
+
+	// Original is the JSON-encoded value (or line) in the input.
+	Original string
+
+	// RoundTrip is the value after parsing and marshaling again.
+	RoundTrip string
+}
+
+// String formats the difference as "- path: value" (dropped),
+// "+ path: value" (added), or "~ path: old -> new" (changed).
+func (d Difference) String() string {
+	switch {
+	case d.RoundTrip == "":
+		return fmt.Sprintf("- %s: %s", d.Path, d.Original)
+	case d.Original == "":
+		return fmt.Sprintf("+ %s: %s", d.Path, d.RoundTrip)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", d.Path, d.Original, d.RoundTrip)
+	}
+}
+
+// Result is the outcome of a round-trip check.
+type Result struct {
+	Kind        string
+	Tool        string
+	Output      []byte
+	Differences []Difference
+}
+
+// Lossless reports whether the round trip reproduced the original.
+func (r *Result) Lossless() bool {
+	return len(r.Differences) == 0
+}
+
+// Verify parses data as the tool's config of the given kind, marshals it back
+// to the same tool format, and reports differences from the original.
+// JSON and TOML are compared structurally so key order and formatting are
+// ignored; other formats are compared line by line.
+func Verify(kindName, tool string, data []byte) (*Result, error) {
+	k, ok := kinds[kindName]
+	if !ok {
+		return nil, fmt.Errorf("%w %q (expected one of %s)", ErrUnknownKind, kindName, strings.Join(Kinds(), ", "))
+	}
+	if !k.known(tool) {
+		return nil, fmt.Errorf("%w %q for %s", ErrUnknownTool, tool, kindName)
+	}
+
+	out, err := k.convert(data, tool, tool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Kind:        kindName,
+		Tool:        tool,
+		Output:      out,
+		Differences: Diff(data, out),
+	}, nil
+}
+
+// Diff compares two encodings of a config. Both are decoded as JSON or TOML
+// when possible and compared structurally; otherwise lines are compared.
+func Diff(original, roundTrip []byte) []Difference {
+	if a, b, ok := decodeBoth(original, roundTrip, decodeJSON); ok {
+		return diffValues(a, b)
+	}
+	if a, b, ok := decodeBoth(original, roundTrip, decodeTOML); ok {
+		return diffValues(a, b)
+	}
+	return diffLines(original, roundTrip)
+}
+
+func decodeBoth(a, b []byte, decode func([]byte) (interface{}, error)) (interface{}, interface{}, bool) {
+	va, err := decode(a)
+	if err != nil {
+		return nil, nil, false
+	}
+	vb, err := decode(b)
+	if err != nil {
+		return nil, nil, false
+	}
+	return va, vb, true
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func decodeTOML(data []byte) (interface{}, error) {
+	var v map[string]interface{}
+	if err := toml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	// Normalize through JSON so numbers and nested tables compare like JSON.
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(normalized)
+}
+
+// diffValues walks two decoded values and collects differences.
+func diffValues(a, b interface{}) []Difference {
+	var diffs []Difference
+	walk("", a, b, &diffs)
+	return diffs
+}
+
+func walk(path string, a, b interface{}, diffs *[]Difference) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			keys := make(map[string]bool, len(av)+len(bv))
+			for key := range av {
+				keys[key] = true
+			}
+			for key := range bv {
+				keys[key] = true
+			}
+			sorted := make([]string, 0, len(keys))
+			for key := range keys {
+				sorted = append(sorted, key)
+			}
+			sort.Strings(sorted)
+
+			for _, key := range sorted {
+				childPath := joinPath(path, key)
+				ac, inA := av[key]
+				bc, inB := bv[key]
+				switch {
+				case !inB:
+					*diffs = append(*diffs, Difference{Path: childPath, Original: encode(ac)})
+				case !inA:
+					*diffs = append(*diffs, Difference{Path: childPath, RoundTrip: encode(bc)})
+				default:
+					walk(childPath, ac, bc, diffs)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			for i := 0; i < len(av) || i < len(bv); i++ {
+				childPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(bv):
+					*diffs = append(*diffs, Difference{Path: childPath, Original: encode(av[i])})
+				case i >= len(av):
+					*diffs = append(*diffs, Difference{Path: childPath, RoundTrip: encode(bv[i])})
+				default:
+					walk(childPath, av[i], bv[i], diffs)
+				}
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, Difference{Path: rootPath(path), Original: encode(a), RoundTrip: encode(b)})
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func rootPath(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func encode(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// diffLines compares text line by line using a longest common subsequence,
+// ignoring trailing whitespace.
+func diffLines(original, roundTrip []byte) []Difference {
+	a := splitLines(original)
+	b := splitLines(roundTrip)
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diffs []Difference
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j >= len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			diffs = append(diffs, Difference{Path: fmt.Sprintf("line %d", i+1), Original: encode(a[i])})
+			i++
+		default:
+			diffs = append(diffs, Difference{Path: fmt.Sprintf("line %d", j+1), RoundTrip: encode(b[j])})
+			j++
+		}
+	}
+	return diffs
+}
+
+func splitLines(data []byte) []string {
+	text := strings.TrimRight(string(data), "\n")
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return lines
+}
@@ -0,0 +1,73 @@
+package roundtrip
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVerifyLossless(t *testing.T) {
+	data := []byte(`{
+  "mcpServers": {
+    "github": {
+      "type": "stdio",
+      "command": "npx",
+      "args": ["-y", "@modelcontextprotocol/server-github"]
+    }
+  }
+}`)
+
+	result, err := Verify("mcp", "claude", data)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if !result.Lossless() {
+		t.Errorf("expected lossless round-trip, got %v", result.Differences)
+	}
+}
+
+func TestVerifyReportsDroppedField(t *testing.T) {
+	data := []byte(`{
+  "mcpServers": {
+    "github": {
+      "type": "stdio",
+      "command": "npx",
+      "unknownField": true
+    }
+  }
+}`)
+
+	result, err := Verify("mcp", "claude", data)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if len(result.Differences) != 1 {
+		t.Fatalf("expected 1 difference, got %v", result.Differences)
+	}
+	diff := result.Differences[0]
+	if diff.Path != "mcpServers.github.unknownField" || diff.RoundTrip != "" {
+		t.Errorf("expected dropped unknownField, got %+v", diff)
+	}
+	if !strings.HasPrefix(diff.String(), "- mcpServers.github.unknownField") {
+		t.Errorf("unexpected difference string %q", diff.String())
+	}
+}
+
+func TestVerifyUnknownKindAndTool(t *testing.T) {
+	if _, err := Verify("widgets", "claude", nil); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("expected ErrUnknownKind, got %v", err)
+	}
+	if _, err := Verify("mcp", "nope", nil); !errors.Is(err, ErrUnknownTool) {
+		t.Errorf("expected ErrUnknownTool, got %v", err)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	diffs := Diff([]byte("a\nb\nc\n"), []byte("a\nc\nd\n"))
+	if len(diffs) != 2 {
+		t.Fatalf("expected 2 differences, got %v", diffs)
+	}
+	if diffs[0].String() != `- line 2: "b"` || diffs[1].String() != `+ line 3: "d"` {
+		t.Errorf("unexpected differences %v", diffs)
+	}
+}
@@ -0,0 +1,70 @@
+// Package version compares dotted tool version strings such as "1.0.27".
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse splits a version like "v1.2.3" or "1.2.3-beta" into its numeric
+// components. A leading "v" and any pre-release or build suffix are ignored.
+func Parse(v string) ([]int, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	if s == "" {
+		return nil, fmt.Errorf("invalid version %q", v)
+	}
+
+	parts := strings.Split(s, ".")
+	nums := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version %q", v)
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
+
+// Compare returns -1, 0, or 1 as a is older than, equal to, or newer than b.
+// Missing components count as zero, so "1.2" equals "1.2.0".
+func Compare(a, b string) (int, error) {
+	va, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(va) || i < len(vb); i++ {
+		var x, y int
+		if i < len(va) {
+			x = va[i]
+		}
+		if i < len(vb) {
+			y = vb[i]
+		}
+		switch {
+		case x < y:
+			return -1, nil
+		case x > y:
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// AtLeast reports whether v is min or newer.
+func AtLeast(v, min string) (bool, error) {
+	c, err := Compare(v, min)
+	if err != nil {
+		return false, err
+	}
+	return c >= 0, nil
+}
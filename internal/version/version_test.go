@@ -0,0 +1,47 @@
+package version
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.27", "1.0.27", 0},
+		{"1.0.20", "1.0.27", -1},
+		{"1.1", "1.0.27", 1},
+		{"v2.0.0", "1.9.9", 1},
+		{"1.2", "1.2.0", 0},
+		{"1.0.27-beta", "1.0.27", 0},
+		{"1.10.0", "1.9.0", 1},
+	}
+
+	for _, tt := range tests {
+		got, err := Compare(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("Compare(%q, %q) failed: %v", tt.a, tt.b, err)
+		}
+		if got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, v := range []string{"", "latest", "1.x", "v"} {
+		if _, err := Parse(v); err == nil {
+			t.Errorf("Parse(%q): expected error", v)
+		}
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	ok, err := AtLeast("1.0.27", "1.0.27")
+	if err != nil || !ok {
+		t.Errorf("AtLeast(1.0.27, 1.0.27) = %v, %v", ok, err)
+	}
+	ok, err = AtLeast("0.2.0", "1.0.27")
+	if err != nil || ok {
+		t.Errorf("AtLeast(0.2.0, 1.0.27) = %v, %v", ok, err)
+	}
+}
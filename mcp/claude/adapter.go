@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+	"github.com/agentplexus/assistantkit/internal/version"
 	"github.com/agentplexus/assistantkit/mcp/core"
 )
 
@@ -23,6 +25,12 @@ const (
 	ManagedConfigFile = "managed-mcp.json"
 )
 
+// featureVersions lists the first Claude Code release supporting each
+// version-gated feature.
+var featureVersions = map[core.Feature]string{
+	core.FeatureHTTPTransport: "1.0.27",
+}
+
 // Adapter implements core.Adapter for Claude Code / Claude Desktop.
 type Adapter struct{}
 
@@ -58,6 +66,17 @@ func (a *Adapter) DefaultPaths() []string {
 	return paths
 }
 
+// SupportsFeature reports whether the given Claude Code version supports
+// feature. Features without a recorded minimum version are always supported.
+func (a *Adapter) SupportsFeature(feature core.Feature, v string) bool {
+	min, ok := featureVersions[feature]
+	if !ok {
+		return true
+	}
+	supported, err := version.AtLeast(v, min)
+	return err == nil && supported
+}
+
 // Parse parses Claude config data into the canonical format.
 func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 	var claudeCfg Config
@@ -70,7 +89,7 @@ func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 // Marshal converts canonical config to Claude format.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
 	claudeCfg := a.FromCore(cfg)
-	data, err := json.MarshalIndent(claudeCfg, "", "  ")
+	data, err := canonicaljson.MarshalOrdered(claudeCfg, "mcpServers", cfg.OrderedServerNames())
 	if err != nil {
 		return nil, err
 	}
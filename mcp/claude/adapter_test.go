@@ -1,6 +1,7 @@
 package claude
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
@@ -100,6 +101,29 @@ func TestAdapterMarshal(t *testing.T) {
 	}
 }
 
+func TestAdapterMarshalHonorsOrder(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("auth", core.Server{Command: "auth", Order: 1})
+	cfg.AddServer("zeta", core.Server{Command: "zeta"})
+	cfg.AddServer("alpha", core.Server{Command: "alpha"})
+	cfg.AddServer("proxy", core.Server{Command: "proxy", Order: -1})
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	out := string(data)
+	want := []string{`"proxy"`, `"alpha"`, `"zeta"`, `"auth"`}
+	for i := 1; i < len(want); i++ {
+		if strings.Index(out, want[i-1]) > strings.Index(out, want[i]) {
+			t.Errorf("expected %s before %s in:\n%s", want[i-1], want[i], out)
+		}
+	}
+}
+
 func TestAdapterRoundTrip(t *testing.T) {
 	adapter := NewAdapter()
 
@@ -143,3 +167,32 @@ func TestAdapterRoundTrip(t *testing.T) {
 		t.Errorf("http server URL mismatch")
 	}
 }
+
+func TestCheckFeaturesHTTPOnOldClaude(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddServer("remote", core.Server{Transport: core.TransportHTTP, URL: "https://mcp.example.com/mcp"})
+	cfg.AddServer("local", core.Server{Command: "npx", Args: []string{"server"}})
+
+	unsupported, err := core.CheckFeatures(NewAdapter(), cfg, "1.0.20")
+	if err != nil {
+		t.Fatalf("CheckFeatures failed: %v", err)
+	}
+	if len(unsupported) != 1 {
+		t.Fatalf("expected 1 unsupported feature, got %v", unsupported)
+	}
+	if unsupported[0].Server != "remote" || unsupported[0].Feature != core.FeatureHTTPTransport {
+		t.Errorf("unexpected unsupported feature %+v", unsupported[0])
+	}
+
+	unsupported, err = core.CheckFeatures(NewAdapter(), cfg, "1.0.27")
+	if err != nil {
+		t.Fatalf("CheckFeatures failed: %v", err)
+	}
+	if len(unsupported) != 0 {
+		t.Errorf("expected HTTP to be supported in 1.0.27, got %v", unsupported)
+	}
+
+	if _, err := core.CheckFeatures(NewAdapter(), cfg, "latest"); err == nil {
+		t.Error("expected an error for an unparseable version")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
 	"github.com/agentplexus/assistantkit/mcp/core"
 )
 
@@ -72,7 +73,7 @@ func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 // Marshal converts canonical config to Cline format.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
 	clineCfg := a.FromCore(cfg)
-	return json.MarshalIndent(clineCfg, "", "  ")
+	return canonicaljson.MarshalOrdered(clineCfg, "mcpServers", cfg.OrderedServerNames())
 }
 
 // ReadFile reads a Cline config file.
@@ -10,8 +10,11 @@
 package codex
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
 	"github.com/pelletier/go-toml/v2"
@@ -59,9 +62,40 @@ func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 }
 
 // Marshal converts canonical config to Codex TOML format.
+// Servers are grouped by category, each group introduced by a comment,
+// and sorted by Order, then name, within the group. Groups are not
+// reordered by Order: a low Order cannot move a server ahead of an earlier
+// category. Server descriptions are written as comments above each table.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
 	codexCfg := a.FromCore(cfg)
-	return toml.Marshal(codexCfg)
+
+	var buf bytes.Buffer
+	buf.WriteString("[mcp_servers]\n")
+	for _, group := range cfg.ServerGroups() {
+		if group.Category != "" {
+			fmt.Fprintf(&buf, "\n# %s\n", group.Category)
+		}
+		for i, name := range group.Names {
+			table, err := toml.Marshal(map[string]map[string]ServerConfig{
+				"mcp_servers": {name: codexCfg.MCPServers[name]},
+			})
+			if err != nil {
+				return nil, err
+			}
+			// Separate tables with a blank line; a category comment sits directly above its first table.
+			if i > 0 || group.Category == "" {
+				buf.WriteString("\n")
+			}
+			if desc := cfg.Servers[name].Description; desc != "" {
+				for _, line := range strings.Split(desc, "\n") {
+					fmt.Fprintf(&buf, "# %s\n", line)
+				}
+			}
+			// Drop the repeated [mcp_servers] header emitted for each server.
+			buf.Write(bytes.TrimPrefix(table, []byte("[mcp_servers]\n")))
+		}
+	}
+	return buf.Bytes(), nil
 }
 
 // ReadFile reads a Codex config file.
@@ -2,6 +2,7 @@ package codex
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
@@ -245,3 +246,64 @@ func TestNewConfig(t *testing.T) {
 		t.Error("Expected MCPServers to be initialized")
 	}
 }
+
+func TestAdapterMarshalGroupsByCategory(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("search", core.Server{Command: "search", Category: "web"})
+	cfg.AddServer("fetch", core.Server{Command: "fetch", Category: "web"})
+	cfg.AddServer("files", core.Server{Command: "files", Category: "filesystem"})
+	cfg.AddServer("misc", core.Server{Command: "misc"})
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	out := string(data)
+
+	order := []string{
+		"[mcp_servers.misc]",
+		"# filesystem",
+		"[mcp_servers.files]",
+		"# web",
+		"[mcp_servers.fetch]",
+		"[mcp_servers.search]",
+	}
+	last := -1
+	for _, want := range order {
+		idx := strings.Index(out, want)
+		if idx < 0 {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+		if idx < last {
+			t.Errorf("expected %q after previous entries in output:\n%s", want, out)
+		}
+		last = idx
+	}
+
+	// Comments must not break parsing.
+	cfg2, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse after marshal failed: %v", err)
+	}
+	if len(cfg2.Servers) != 4 {
+		t.Errorf("expected 4 servers after round-trip, got %d", len(cfg2.Servers))
+	}
+}
+
+func TestAdapterMarshalDescription(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddServer("github", core.Server{
+		Command:     "github-mcp",
+		Description: "GitHub issues and pull requests",
+	})
+
+	data, err := NewAdapter().Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), "# GitHub issues and pull requests\n[mcp_servers.github]") {
+		t.Errorf("expected description comment above the server table, got:\n%s", data)
+	}
+}
@@ -73,6 +73,28 @@ func (r *AdapterRegistry) Convert(data []byte, from, to string) ([]byte, error)
 	return toAdapter.Marshal(cfg)
 }
 
+// MarshalAll renders cfg in the format of each named tool, keyed by tool name.
+// A nil or empty tools slice renders the config for every registered adapter.
+func (r *AdapterRegistry) MarshalAll(cfg *Config, tools []string) (map[string][]byte, error) {
+	if len(tools) == 0 {
+		tools = r.Names()
+	}
+
+	out := make(map[string][]byte, len(tools))
+	for _, tool := range tools {
+		adapter, ok := r.Get(tool)
+		if !ok {
+			return nil, &MarshalError{Format: tool, Err: ErrUnknownAdapter}
+		}
+		data, err := adapter.Marshal(cfg)
+		if err != nil {
+			return nil, &MarshalError{Format: tool, Err: err}
+		}
+		out[tool] = data
+	}
+	return out, nil
+}
+
 // DefaultRegistry is the global adapter registry.
 var DefaultRegistry = NewAdapterRegistry()
 
@@ -90,3 +112,8 @@ func GetAdapter(name string) (Adapter, bool) {
 func Convert(data []byte, from, to string) ([]byte, error) {
 	return DefaultRegistry.Convert(data, from, to)
 }
+
+// MarshalAll renders cfg for each named tool using the default registry.
+func MarshalAll(cfg *Config, tools []string) (map[string][]byte, error) {
+	return DefaultRegistry.MarshalAll(cfg, tools)
+}
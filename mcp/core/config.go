@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"io/fs"
 	"os"
+	"sort"
+
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+	"github.com/agentplexus/assistantkit/internal/envfile"
 )
 
 // DefaultFileMode is the default permission mode for configuration files.
@@ -71,6 +75,91 @@ func (c *Config) ServerNames() []string {
 	return names
 }
 
+// OrderedServerNames returns the server names sorted by Order, with ties
+// broken alphabetically. This is the order in which adapters emit servers.
+func (c *Config) OrderedServerNames() []string {
+	names := c.ServerNames()
+	c.sortByOrder(names)
+	return names
+}
+
+// sortByOrder sorts names by their server's Order, then alphabetically.
+func (c *Config) sortByOrder(names []string) {
+	sort.Slice(names, func(i, j int) bool {
+		oi, oj := c.Servers[names[i]].Order, c.Servers[names[j]].Order
+		if oi != oj {
+			return oi < oj
+		}
+		return names[i] < names[j]
+	})
+}
+
+// ExpandEnv returns a copy of the config with ${VAR} placeholders in server
+// commands, args, env values, working directories, URLs, and headers resolved
+// through lookup. Unresolved placeholders are left unchanged.
+func (c *Config) ExpandEnv(lookup func(name string) (string, bool)) *Config {
+	expanded := &Config{
+		Servers: make(map[string]Server, len(c.Servers)),
+		Inputs:  c.Inputs,
+	}
+	for name, server := range c.Servers {
+		server.Command = envfile.Expand(server.Command, lookup)
+		server.Args = envfile.ExpandAll(server.Args, lookup)
+		server.Env = envfile.ExpandMap(server.Env, lookup)
+		server.Cwd = envfile.Expand(server.Cwd, lookup)
+		server.URL = envfile.Expand(server.URL, lookup)
+		server.Headers = envfile.ExpandMap(server.Headers, lookup)
+		expanded.Servers[name] = server
+	}
+	return expanded
+}
+
+// WithEnv returns a copy of the config with env merged into the environment
+// of every stdio server (see Server.MergeEnv).
+func (c *Config) WithEnv(env map[string]string) *Config {
+	merged := &Config{
+		Servers: make(map[string]Server, len(c.Servers)),
+		Inputs:  c.Inputs,
+	}
+	for name, server := range c.Servers {
+		server.MergeEnv(env)
+		merged.Servers[name] = server
+	}
+	return merged
+}
+
+// ServerGroup is a set of servers sharing a category.
+type ServerGroup struct {
+	// Category is the shared category, or empty for uncategorized servers.
+	Category string
+
+	// Names are the server names sorted by Order, then alphabetically.
+	Names []string
+}
+
+// ServerGroups returns the servers grouped by Category. Uncategorized servers
+// come first, followed by categories in alphabetical order.
+func (c *Config) ServerGroups() []ServerGroup {
+	byCategory := make(map[string][]string)
+	for name, server := range c.Servers {
+		byCategory[server.Category] = append(byCategory[server.Category], name)
+	}
+
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	groups := make([]ServerGroup, 0, len(categories))
+	for _, category := range categories {
+		names := byCategory[category]
+		c.sortByOrder(names)
+		groups = append(groups, ServerGroup{Category: category, Names: names})
+	}
+	return groups
+}
+
 // AddInput adds an input variable to the configuration.
 func (c *Config) AddInput(input InputVariable) {
 	c.Inputs = append(c.Inputs, input)
@@ -172,7 +261,7 @@ func (c *Config) WriteFile(path string) error {
 
 // WriteFileWithMode writes the config to a file in JSON format with the specified permission mode.
 func (c *Config) WriteFileWithMode(path string, mode fs.FileMode) error {
-	data, err := json.MarshalIndent(c, "", "  ")
+	data, err := canonicaljson.Marshal(c)
 	if err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -56,6 +57,26 @@ func TestConfigServerNames(t *testing.T) {
 	}
 }
 
+func TestConfigOrderedServerNames(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddServer("github", Server{Command: "gh", Order: 2})
+	cfg.AddServer("memory", Server{Command: "mem"})
+	cfg.AddServer("filesystem", Server{Command: "fs", Order: -1})
+	cfg.AddServer("browser", Server{Command: "br"})
+	cfg.AddServer("auth", Server{Command: "auth", Order: 2})
+
+	got := cfg.OrderedServerNames()
+	want := []string{"filesystem", "browser", "memory", "auth", "github"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderedServerNames() = %v, want %v", got, want)
+	}
+
+	groups := cfg.ServerGroups()
+	if len(groups) != 1 || !reflect.DeepEqual(groups[0].Names, want) {
+		t.Errorf("ServerGroups() = %v, want one group with %v", groups, want)
+	}
+}
+
 func TestConfigStdioServers(t *testing.T) {
 	cfg := NewConfig()
 	cfg.AddServer("stdio-server", Server{Transport: TransportStdio, Command: "test"})
@@ -21,6 +21,18 @@ var (
 
 	// ErrEmptyConfig is returned when configuration is empty.
 	ErrEmptyConfig = errors.New("configuration is empty")
+
+	// ErrUnknownAdapter is returned when no adapter is registered for a tool.
+	ErrUnknownAdapter = errors.New("unknown adapter")
+
+	// ErrUnsupportedFeature is returned when a tool version lacks a feature a server requires.
+	ErrUnsupportedFeature = errors.New("feature not supported by tool version")
+
+	// ErrInvalidToolVersion is returned when a target tool version cannot be parsed.
+	ErrInvalidToolVersion = errors.New("invalid tool version")
+
+	// ErrInvalidRoot is returned when a server root is not a valid workspace path.
+	ErrInvalidRoot = errors.New("invalid root")
 )
 
 // ServerValidationError wraps a validation error with the server name.
@@ -37,6 +49,23 @@ func (e *ServerValidationError) Unwrap() error {
 	return e.Err
 }
 
+// UnsupportedFeatureError reports a server that requires a feature the
+// target tool version does not support.
+type UnsupportedFeatureError struct {
+	Server  string
+	Feature Feature
+	Tool    string
+	Version string
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("server %q uses %s, which %s %s does not support", e.Server, e.Feature, e.Tool, e.Version)
+}
+
+func (e *UnsupportedFeatureError) Unwrap() error {
+	return ErrUnsupportedFeature
+}
+
 // ParseError represents an error parsing a configuration file.
 type ParseError struct {
 	Format string
@@ -69,3 +98,17 @@ func (e *WriteError) Error() string {
 func (e *WriteError) Unwrap() error {
 	return e.Err
 }
+
+// MarshalError represents an error rendering a configuration for a tool.
+type MarshalError struct {
+	Format string
+	Err    error
+}
+
+func (e *MarshalError) Error() string {
+	return fmt.Sprintf("failed to marshal %s config: %v", e.Format, e.Err)
+}
+
+func (e *MarshalError) Unwrap() error {
+	return e.Err
+}
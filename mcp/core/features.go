@@ -0,0 +1,77 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/agentplexus/assistantkit/internal/version"
+)
+
+// Feature is an MCP capability whose support can depend on the version of
+// the target tool.
+type Feature string
+
+const (
+	// FeatureHTTPTransport is a remote server using Streamable HTTP.
+	FeatureHTTPTransport Feature = "http-transport"
+
+	// FeatureSSETransport is a remote server using Server-Sent Events.
+	FeatureSSETransport Feature = "sse-transport"
+)
+
+// FeatureGate is implemented by adapters whose support for a feature depends
+// on the tool version. Adapters that do not implement it are assumed to
+// support every feature they can marshal.
+type FeatureGate interface {
+	// SupportsFeature reports whether the given tool version supports feature.
+	SupportsFeature(feature Feature, version string) bool
+}
+
+// Features returns the version-gated features the server requires.
+func (s *Server) Features() []Feature {
+	switch {
+	case s.IsHTTP():
+		return []Feature{FeatureHTTPTransport}
+	case s.IsSSE():
+		return []Feature{FeatureSSETransport}
+	default:
+		return nil
+	}
+}
+
+// CheckFeatures reports each server in cfg that requires a feature the
+// adapter's tool does not support at the given version, sorted by server
+// name. An empty version, or an adapter without a FeatureGate, reports
+// nothing.
+func CheckFeatures(adapter Adapter, cfg *Config, toolVersion string) ([]*UnsupportedFeatureError, error) {
+	if toolVersion == "" || cfg == nil {
+		return nil, nil
+	}
+	if _, err := version.Parse(toolVersion); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToolVersion, err)
+	}
+
+	gate, ok := adapter.(FeatureGate)
+	if !ok {
+		return nil, nil
+	}
+
+	names := cfg.ServerNames()
+	sort.Strings(names)
+
+	var unsupported []*UnsupportedFeatureError
+	for _, name := range names {
+		server := cfg.Servers[name]
+		for _, feature := range server.Features() {
+			if !gate.SupportsFeature(feature, toolVersion) {
+				unsupported = append(unsupported, &UnsupportedFeatureError{
+					Server:  name,
+					Feature: feature,
+					Tool:    adapter.Name(),
+					Version: toolVersion,
+				})
+			}
+		}
+	}
+	return unsupported, nil
+}
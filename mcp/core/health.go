@@ -0,0 +1,105 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultHealthCheckTimeout bounds each health check run by CheckHealth.
+const DefaultHealthCheckTimeout = 10 * time.Second
+
+// HealthStatus is the outcome of checking a single server.
+type HealthStatus string
+
+const (
+	// HealthOK means the health check passed.
+	HealthOK HealthStatus = "ok"
+
+	// HealthFailed means the health check failed or the command was not found.
+	HealthFailed HealthStatus = "failed"
+
+	// HealthSkipped means there was nothing to check (a remote server
+	// without a HealthCheck, or a disabled server).
+	HealthSkipped HealthStatus = "skipped"
+)
+
+// HealthResult reports the health of a single server.
+type HealthResult struct {
+	Name   string
+	Status HealthStatus
+
+	// Detail explains the status: the check that ran, its output on
+	// failure, or why the server was skipped.
+	Detail string
+}
+
+// CheckHealth checks every server in name order. Servers with a HealthCheck
+// run it through the shell with the server's Env and Cwd; other stdio
+// servers pass when their command is found on PATH. Each check is limited
+// to DefaultHealthCheckTimeout unless ctx has an earlier deadline.
+func (c *Config) CheckHealth(ctx context.Context) []HealthResult {
+	names := c.ServerNames()
+	sort.Strings(names)
+	results := make([]HealthResult, 0, len(names))
+	for _, name := range names {
+		server := c.Servers[name]
+		result := server.CheckHealth(ctx)
+		result.Name = name
+		results = append(results, result)
+	}
+	return results
+}
+
+// CheckHealth checks a single server. See Config.CheckHealth.
+func (s *Server) CheckHealth(ctx context.Context) HealthResult {
+	switch {
+	case !s.IsEnabled():
+		return HealthResult{Status: HealthSkipped, Detail: "disabled"}
+	case s.HealthCheck != "":
+		return s.runHealthCheck(ctx)
+	case s.IsStdio():
+		path, err := exec.LookPath(s.Command)
+		if err != nil {
+			return HealthResult{Status: HealthFailed, Detail: fmt.Sprintf("command %q not found", s.Command)}
+		}
+		return HealthResult{Status: HealthOK, Detail: "found " + path}
+	default:
+		return HealthResult{Status: HealthSkipped, Detail: "no health check"}
+	}
+}
+
+func (s *Server) runHealthCheck(ctx context.Context) HealthResult {
+	ctx, cancel := context.WithTimeout(ctx, DefaultHealthCheckTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.HealthCheck)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", s.HealthCheck)
+	}
+	cmd.Dir = s.Cwd
+	cmd.Env = os.Environ()
+	for key, value := range s.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		detail := err.Error()
+		if out := strings.TrimSpace(output.String()); out != "" {
+			detail += ": " + out
+		}
+		return HealthResult{Status: HealthFailed, Detail: detail}
+	}
+	return HealthResult{Status: HealthOK, Detail: s.HealthCheck}
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCheckHealth(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("health check commands use POSIX shell syntax")
+	}
+
+	marker := filepath.Join(t.TempDir(), "checked")
+	disabled := false
+
+	cfg := NewConfig()
+	cfg.AddServer("healthy", Server{Command: "healthy-mcp", HealthCheck: `touch "$MARKER"`, Env: map[string]string{"MARKER": marker}})
+	cfg.AddServer("broken", Server{Command: "broken-mcp", HealthCheck: "echo unreachable; exit 3"})
+	cfg.AddServer("missing", Server{Command: "assistantkit-no-such-command"})
+	cfg.AddServer("remote", Server{URL: "https://example.com/mcp"})
+	cfg.AddServer("off", Server{Command: "off-mcp", Enabled: &disabled})
+
+	results := cfg.CheckHealth(context.Background())
+
+	want := map[string]HealthStatus{
+		"broken":  HealthFailed,
+		"healthy": HealthOK,
+		"missing": HealthFailed,
+		"off":     HealthSkipped,
+		"remote":  HealthSkipped,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(results))
+	}
+	for i, result := range results {
+		if i > 0 && results[i-1].Name > result.Name {
+			t.Errorf("results not sorted by name: %q before %q", results[i-1].Name, result.Name)
+		}
+		if result.Status != want[result.Name] {
+			t.Errorf("%s: status %q, want %q (%s)", result.Name, result.Status, want[result.Name], result.Detail)
+		}
+	}
+
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("expected health check to run with the server env: %v", err)
+	}
+}
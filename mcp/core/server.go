@@ -1,5 +1,14 @@
 package core
 
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
 // Server represents a canonical MCP server configuration that can be
 // converted to/from various AI assistant formats (Claude, Cursor, VS Code, etc.).
 type Server struct {
@@ -7,6 +16,25 @@ type Server struct {
 	// If empty, it will be inferred from Command (stdio) or URL (http).
 	Transport TransportType `json:"transport,omitempty"`
 
+	// Description is a human-readable summary of what the server provides.
+	// Formats that allow comments emit it above the server entry.
+	Description string `json:"description,omitempty"`
+
+	// HealthCheck is a shell command that exits 0 when the server is usable
+	// (e.g., "curl -sf http://localhost:8080/health"). Run by CheckHealth.
+	HealthCheck string `json:"healthCheck,omitempty"`
+
+	// Category groups related servers (e.g., "filesystem", "web") in
+	// generated output. Formats that allow comments emit a section header per category.
+	Category string `json:"category,omitempty"`
+
+	// Order positions the server in generated output, for tools that start
+	// servers in file order. Lower values come first; servers with equal
+	// Order (including the default 0) are sorted by name. Formats that
+	// group servers by Category (see Config.ServerGroups) apply Order
+	// within each group only.
+	Order int `json:"order,omitempty"`
+
 	// --- STDIO Server Fields ---
 
 	// Command is the executable to launch for stdio servers.
@@ -24,6 +52,11 @@ type Server struct {
 	// Cwd is the working directory for the server process (Codex feature).
 	Cwd string `json:"cwd,omitempty"`
 
+	// Roots scopes the server to workspace directories, given as paths
+	// relative to the project root, absolute paths, or file:// URIs
+	// (VS Code feature). See ValidateRoots.
+	Roots []string `json:"roots,omitempty"`
+
 	// --- HTTP/SSE Server Fields ---
 
 	// URL is the endpoint for HTTP/SSE servers.
@@ -102,6 +135,23 @@ func (s *Server) SetEnabled(enabled bool) {
 	s.Enabled = &enabled
 }
 
+// MergeEnv adds env to the environment of a stdio server. Keys the server
+// already sets keep their values. Remote servers have no process
+// environment and are left unchanged.
+func (s *Server) MergeEnv(env map[string]string) {
+	if len(env) == 0 || !s.IsStdio() {
+		return
+	}
+	merged := make(map[string]string, len(env)+len(s.Env))
+	for key, value := range env {
+		merged[key] = value
+	}
+	for key, value := range s.Env {
+		merged[key] = value
+	}
+	s.Env = merged
+}
+
 // InferTransport returns the inferred transport type based on configuration.
 func (s *Server) InferTransport() TransportType {
 	if s.Transport != "" {
@@ -124,5 +174,42 @@ func (s *Server) Validate() error {
 	if s.Command != "" && s.URL != "" {
 		return ErrBothCommandAndURL
 	}
+	return ValidateRoots(s.Roots)
+}
+
+// ValidateRoots checks that each root is a relative path inside the project,
+// an absolute path, or a file:// URI.
+func ValidateRoots(roots []string) error {
+	for _, root := range roots {
+		if err := validateRoot(root); err != nil {
+			return fmt.Errorf("%w %q: %v", ErrInvalidRoot, root, err)
+		}
+	}
+	return nil
+}
+
+func validateRoot(root string) error {
+	if strings.TrimSpace(root) == "" {
+		return errors.New("root is empty")
+	}
+	if strings.Contains(root, "://") {
+		u, err := url.Parse(root)
+		if err != nil {
+			return err
+		}
+		if u.Scheme != "file" {
+			return fmt.Errorf("unsupported scheme %q", u.Scheme)
+		}
+		if u.Path == "" {
+			return errors.New("file URI has no path")
+		}
+		return nil
+	}
+	if filepath.IsAbs(root) || path.IsAbs(root) {
+		return nil
+	}
+	if clean := path.Clean(filepath.ToSlash(root)); clean == ".." || strings.HasPrefix(clean, "../") {
+		return errors.New("relative root escapes the project")
+	}
 	return nil
 }
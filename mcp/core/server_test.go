@@ -177,6 +177,26 @@ func TestServerValidate(t *testing.T) {
 			server:    Server{Command: "npx", URL: "http://example.com"},
 			wantError: true,
 		},
+		{
+			name:      "valid roots",
+			server:    Server{Command: "npx", Roots: []string{"src", "./docs", "/srv/data", "file:///srv/shared"}},
+			wantError: false,
+		},
+		{
+			name:      "root escaping the project",
+			server:    Server{Command: "npx", Roots: []string{"src/../../etc"}},
+			wantError: true,
+		},
+		{
+			name:      "non-file root URI",
+			server:    Server{Command: "npx", Roots: []string{"https://example.com/repo"}},
+			wantError: true,
+		},
+		{
+			name:      "empty root",
+			server:    Server{Command: "npx", Roots: []string{""}},
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -188,3 +208,22 @@ func TestServerValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestServerMergeEnv(t *testing.T) {
+	shared := map[string]string{"LOG_LEVEL": "info", "TEAM": "platform"}
+
+	s := Server{Command: "github-mcp-server", Env: map[string]string{"LOG_LEVEL": "debug"}}
+	s.MergeEnv(shared)
+	if s.Env["LOG_LEVEL"] != "debug" || s.Env["TEAM"] != "platform" {
+		t.Errorf("expected server keys to win over shared env, got %v", s.Env)
+	}
+	if shared["LOG_LEVEL"] != "info" {
+		t.Errorf("expected shared env to be unchanged, got %v", shared)
+	}
+
+	remote := Server{URL: "https://example.com/mcp"}
+	remote.MergeEnv(shared)
+	if remote.Env != nil {
+		t.Errorf("expected remote server to be left unchanged, got %v", remote.Env)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
 	"github.com/agentplexus/assistantkit/mcp/core"
 )
 
@@ -74,7 +75,7 @@ func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 // Marshal converts canonical config to Kiro format.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
 	kiroCfg := a.FromCore(cfg)
-	return json.MarshalIndent(kiroCfg, "", "  ")
+	return canonicaljson.MarshalOrdered(kiroCfg, "mcpServers", cfg.OrderedServerNames())
 }
 
 // ReadFile reads a Kiro config file.
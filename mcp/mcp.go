@@ -61,6 +61,43 @@ type (
 
 	// Adapter is the interface for tool-specific adapters.
 	Adapter = core.Adapter
+
+	// HealthResult reports the health of a single server.
+	HealthResult = core.HealthResult
+
+	// HealthStatus is the outcome of a server health check.
+	HealthStatus = core.HealthStatus
+
+	// Feature is a capability gated by the target tool version.
+	Feature = core.Feature
+
+	// FeatureGate is implemented by adapters that gate features by tool version.
+	FeatureGate = core.FeatureGate
+
+	// UnsupportedFeatureError reports a feature the target tool version lacks.
+	UnsupportedFeatureError = core.UnsupportedFeatureError
+)
+
+// Feature constants
+const (
+	FeatureHTTPTransport = core.FeatureHTTPTransport
+	FeatureSSETransport  = core.FeatureSSETransport
+)
+
+// Re-export version gating errors.
+var (
+	ErrUnsupportedFeature = core.ErrUnsupportedFeature
+	ErrInvalidToolVersion = core.ErrInvalidToolVersion
+)
+
+// ErrInvalidRoot is returned when a server root is not a valid workspace path.
+var ErrInvalidRoot = core.ErrInvalidRoot
+
+// Health status constants
+const (
+	HealthOK      = core.HealthOK
+	HealthFailed  = core.HealthFailed
+	HealthSkipped = core.HealthSkipped
 )
 
 // Transport type constants
@@ -87,6 +124,23 @@ func Convert(data []byte, from, to string) ([]byte, error) {
 	return core.Convert(data, from, to)
 }
 
+// MarshalAll renders cfg in each named tool's format, keyed by tool name.
+// A nil or empty tools slice renders every registered adapter.
+func MarshalAll(cfg *Config, tools []string) (map[string][]byte, error) {
+	return core.MarshalAll(cfg, tools)
+}
+
+// CheckFeatures reports servers in cfg that need a feature the adapter's
+// tool does not support at toolVersion. An empty version reports nothing.
+func CheckFeatures(adapter Adapter, cfg *Config, toolVersion string) ([]*UnsupportedFeatureError, error) {
+	return core.CheckFeatures(adapter, cfg, toolVersion)
+}
+
+// ReadFile reads a canonical MCP config from a JSON file.
+func ReadFile(path string) (*Config, error) {
+	return core.ReadFile(path)
+}
+
 // AdapterNames returns the names of all registered adapters.
 func AdapterNames() []string {
 	return core.DefaultRegistry.Names()
@@ -1,7 +1,11 @@
 package mcp
 
 import (
+	"errors"
+	"strings"
 	"testing"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
 )
 
 func TestGetAdapter(t *testing.T) {
@@ -101,3 +105,38 @@ func TestTransportConstants(t *testing.T) {
 		t.Errorf("TransportSSE mismatch")
 	}
 }
+
+func TestMarshalAll(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddServer("github", Server{
+		Command: "npx",
+		Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+	})
+
+	rendered, err := MarshalAll(cfg, []string{"claude", "cursor"})
+	if err != nil {
+		t.Fatalf("MarshalAll failed: %v", err)
+	}
+	for _, tool := range []string{"claude", "cursor"} {
+		data, ok := rendered[tool]
+		if !ok {
+			t.Errorf("expected %s output", tool)
+			continue
+		}
+		if !strings.Contains(string(data), "server-github") {
+			t.Errorf("expected %s output to contain the github server, got:\n%s", tool, data)
+		}
+	}
+
+	all, err := MarshalAll(cfg, nil)
+	if err != nil {
+		t.Fatalf("MarshalAll(nil) failed: %v", err)
+	}
+	if len(all) != len(AdapterNames()) {
+		t.Errorf("expected %d outputs, got %d", len(AdapterNames()), len(all))
+	}
+
+	if _, err := MarshalAll(cfg, []string{"unknown"}); !errors.Is(err, core.ErrUnknownAdapter) {
+		t.Errorf("expected ErrUnknownAdapter, got %v", err)
+	}
+}
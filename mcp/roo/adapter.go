@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
 	"github.com/agentplexus/assistantkit/mcp/core"
 )
 
@@ -78,7 +79,7 @@ func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 // Marshal converts canonical config to Roo Code format.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
 	rooCfg := a.FromCore(cfg)
-	return json.MarshalIndent(rooCfg, "", "  ")
+	return canonicaljson.MarshalOrdered(rooCfg, "mcpServers", cfg.OrderedServerNames())
 }
 
 // ReadFile reads a Roo Code config file.
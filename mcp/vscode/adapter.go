@@ -5,6 +5,7 @@
 //   - Has "inputs" section for secret management
 //   - Requires explicit "type" field
 //   - Supports "envFile" for loading env files
+//   - Supports "roots" for scoping a server to workspace directories
 //
 // File locations:
 //   - Workspace: .vscode/mcp.json
@@ -17,6 +18,7 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
 	"github.com/agentplexus/assistantkit/mcp/core"
 )
 
@@ -80,8 +82,13 @@ func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 
 // Marshal converts canonical config to VS Code format.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	for name, server := range cfg.Servers {
+		if err := core.ValidateRoots(server.Roots); err != nil {
+			return nil, &core.ServerValidationError{Name: name, Err: err}
+		}
+	}
 	vscodeCfg := a.FromCore(cfg)
-	return json.MarshalIndent(vscodeCfg, "", "  ")
+	return canonicaljson.MarshalOrdered(vscodeCfg, "servers", cfg.OrderedServerNames())
 }
 
 // ReadFile reads a VS Code config file.
@@ -133,6 +140,7 @@ func (a *Adapter) ToCore(vscodeCfg *Config) *core.Config {
 			Args:    server.Args,
 			Env:     server.Env,
 			EnvFile: server.EnvFile,
+			Roots:   server.Roots,
 			URL:     server.URL,
 			Headers: server.Headers,
 		}
@@ -176,6 +184,7 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 			Args:    server.Args,
 			Env:     server.Env,
 			EnvFile: server.EnvFile,
+			Roots:   server.Roots,
 			URL:     server.URL,
 			Headers: server.Headers,
 		}
@@ -1,7 +1,10 @@
 package vscode
 
 import (
+	"errors"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
@@ -229,3 +232,34 @@ func TestSSETransport(t *testing.T) {
 		t.Errorf("Expected sse transport, got %v", server.Transport)
 	}
 }
+
+func TestAdapterRoots(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("fs", core.Server{
+		Command: "mcp-fs",
+		Roots:   []string{"src", "file:///srv/shared"},
+	})
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"roots": [`) || !strings.Contains(string(data), `"file:///srv/shared"`) {
+		t.Errorf("expected roots in output, got:\n%s", data)
+	}
+
+	parsed, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := parsed.Servers["fs"].Roots; !reflect.DeepEqual(got, []string{"src", "file:///srv/shared"}) {
+		t.Errorf("round-tripped Roots = %v", got)
+	}
+
+	cfg.AddServer("bad", core.Server{Command: "mcp-fs", Roots: []string{"../outside"}})
+	if _, err := adapter.Marshal(cfg); !errors.Is(err, core.ErrInvalidRoot) {
+		t.Errorf("expected ErrInvalidRoot, got %v", err)
+	}
+}
@@ -36,6 +36,9 @@ type ServerConfig struct {
 	Env     map[string]string `json:"env,omitempty"`
 	EnvFile string            `json:"envFile,omitempty"`
 
+	// Roots are the workspace directories the server is scoped to.
+	Roots []string `json:"roots,omitempty"`
+
 	// --- HTTP/SSE Server Fields ---
 	URL     string            `json:"url,omitempty"`
 	Headers map[string]string `json:"headers,omitempty"`
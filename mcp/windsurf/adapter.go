@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
 	"github.com/agentplexus/assistantkit/mcp/core"
 )
 
@@ -58,7 +59,7 @@ func (a *Adapter) Parse(data []byte) (*core.Config, error) {
 // Marshal converts canonical config to Windsurf format.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
 	windsurfCfg := a.FromCore(cfg)
-	return json.MarshalIndent(windsurfCfg, "", "  ")
+	return canonicaljson.MarshalOrdered(windsurfCfg, "mcpServers", cfg.OrderedServerNames())
 }
 
 // ReadFile reads a Windsurf config file.
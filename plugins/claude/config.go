@@ -17,6 +17,13 @@ type ClaudePlugin struct {
 	Repository string `json:"repository,omitempty"`
 	Homepage   string `json:"homepage,omitempty"`
 
+	// Keywords aid marketplace discovery; bundle generation fills them
+	// with the agents' categories.
+	Keywords []string `json:"keywords,omitempty"`
+
+	// Changelog is the version history shown by marketplaces.
+	Changelog []core.ChangeEntry `json:"changelog,omitempty"`
+
 	// MCP Servers - embedded directly in plugin.json for consolidated config
 	MCPServers map[string]MCPServerConfig `json:"mcpServers,omitempty"`
 
@@ -30,11 +37,16 @@ type ClaudePlugin struct {
 }
 
 // MCPServerConfig represents an MCP server configuration in Claude format.
+// Stdio servers set Command; remote servers set Type ("http" or "sse"),
+// URL, and Headers.
 type MCPServerConfig struct {
-	Command  string            `json:"command"`
+	Type     string            `json:"type,omitempty"`
+	Command  string            `json:"command,omitempty"`
 	Args     []string          `json:"args,omitempty"`
 	Env      map[string]string `json:"env,omitempty"`
 	Cwd      string            `json:"cwd,omitempty"`
+	URL      string            `json:"url,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
 	Disabled bool              `json:"disabled,omitempty"`
 }
 
@@ -74,6 +86,7 @@ func (cp *ClaudePlugin) ToCanonical() *core.Plugin {
 		Commands:    cp.Commands,
 		Skills:      cp.Skills,
 		Agents:      cp.Agents,
+		Changelog:   cp.Changelog,
 	}
 
 	// Convert MCP servers
@@ -102,6 +115,7 @@ func FromCanonical(p *core.Plugin) *ClaudePlugin {
 		License:     p.License,
 		Repository:  p.Repository,
 		Homepage:    p.Homepage,
+		Changelog:   p.Changelog,
 	}
 
 	// Set default paths if components are specified
@@ -25,6 +25,10 @@ type Plugin struct {
 	// Dependencies
 	Dependencies []Dependency `json:"dependencies,omitempty"`
 
+	// Changelog lists what changed in each release, newest first, for
+	// marketplaces that show version history
+	Changelog []ChangeEntry `json:"changelog,omitempty"`
+
 	// MCP Servers (used by Gemini extensions)
 	MCPServers map[string]MCPServer `json:"mcp_servers,omitempty"`
 }
@@ -36,6 +40,13 @@ type Dependency struct {
 	Optional bool   `json:"optional,omitempty"` // If true, missing dependency is a warning
 }
 
+// ChangeEntry describes the changes in one plugin release.
+type ChangeEntry struct {
+	Version string   `json:"version"`
+	Date    string   `json:"date,omitempty"` // Release date, e.g. "2025-06-01"
+	Changes []string `json:"changes,omitempty"`
+}
+
 // MCPServer represents an MCP server configuration.
 type MCPServer struct {
 	Command string            `json:"command"`
@@ -70,6 +81,11 @@ func (p *Plugin) AddOptionalDependency(name, command string) {
 	})
 }
 
+// AddChangeEntry records the changes in a release at the top of the changelog.
+func (p *Plugin) AddChangeEntry(version, date string, changes ...string) {
+	p.Changelog = append([]ChangeEntry{{Version: version, Date: date, Changes: changes}}, p.Changelog...)
+}
+
 // AddMCPServer adds an MCP server configuration to the plugin.
 func (p *Plugin) AddMCPServer(name string, server MCPServer) {
 	if p.MCPServers == nil {
@@ -3,6 +3,7 @@
 // Supported tools:
 //   - Claude Code: .claude-plugin/plugin.json
 //   - Gemini CLI: gemini-extension.json
+//   - Windsurf: .windsurf/plugin.json
 //
 // Example usage:
 //
@@ -36,14 +37,16 @@ import (
 	// Import adapters for side-effect registration
 	_ "github.com/agentplexus/assistantkit/plugins/claude"
 	_ "github.com/agentplexus/assistantkit/plugins/gemini"
+	_ "github.com/agentplexus/assistantkit/plugins/windsurf"
 )
 
 // Re-export core types for convenience
 type (
-	Plugin     = core.Plugin
-	Dependency = core.Dependency
-	MCPServer  = core.MCPServer
-	Adapter    = core.Adapter
+	Plugin      = core.Plugin
+	Dependency  = core.Dependency
+	ChangeEntry = core.ChangeEntry
+	MCPServer   = core.MCPServer
+	Adapter     = core.Adapter
 )
 
 // Re-export core functions
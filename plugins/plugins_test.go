@@ -2,6 +2,7 @@ package plugins
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -142,3 +143,79 @@ func TestConvert(t *testing.T) {
 		t.Errorf("expected name 'convert-test', got '%v'", result["name"])
 	}
 }
+
+func TestWindsurfAdapter(t *testing.T) {
+	adapter, ok := GetAdapter("windsurf")
+	if !ok {
+		t.Fatal("Windsurf adapter not found")
+	}
+
+	plugin := NewPlugin("test-plugin", "1.0.0", "A test plugin")
+	plugin.Commands = ".windsurf/workflows"
+	plugin.Skills = ".windsurf/skills"
+	plugin.AddDependency("git", "git")
+
+	data, err := adapter.Marshal(plugin)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to parse marshaled JSON: %v", err)
+	}
+	if result["workflows"] != ".windsurf/workflows" {
+		t.Errorf("expected workflows '.windsurf/workflows', got '%v'", result["workflows"])
+	}
+
+	parsed, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if parsed.Commands != plugin.Commands || parsed.Skills != plugin.Skills {
+		t.Errorf("round-trip: expected paths %q/%q, got %q/%q", plugin.Commands, plugin.Skills, parsed.Commands, parsed.Skills)
+	}
+	if len(parsed.Dependencies) != 1 {
+		t.Errorf("round-trip: expected 1 dependency, got %d", len(parsed.Dependencies))
+	}
+}
+
+func TestChangelogInManifests(t *testing.T) {
+	plugin := NewPlugin("test-plugin", "1.1.0", "A test plugin")
+	plugin.AddChangeEntry("1.0.0", "2025-05-01", "Initial release")
+	plugin.AddChangeEntry("1.1.0", "2025-06-01", "Add release agent", "Fix hook paths")
+
+	for _, name := range []string{"claude", "windsurf"} {
+		adapter, _ := GetAdapter(name)
+		data, err := adapter.Marshal(plugin)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", name, err)
+		}
+		var manifest struct {
+			Changelog []ChangeEntry `json:"changelog"`
+		}
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			t.Fatalf("%s: invalid manifest: %v", name, err)
+		}
+		if len(manifest.Changelog) != 2 || manifest.Changelog[0].Version != "1.1.0" || len(manifest.Changelog[0].Changes) != 2 {
+			t.Errorf("%s: expected the changelog newest first, got %+v", name, manifest.Changelog)
+		}
+
+		parsed, err := adapter.Parse(data)
+		if err != nil {
+			t.Fatalf("%s: Parse failed: %v", name, err)
+		}
+		if len(parsed.Changelog) != 2 || parsed.Changelog[1].Date != "2025-05-01" {
+			t.Errorf("%s: expected the changelog to round-trip, got %+v", name, parsed.Changelog)
+		}
+	}
+
+	gemini, _ := GetAdapter("gemini")
+	data, err := gemini.Marshal(plugin)
+	if err != nil {
+		t.Fatalf("gemini: Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "changelog") {
+		t.Errorf("expected gemini-extension.json to omit the changelog, got:\n%s", data)
+	}
+}
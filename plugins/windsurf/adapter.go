@@ -0,0 +1,135 @@
+// Package windsurf provides the Windsurf (Codeium) plugin adapter.
+package windsurf
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/agentplexus/assistantkit/internal/canonicaljson"
+	"github.com/agentplexus/assistantkit/plugins/core"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "windsurf"
+
+	// WorkspaceDir is the workspace directory Windsurf reads configuration from.
+	WorkspaceDir = ".windsurf"
+
+	// ManifestFileName is the plugin manifest file name.
+	ManifestFileName = "plugin.json"
+
+	// WorkflowsDir is the workflows directory within WorkspaceDir.
+	WorkflowsDir = "workflows"
+
+	// SkillsDir is the skills directory within WorkspaceDir.
+	SkillsDir = "skills"
+)
+
+func init() {
+	core.Register(&Adapter{})
+}
+
+// Adapter converts between canonical Plugin and the Windsurf plugin manifest.
+type Adapter struct{}
+
+// Name returns the adapter identifier.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns default file paths for the Windsurf plugin manifest.
+func (a *Adapter) DefaultPaths() []string {
+	return []string{
+		filepath.Join(WorkspaceDir, ManifestFileName),
+	}
+}
+
+// Parse converts Windsurf plugin JSON bytes to canonical Plugin.
+func (a *Adapter) Parse(data []byte) (*core.Plugin, error) {
+	var wp WindsurfPlugin
+	if err := json.Unmarshal(data, &wp); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return wp.ToCanonical(), nil
+}
+
+// Marshal converts canonical Plugin to Windsurf plugin JSON bytes.
+func (a *Adapter) Marshal(plugin *core.Plugin) ([]byte, error) {
+	data, err := canonicaljson.Marshal(FromCanonical(plugin))
+	if err != nil {
+		return nil, &core.MarshalError{Format: AdapterName, Err: err}
+	}
+	return data, nil
+}
+
+// ReadFile reads a Windsurf plugin JSON file and returns canonical Plugin.
+func (a *Adapter) ReadFile(path string) (*core.Plugin, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ReadError{Path: path, Err: err}
+	}
+
+	plugin, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+
+	return plugin, nil
+}
+
+// WriteFile writes canonical Plugin to a Windsurf plugin JSON file.
+func (a *Adapter) WriteFile(plugin *core.Plugin, path string) error {
+	data, err := a.Marshal(plugin)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, core.DefaultDirMode); err != nil {
+			return &core.WriteError{Path: path, Err: err}
+		}
+	}
+
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Path: path, Err: err}
+	}
+
+	return nil
+}
+
+// WritePlugin writes the complete Windsurf workspace structure to the given
+// directory: .windsurf/plugin.json plus the workflows and skills directories
+// referenced by the plugin.
+func (a *Adapter) WritePlugin(plugin *core.Plugin, dir string) error {
+	workspaceDir := filepath.Join(dir, WorkspaceDir)
+	if err := os.MkdirAll(workspaceDir, core.DefaultDirMode); err != nil {
+		return &core.WriteError{Path: workspaceDir, Err: err}
+	}
+
+	manifestPath := filepath.Join(workspaceDir, ManifestFileName)
+	if err := a.WriteFile(plugin, manifestPath); err != nil {
+		return err
+	}
+
+	if plugin.Commands != "" {
+		workflowsDir := filepath.Join(workspaceDir, WorkflowsDir)
+		if err := os.MkdirAll(workflowsDir, core.DefaultDirMode); err != nil {
+			return &core.WriteError{Path: workflowsDir, Err: err}
+		}
+	}
+
+	if plugin.Skills != "" {
+		skillsDir := filepath.Join(workspaceDir, SkillsDir)
+		if err := os.MkdirAll(skillsDir, core.DefaultDirMode); err != nil {
+			return &core.WriteError{Path: skillsDir, Err: err}
+		}
+	}
+
+	return nil
+}
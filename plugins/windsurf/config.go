@@ -0,0 +1,95 @@
+package windsurf
+
+import (
+	"github.com/agentplexus/assistantkit/plugins/core"
+)
+
+// WindsurfPlugin represents the .windsurf/plugin.json manifest.
+//
+// Windsurf has no native extension manifest; this file records the plugin
+// metadata alongside the workspace directories Cascade reads
+// (.windsurf/workflows, .windsurf/skills, .windsurf/hooks.json).
+// Component paths are relative to the workspace root.
+type WindsurfPlugin struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+
+	// Optional metadata
+	Description string `json:"description,omitempty"`
+	Author      string `json:"author,omitempty"`
+	License     string `json:"license,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+	Homepage    string `json:"homepage,omitempty"`
+
+	// Component paths
+	Workflows string `json:"workflows,omitempty"`
+	Skills    string `json:"skills,omitempty"`
+	Hooks     string `json:"hooks,omitempty"`
+
+	// Dependencies
+	Dependencies []WindsurfDependency `json:"dependencies,omitempty"`
+
+	// Changelog is the plugin's version history
+	Changelog []core.ChangeEntry `json:"changelog,omitempty"`
+}
+
+// WindsurfDependency represents a required or optional CLI dependency.
+type WindsurfDependency struct {
+	Name     string `json:"name"`
+	Command  string `json:"command,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// ToCanonical converts WindsurfPlugin to canonical Plugin.
+func (wp *WindsurfPlugin) ToCanonical() *core.Plugin {
+	plugin := &core.Plugin{
+		Name:        wp.Name,
+		Version:     wp.Version,
+		Description: wp.Description,
+		Author:      wp.Author,
+		License:     wp.License,
+		Repository:  wp.Repository,
+		Homepage:    wp.Homepage,
+		Commands:    wp.Workflows,
+		Skills:      wp.Skills,
+		Hooks:       wp.Hooks,
+		Changelog:   wp.Changelog,
+	}
+
+	for _, dep := range wp.Dependencies {
+		plugin.Dependencies = append(plugin.Dependencies, core.Dependency{
+			Name:     dep.Name,
+			Command:  dep.Command,
+			Optional: dep.Optional,
+		})
+	}
+
+	return plugin
+}
+
+// FromCanonical creates a WindsurfPlugin from canonical Plugin.
+func FromCanonical(p *core.Plugin) *WindsurfPlugin {
+	wp := &WindsurfPlugin{
+		Name:        p.Name,
+		Version:     p.Version,
+		Description: p.Description,
+		Author:      p.Author,
+		License:     p.License,
+		Repository:  p.Repository,
+		Homepage:    p.Homepage,
+		Workflows:   p.Commands,
+		Skills:      p.Skills,
+		Hooks:       p.Hooks,
+		Changelog:   p.Changelog,
+	}
+
+	for _, dep := range p.Dependencies {
+		wp.Dependencies = append(wp.Dependencies, WindsurfDependency{
+			Name:     dep.Name,
+			Command:  dep.Command,
+			Optional: dep.Optional,
+		})
+	}
+
+	return wp
+}
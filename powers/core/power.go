@@ -74,6 +74,9 @@ type MCPServer struct {
 	// URL is the endpoint for remote HTTP/SSE servers.
 	URL string `json:"url,omitempty" yaml:"url,omitempty"`
 
+	// Headers are HTTP headers sent to URL, e.g. for authentication.
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+
 	// Description explains what tools this server provides.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 }
@@ -84,7 +84,17 @@ func (a *Adapter) GeneratePowerDir(power *core.Power, outputDir string) ([]strin
 		createdFiles = append(createdFiles, mcpPath)
 	}
 
-	// Generate steering files
+	steeringFiles, err := a.GenerateSteeringFiles(power, outputDir)
+	if err != nil {
+		return nil, err
+	}
+	return append(createdFiles, steeringFiles...), nil
+}
+
+// GenerateSteeringFiles writes only the power's steering files into
+// outputDir, leaving POWER.md and mcp.json untouched.
+func (a *Adapter) GenerateSteeringFiles(power *core.Power, outputDir string) ([]string, error) {
+	var createdFiles []string
 	if len(power.SteeringFiles) > 0 {
 		steeringPath := filepath.Join(outputDir, SteeringDir)
 		if err := os.MkdirAll(steeringPath, core.DefaultDirMode); err != nil {
@@ -198,6 +208,9 @@ func (a *Adapter) generatePowerMD(power *core.Power) string {
 			if server.Command != "" {
 				sb.WriteString(fmt.Sprintf("**Command:** `%s`\n\n", server.Command))
 			}
+			if server.URL != "" {
+				sb.WriteString(fmt.Sprintf("**URL:** `%s`\n\n", server.URL))
+			}
 		}
 	}
 
@@ -238,6 +251,7 @@ type MCPServerConfig struct {
 	Args    []string          `json:"args,omitempty"`
 	Env     map[string]string `json:"env,omitempty"`
 	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
 }
 
 // generateMCPConfig creates the mcp.json structure.
@@ -252,6 +266,7 @@ func (a *Adapter) generateMCPConfig(power *core.Power) *MCPConfig {
 			Args:    server.Args,
 			Env:     server.Env,
 			URL:     server.URL,
+			Headers: server.Headers,
 		}
 	}
 
@@ -336,6 +351,7 @@ func (a *Adapter) parseMCPConfig(power *core.Power, data []byte) error {
 			Args:    server.Args,
 			Env:     server.Env,
 			URL:     server.URL,
+			Headers: server.Headers,
 		}
 	}
 
@@ -6,11 +6,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/agentplexus/assistantkit/internal/frontmatter"
+	"github.com/agentplexus/assistantkit/internal/passthrough"
 	"github.com/agentplexus/assistantkit/skills/core"
 )
 
+// knownFrontmatterKeys are the frontmatter keys mapped to canonical fields.
+var knownFrontmatterKeys = map[string]bool{
+	"name":                     true,
+	"description":              true,
+	"triggers":                 true,
+	"paths":                    true,
+	"dependencies":             true,
+	"disable-model-invocation": true,
+}
+
 func init() {
 	core.Register(&Adapter{})
 }
@@ -33,6 +46,18 @@ func (a *Adapter) DefaultDir() string {
 	return "skills"
 }
 
+// SupportsDisabled reports that skills can ship disabled, with
+// disable-model-invocation.
+func (a *Adapter) SupportsDisabled() bool {
+	return true
+}
+
+// SupportsExperimental reports that experimental options are written to the
+// SKILL.md frontmatter.
+func (a *Adapter) SupportsExperimental() bool {
+	return true
+}
+
 // Parse converts Claude SKILL.md bytes to canonical Skill.
 func (a *Adapter) Parse(data []byte) (*core.Skill, error) {
 	frontmatter, body := parseFrontmatter(data)
@@ -48,11 +73,29 @@ func (a *Adapter) Parse(data []byte) (*core.Skill, error) {
 		skill.Triggers = parseList(triggers)
 	}
 
+	// Parse activation globs if present
+	if paths, ok := frontmatter["paths"]; ok {
+		skill.ActivationGlobs = parseList(paths)
+	}
+
 	// Parse dependencies if present
 	if deps, ok := frontmatter["dependencies"]; ok {
 		skill.Dependencies = parseList(deps)
 	}
 
+	// Skills Claude may not load on its own are disabled by default
+	if frontmatter["disable-model-invocation"] == "true" {
+		enabled := false
+		skill.EnabledByDefault = &enabled
+	}
+
+	// Preserve remaining keys as metadata
+	for key, value := range frontmatter {
+		if !knownFrontmatterKeys[key] {
+			skill.SetMetadata(key, value)
+		}
+	}
+
 	return skill, nil
 }
 
@@ -69,10 +112,38 @@ func (a *Adapter) Marshal(skill *core.Skill) ([]byte, error) {
 		buf.WriteString(fmt.Sprintf("triggers: [%s]\n", strings.Join(skill.Triggers, ", ")))
 	}
 
+	// Claude activates skills for files matching "paths". Globs are quoted
+	// since a leading "*" would otherwise start a YAML alias.
+	if len(skill.ActivationGlobs) > 0 {
+		quoted := make([]string, len(skill.ActivationGlobs))
+		for i, glob := range skill.ActivationGlobs {
+			quoted[i] = strconv.Quote(glob)
+		}
+		buf.WriteString(fmt.Sprintf("paths: [%s]\n", strings.Join(quoted, ", ")))
+	}
+
 	if len(skill.Dependencies) > 0 {
 		buf.WriteString(fmt.Sprintf("dependencies: [%s]\n", strings.Join(skill.Dependencies, ", ")))
 	}
 
+	// Only the user can load a disabled skill, with /name
+	if !skill.IsEnabledByDefault() {
+		buf.WriteString("disable-model-invocation: true\n")
+	}
+
+	for _, key := range skill.MetadataKeys() {
+		if knownFrontmatterKeys[key] {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("%s: %s\n", key, frontmatter.QuoteValue(skill.Metadata[key])))
+	}
+	// Experimental options follow the keys written above, which they never override
+	experimental, err := passthrough.Frontmatter(buf.String(), skill.Experimental)
+	if err != nil {
+		return nil, &core.MarshalError{Format: "claude", Err: err}
+	}
+	buf.WriteString(experimental)
+
 	buf.WriteString("---\n\n")
 
 	// Write title
@@ -94,6 +165,10 @@ func (a *Adapter) Marshal(skill *core.Skill) ([]byte, error) {
 	if len(skill.Scripts) > 0 {
 		buf.WriteString("## Scripts\n\n")
 		for _, script := range skill.Scripts {
+			if interpreter := skill.ScriptInterpreter(script); interpreter != "" {
+				buf.WriteString(fmt.Sprintf("- `%s` (run with `%s`)\n", script, interpreter))
+				continue
+			}
 			buf.WriteString(fmt.Sprintf("- `%s`\n", script))
 		}
 		buf.WriteString("\n")
@@ -115,6 +190,22 @@ func (a *Adapter) Marshal(skill *core.Skill) ([]byte, error) {
 		buf.WriteString("\n")
 	}
 
+	if len(skill.Tests) > 0 {
+		buf.WriteString("## Tests\n\n")
+		buf.WriteString("The skill is working when each input below produces a response containing the expected text.\n\n")
+		for i, test := range skill.Tests {
+			buf.WriteString(fmt.Sprintf("%d. Input: %s\n", i+1, strconv.Quote(test.Input)))
+			if len(test.ExpectedContains) > 0 {
+				quoted := make([]string, len(test.ExpectedContains))
+				for j, expected := range test.ExpectedContains {
+					quoted[j] = strconv.Quote(expected)
+				}
+				buf.WriteString(fmt.Sprintf("   Expected: %s\n", strings.Join(quoted, ", ")))
+			}
+		}
+		buf.WriteString("\n")
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -168,18 +259,16 @@ func (a *Adapter) WriteSkillDir(skill *core.Skill, baseDir string) error {
 		return &core.WriteError{Path: skillDir, Err: err}
 	}
 
-	// Write SKILL.md
+	// Write SKILL.md in the skill's own directory, rebasing links written
+	// relative to the canonical skills directory.
 	skillPath := filepath.Join(skillDir, a.SkillFileName())
-	if err := a.WriteFile(skill, skillPath); err != nil {
+	if err := a.WriteFile(core.RebaseLinks(skill, core.SkillDirLinkPrefix(skill)), skillPath); err != nil {
 		return err
 	}
 
-	// Create resource directories if specified
-	if len(skill.Scripts) > 0 {
-		scriptsDir := filepath.Join(skillDir, "scripts")
-		if err := os.MkdirAll(scriptsDir, core.DefaultDirMode); err != nil {
-			return &core.WriteError{Path: scriptsDir, Err: err}
-		}
+	// Copy scripts, then create the other resource directories
+	if err := core.WriteScripts(skill, skillDir); err != nil {
+		return err
 	}
 
 	if len(skill.References) > 0 {
@@ -225,7 +314,11 @@ func parseFrontmatter(data []byte) (map[string]string, string) {
 			key := strings.TrimSpace(line[:idx])
 			value := strings.TrimSpace(line[idx+1:])
 			// Remove quotes if present
-			value = strings.Trim(value, "\"'")
+			if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, "\"") {
+				value = unquoted
+			} else {
+				value = strings.Trim(value, "\"'")
+			}
 			frontmatter[key] = value
 		}
 	}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/agentplexus/assistantkit/internal/frontmatter"
+	"github.com/agentplexus/assistantkit/internal/passthrough"
 	"github.com/agentplexus/assistantkit/skills/core"
 )
 
@@ -33,6 +36,12 @@ func (a *Adapter) DefaultDir() string {
 	return "skills"
 }
 
+// SupportsExperimental reports that experimental options are written to the
+// SKILL.md frontmatter.
+func (a *Adapter) SupportsExperimental() bool {
+	return true
+}
+
 // Parse converts Codex SKILL.md bytes to canonical Skill.
 func (a *Adapter) Parse(data []byte) (*core.Skill, error) {
 	frontmatter, body := parseFrontmatter(data)
@@ -43,6 +52,13 @@ func (a *Adapter) Parse(data []byte) (*core.Skill, error) {
 		Instructions: strings.TrimSpace(body),
 	}
 
+	// Preserve remaining keys as metadata
+	for key, value := range frontmatter {
+		if key != "name" && key != "description" {
+			skill.SetMetadata(key, value)
+		}
+	}
+
 	return skill, nil
 }
 
@@ -54,6 +70,18 @@ func (a *Adapter) Marshal(skill *core.Skill) ([]byte, error) {
 	buf.WriteString("---\n")
 	buf.WriteString(fmt.Sprintf("name: %s\n", skill.Name))
 	buf.WriteString(fmt.Sprintf("description: %s\n", skill.Description))
+	for _, key := range skill.MetadataKeys() {
+		if key == "name" || key == "description" {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("%s: %s\n", key, frontmatter.QuoteValue(skill.Metadata[key])))
+	}
+	// Experimental options follow the keys written above, which they never override
+	experimental, err := passthrough.Frontmatter(buf.String(), skill.Experimental)
+	if err != nil {
+		return nil, &core.MarshalError{Format: "codex", Err: err}
+	}
+	buf.WriteString(experimental)
 	buf.WriteString("---\n\n")
 
 	// Write instructions (Codex puts the main content after frontmatter)
@@ -118,18 +146,16 @@ func (a *Adapter) WriteSkillDir(skill *core.Skill, baseDir string) error {
 		return &core.WriteError{Path: skillDir, Err: err}
 	}
 
-	// Write SKILL.md
+	// Write SKILL.md in the skill's own directory, rebasing links written
+	// relative to the canonical skills directory.
 	skillPath := filepath.Join(skillDir, a.SkillFileName())
-	if err := a.WriteFile(skill, skillPath); err != nil {
+	if err := a.WriteFile(core.RebaseLinks(skill, core.SkillDirLinkPrefix(skill)), skillPath); err != nil {
 		return err
 	}
 
 	// Create optional directories based on Codex convention
-	if len(skill.Scripts) > 0 {
-		scriptsDir := filepath.Join(skillDir, "scripts")
-		if err := os.MkdirAll(scriptsDir, core.DefaultDirMode); err != nil {
-			return &core.WriteError{Path: scriptsDir, Err: err}
-		}
+	if err := core.WriteScripts(skill, skillDir); err != nil {
+		return err
 	}
 
 	if len(skill.References) > 0 {
@@ -175,7 +201,11 @@ func parseFrontmatter(data []byte) (map[string]string, string) {
 			key := strings.TrimSpace(line[:idx])
 			value := strings.TrimSpace(line[idx+1:])
 			// Remove quotes if present
-			value = strings.Trim(value, "\"'")
+			if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, "\"") {
+				value = unquoted
+			} else {
+				value = strings.Trim(value, "\"'")
+			}
 			frontmatter[key] = value
 		}
 	}
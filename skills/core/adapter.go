@@ -7,8 +7,18 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentplexus/assistantkit/internal/fields"
+	"github.com/agentplexus/assistantkit/internal/frontmatter"
+	"github.com/agentplexus/assistantkit/internal/ignore"
+	"github.com/agentplexus/assistantkit/internal/include"
+	"github.com/agentplexus/assistantkit/internal/jsonc"
 )
 
 // DefaultFileMode is the default permission for generated files.
@@ -104,6 +114,39 @@ func (r *Registry) Convert(data []byte, from, to string) ([]byte, error) {
 	return toAdapter.Marshal(skill)
 }
 
+// ConvertWithWarnings converts skill data from one format to another and
+// returns warnings describing fields the target format could not represent.
+// Dropped fields are detected by parsing the converted output back and
+// comparing it with the source skill.
+func (r *Registry) ConvertWithWarnings(data []byte, from, to string) ([]byte, []string, error) {
+	fromAdapter, ok := r.GetAdapter(from)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown source adapter: %s", from)
+	}
+
+	toAdapter, ok := r.GetAdapter(to)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown target adapter: %s", to)
+	}
+
+	skill, err := fromAdapter.Parse(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", from, err)
+	}
+
+	out, err := toAdapter.Marshal(skill)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	converted, err := toAdapter.Parse(out)
+	if err != nil {
+		return out, []string{fmt.Sprintf("unable to verify conversion to %s: %v", to, err)}, nil
+	}
+
+	return out, fields.Dropped(skill, converted, to), nil
+}
+
 // DefaultRegistry is the global adapter registry.
 var DefaultRegistry = NewRegistry()
 
@@ -127,6 +170,11 @@ func Convert(data []byte, from, to string) ([]byte, error) {
 	return DefaultRegistry.Convert(data, from, to)
 }
 
+// ConvertWithWarnings converts using the default registry and reports dropped fields.
+func ConvertWithWarnings(data []byte, from, to string) ([]byte, []string, error) {
+	return DefaultRegistry.ConvertWithWarnings(data, from, to)
+}
+
 // ReadCanonicalFile reads a canonical skill file (JSON or Markdown with YAML frontmatter).
 func ReadCanonicalFile(path string) (*Skill, error) {
 	data, err := os.ReadFile(path)
@@ -134,9 +182,9 @@ func ReadCanonicalFile(path string) (*Skill, error) {
 		return nil, &ReadError{Path: path, Err: err}
 	}
 
-	// Detect format: if it starts with "---" or has .md extension, parse as markdown
+	// Detect format: if it starts with "---"/"+++" or has .md extension, parse as markdown
 	ext := filepath.Ext(path)
-	if ext == ".md" || (len(data) >= 3 && string(data[:3]) == "---") {
+	if ext == ".md" || (len(data) >= 3 && (string(data[:3]) == "---" || string(data[:3]) == "+++")) {
 		skill, err := ParseSkillMarkdown(data)
 		if err != nil {
 			return nil, &ParseError{Format: "markdown", Path: path, Err: err}
@@ -146,14 +194,16 @@ func ReadCanonicalFile(path string) (*Skill, error) {
 			base := filepath.Base(path)
 			skill.Name = strings.TrimSuffix(base, filepath.Ext(base))
 		}
+		skill.SourceDir = filepath.Dir(path)
 		return skill, nil
 	}
 
-	// Fall back to JSON
+	// Fall back to JSON. Comments and trailing commas are allowed.
 	var skill Skill
-	if err := json.Unmarshal(data, &skill); err != nil {
+	if err := jsonc.Unmarshal(data, &skill); err != nil {
 		return nil, &ParseError{Format: "canonical", Path: path, Err: err}
 	}
+	skill.SourceDir = filepath.Dir(path)
 
 	return &skill, nil
 }
@@ -181,7 +231,16 @@ func WriteCanonicalFile(skill *Skill, path string) error {
 // Supports both:
 // - Subdirectories with skill.json files
 // - Direct .md files with YAML frontmatter
+// Include directives are resolved relative to the parent of dir, and files
+// matched by a .assistantkitignore file there are skipped.
 func ReadCanonicalDir(dir string) ([]*Skill, error) {
+	root := filepath.Dir(filepath.Clean(dir))
+
+	ignored, err := ignore.Load(root)
+	if err != nil {
+		return nil, &ReadError{Path: filepath.Join(root, ignore.FileName), Err: err}
+	}
+
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, &ReadError{Path: dir, Err: err}
@@ -189,6 +248,10 @@ func ReadCanonicalDir(dir string) ([]*Skill, error) {
 
 	var skills []*Skill
 	for _, entry := range entries {
+		if rel, err := filepath.Rel(root, filepath.Join(dir, entry.Name())); err == nil && ignored.Match(rel, entry.IsDir()) {
+			continue
+		}
+
 		// Handle direct .md files (flat structure)
 		if !entry.IsDir() {
 			ext := filepath.Ext(entry.Name())
@@ -198,6 +261,9 @@ func ReadCanonicalDir(dir string) ([]*Skill, error) {
 				if err != nil {
 					return nil, err
 				}
+				if err := ResolveIncludes(skill, root); err != nil {
+					return nil, &ReadError{Path: skillPath, Err: err}
+				}
 				skills = append(skills, skill)
 			}
 			continue
@@ -213,12 +279,26 @@ func ReadCanonicalDir(dir string) ([]*Skill, error) {
 		if err != nil {
 			return nil, err
 		}
+		if err := ResolveIncludes(skill, root); err != nil {
+			return nil, &ReadError{Path: skillPath, Err: err}
+		}
 		skills = append(skills, skill)
 	}
 
 	return skills, nil
 }
 
+// ResolveIncludes inlines {{include: path}} fragments in the skill's
+// instructions. Paths are relative to root, normally the specs directory.
+func ResolveIncludes(skill *Skill, root string) error {
+	instructions, err := include.Resolve(skill.Instructions, root)
+	if err != nil {
+		return err
+	}
+	skill.Instructions = instructions
+	return nil
+}
+
 // WriteSkillsToDir writes multiple skills to a directory using the specified adapter.
 func WriteSkillsToDir(skills []*Skill, dir string, adapterName string) error {
 	adapter, ok := GetAdapter(adapterName)
@@ -230,7 +310,7 @@ func WriteSkillsToDir(skills []*Skill, dir string, adapterName string) error {
 		return &WriteError{Path: dir, Err: err}
 	}
 
-	for _, skill := range skills {
+	for _, skill := range SortByOrder(skills) {
 		if err := adapter.WriteSkillDir(skill, dir); err != nil {
 			return err
 		}
@@ -240,9 +320,14 @@ func WriteSkillsToDir(skills []*Skill, dir string, adapterName string) error {
 }
 
 // ParseSkillMarkdown parses a Markdown file with YAML frontmatter into a Skill.
+// Hugo-style TOML frontmatter delimited by +++ is also supported.
 func ParseSkillMarkdown(data []byte) (*Skill, error) {
 	content := string(data)
 
+	if strings.HasPrefix(content, "+++") {
+		return parseTOMLSkillMarkdown(content)
+	}
+
 	if !strings.HasPrefix(content, "---") {
 		// No frontmatter, treat entire content as instructions
 		return &Skill{Instructions: strings.TrimSpace(content)}, nil
@@ -257,7 +342,9 @@ func ParseSkillMarkdown(data []byte) (*Skill, error) {
 
 	// Parse simple YAML key: value pairs from frontmatter
 	lines := strings.Split(strings.TrimSpace(parts[1]), "\n")
+	var currentKey string
 	for _, line := range lines {
+		indented := line != "" && (line[0] == ' ' || line[0] == '\t')
 		line = strings.TrimSpace(line)
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
@@ -266,7 +353,18 @@ func ParseSkillMarkdown(data []byte) (*Skill, error) {
 		if idx <= 0 {
 			continue
 		}
+
+		// Indented key: value lines under experimental are passthrough options
+		if indented && currentKey == "experimental" {
+			if skill.Experimental == nil {
+				skill.Experimental = make(map[string]any)
+			}
+			skill.Experimental[strings.TrimSpace(line[:idx])] = parseValue(strings.TrimSpace(line[idx+1:]))
+			continue
+		}
+
 		key := strings.TrimSpace(line[:idx])
+		currentKey = key
 		value := strings.TrimSpace(line[idx+1:])
 		// Remove quotes if present
 		value = strings.Trim(value, "\"'")
@@ -278,6 +376,8 @@ func ParseSkillMarkdown(data []byte) (*Skill, error) {
 			skill.Description = value
 		case "triggers":
 			skill.Triggers = parseList(value)
+		case "activationGlobs":
+			skill.ActivationGlobs = parseList(value)
 		case "dependencies":
 			skill.Dependencies = parseList(value)
 		case "scripts":
@@ -286,6 +386,18 @@ func ParseSkillMarkdown(data []byte) (*Skill, error) {
 			skill.References = parseList(value)
 		case "assets":
 			skill.Assets = parseList(value)
+		case "enabledByDefault":
+			if enabled, err := strconv.ParseBool(value); err == nil {
+				skill.EnabledByDefault = &enabled
+			}
+		case "deprecated":
+			skill.Deprecated = value == "true"
+		case "deprecationMessage":
+			skill.DeprecationMessage = value
+		case "order":
+			if order, err := strconv.Atoi(value); err == nil {
+				skill.Order = order
+			}
 		}
 	}
 
@@ -295,6 +407,16 @@ func ParseSkillMarkdown(data []byte) (*Skill, error) {
 	return skill, nil
 }
 
+// parseValue reads a scalar or flow-style YAML value, falling back to the
+// raw string.
+func parseValue(s string) any {
+	var v any
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil || v == nil {
+		return s
+	}
+	return v
+}
+
 // parseList parses a comma-separated or bracket-enclosed list.
 func parseList(s string) []string {
 	s = strings.Trim(s, "[]")
@@ -309,3 +431,21 @@ func parseList(s string) []string {
 	}
 	return result
 }
+
+// parseTOMLSkillMarkdown parses a Markdown skill with +++ delimited TOML frontmatter.
+func parseTOMLSkillMarkdown(content string) (*Skill, error) {
+	fm, body, err := frontmatter.Split([]byte(content), "+++")
+	if err != nil {
+		return nil, err
+	}
+
+	var skill Skill
+	if err := toml.Unmarshal(fm, &skill); err != nil {
+		return nil, fmt.Errorf("parse toml: %w", err)
+	}
+
+	// Body becomes instructions
+	skill.Instructions = strings.TrimSpace(string(body))
+
+	return &skill, nil
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// linkPattern matches the target of an inline Markdown link or image,
+// with an optional quoted title: ](target) or ](target "title").
+var linkPattern = regexp.MustCompile(`\]\(([^)\s]+)((?:\s+"[^"]*")?)\)`)
+
+// resourceDirs are the skill-local directories that tool layouts keep next
+// to the skill file.
+var resourceDirs = map[string]bool{
+	"scripts":    true,
+	"references": true,
+	"assets":     true,
+}
+
+// SkillDirLinkPrefix returns the RebaseLinks prefix for a layout that writes
+// the skill file into its own <name>/ directory below the skills directory.
+// A skill read from its own directory (e.g., skills/<name>/skill.json) has
+// links relative to that directory and needs no prefix; any other skill,
+// such as a flat skills/<name>.md or one built in memory, has links relative
+// to the skills directory and needs "..".
+func SkillDirLinkPrefix(skill *Skill) string {
+	if skill.SourceDir != "" && filepath.Base(skill.SourceDir) == skill.Name {
+		return ""
+	}
+	return ".."
+}
+
+// RebaseLinks returns a copy of the skill whose relative Markdown links are
+// rewritten for an output layout that places the skill file at a different
+// depth than the canonical skills directory.
+//
+// Canonical links are relative to the skills directory (where flat
+// <name>.md skill files live). Links to the skill's own resources (its
+// Scripts, References, and Assets, or anything under scripts/, references/,
+// or assets/) are left alone, since directory layouts keep those next to the
+// skill file. Every other relative link is joined onto prefix, so with
+// prefix ".." a link to ./shared/style.md becomes ../shared/style.md.
+// Absolute links, URLs, anchors, and links inside fenced code blocks are
+// never changed.
+func RebaseLinks(skill *Skill, prefix string) *Skill {
+	if prefix == "" || prefix == "." || !strings.Contains(skill.Instructions, "](") {
+		return skill
+	}
+
+	local := make(map[string]bool)
+	for _, list := range [][]string{skill.Scripts, skill.References, skill.Assets} {
+		for _, p := range list {
+			local[path.Clean(p)] = true
+		}
+	}
+
+	rebased := *skill
+	rebased.Instructions = rewriteLinks(skill.Instructions, func(target string) string {
+		file, fragment, _ := strings.Cut(target, "#")
+		if file == "" || !isRelativeLink(file) {
+			return target
+		}
+		clean := path.Clean(file)
+		first, _, _ := strings.Cut(clean, "/")
+		if local[clean] || resourceDirs[first] {
+			return target
+		}
+		out := path.Join(prefix, clean)
+		if fragment != "" {
+			out += "#" + fragment
+		}
+		return out
+	})
+	return &rebased
+}
+
+// rewriteLinks applies rewrite to every inline link target outside fenced
+// code blocks.
+func rewriteLinks(markdown string, rewrite func(target string) string) string {
+	lines := strings.Split(markdown, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.Contains(line, "](") {
+			continue
+		}
+		lines[i] = linkPattern.ReplaceAllStringFunc(line, func(match string) string {
+			sub := linkPattern.FindStringSubmatch(match)
+			return "](" + rewrite(sub[1]) + sub[2] + ")"
+		})
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isRelativeLink reports whether a link target is a relative file path.
+func isRelativeLink(target string) bool {
+	if strings.HasPrefix(target, "/") || strings.HasPrefix(target, "<") {
+		return false
+	}
+	if scheme, _, ok := strings.Cut(target, ":"); ok && !strings.Contains(scheme, "/") {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExecutableFileMode is the permission for generated executable scripts.
+const ExecutableFileMode fs.FileMode = 0700
+
+// ScriptMeta describes how one of a skill's scripts runs.
+type ScriptMeta struct {
+	// Interpreter runs the script (e.g., "bash", "python3"). Tools that list
+	// scripts in the skill file mention it next to the script.
+	Interpreter string `json:"interpreter,omitempty"`
+
+	// Executable marks the script to be written with execute permission.
+	// Scripts with an Interpreter or a "#!" line are executable regardless.
+	Executable bool `json:"executable,omitempty"`
+}
+
+// ScriptInterpreter returns the interpreter recorded for a script, or "".
+func (s *Skill) ScriptInterpreter(script string) string {
+	return s.ScriptMeta[script].Interpreter
+}
+
+// WriteScripts creates the scripts directory in skillDir and copies each of
+// the skill's scripts into it from SourceDir, keeping their relative paths.
+// Executable scripts are written with ExecutableFileMode. Scripts are not
+// copied when SourceDir is unset or the script file does not exist there.
+func WriteScripts(skill *Skill, skillDir string) error {
+	if len(skill.Scripts) == 0 {
+		return nil
+	}
+
+	scriptsDir := filepath.Join(skillDir, "scripts")
+	if err := os.MkdirAll(scriptsDir, DefaultDirMode); err != nil {
+		return &WriteError{Path: scriptsDir, Err: err}
+	}
+	if skill.SourceDir == "" {
+		return nil
+	}
+
+	for _, script := range skill.Scripts {
+		rel := filepath.Clean(filepath.FromSlash(script))
+		if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue // only scripts inside the skill directory are copied
+		}
+
+		src := filepath.Join(skill.SourceDir, rel)
+		data, err := os.ReadFile(src)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return &ReadError{Path: src, Err: err}
+		}
+
+		mode := DefaultFileMode
+		meta := skill.ScriptMeta[script]
+		if meta.Executable || meta.Interpreter != "" || bytes.HasPrefix(data, []byte("#!")) {
+			mode = ExecutableFileMode
+		}
+
+		dst := filepath.Join(skillDir, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), DefaultDirMode); err != nil {
+			return &WriteError{Path: dst, Err: err}
+		}
+		if err := os.WriteFile(dst, data, mode); err != nil {
+			return &WriteError{Path: dst, Err: err}
+		}
+		// WriteFile leaves the mode of an existing file unchanged.
+		if err := os.Chmod(dst, mode); err != nil {
+			return &WriteError{Path: dst, Err: err}
+		}
+	}
+	return nil
+}
@@ -1,6 +1,11 @@
 // Package core provides canonical types for AI assistant skill definitions.
 package core
 
+import (
+	"fmt"
+	"sort"
+)
+
 // Skill represents a canonical skill definition that can be
 // converted to tool-specific formats (Claude, Codex).
 type Skill struct {
@@ -16,11 +21,58 @@ type Skill struct {
 	References []string `json:"references,omitempty"` // Reference documentation
 	Assets     []string `json:"assets,omitempty"`     // Templates, config files
 
+	// ScriptMeta records how scripts run, keyed by their path in Scripts.
+	ScriptMeta map[string]ScriptMeta `json:"scriptMeta,omitempty"`
+
+	// SourceDir is the directory resource paths are relative to when the
+	// skill was read from disk (see WriteScripts). It is not serialized.
+	SourceDir string `json:"-"`
+
 	// Invocation
 	Triggers []string `json:"triggers,omitempty"` // Keywords that invoke this skill
 
+	// ActivationGlobs are file patterns (e.g., "**/*.go") that activate the
+	// skill automatically when matching files are in play.
+	ActivationGlobs []string `json:"activationGlobs,omitempty"`
+
 	// Dependencies
 	Dependencies []string `json:"dependencies,omitempty"` // Required CLI tools
+
+	// Tests are acceptance checks describing how the skill should behave.
+	Tests []SkillTest `json:"tests,omitempty"`
+
+	// EnabledByDefault set to false ships the skill disabled, so users opt
+	// in to it. Nil means enabled. Tools with a disabled marker emit it
+	// (see DisabledAdapter).
+	EnabledByDefault *bool `json:"enabledByDefault,omitempty"`
+
+	// Deprecated marks a skill being phased out. It is still generated, but
+	// generation warns, and the generation manifest records it.
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// DeprecationMessage tells users what to use instead. It is included in
+	// the deprecation warning.
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+
+	// Experimental holds tool options the library does not model, written
+	// verbatim into the skill's frontmatter by adapters with passthrough
+	// (see ExperimentalAdapter). They are not validated, and never override
+	// a setting the adapter already writes.
+	Experimental map[string]any `json:"experimental,omitempty"`
+
+	// Loading
+	Order int `json:"order,omitempty"` // Load order; lower values load first, 0 means unordered
+
+	// Metadata holds tool-specific frontmatter keys that have no canonical
+	// field. Adapters with frontmatter write these through and parse them back.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// SkillTest is an acceptance check for a skill: given Input, the assistant's
+// response should contain each of ExpectedContains.
+type SkillTest struct {
+	Input            string   `json:"input"`
+	ExpectedContains []string `json:"expectedContains,omitempty"`
 }
 
 // NewSkill creates a new Skill with the given name and description.
@@ -51,7 +103,88 @@ func (s *Skill) AddTrigger(keyword string) {
 	s.Triggers = append(s.Triggers, keyword)
 }
 
+// AddActivationGlob adds a file pattern that activates the skill.
+func (s *Skill) AddActivationGlob(pattern string) {
+	s.ActivationGlobs = append(s.ActivationGlobs, pattern)
+}
+
+// AddTest adds an acceptance test to the skill.
+func (s *Skill) AddTest(input string, expectedContains ...string) {
+	s.Tests = append(s.Tests, SkillTest{Input: input, ExpectedContains: expectedContains})
+}
+
 // AddDependency adds a dependency to the skill.
 func (s *Skill) AddDependency(dep string) {
 	s.Dependencies = append(s.Dependencies, dep)
 }
+
+// SetMetadata sets a tool-specific metadata key on the skill.
+func (s *Skill) SetMetadata(key, value string) {
+	if s.Metadata == nil {
+		s.Metadata = make(map[string]string)
+	}
+	s.Metadata[key] = value
+}
+
+// MetadataKeys returns the metadata keys sorted alphabetically.
+func (s *Skill) MetadataKeys() []string {
+	keys := make([]string, 0, len(s.Metadata))
+	for key := range s.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsEnabledByDefault reports whether the skill ships enabled.
+func (s *Skill) IsEnabledByDefault() bool {
+	return s.EnabledByDefault == nil || *s.EnabledByDefault
+}
+
+// DeprecationWarning returns the warning printed when a deprecated skill is
+// generated, or "" when the skill is not deprecated.
+func (s *Skill) DeprecationWarning() string {
+	if !s.Deprecated {
+		return ""
+	}
+	if s.DeprecationMessage == "" {
+		return fmt.Sprintf("skill %q is deprecated", s.Name)
+	}
+	return fmt.Sprintf("skill %q is deprecated: %s", s.Name, s.DeprecationMessage)
+}
+
+// SortByOrder returns the skills sorted by load order.
+// Skills with a positive Order come first in ascending order; unordered
+// skills follow in their original order.
+func SortByOrder(skills []*Skill) []*Skill {
+	sorted := make([]*Skill, len(skills))
+	copy(sorted, skills)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		oi, oj := sorted[i].Order, sorted[j].Order
+		if oi > 0 && oj > 0 {
+			return oi < oj
+		}
+		return oi > 0 && oj <= 0
+	})
+	return sorted
+}
+
+// SortNamesByOrder returns skill names sorted by the load order of the
+// matching skills, as SortByOrder sorts skills. Names without a matching
+// skill are unordered.
+func SortNamesByOrder(names []string, skills []*Skill) []string {
+	order := make(map[string]int, len(skills))
+	for _, skill := range skills {
+		order[skill.Name] = skill.Order
+	}
+	sorted := make([]string, len(names))
+	copy(sorted, names)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		oi, oj := order[sorted[i]], order[sorted[j]]
+		if oi > 0 && oj > 0 {
+			return oi < oj
+		}
+		return oi > 0 && oj <= 0
+	})
+	return sorted
+}
@@ -1,6 +1,9 @@
 package core
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestNewSkill(t *testing.T) {
 	skill := NewSkill("version-analysis", "Analyze git history")
@@ -65,3 +68,106 @@ func TestSkillAddDependency(t *testing.T) {
 		t.Errorf("expected 2 dependencies, got %d", len(skill.Dependencies))
 	}
 }
+
+func TestSortByOrder(t *testing.T) {
+	unordered := NewSkill("unordered", "test")
+	first := NewSkill("first", "test")
+	first.Order = 1
+	second := NewSkill("second", "test")
+	second.Order = 2
+	later := NewSkill("later", "test")
+
+	skills := []*Skill{unordered, second, later, first}
+	sorted := SortByOrder(skills)
+
+	want := []string{"first", "second", "unordered", "later"}
+	for i, name := range want {
+		if sorted[i].Name != name {
+			t.Errorf("sorted[%d] = '%s', want '%s'", i, sorted[i].Name, name)
+		}
+	}
+
+	// Original slice must not be reordered
+	if skills[0].Name != "unordered" {
+		t.Errorf("expected original slice to be unchanged, got '%s' first", skills[0].Name)
+	}
+
+	names := SortNamesByOrder([]string{"missing", "second", "later", "first"}, skills)
+	if got := strings.Join(names, ","); got != "first,second,missing,later" {
+		t.Errorf("SortNamesByOrder = %s, want first,second,missing,later", got)
+	}
+}
+
+func TestParseSkillMarkdownOrder(t *testing.T) {
+	skill, err := ParseSkillMarkdown([]byte("---\nname: setup\norder: 3\n---\n\nSetup steps."))
+	if err != nil {
+		t.Fatalf("ParseSkillMarkdown failed: %v", err)
+	}
+	if skill.Order != 3 {
+		t.Errorf("expected Order 3, got %d", skill.Order)
+	}
+}
+
+func TestParseSkillMarkdownActivationGlobs(t *testing.T) {
+	skill, err := ParseSkillMarkdown([]byte("---\nname: go-style\nactivationGlobs: [\"**/*.go\", go.mod]\n---\n\nFollow Effective Go."))
+	if err != nil {
+		t.Fatalf("ParseSkillMarkdown failed: %v", err)
+	}
+	if len(skill.ActivationGlobs) != 2 || skill.ActivationGlobs[0] != "**/*.go" || skill.ActivationGlobs[1] != "go.mod" {
+		t.Errorf("expected activation globs [**/*.go go.mod], got %v", skill.ActivationGlobs)
+	}
+}
+
+func TestParseSkillMarkdownTOML(t *testing.T) {
+	data := "+++\nname = \"setup\"\ndescription = \"Project setup\"\ntriggers = [\"setup\", \"install\"]\n+++\n\nRun the setup script."
+	skill, err := ParseSkillMarkdown([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseSkillMarkdown failed: %v", err)
+	}
+	if skill.Name != "setup" {
+		t.Errorf("expected Name 'setup', got '%s'", skill.Name)
+	}
+	if skill.Description != "Project setup" {
+		t.Errorf("expected Description 'Project setup', got '%s'", skill.Description)
+	}
+	if len(skill.Triggers) != 2 {
+		t.Errorf("expected 2 triggers, got %d", len(skill.Triggers))
+	}
+	if skill.Instructions != "Run the setup script." {
+		t.Errorf("expected Instructions 'Run the setup script.', got '%s'", skill.Instructions)
+	}
+}
+
+func TestParseSkillMarkdownTOMLDelimiterInValue(t *testing.T) {
+	data := "+++\nname = \"concat\"\ndescription = \"Joins a+++b\"\n+++\n\nUse +++ to join.\n+++\nStill the body."
+	skill, err := ParseSkillMarkdown([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseSkillMarkdown failed: %v", err)
+	}
+	if skill.Description != "Joins a+++b" {
+		t.Errorf("expected Description 'Joins a+++b', got '%s'", skill.Description)
+	}
+	if skill.Instructions != "Use +++ to join.\n+++\nStill the body." {
+		t.Errorf("unexpected Instructions %q", skill.Instructions)
+	}
+}
+
+func TestRebaseLinks(t *testing.T) {
+	skill := NewSkill("review", "Review code")
+	skill.AddReference("references/checklist.md")
+	skill.Instructions = "See [style](./shared/style.md#naming) and [checklist](references/checklist.md).\n" +
+		"Docs: [site](https://example.com/x.md), [top](#usage), ![diagram](../docs/flow.png \"Flow\").\n" +
+		"```\n[literal](./shared/style.md)\n```"
+
+	got := RebaseLinks(skill, "..").Instructions
+
+	want := "See [style](../shared/style.md#naming) and [checklist](references/checklist.md).\n" +
+		"Docs: [site](https://example.com/x.md), [top](#usage), ![diagram](../../docs/flow.png \"Flow\").\n" +
+		"```\n[literal](./shared/style.md)\n```"
+	if got != want {
+		t.Errorf("RebaseLinks =\n%s\nwant\n%s", got, want)
+	}
+	if skill.Instructions == got {
+		t.Error("expected the input skill to be left unchanged")
+	}
+}
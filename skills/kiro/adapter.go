@@ -19,6 +19,10 @@ const (
 	SteeringDir = "steering"
 )
 
+// manualFrontmatter marks a steering file as manually included: Kiro loads
+// it only when the user references it with #name in chat.
+const manualFrontmatter = "---\ninclusion: manual\n---\n"
+
 func init() {
 	core.Register(&Adapter{})
 }
@@ -45,10 +49,17 @@ func (a *Adapter) DefaultDir() string {
 // Parse converts Kiro steering file bytes to canonical Skill.
 func (a *Adapter) Parse(data []byte) (*core.Skill, error) {
 	content := string(data)
-	lines := strings.SplitN(content, "\n", 2)
-
 	skill := &core.Skill{}
 
+	// Manually included steering files are skills disabled by default
+	if rest, ok := strings.CutPrefix(content, manualFrontmatter); ok {
+		enabled := false
+		skill.EnabledByDefault = &enabled
+		content = strings.TrimLeft(rest, "\n")
+	}
+
+	lines := strings.SplitN(content, "\n", 2)
+
 	// Extract name from first line (# Title)
 	if len(lines) > 0 && strings.HasPrefix(lines[0], "# ") {
 		title := strings.TrimPrefix(lines[0], "# ")
@@ -68,6 +79,11 @@ func (a *Adapter) Parse(data []byte) (*core.Skill, error) {
 func (a *Adapter) Marshal(skill *core.Skill) ([]byte, error) {
 	var buf bytes.Buffer
 
+	// Kiro loads manually included steering only when the user references it
+	if !skill.IsEnabledByDefault() {
+		buf.WriteString(manualFrontmatter + "\n")
+	}
+
 	// Write title from name (convert kebab-case to Title Case)
 	title := toTitleCase(skill.Name)
 	buf.WriteString(fmt.Sprintf("# %s\n\n", title))
@@ -88,6 +104,12 @@ func (a *Adapter) Marshal(skill *core.Skill) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// SupportsDisabled reports that steering files can ship disabled, as
+// manually included steering.
+func (a *Adapter) SupportsDisabled() bool {
+	return true
+}
+
 // ReadFile reads a Kiro steering file and returns canonical Skill.
 func (a *Adapter) ReadFile(path string) (*core.Skill, error) {
 	data, err := os.ReadFile(path)
@@ -131,19 +153,41 @@ func (a *Adapter) WriteFile(skill *core.Skill, path string) error {
 	return nil
 }
 
-// WriteSkillDir writes the skill as a steering file.
-// For Kiro, skills are flat files in the steering directory, not subdirectories.
+// WriteSkillDir writes the skill as a standalone steering file, named by
+// OrderedSteeringFileName. For Kiro, skills are flat files in the steering
+// directory, not subdirectories.
 func (a *Adapter) WriteSkillDir(skill *core.Skill, baseDir string) error {
 	// Ensure directory exists
 	if err := os.MkdirAll(baseDir, core.DefaultDirMode); err != nil {
 		return &core.WriteError{Path: baseDir, Err: err}
 	}
 
-	// Write steering file: steering/<skill-name>.md
-	steeringPath := filepath.Join(baseDir, skill.Name+".md")
+	// Write steering file: steering/[NN-]<skill-name>.md
+	steeringPath := filepath.Join(baseDir, OrderedSteeringFileName(skill))
 	return a.WriteFile(skill, steeringPath)
 }
 
+// SteeringFileName returns the steering file name for a skill,
+// "<skill-name>.md". The name does not depend on the skill's Order, so it
+// always matches the file://.kiro/steering/<skill-name>.md resource that Kiro
+// agents use to load it; agents express load order by the order of their
+// resources (see core.SortNamesByOrder).
+func SteeringFileName(skill *core.Skill) string {
+	return skill.Name + ".md"
+}
+
+// OrderedSteeringFileName returns the file name for a steering file that no
+// agent references, such as a power's steering or a standalone steering
+// directory. Ordered skills get a numeric prefix (e.g., "01-setup.md") so
+// that Kiro's alphabetical loading honors the canonical order; unordered
+// skills are named as by SteeringFileName.
+func OrderedSteeringFileName(skill *core.Skill) string {
+	if skill.Order > 0 {
+		return fmt.Sprintf("%02d-%s.md", skill.Order, skill.Name)
+	}
+	return SteeringFileName(skill)
+}
+
 // toKebabCase converts "Title Case" or "Title-Case" to "title-case".
 func toKebabCase(s string) string {
 	s = strings.ToLower(s)
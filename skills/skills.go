@@ -3,6 +3,7 @@
 // Supported tools:
 //   - Claude Code: skills/<name>/SKILL.md
 //   - OpenAI Codex: skills/<name>/SKILL.md
+//   - Windsurf: .windsurf/skills/<name>/SKILL.md
 //
 // Example usage:
 //
@@ -37,24 +38,41 @@ import (
 	_ "github.com/agentplexus/assistantkit/skills/claude"
 	_ "github.com/agentplexus/assistantkit/skills/codex"
 	_ "github.com/agentplexus/assistantkit/skills/kiro"
+	_ "github.com/agentplexus/assistantkit/skills/windsurf"
 )
 
 // Re-export core types for convenience
 type (
-	Skill   = core.Skill
-	Adapter = core.Adapter
+	Skill      = core.Skill
+	ScriptMeta = core.ScriptMeta
+	SkillTest  = core.SkillTest
+	Adapter    = core.Adapter
+
+	DisabledAdapter = core.DisabledAdapter
 )
 
 // Re-export core functions
 var (
-	NewSkill           = core.NewSkill
-	GetAdapter         = core.GetAdapter
-	AdapterNames       = core.AdapterNames
-	Convert            = core.Convert
-	ReadCanonicalFile  = core.ReadCanonicalFile
-	WriteCanonicalFile = core.WriteCanonicalFile
-	ReadCanonicalDir   = core.ReadCanonicalDir
-	WriteSkillsToDir   = core.WriteSkillsToDir
+	NewSkill            = core.NewSkill
+	GetAdapter          = core.GetAdapter
+	AdapterNames        = core.AdapterNames
+	Convert             = core.Convert
+	ConvertWithWarnings = core.ConvertWithWarnings
+	ReadCanonicalFile   = core.ReadCanonicalFile
+	WriteCanonicalFile  = core.WriteCanonicalFile
+	ReadCanonicalDir    = core.ReadCanonicalDir
+	ResolveIncludes     = core.ResolveIncludes
+	WriteSkillsToDir    = core.WriteSkillsToDir
+	SortByOrder         = core.SortByOrder
+	SortNamesByOrder    = core.SortNamesByOrder
+	RebaseLinks         = core.RebaseLinks
+	SkillDirLinkPrefix  = core.SkillDirLinkPrefix
+	WriteScripts        = core.WriteScripts
+
+	SupportsDisabled      = core.SupportsDisabled
+	CheckEnabledByDefault = core.CheckEnabledByDefault
+	SupportsExperimental  = core.SupportsExperimental
+	CheckExperimental     = core.CheckExperimental
 )
 
 // Re-export error types
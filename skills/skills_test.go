@@ -1,6 +1,8 @@
 package skills
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -88,6 +90,71 @@ func TestClaudeAdapter(t *testing.T) {
 	}
 }
 
+func TestClaudeAdapterMetadataRoundTrip(t *testing.T) {
+	adapter, ok := GetAdapter("claude")
+	if !ok {
+		t.Fatal("Claude adapter not found")
+	}
+
+	skill := NewSkill("pdf-tools", "Work with PDF files")
+	skill.Instructions = "Extract text and tables from PDFs."
+	skill.SetMetadata("allowed-tools", "[Read, Bash]")
+	skill.SetMetadata("license", "Apache-2.0")
+	skill.SetMetadata("x-note", "see: docs #2")
+
+	data, err := adapter.Marshal(skill)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), "license: Apache-2.0\n") {
+		t.Errorf("expected metadata in frontmatter, got:\n%s", data)
+	}
+
+	parsed, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(parsed.Metadata) != len(skill.Metadata) {
+		t.Fatalf("round-trip: expected metadata %v, got %v", skill.Metadata, parsed.Metadata)
+	}
+	for key, want := range skill.Metadata {
+		if got := parsed.Metadata[key]; got != want {
+			t.Errorf("round-trip: metadata %q = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestClaudeAdapterActivationGlobsRoundTrip(t *testing.T) {
+	adapter, ok := GetAdapter("claude")
+	if !ok {
+		t.Fatal("Claude adapter not found")
+	}
+
+	skill := NewSkill("go-style", "Go style guide")
+	skill.Instructions = "Follow Effective Go."
+	skill.AddActivationGlob("**/*.go")
+	skill.AddActivationGlob("go.mod")
+
+	data, err := adapter.Marshal(skill)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `paths: ["**/*.go", "go.mod"]`+"\n") {
+		t.Errorf("expected quoted paths in frontmatter, got:\n%s", data)
+	}
+
+	parsed, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if strings.Join(parsed.ActivationGlobs, ",") != "**/*.go,go.mod" {
+		t.Errorf("round-trip: expected activation globs %v, got %v", skill.ActivationGlobs, parsed.ActivationGlobs)
+	}
+	if _, ok := parsed.Metadata["paths"]; ok {
+		t.Error("paths should not be kept as metadata")
+	}
+}
+
 func TestCodexAdapter(t *testing.T) {
 	adapter, ok := GetAdapter("codex")
 	if !ok {
@@ -158,3 +225,308 @@ Test instructions here.
 		t.Error("expected description in converted output")
 	}
 }
+
+func TestWriteSkillsToDirOrder(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "skills-order-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	setup := NewSkill("setup", "Project setup")
+	setup.Order = 1
+	style := NewSkill("style", "Code style")
+	style.Order = 2
+	misc := NewSkill("misc", "Miscellaneous")
+
+	if err := WriteSkillsToDir([]*Skill{misc, style, setup}, tmpDir, "kiro"); err != nil {
+		t.Fatalf("WriteSkillsToDir failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	// Standalone steering files are prefixed so Kiro loads them in Order
+	want := []string{"01-setup.md", "02-style.md", "misc.md"}
+	if len(names) != len(want) {
+		t.Fatalf("expected files %v, got %v", want, names)
+	}
+	for i, name := range want {
+		if names[i] != name {
+			t.Errorf("file[%d] = '%s', want '%s'", i, names[i], name)
+		}
+	}
+
+	// Claude skills are directories named after the skill
+	claudeDir := filepath.Join(tmpDir, "claude")
+	if err := WriteSkillsToDir([]*Skill{setup}, claudeDir, "claude"); err != nil {
+		t.Fatalf("WriteSkillsToDir failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(claudeDir, "setup", "SKILL.md")); err != nil {
+		t.Errorf("expected unprefixed claude skill dir: %v", err)
+	}
+}
+
+func TestClaudeWriteSkillDirRebasesLinks(t *testing.T) {
+	skill := NewSkill("review", "Review code")
+	skill.Instructions = "Follow [the style guide](./shared/style.md) and [the checklist](./references/checklist.md)."
+	skill.AddReference("references/checklist.md")
+
+	adapter, _ := GetAdapter("claude")
+	dir := t.TempDir()
+	if err := adapter.WriteSkillDir(skill, dir); err != nil {
+		t.Fatalf("WriteSkillDir failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "review", "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "[the style guide](../shared/style.md)") {
+		t.Errorf("expected shared link rebased for the skill directory, got:\n%s", content)
+	}
+	if !strings.Contains(content, "[the checklist](./references/checklist.md)") {
+		t.Errorf("expected skill-local link left alone, got:\n%s", content)
+	}
+
+	// A skill.json in its own directory already links relative to it.
+	specs := t.TempDir()
+	source := filepath.Join(specs, "skills", "review", "skill.json")
+	if err := os.MkdirAll(filepath.Dir(source), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(source, []byte(`{"name":"review","description":"Review code","instructions":"See [notes](./notes.md)."}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	nested, err := ReadCanonicalFile(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"claude", "codex"} {
+		adapter, _ := GetAdapter(name)
+		out := t.TempDir()
+		if err := adapter.WriteSkillDir(nested, out); err != nil {
+			t.Fatalf("%s: WriteSkillDir failed: %v", name, err)
+		}
+		data, err := os.ReadFile(filepath.Join(out, "review", "SKILL.md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "[notes](./notes.md)") {
+			t.Errorf("%s: expected links of a nested skill.json to stay as written, got:\n%s", name, data)
+		}
+	}
+}
+
+func TestConvertWithWarnings(t *testing.T) {
+	claudeMD := `---
+name: test-skill
+description: A test skill
+triggers: [review, audit]
+---
+
+# Test Skill
+
+Test instructions here.
+`
+
+	// Kiro steering files cannot represent triggers
+	_, warnings, err := ConvertWithWarnings([]byte(claudeMD), "claude", "kiro")
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings failed: %v", err)
+	}
+
+	found := false
+	for _, w := range warnings {
+		if strings.Contains(w, `"triggers"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected warning about dropped triggers, got %v", warnings)
+	}
+
+	// Claude supports triggers, so nothing is dropped
+	_, warnings, err = ConvertWithWarnings([]byte(claudeMD), "claude", "claude")
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings failed: %v", err)
+	}
+	for _, w := range warnings {
+		if strings.Contains(w, `"triggers"`) {
+			t.Errorf("unexpected triggers warning for claude: %s", w)
+		}
+	}
+}
+
+func TestWriteSkillDirCopiesExecutableScripts(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "release")
+	if err := os.MkdirAll(filepath.Join(src, "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"skill.json": `{"name":"release","description":"Cut a release","instructions":"Tag it.",` +
+			`"scripts":["scripts/tag.sh","scripts/notes.txt","scripts/bump.py"],` +
+			`"scriptMeta":{"scripts/tag.sh":{"interpreter":"bash"},"scripts/bump.py":{"executable":true}}}`,
+		"scripts/tag.sh":    "git tag \"$1\"\n",
+		"scripts/notes.txt": "Release notes template\n",
+		"scripts/bump.py":   "print('bump')\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(src, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	skill, err := ReadCanonicalFile(filepath.Join(src, "skill.json"))
+	if err != nil {
+		t.Fatalf("ReadCanonicalFile failed: %v", err)
+	}
+
+	adapter, _ := GetAdapter("claude")
+	out := t.TempDir()
+	if err := adapter.WriteSkillDir(skill, out); err != nil {
+		t.Fatalf("WriteSkillDir failed: %v", err)
+	}
+
+	for script, executable := range map[string]bool{
+		"scripts/tag.sh":    true,
+		"scripts/bump.py":   true,
+		"scripts/notes.txt": false,
+	} {
+		info, err := os.Stat(filepath.Join(out, "release", script))
+		if err != nil {
+			t.Fatalf("expected %s to be copied: %v", script, err)
+		}
+		if got := info.Mode().Perm()&0100 != 0; got != executable {
+			t.Errorf("%s: expected executable=%v, got mode %v", script, executable, info.Mode().Perm())
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, "release", "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "- `scripts/tag.sh` (run with `bash`)") {
+		t.Errorf("expected the interpreter to be recorded, got:\n%s", data)
+	}
+}
+
+func TestClaudeAdapterRendersTests(t *testing.T) {
+	skill := NewSkill("commit-message", "Write conventional commit messages")
+	skill.Instructions = "Summarize the staged diff as a conventional commit."
+	skill.AddTest("Write a commit for a bug fix in the parser", "fix(parser):")
+	skill.AddTest("Describe a new CLI flag", "feat", "--")
+
+	adapter, _ := GetAdapter("claude")
+	data, err := adapter.Marshal(skill)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	out := string(data)
+
+	for _, want := range []string{
+		"## Tests\n",
+		`1. Input: "Write a commit for a bug fix in the parser"` + "\n   Expected: \"fix(parser):\"\n",
+		`2. Input: "Describe a new CLI flag"` + "\n   Expected: \"feat\", \"--\"\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "## Tests") < strings.Index(out, "## Instructions") {
+		t.Error("expected tests to follow the instructions")
+	}
+
+	skill.Tests = nil
+	data, _ = adapter.Marshal(skill)
+	if strings.Contains(string(data), "## Tests") {
+		t.Error("expected no test section without tests")
+	}
+}
+
+func TestDisabledByDefaultSkill(t *testing.T) {
+	enabled := false
+	skill := NewSkill("release-notes", "Drafts release notes")
+	skill.Instructions = "Summarize merged changes."
+	skill.EnabledByDefault = &enabled
+
+	for _, tt := range []struct{ tool, marker string }{
+		{"claude", "disable-model-invocation: true\n"},
+		{"kiro", "---\ninclusion: manual\n---\n"},
+	} {
+		adapter, _ := GetAdapter(tt.tool)
+		data, err := adapter.Marshal(skill)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", tt.tool, err)
+		}
+		if !strings.Contains(string(data), tt.marker) {
+			t.Errorf("%s: expected disabled marker %q, got:\n%s", tt.tool, tt.marker, data)
+		}
+		parsed, err := adapter.Parse(data)
+		if err != nil {
+			t.Fatalf("%s: Parse failed: %v", tt.tool, err)
+		}
+		if parsed.IsEnabledByDefault() {
+			t.Errorf("%s: expected the parsed skill to be disabled by default", tt.tool)
+		}
+		if len(CheckEnabledByDefault(adapter, skill)) != 0 {
+			t.Errorf("%s: expected no warning for a supporting tool", tt.tool)
+		}
+	}
+
+	enabled = true
+	claude, _ := GetAdapter("claude")
+	data, _ := claude.Marshal(skill)
+	if strings.Contains(string(data), "disable-model-invocation") {
+		t.Errorf("expected no disabled marker for an enabled skill, got:\n%s", data)
+	}
+
+	enabled = false
+	codex, _ := GetAdapter("codex")
+	if warnings := CheckEnabledByDefault(codex, skill); len(warnings) != 1 {
+		t.Errorf("expected a warning for codex, got %v", warnings)
+	}
+}
+
+func TestExperimentalPassthrough(t *testing.T) {
+	md := "---\nname: lint\ndescription: Lint the code\nexperimental:\n  model: opus\n  allowed-tools: [\"Bash\"]\n---\n\nRun the linter.\n"
+	path := filepath.Join(t.TempDir(), "lint.md")
+	if err := os.WriteFile(path, []byte(md), 0600); err != nil {
+		t.Fatal(err)
+	}
+	skill, err := ReadCanonicalFile(path)
+	if err != nil {
+		t.Fatalf("ReadCanonicalFile failed: %v", err)
+	}
+	if skill.Experimental["model"] != "opus" {
+		t.Fatalf("expected experimental options from frontmatter, got %v", skill.Experimental)
+	}
+
+	for _, tool := range []string{"claude", "codex"} {
+		adapter, _ := GetAdapter(tool)
+		data, err := adapter.Marshal(skill)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", tool, err)
+		}
+		for _, want := range []string{"allowed-tools: [\"Bash\"]\n", "model: \"opus\"\n"} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("expected %q in %s output, got:\n%s", want, tool, data)
+			}
+		}
+		if warnings := CheckExperimental(adapter, skill); len(warnings) != 1 || !strings.Contains(warnings[0], "unvalidated") {
+			t.Errorf("expected an unvalidated warning for %s, got %v", tool, warnings)
+		}
+	}
+
+	kiro, _ := GetAdapter("kiro")
+	if warnings := CheckExperimental(kiro, skill); len(warnings) != 1 || !strings.Contains(warnings[0], "ignored") {
+		t.Errorf("expected an ignored warning for kiro, got %v", warnings)
+	}
+}
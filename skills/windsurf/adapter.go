@@ -0,0 +1,38 @@
+// Package windsurf provides the Windsurf (Codeium) skill adapter.
+//
+// Windsurf reads skills from .windsurf/skills/<name>/SKILL.md using the same
+// SKILL.md format as Claude Code.
+package windsurf
+
+import (
+	"github.com/agentplexus/assistantkit/skills/claude"
+	"github.com/agentplexus/assistantkit/skills/core"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "windsurf"
+
+	// SkillsDir is the default skills directory name.
+	SkillsDir = ".windsurf/skills"
+)
+
+func init() {
+	core.Register(&Adapter{})
+}
+
+// Adapter converts between canonical Skill and Windsurf skill format.
+// Parsing and marshaling are shared with the Claude adapter.
+type Adapter struct {
+	claude.Adapter
+}
+
+// Name returns the adapter identifier.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultDir returns the default directory name for Windsurf skills.
+func (a *Adapter) DefaultDir() string {
+	return SkillsDir
+}
@@ -1,5 +1,10 @@
 package core
 
+import (
+	hookscore "github.com/agentplexus/assistantkit/hooks/core"
+	mcpcore "github.com/agentplexus/assistantkit/mcp/core"
+)
+
 // Team represents a multi-agent orchestration definition.
 // A team coordinates multiple agents to accomplish a complex workflow.
 type Team struct {
@@ -23,6 +28,15 @@ type Team struct {
 
 	// Version is the target version for release workflows.
 	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
+	// SharedHooks run for every agent in the team. Deployment generation
+	// merges them into each target's hooks output, dropping duplicates.
+	SharedHooks *hookscore.Config `json:"sharedHooks,omitempty" yaml:"sharedHooks,omitempty"`
+
+	// SharedMCP are MCP servers available to every agent in the team, by
+	// name. Deployment generation merges them into each target's MCP
+	// output; a server the target already defines under the same name wins.
+	SharedMCP map[string]mcpcore.Server `json:"sharedMcp,omitempty" yaml:"sharedMcp,omitempty"`
 }
 
 // NewTeam creates a new Team with the given name and process type.
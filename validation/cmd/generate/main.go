@@ -11,9 +11,9 @@ import (
 	"strings"
 
 	"github.com/agentplexus/assistantkit/validation"
-	_ "github.com/agentplexus/assistantkit/validation/claude" // Register Claude adapter
-	_ "github.com/agentplexus/assistantkit/validation/codex"  // Register Codex adapter
-	_ "github.com/agentplexus/assistantkit/validation/gemini" // Register Gemini adapter
+	"github.com/agentplexus/assistantkit/validation/claude" // Register Claude adapter
+	"github.com/agentplexus/assistantkit/validation/codex"  // Register Codex adapter
+	"github.com/agentplexus/assistantkit/validation/gemini" // Register Gemini adapter
 )
 
 func main() {
@@ -22,6 +22,7 @@ func main() {
 		outputDir = flag.String("output", "/tmp/validation-agents", "Output directory")
 		adapters  = flag.String("adapters", "claude", "Comma-separated list of adapters (claude, gemini, codex, or all)")
 		listOnly  = flag.Bool("list", false, "List available adapters and exit")
+		preflight = flag.Bool("preflight", false, "Embed dependency install steps in each area and write install-deps.sh")
 	)
 
 	flag.Usage = func() {
@@ -32,6 +33,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  %s -specs=./specs -output=./output -adapters=claude\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -specs=./specs -output=./output -adapters=all\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -specs=./specs -output=./output -preflight\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -list\n", os.Args[0])
 	}
 
@@ -55,6 +57,22 @@ func main() {
 
 	fmt.Printf("Found %d validation areas\n", len(areas))
 
+	// Replace the default adapters with ones that embed install steps
+	if *preflight {
+		validation.Register(&claude.Adapter{Preflight: true})
+		validation.Register(&codex.Adapter{Preflight: true})
+		validation.Register(&gemini.Adapter{Preflight: true})
+
+		if err := os.MkdirAll(*outputDir, 0700); err != nil {
+			log.Fatalf("Failed to create %s: %v", *outputDir, err)
+		}
+		scriptPath := filepath.Join(*outputDir, "install-deps.sh")
+		if err := os.WriteFile(scriptPath, validation.InstallScriptForAreas(areas), 0700); err != nil {
+			log.Fatalf("Failed to write %s: %v", scriptPath, err)
+		}
+		fmt.Printf("Wrote %s\n", scriptPath)
+	}
+
 	// Determine which adapters to use
 	var adapterNames []string
 	if *adapters == "all" {
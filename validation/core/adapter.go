@@ -132,7 +132,8 @@ func WriteCanonicalFile(area *ValidationArea, path string) error {
 	return nil
 }
 
-// ReadCanonicalDir reads all validation-area.json files from a directory.
+// ReadCanonicalDir reads all validation-area.json files from a directory,
+// resolving each area's BaseRef (see ResolveBases).
 func ReadCanonicalDir(dir string) ([]*ValidationArea, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -153,7 +154,7 @@ func ReadCanonicalDir(dir string) ([]*ValidationArea, error) {
 		areas = append(areas, area)
 	}
 
-	return areas, nil
+	return ResolveBases(areas, dir)
 }
 
 // WriteAreasToDir writes multiple validation areas to a directory using the specified adapter.
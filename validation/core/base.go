@@ -0,0 +1,112 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ResolveBases returns copies of areas with each BaseRef chain flattened
+// into the child. A BaseRef ending in ".json" is a base spec file, relative
+// to dir; any other BaseRef names another area in areas. Keeping base files
+// in a subdirectory of dir stops them from being generated as areas.
+//
+// Every field except Name is inherited from the base unless the child sets
+// it; a child's Checks, Dependencies, Tools, or Skills replace the base's
+// rather than extending them. The resolved areas have BaseRef cleared.
+func ResolveBases(areas []*ValidationArea, dir string) ([]*ValidationArea, error) {
+	byName := make(map[string]*ValidationArea, len(areas))
+	for _, area := range areas {
+		byName[area.Name] = area
+	}
+	files := make(map[string]*ValidationArea)
+
+	lookup := func(area *ValidationArea) (*ValidationArea, error) {
+		if !strings.HasSuffix(area.BaseRef, ".json") {
+			base, ok := byName[area.BaseRef]
+			if !ok {
+				return nil, &BaseError{Area: area.Name, Base: area.BaseRef, Message: "base area not found"}
+			}
+			return base, nil
+		}
+		path := filepath.Join(dir, filepath.FromSlash(area.BaseRef))
+		if base, ok := files[path]; ok {
+			return base, nil
+		}
+		base, err := ReadCanonicalFile(path)
+		if err != nil {
+			return nil, &BaseError{Area: area.Name, Base: area.BaseRef, Err: err}
+		}
+		files[path] = base
+		return base, nil
+	}
+
+	resolved := make(map[*ValidationArea]*ValidationArea, len(areas))
+	var resolve func(area *ValidationArea, visiting map[*ValidationArea]bool) (*ValidationArea, error)
+	resolve = func(area *ValidationArea, visiting map[*ValidationArea]bool) (*ValidationArea, error) {
+		if r, ok := resolved[area]; ok {
+			return r, nil
+		}
+		if area.BaseRef == "" {
+			r := *area
+			resolved[area] = &r
+			return &r, nil
+		}
+		if visiting[area] {
+			return nil, &BaseError{Area: area.Name, Base: area.BaseRef, Message: "base cycle"}
+		}
+		visiting[area] = true
+
+		base, err := lookup(area)
+		if err != nil {
+			return nil, err
+		}
+		resolvedBase, err := resolve(base, visiting)
+		if err != nil {
+			return nil, err
+		}
+		r := mergeAreas(resolvedBase, area)
+		resolved[area] = r
+		return r, nil
+	}
+
+	out := make([]*ValidationArea, 0, len(areas))
+	for _, area := range areas {
+		r, err := resolve(area, map[*ValidationArea]bool{})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// mergeAreas returns child merged over an already-resolved base.
+func mergeAreas(base, child *ValidationArea) *ValidationArea {
+	merged := *child
+	merged.BaseRef = ""
+	merged.Description = firstNonEmpty(child.Description, base.Description)
+	merged.SignOffCriteria = firstNonEmpty(child.SignOffCriteria, base.SignOffCriteria)
+	merged.Instructions = firstNonEmpty(child.Instructions, base.Instructions)
+	merged.Model = firstNonEmpty(child.Model, base.Model)
+	if len(child.Checks) == 0 {
+		merged.Checks = base.Checks
+	}
+	if len(child.Dependencies) == 0 {
+		merged.Dependencies = base.Dependencies
+	}
+	if len(child.Tools) == 0 {
+		merged.Tools = base.Tools
+	}
+	if len(child.Skills) == 0 {
+		merged.Skills = base.Skills
+	}
+	return &merged
+}
+
+// firstNonEmpty returns a if it is set, otherwise b.
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
@@ -48,6 +48,25 @@ func (e *ParseError) Unwrap() error {
 	return e.Err
 }
 
+// BaseError indicates an area's BaseRef chain cannot be resolved.
+type BaseError struct {
+	Area    string
+	Base    string
+	Message string
+	Err     error
+}
+
+func (e *BaseError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("validation area %s base %s: %v", e.Area, e.Base, e.Err)
+	}
+	return fmt.Sprintf("validation area %s base %s: %s", e.Area, e.Base, e.Message)
+}
+
+func (e *BaseError) Unwrap() error {
+	return e.Err
+}
+
 // MarshalError represents an error marshaling to a format.
 type MarshalError struct {
 	Format string
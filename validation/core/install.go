@@ -0,0 +1,212 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Installer identifies a package manager used to provision a dependency.
+type Installer string
+
+const (
+	// InstallerBrew installs with Homebrew (macOS and Linuxbrew).
+	InstallerBrew Installer = "brew"
+
+	// InstallerApt installs with apt-get (Debian and Ubuntu).
+	InstallerApt Installer = "apt"
+
+	// InstallerGo installs with go install.
+	InstallerGo Installer = "go"
+
+	// InstallerNpm installs a global package with npm.
+	InstallerNpm Installer = "npm"
+
+	// InstallerPip installs with pip.
+	InstallerPip Installer = "pip"
+)
+
+// installerOrder is the order installers are tried in a generated script.
+var installerOrder = []Installer{InstallerBrew, InstallerApt, InstallerGo, InstallerNpm, InstallerPip}
+
+// installerProbe is the command whose presence makes an installer usable.
+var installerProbe = map[Installer]string{
+	InstallerBrew: "brew",
+	InstallerApt:  "apt-get",
+	InstallerGo:   "go",
+	InstallerNpm:  "npm",
+	InstallerPip:  "pip3",
+}
+
+// knownInstalls maps dependency names to their install commands per
+// installer. Tools missing from an installer are not packaged there.
+var knownInstalls = map[string]map[Installer]string{
+	"gh": {
+		InstallerBrew: "brew install gh",
+		InstallerApt:  "sudo apt-get install -y gh",
+	},
+	"git": {
+		InstallerBrew: "brew install git",
+		InstallerApt:  "sudo apt-get install -y git",
+	},
+	"jq": {
+		InstallerBrew: "brew install jq",
+		InstallerApt:  "sudo apt-get install -y jq",
+	},
+	"yq": {
+		InstallerBrew: "brew install yq",
+		InstallerGo:   "go install github.com/mikefarah/yq/v4@latest",
+	},
+	"shellcheck": {
+		InstallerBrew: "brew install shellcheck",
+		InstallerApt:  "sudo apt-get install -y shellcheck",
+	},
+	"go": {
+		InstallerBrew: "brew install go",
+		InstallerApt:  "sudo apt-get install -y golang-go",
+	},
+	"golangci-lint": {
+		InstallerBrew: "brew install golangci-lint",
+		InstallerGo:   "go install github.com/golangci/golangci-lint/cmd/golangci-lint@latest",
+	},
+	"govulncheck": {
+		InstallerGo: "go install golang.org/x/vuln/cmd/govulncheck@latest",
+	},
+	"gosec": {
+		InstallerBrew: "brew install gosec",
+		InstallerGo:   "go install github.com/securego/gosec/v2/cmd/gosec@latest",
+	},
+	"staticcheck": {
+		InstallerBrew: "brew install staticcheck",
+		InstallerGo:   "go install honnef.co/go/tools/cmd/staticcheck@latest",
+	},
+	"goreleaser": {
+		InstallerBrew: "brew install goreleaser",
+		InstallerGo:   "go install github.com/goreleaser/goreleaser/v2@latest",
+	},
+	"git-cliff": {
+		InstallerBrew: "brew install git-cliff",
+	},
+	"trivy": {
+		InstallerBrew: "brew install trivy",
+	},
+	"markdownlint": {
+		InstallerBrew: "brew install markdownlint-cli",
+		InstallerNpm:  "npm install -g markdownlint-cli",
+	},
+	"semgrep": {
+		InstallerBrew: "brew install semgrep",
+		InstallerPip:  "pip3 install semgrep",
+	},
+}
+
+// InstallCommands returns the known install commands for a dependency,
+// keyed by installer. The second result is false for unknown dependencies.
+func InstallCommands(dep string) (map[Installer]string, bool) {
+	commands, ok := knownInstalls[dep]
+	if !ok {
+		return nil, false
+	}
+	out := make(map[Installer]string, len(commands))
+	for installer, cmd := range commands {
+		out[installer] = cmd
+	}
+	return out, true
+}
+
+// KnownDependencies returns the dependency names with install commands,
+// sorted alphabetically.
+func KnownDependencies() []string {
+	names := make([]string, 0, len(knownInstalls))
+	for name := range knownInstalls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GenerateInstallScript returns a POSIX shell script that installs each
+// missing dependency with the first available installer, in the order
+// brew, apt, go, npm, pip. Dependencies already on PATH are skipped, and
+// unknown dependencies produce a warning instead of an install line.
+// Duplicate dependencies are installed once.
+func GenerateInstallScript(deps []string) []byte {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	b.WriteString("# Pre-flight: install CLI tools required by validation checks.\n")
+	b.WriteString("set -e\n\n")
+	b.WriteString("has() { command -v \"$1\" >/dev/null 2>&1; }\n")
+
+	seen := make(map[string]bool)
+	for _, dep := range deps {
+		if dep == "" || seen[dep] {
+			continue
+		}
+		seen[dep] = true
+
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("if ! has %s; then\n", shellQuote(dep)))
+
+		commands, ok := knownInstalls[dep]
+		if !ok {
+			b.WriteString(fmt.Sprintf("  echo \"warning: no known installer for %s; install it manually\" >&2\n", dep))
+			b.WriteString("fi\n")
+			continue
+		}
+
+		keyword := "if"
+		for _, installer := range installerOrder {
+			cmd, ok := commands[installer]
+			if !ok {
+				continue
+			}
+			b.WriteString(fmt.Sprintf("  %s has %s; then\n", keyword, installerProbe[installer]))
+			b.WriteString(fmt.Sprintf("    %s\n", cmd))
+			keyword = "elif"
+		}
+		b.WriteString("  else\n")
+		b.WriteString(fmt.Sprintf("    echo \"warning: cannot install %s: no supported package manager found\" >&2\n", dep))
+		b.WriteString("  fi\n")
+		b.WriteString("fi\n")
+	}
+
+	return []byte(b.String())
+}
+
+// InstallScriptForAreas returns an install script covering the
+// dependencies of all the given areas.
+func InstallScriptForAreas(areas []*ValidationArea) []byte {
+	var deps []string
+	for _, area := range areas {
+		deps = append(deps, area.Dependencies...)
+	}
+	return GenerateInstallScript(deps)
+}
+
+// PreflightSection returns a Markdown section instructing an agent to run
+// the install script for the area's dependencies before its checks, or an
+// empty string when the area has no dependencies. Adapters include it when
+// their Preflight option is set.
+func PreflightSection(area *ValidationArea) string {
+	if len(area.Dependencies) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("## Pre-flight\n\n")
+	b.WriteString("Before running checks, install any missing tools:\n\n")
+	b.WriteString("```sh\n")
+	b.Write(GenerateInstallScript(area.Dependencies))
+	b.WriteString("```\n\n")
+	return b.String()
+}
+
+// shellQuote quotes s for a POSIX shell when it contains special characters.
+func shellQuote(s string) string {
+	if strings.IndexFunc(s, func(r rune) bool {
+		return !(r == '-' || r == '_' || r == '.' || r == '/' || r == '+' ||
+			('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9'))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -13,6 +13,12 @@ type ValidationArea struct {
 	Name        string `json:"name"`        // Area identifier (e.g., "qa", "documentation")
 	Description string `json:"description"` // Brief description of the area's responsibility
 
+	// BaseRef references a shared base spec whose fields the area inherits
+	// unless it sets them: a base spec file ending in ".json", relative to
+	// the area's directory, or the name of another area. ReadCanonicalDir
+	// resolves it (see ResolveBases).
+	BaseRef string `json:"base_ref,omitempty"`
+
 	// Sign-off criteria
 	SignOffCriteria string `json:"sign_off_criteria"` // What must pass for GO status
 
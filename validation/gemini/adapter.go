@@ -17,7 +17,11 @@ func init() {
 }
 
 // Adapter converts between canonical ValidationArea and Gemini CLI command format.
-type Adapter struct{}
+type Adapter struct {
+	// Preflight adds an install script for the area's Dependencies, so
+	// the agent can provision missing tools before running checks.
+	Preflight bool
+}
 
 // Name returns the adapter identifier.
 func (a *Adapter) Name() string {
@@ -167,6 +171,10 @@ func (a *Adapter) Marshal(area *core.ValidationArea) ([]byte, error) {
 		buf.WriteString("\n")
 	}
 
+	if a.Preflight {
+		buf.WriteString(core.PreflightSection(area))
+	}
+
 	// Instructions
 	if area.Instructions != "" {
 		buf.WriteString("## Instructions\n\n")
@@ -61,6 +61,33 @@ func NewValidationArea(name, description string) *ValidationArea {
 	return core.NewValidationArea(name, description)
 }
 
+// Installer identifies a package manager used to provision a dependency.
+type Installer = core.Installer
+
+// Installer constants
+const (
+	InstallerBrew = core.InstallerBrew
+	InstallerApt  = core.InstallerApt
+	InstallerGo   = core.InstallerGo
+	InstallerNpm  = core.InstallerNpm
+	InstallerPip  = core.InstallerPip
+)
+
+// InstallCommands returns the known install commands for a dependency.
+func InstallCommands(dep string) (map[Installer]string, bool) {
+	return core.InstallCommands(dep)
+}
+
+// GenerateInstallScript returns a shell script that installs missing dependencies.
+func GenerateInstallScript(deps []string) []byte {
+	return core.GenerateInstallScript(deps)
+}
+
+// InstallScriptForAreas returns an install script for the dependencies of all areas.
+func InstallScriptForAreas(areas []*ValidationArea) []byte {
+	return core.InstallScriptForAreas(areas)
+}
+
 // Adapter is the adapter interface.
 type Adapter = core.Adapter
 
@@ -89,11 +116,20 @@ func WriteCanonicalFile(area *ValidationArea, path string) error {
 	return core.WriteCanonicalFile(area, path)
 }
 
-// ReadCanonicalDir reads all validation-area.json files from a directory.
+// ReadCanonicalDir reads all validation-area.json files from a directory,
+// resolving each area's BaseRef.
 func ReadCanonicalDir(dir string) ([]*ValidationArea, error) {
 	return core.ReadCanonicalDir(dir)
 }
 
+// ResolveBases flattens each area's BaseRef chain into the area.
+func ResolveBases(areas []*ValidationArea, dir string) ([]*ValidationArea, error) {
+	return core.ResolveBases(areas, dir)
+}
+
+// BaseError indicates an area's BaseRef chain cannot be resolved.
+type BaseError = core.BaseError
+
 // WriteAreasToDir writes validation areas to a directory using the specified adapter.
 func WriteAreasToDir(areas []*ValidationArea, dir string, adapterName string) error {
 	return core.WriteAreasToDir(areas, dir, adapterName)
@@ -1,13 +1,14 @@
 package validation_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/agentplexus/assistantkit/validation"
-	_ "github.com/agentplexus/assistantkit/validation/claude" // Register Claude adapter
+	"github.com/agentplexus/assistantkit/validation/claude"   // Register Claude adapter
 	_ "github.com/agentplexus/assistantkit/validation/codex"  // Register Codex adapter
 	_ "github.com/agentplexus/assistantkit/validation/gemini" // Register Gemini adapter
 )
@@ -396,3 +397,132 @@ func TestReadCanonicalDir(t *testing.T) {
 		}
 	}
 }
+
+func TestReadCanonicalDirResolvesBaseRef(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"_base/common.json": `{"name":"common","description":"Shared setup","sign_off_criteria":"All required checks pass",` +
+			`"instructions":"Report results as a GO/NO-GO table.","tools":["Read","Grep","Bash"],` +
+			`"checks":[{"name":"lint","command":"make lint","required":true}]}`,
+		"qa.json": `{"name":"qa","description":"Quality assurance","base_ref":"_base/common.json",` +
+			`"checks":[{"name":"tests","command":"go test ./...","required":true}]}`,
+		"security.json": `{"name":"security","description":"Security review","base_ref":"qa","tools":["Read"]}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	areas, err := validation.ReadCanonicalDir(dir)
+	if err != nil {
+		t.Fatalf("ReadCanonicalDir failed: %v", err)
+	}
+	if len(areas) != 2 {
+		t.Fatalf("expected the base file not to be read as an area, got %d areas", len(areas))
+	}
+	byName := map[string]*validation.ValidationArea{}
+	for _, area := range areas {
+		byName[area.Name] = area
+	}
+
+	qa := byName["qa"]
+	if strings.Join(qa.Tools, ",") != "Read,Grep,Bash" {
+		t.Errorf("expected qa to inherit the base's tools, got %v", qa.Tools)
+	}
+	if len(qa.Checks) != 1 || qa.Checks[0].Name != "tests" {
+		t.Errorf("expected qa's checks to override the base's, got %+v", qa.Checks)
+	}
+	if qa.Description != "Quality assurance" || qa.SignOffCriteria != "All required checks pass" {
+		t.Errorf("expected own description and inherited sign-off criteria, got %q / %q", qa.Description, qa.SignOffCriteria)
+	}
+	if qa.BaseRef != "" {
+		t.Errorf("expected BaseRef to be cleared, got %q", qa.BaseRef)
+	}
+
+	security := byName["security"]
+	if strings.Join(security.Tools, ",") != "Read" {
+		t.Errorf("expected security's tools to override, got %v", security.Tools)
+	}
+	if len(security.Checks) != 1 || security.Checks[0].Name != "tests" || security.Instructions != "Report results as a GO/NO-GO table." {
+		t.Errorf("expected security to inherit through qa, got %+v", security)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "qa.json"), []byte(`{"name":"qa","base_ref":"security"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var baseErr *validation.BaseError
+	if _, err := validation.ReadCanonicalDir(dir); !errors.As(err, &baseErr) {
+		t.Errorf("expected a BaseError for a base cycle, got %v", err)
+	}
+}
+
+func TestInstallCommands(t *testing.T) {
+	tests := []struct {
+		dep       string
+		installer validation.Installer
+		want      string
+	}{
+		{"gh", validation.InstallerBrew, "brew install gh"},
+		{"jq", validation.InstallerApt, "sudo apt-get install -y jq"},
+		{"govulncheck", validation.InstallerGo, "go install golang.org/x/vuln/cmd/govulncheck@latest"},
+		{"golangci-lint", validation.InstallerGo, "go install github.com/golangci/golangci-lint/cmd/golangci-lint@latest"},
+		{"markdownlint", validation.InstallerNpm, "npm install -g markdownlint-cli"},
+	}
+	for _, tt := range tests {
+		commands, ok := validation.InstallCommands(tt.dep)
+		if !ok {
+			t.Errorf("expected %s to be a known dependency", tt.dep)
+			continue
+		}
+		if got := commands[tt.installer]; got != tt.want {
+			t.Errorf("%s via %s = %q, want %q", tt.dep, tt.installer, got, tt.want)
+		}
+	}
+
+	if _, ok := validation.InstallCommands("not-a-real-tool"); ok {
+		t.Error("expected unknown dependency to report false")
+	}
+}
+
+func TestGenerateInstallScript(t *testing.T) {
+	script := string(validation.InstallScriptForAreas(testAreas()))
+
+	for _, want := range []string{
+		"#!/bin/sh",
+		"if ! has golangci-lint; then",
+		"  if has brew; then\n    brew install golangci-lint\n  elif has go; then\n    go install github.com/golangci/golangci-lint/cmd/golangci-lint@latest\n",
+		"go install golang.org/x/vuln/cmd/govulncheck@latest",
+		"warning: no known installer for schangelog",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("expected install script to contain %q, got:\n%s", want, script)
+		}
+	}
+}
+
+func TestPreflightOption(t *testing.T) {
+	area := testAreas()[2]
+
+	adapter, _ := validation.GetAdapter("claude")
+	data, err := adapter.Marshal(area)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "## Pre-flight") {
+		t.Error("expected no pre-flight section by default")
+	}
+
+	data, err = (&claude.Adapter{Preflight: true}).Marshal(area)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "## Pre-flight") || !strings.Contains(content, "go install golang.org/x/vuln/cmd/govulncheck@latest") {
+		t.Errorf("expected pre-flight install steps, got:\n%s", content)
+	}
+}